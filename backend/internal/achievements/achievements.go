@@ -0,0 +1,157 @@
+// Package achievements przyznaje odznaki za osiągnięcia w treningach (pierwsze 100 kg
+// w wyciskaniu, 100 treningów, 30 dni z rzędu itd.). Odznaki opisują reguły z rejestru;
+// zdobyte trafiają do AchievementStore, żeby nie znikały po usunięciu treningu.
+package achievements
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"gym-api/internal/models"
+	"gym-api/internal/store"
+)
+
+// Kategorie odznak.
+const (
+	CategoryWorkouts    = "workouts"
+	CategoryStrength    = "strength"
+	CategoryVolume      = "volume"
+	CategoryConsistency = "consistency"
+)
+
+// Stats to dane z historii treningów, na podstawie których reguły liczą postęp.
+type Stats struct {
+	Workouts       int                // wykonane treningi
+	Tonnage        float64            // kg × powtórzenia ze wszystkich treningów
+	Heaviest       map[string]float64 // najcięższa seria per ćwiczenie (nazwa małymi literami)
+	LongestDays    int                // najdłuższy ciąg kolejnych dni z treningiem
+	LongestWeeks   int                // najdłuższy ciąg kolejnych tygodni z treningiem
+	DistinctLifted int                // różne ćwiczenia z historii
+}
+
+// HeaviestOf zwraca najcięższą serię ćwiczenia o podanej nazwie (bez rozróżniania wielkości liter).
+func (s Stats) HeaviestOf(name string) float64 {
+	return s.Heaviest[strings.ToLower(name)]
+}
+
+// Rule opisuje odznakę: zdobywa się ją, gdy Progress osiągnie Target.
+type Rule struct {
+	ID          string
+	Name        string
+	Description string
+	Category    string
+	Target      float64
+	Progress    func(Stats) float64
+}
+
+var registry []Rule
+
+// Register dodaje regułę do rejestru; odznaki listujemy w kolejności rejestracji.
+// Reguła o istniejącym ID zastępuje poprzednią.
+func Register(r Rule) {
+	if i := slices.IndexFunc(registry, func(x Rule) bool { return x.ID == r.ID }); i >= 0 {
+		registry[i] = r
+		return
+	}
+	registry = append(registry, r)
+}
+
+// Rules zwraca zarejestrowane reguły.
+func Rules() []Rule {
+	return slices.Clone(registry)
+}
+
+// Collect liczy statystyki z wykonanych treningów i rekordów osobistych.
+func Collect(ctx context.Context, workouts *store.WorkoutStore) Stats {
+	st := Stats{Heaviest: map[string]float64{}}
+	all, _, _ := workouts.List(ctx, store.WorkoutQuery{Sort: []store.SortKey{{Field: "date"}}})
+	var days []time.Time
+	for _, w := range all {
+		if w.Planned {
+			continue
+		}
+		st.Workouts++
+		if d, err := time.Parse("2006-01-02", w.Date); err == nil && (len(days) == 0 || !days[len(days)-1].Equal(d)) {
+			days = append(days, d)
+		}
+		for _, ex := range w.Exercises {
+			for _, set := range ex.Sets {
+				if set.Weight != nil && set.Type != models.SetWarmup {
+					st.Tonnage += *set.Weight * float64(set.Reps)
+				}
+			}
+		}
+	}
+	for _, rec := range workouts.Records(ctx) {
+		st.DistinctLifted++
+		if rec.HeaviestWeight != nil {
+			name := strings.ToLower(rec.Name)
+			st.Heaviest[name] = max(st.Heaviest[name], rec.HeaviestWeight.Value)
+		}
+	}
+	st.LongestDays = longestRun(days, func(prev, next time.Time) bool { return next.Sub(prev) == 24*time.Hour })
+	st.LongestWeeks = longestRun(weeks(days), func(prev, next time.Time) bool { return next.Sub(prev) == 7*24*time.Hour })
+	return st
+}
+
+// weeks zamienia posortowane dni na kolejne poniedziałki tygodni bez powtórzeń.
+func weeks(days []time.Time) []time.Time {
+	var out []time.Time
+	for _, d := range days {
+		monday := d.AddDate(0, 0, -(int(d.Weekday())+6)%7)
+		if len(out) == 0 || !out[len(out)-1].Equal(monday) {
+			out = append(out, monday)
+		}
+	}
+	return out
+}
+
+// longestRun zwraca długość najdłuższego ciągu elementów, w którym każda para sąsiadów spełnia next.
+func longestRun(ts []time.Time, next func(prev, cur time.Time) bool) int {
+	best, run := 0, 0
+	for i := range ts {
+		if i > 0 && next(ts[i-1], ts[i]) {
+			run++
+		} else {
+			run = 1
+		}
+		best = max(best, run)
+	}
+	return best
+}
+
+// Evaluate sprawdza wszystkie reguły, zapisuje nowo zdobyte odznaki i zwraca pełną listę.
+func Evaluate(ctx context.Context, workouts *store.WorkoutStore, earned *store.AchievementStore) models.AchievementList {
+	st := Collect(ctx, workouts)
+	have := earned.Earned(ctx)
+	list := models.AchievementList{Items: []models.Achievement{}}
+	for _, r := range registry {
+		a := models.Achievement{ID: r.ID, Name: r.Name, Description: r.Description, Category: r.Category, Target: r.Target}
+		a.Progress = min(r.Progress(st), r.Target)
+		at, ok := have[r.ID]
+		if !ok && a.Progress >= r.Target {
+			at, ok = earned.Award(ctx, r.ID), true
+		}
+		if ok {
+			a.Earned, a.EarnedAt, a.Progress = true, &at, r.Target
+			list.Earned++
+		}
+		a.Percent = int(a.Progress / r.Target * 100)
+		list.Items = append(list.Items, a)
+	}
+	list.Total = len(list.Items)
+	slices.SortStableFunc(list.Items, func(a, b models.Achievement) int {
+		switch {
+		case a.Earned && b.Earned:
+			return b.EarnedAt.Compare(*a.EarnedAt)
+		case a.Earned:
+			return -1
+		case b.Earned:
+			return 1
+		}
+		return 0
+	})
+	return list
+}
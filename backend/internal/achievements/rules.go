@@ -0,0 +1,61 @@
+package achievements
+
+import "strconv"
+
+// Wbudowane odznaki. Nowe reguły dodaje się wywołaniem Register (także z innych pakietów).
+func init() {
+	for _, n := range []int{1, 10, 50, 100, 250, 500} {
+		Register(workoutsRule(n))
+	}
+	Register(liftRule("bench_100", "Setka na klatę", "Wyciśnij leżąc 100 kg", "Bench Press", 100))
+	Register(liftRule("squat_140", "Przysiad 140", "Przysiądź ze sztangą 140 kg", "Squat", 140))
+	Register(liftRule("deadlift_180", "Martwy 180", "Podnieś 180 kg w martwym ciągu", "Deadlift", 180))
+	Register(liftRule("press_60", "Żołnierskie 60", "Wyciśnij nad głowę 60 kg", "Overhead Press", 60))
+	Register(Rule{
+		ID: "tonnage_100t", Name: "100 ton", Description: "Podnieś łącznie 100 000 kg",
+		Category: CategoryVolume, Target: 100_000,
+		Progress: func(s Stats) float64 { return s.Tonnage },
+	})
+	Register(Rule{
+		ID: "tonnage_1000t", Name: "Tysiąc ton", Description: "Podnieś łącznie 1 000 000 kg",
+		Category: CategoryVolume, Target: 1_000_000,
+		Progress: func(s Stats) float64 { return s.Tonnage },
+	})
+	Register(Rule{
+		ID: "streak_30_days", Name: "30 dni z rzędu", Description: "Trenuj 30 dni z rzędu",
+		Category: CategoryConsistency, Target: 30,
+		Progress: func(s Stats) float64 { return float64(s.LongestDays) },
+	})
+	Register(Rule{
+		ID: "streak_12_weeks", Name: "Kwartał bez przerwy", Description: "Trenuj co tydzień przez 12 tygodni z rzędu",
+		Category: CategoryConsistency, Target: 12,
+		Progress: func(s Stats) float64 { return float64(s.LongestWeeks) },
+	})
+	Register(Rule{
+		ID: "explorer_25", Name: "Odkrywca", Description: "Wykonaj 25 różnych ćwiczeń",
+		Category: CategoryWorkouts, Target: 25,
+		Progress: func(s Stats) float64 { return float64(s.DistinctLifted) },
+	})
+}
+
+func workoutsRule(n int) Rule {
+	r := Rule{
+		ID:       "workouts_" + strconv.Itoa(n),
+		Name:     strconv.Itoa(n) + " treningów",
+		Category: CategoryWorkouts,
+		Target:   float64(n),
+		Progress: func(s Stats) float64 { return float64(s.Workouts) },
+	}
+	r.Description = "Zapisz " + strconv.Itoa(n) + " wykonanych treningów"
+	if n == 1 {
+		r.Name, r.Description = "Pierwszy trening", "Zapisz pierwszy wykonany trening"
+	}
+	return r
+}
+
+func liftRule(id, name, description, exercise string, kg float64) Rule {
+	return Rule{
+		ID: id, Name: name, Description: description, Category: CategoryStrength, Target: kg,
+		Progress: func(s Stats) float64 { return s.HeaviestOf(exercise) },
+	}
+}
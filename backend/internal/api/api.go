@@ -0,0 +1,94 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"gym-api/internal/openapi"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Prefix to wspólny prefiks wszystkich wersji API, np. /api/v1/workouts.
+const Prefix = "/api/"
+
+// CurrentVersion to najnowsza wersja, na którą wskazują przestarzałe ścieżki bez prefiksu.
+const CurrentVersion = "v1"
+
+// Version to zbudowana wersja API: router i opisujący ją dokument OpenAPI.
+type Version struct {
+	Name    string
+	Handler http.Handler
+	Spec    openapi.Document
+}
+
+// route łączy wzorzec w routerze wersji z handlerem i dokumentacją jego operacji,
+// dzięki czemu spec OpenAPI powstaje z tej samej tabeli co routing.
+type route struct {
+	pattern string // wzorzec http.ServeMux, np. "/workouts/"
+	path    string // ścieżka w dokumentacji, np. "/workouts/{id}"
+	handler http.Handler
+	ops     []operation
+}
+
+// operation opisuje jedną metodę HTTP na ścieżce na potrzeby OpenAPI.
+type operation struct {
+	method    string
+	summary   string
+	params    []openapi.Parameter
+	body      any         // przykładowa wartość typu body żądania (nil = brak body)
+	bodyType  string      // media type body; pusty = application/json
+	responses map[int]any // status -> przykładowa wartość typu odpowiedzi (nil = bez treści)
+}
+
+// build rejestruje trasy w nowym routerze i generuje dla nich dokument OpenAPI.
+func build(name string, routes []route) Version {
+	mux := http.NewServeMux()
+	gen := openapi.NewGenerator()
+	doc := openapi.Document{
+		OpenAPI: "3.0.3",
+		Info:    openapi.Info{Title: "Gym API", Version: name},
+		Servers: []openapi.Server{{URL: Prefix + name}},
+		Paths:   make(map[string]openapi.PathItem),
+	}
+
+	for _, rt := range routes {
+		mux.Handle(rt.pattern, traced(Prefix+name+rt.path, rt.handler))
+
+		item := doc.Paths[rt.path]
+		if item == nil {
+			item = make(openapi.PathItem)
+			doc.Paths[rt.path] = item
+		}
+		for _, op := range rt.ops {
+			item[strings.ToLower(op.method)] = op.document(gen, tagOf(rt.path))
+		}
+	}
+	doc.Components = gen.Components()
+	return Version{Name: name, Handler: mux, Spec: doc}
+}
+
+// Mount rejestruje router danej wersji pod /api/{version}/.
+// Prefiks jest obcinany, więc handlery wersji widzą ścieżki typu /workouts/{id}.
+// Kolejne wersje (v2, ...) montujemy obok, bez zmian w starszych.
+func Mount(mux *http.ServeMux, v Version) {
+	prefix := Prefix + v.Name
+	mux.Handle(prefix+"/", http.StripPrefix(prefix, v.Handler))
+}
+
+// Legacy obsługuje stare ścieżki bez prefiksu wersji, przekazując je do bieżącej wersji
+// i oznaczając odpowiedź jako przestarzałą (nagłówki Deprecation i Link),
+// żeby istniejący klienci działali dalej, a mieli sygnał do migracji.
+func Legacy(current http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "<"+Prefix+CurrentVersion+r.URL.Path+`>; rel="successor-version"`)
+		current.ServeHTTP(w, r)
+	})
+}
+
+// traced otacza handler spanem OpenTelemetry nazwanym wzorcem trasy
+// (a nie konkretną ścieżką, żeby ID nie mnożyły nazw spanów).
+func traced(route string, h http.Handler) http.Handler {
+	return otelhttp.NewHandler(h, route)
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"embed"
+	"net/http"
+
+	"gym-api/internal/httpjson"
+)
+
+//go:embed static
+var static embed.FS
+
+// docsCSP luzuje domyślne CSP tylko dla strony dokumentacji:
+// Swagger UI ładuje skrypty i style z CDN unpkg.
+const docsCSP = "default-src 'none'; script-src 'self' https://unpkg.com; style-src 'self' https://unpkg.com; " +
+	"img-src 'self' data: https:; connect-src 'self'; frame-ancestors 'none'"
+
+// Docs serwuje Swagger UI (/docs/) i dokument OpenAPI danej wersji (/docs/openapi.json).
+// Handler oczekuje ścieżek z obciętym prefiksem /docs.
+func Docs(v Version) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		httpjson.WriteJSON(w, http.StatusOK, v.Spec)
+	})
+	mux.HandleFunc("/swagger-init.js", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFileFS(w, r, static, "static/swagger-init.js")
+	})
+	mux.HandleFunc("/{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy", docsCSP)
+		http.ServeFileFS(w, r, static, "static/index.html")
+	})
+	return mux
+}
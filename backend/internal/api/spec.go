@@ -0,0 +1,72 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gym-api/internal/models"
+	"gym-api/internal/openapi"
+)
+
+// document zamienia opis operacji na obiekt OpenAPI, rejestrując schematy modeli w gen.
+func (op operation) document(gen *openapi.Generator, tag string) *openapi.Operation {
+	out := &openapi.Operation{
+		Summary:    op.summary,
+		Tags:       []string{tag},
+		Parameters: op.params,
+		Responses:  make(map[string]openapi.Response, len(op.responses)),
+	}
+	if op.body != nil {
+		out.RequestBody = &openapi.RequestBody{
+			Required: true,
+			Content:  jsonContent(gen.SchemaOf(op.body)),
+		}
+		if op.bodyType != "" {
+			out.RequestBody.Content = map[string]openapi.MediaType{op.bodyType: {Schema: gen.SchemaOf(op.body)}}
+		}
+	}
+	for status, payload := range op.responses {
+		resp := openapi.Response{Description: http.StatusText(status)}
+		switch payload.(type) {
+		case nil:
+		case models.Problem:
+			resp.Content = map[string]openapi.MediaType{"application/problem+json": {Schema: gen.SchemaOf(payload)}}
+		default:
+			resp.Content = jsonContent(gen.SchemaOf(payload))
+		}
+		out.Responses[strconv.Itoa(status)] = resp
+	}
+	return out
+}
+
+func jsonContent(s *openapi.Schema) map[string]openapi.MediaType {
+	return map[string]openapi.MediaType{"application/json": {Schema: s}}
+}
+
+// pathParam opisuje wymagany parametr ścieżki typu integer (np. {id}).
+func pathParam(name, description string) openapi.Parameter {
+	return openapi.Parameter{
+		Name:        name,
+		In:          "path",
+		Required:    true,
+		Description: description,
+		Schema:      &openapi.Schema{Type: "integer"},
+	}
+}
+
+// queryParam opisuje opcjonalny parametr zapytania danego typu ("integer", "string", ...).
+func queryParam(name, typ, description string) openapi.Parameter {
+	return openapi.Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      &openapi.Schema{Type: typ},
+	}
+}
+
+// tagOf grupuje operacje w Swagger UI po pierwszym segmencie ścieżki.
+func tagOf(path string) string {
+	seg, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return seg
+}
@@ -0,0 +1,5 @@
+// Osobny plik zamiast skryptu inline, żeby CSP nie wymagało 'unsafe-inline'.
+window.ui = SwaggerUIBundle({
+  url: 'openapi.json',
+  dom_id: '#swagger-ui',
+});
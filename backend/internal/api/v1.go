@@ -0,0 +1,1442 @@
+package api
+
+import (
+	"net/http"
+
+	"gym-api/internal/handlers"
+	"gym-api/internal/models"
+	"gym-api/internal/openapi"
+	"gym-api/internal/server"
+	"gym-api/internal/strava"
+)
+
+// V1 buduje router i dokumentację wersji v1 API.
+func V1(srv *server.Server) Version {
+	workoutID := pathParam("id", "ID treningu")
+	exerciseID := pathParam("id", "ID ćwiczenia w katalogu")
+	templateID := pathParam("id", "ID szablonu treningu")
+	programID := pathParam("id", "ID programu treningowego")
+	goalID := pathParam("id", "ID celu")
+	bodyweightID := pathParam("id", "ID pomiaru masy ciała")
+	nutritionID := pathParam("id", "ID wpisu dziennika żywienia")
+	waterID := pathParam("id", "ID porcji wody")
+	sleepID := pathParam("id", "ID wpisu snu")
+	checkinID := pathParam("id", "ID oceny samopoczucia")
+	injuryID := pathParam("id", "ID urazu")
+	supplementID := pathParam("id", "ID dawki suplementu")
+	measurementID := pathParam("id", "ID pomiarów obwodów")
+	photoID := pathParam("id", "ID zdjęcia")
+	apiErr := models.Problem{}
+	revisions := handlers.NewRevisionsHandler(srv)
+	fieldsParam := queryParam("fields", "string", "zwracane pola treningu, np. id,title,date (domyślnie wszystkie)")
+
+	return build("v1", []route{
+		{
+			// Kolekcja treningów: GET (lista), POST (dodanie).
+			pattern: "/workouts",
+			path:    "/workouts",
+			handler: handlers.NewWorkoutsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Lista treningów (stronicowana)",
+					params: []openapi.Parameter{
+						queryParam("limit", "integer", "rozmiar strony (1–200, domyślnie 50)"),
+						queryParam("offset", "integer", "liczba pominiętych treningów (domyślnie 0)"),
+						queryParam("cursor", "string", "kursor nextCursor z poprzedniej strony"),
+						queryParam("from", "string", "najwcześniejsza data treningu (YYYY-MM-DD, włącznie)"),
+						queryParam("to", "string", "najpóźniejsza data treningu (YYYY-MM-DD, włącznie)"),
+						queryParam("exercise", "string", "tylko treningi zawierające ćwiczenie o tej nazwie (bez rozróżniania wielkości liter)"),
+						queryParam("sort", "string", "kolejność, np. date,-createdAt (pola: id, date, title, createdAt, updatedAt; domyślnie -date,-id)"),
+						fieldsParam,
+						queryParam("q", "string", "wyszukiwanie w tytule, nazwach ćwiczeń i notatkach treningu, ćwiczeń i serii (wszystkie słowa, dopasowanie prefiksu)"),
+					},
+					responses: map[int]any{http.StatusOK: models.WorkoutPage{}, http.StatusNotModified: nil, http.StatusBadRequest: apiErr}},
+				{method: http.MethodPost, summary: "Dodanie treningu",
+					body:      models.CreateWorkoutRequest{},
+					responses: map[int]any{http.StatusCreated: models.CreatedWorkout{}, http.StatusBadRequest: apiErr, http.StatusRequestEntityTooLarge: apiErr}},
+			},
+		},
+		{
+			// Import wielu treningów naraz (wszystkie albo żaden).
+			pattern: "/workouts/bulk",
+			path:    "/workouts/bulk",
+			handler: handlers.NewWorkoutsBulkHandler(srv),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Dodanie wielu treningów naraz",
+					body:      []models.CreateWorkoutRequest{},
+					responses: map[int]any{http.StatusCreated: []models.Workout{}, http.StatusBadRequest: apiErr, http.StatusRequestEntityTooLarge: apiErr}},
+			},
+		},
+		{
+			// Kosz: usunięte treningi do przywrócenia.
+			pattern: "/workouts/trash",
+			path:    "/workouts/trash",
+			handler: handlers.NewTrashHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Lista usuniętych treningów (kosz)",
+					responses: map[int]any{http.StatusOK: []models.Workout{}}},
+			},
+		},
+		{
+			pattern: "/workouts/{id}/restore",
+			path:    "/workouts/{id}/restore",
+			handler: handlers.NewRestoreHandler(srv),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Przywrócenie treningu z kosza", params: []openapi.Parameter{workoutID},
+					responses: map[int]any{http.StatusOK: models.Workout{}, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Historia zmian treningu.
+			pattern: "/workouts/{id}/revisions",
+			path:    "/workouts/{id}/revisions",
+			handler: revisions,
+			ops: []operation{
+				{method: http.MethodGet, summary: "Historia wersji treningu", params: []openapi.Parameter{workoutID},
+					responses: map[int]any{http.StatusOK: []models.Revision{}, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			pattern: "/workouts/{id}/revisions/{n}",
+			path:    "/workouts/{id}/revisions/{n}",
+			handler: revisions,
+			ops: []operation{
+				{method: http.MethodGet, summary: "Wersja treningu wraz ze zmianami",
+					params:    []openapi.Parameter{workoutID, pathParam("n", "numer wersji")},
+					responses: map[int]any{http.StatusOK: models.RevisionDetail{}, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Cofnięcie ostatniej zmiany na podstawie historii wersji.
+			pattern: "/workouts/{id}/undo",
+			path:    "/workouts/{id}/undo",
+			handler: handlers.NewUndoHandler(srv),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Cofnięcie ostatniej zmiany treningu", params: []openapi.Parameter{workoutID},
+					responses: map[int]any{http.StatusOK: models.Workout{}, http.StatusNotFound: apiErr, http.StatusConflict: apiErr}},
+			},
+		},
+		{
+			// Kopia treningu ("powtórz ostatnią sesję").
+			pattern: "/workouts/{id}/duplicate",
+			path:    "/workouts/{id}/duplicate",
+			handler: handlers.NewWorkoutDuplicateHandler(srv),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Skopiowanie treningu",
+					params: []openapi.Parameter{workoutID,
+						queryParam("date", "string", "data kopii (YYYY-MM-DD, domyślnie dzisiaj)"),
+						queryParam("clearWeights", "boolean", "kopiuje serie bez ciężarów"),
+					},
+					responses: map[int]any{http.StatusCreated: models.Workout{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Zmiana kolejności ćwiczeń bez przesyłania całego treningu.
+			pattern: "/workouts/{id}/exercises/reorder",
+			path:    "/workouts/{id}/exercises/reorder",
+			handler: handlers.NewReorderExercisesHandler(srv),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Zmiana kolejności ćwiczeń treningu",
+					params: []openapi.Parameter{workoutID}, body: models.ReorderExercisesRequest{},
+					responses: map[int]any{http.StatusOK: models.Workout{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr, http.StatusConflict: apiErr}},
+			},
+		},
+		{
+			// Czas w strefach tętna ćwiczeń kardio.
+			pattern: "/workouts/{id}/hr-zones",
+			path:    "/workouts/{id}/hr-zones",
+			handler: handlers.NewWorkoutHRZonesHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Czas w strefach tętna każdego ćwiczenia kardio treningu",
+					params:    []openapi.Parameter{workoutID},
+					responses: map[int]any{http.StatusOK: models.WorkoutHRZones{}, http.StatusNotFound: apiErr, http.StatusConflict: apiErr}},
+			},
+		},
+		{
+			// Propozycja ciężarów na kolejną sesję (progresja).
+			pattern: "/workouts/next-suggestion",
+			path:    "/workouts/next-suggestion",
+			handler: handlers.NewNextSuggestionHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Ciężary na kolejny trening",
+					params: []openapi.Parameter{
+						queryParam("workoutId", "integer", "trening, po którym liczymy propozycję (domyślnie ostatni wykonany)"),
+						queryParam("increment", "number", "kg dodawane po udanej sesji (domyślnie 2.5)"),
+						queryParam("failures", "integer", "nieudane sesje z rzędu przed deloadem (domyślnie 3)"),
+						queryParam("deload", "integer", "o ile procent zmniejszyć ciężar przy deloadzie (domyślnie 10)"),
+						queryParam("targetRpe", "number", "docelowe RPE serii roboczych (5–10, domyślnie 8)"),
+					},
+					responses: map[int]any{http.StatusOK: models.NextWorkoutSuggestion{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Rekordy osobiste.
+			pattern: "/prs",
+			path:    "/prs",
+			handler: handlers.NewRecordsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Rekordy osobiste per ćwiczenie",
+					params:    []openapi.Parameter{queryParam("exerciseId", "integer", "tylko rekordy tego ćwiczenia z katalogu")},
+					responses: map[int]any{http.StatusOK: []models.ExerciseRecords{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			// Statystyki obciążenia treningowego.
+			pattern: "/stats/load",
+			path:    "/stats/load",
+			handler: handlers.NewLoadStatsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Tygodniowe obciążenie i ACWR",
+					params: []openapi.Parameter{
+						queryParam("date", "string", "ostatni dzień analizy (YYYY-MM-DD, domyślnie dziś)"),
+						queryParam("weeks", "integer", "liczba tygodni w odpowiedzi (4–52, domyślnie 8)"),
+					},
+					responses: map[int]any{http.StatusOK: models.LoadReport{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/stats/volume",
+			path:    "/stats/volume",
+			handler: handlers.NewVolumeStatsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Objętość treningowa (serie, powtórzenia, tonaż) w okresach",
+					params: []openapi.Parameter{
+						queryParam("groupBy", "string", "muscle = podział na grupy mięśni (odpowiedź MuscleVolumeReport)"),
+						queryParam("period", "string", "okres: day, week (domyślnie) lub month"),
+						queryParam("from", "string", "początek zakresu (YYYY-MM-DD)"),
+						queryParam("to", "string", "koniec zakresu (YYYY-MM-DD, domyślnie dziś)"),
+						queryParam("includeWarmups", "boolean", "wlicza serie rozgrzewkowe"),
+					},
+					responses: map[int]any{http.StatusOK: models.VolumeReport{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/stats/strength-standards",
+			path:    "/stats/strength-standards",
+			handler: handlers.NewStrengthStandardsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Siła bojów podstawowych względem norm (untrained–elite)",
+					params: []openapi.Parameter{
+						queryParam("sex", "string", "male lub female"),
+						queryParam("bodyweight", "number", "masa ciała w kg (domyślnie najnowszy pomiar z /bodyweight)"),
+					},
+					responses: map[int]any{http.StatusOK: models.StrengthStandardsReport{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/stats/progress",
+			path:    "/stats/progress",
+			handler: handlers.NewProgressStatsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Seria postępów ćwiczenia do wykresu (jedna wartość na okres)",
+					params: []openapi.Parameter{
+						queryParam("exercise", "integer", "ID ćwiczenia z katalogu"),
+						queryParam("metric", "string", "e1rm (domyślnie), volume albo topset"),
+						queryParam("bucket", "string", "day, week (domyślnie) albo month"),
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: models.ProgressSeries{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			pattern: "/stats/streaks",
+			path:    "/stats/streaks",
+			handler: handlers.NewStreaksStatsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Bieżąca i najdłuższa seria tygodni z wymaganą liczbą treningów",
+					params:    []openapi.Parameter{queryParam("perWeek", "integer", "wymagana liczba treningów w tygodniu (1–7, domyślnie 1)")},
+					responses: map[int]any{http.StatusOK: models.StreakReport{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/stats/heatmap",
+			path:    "/stats/heatmap",
+			handler: handlers.NewHeatmapStatsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Mapa aktywności roku: treningi, tonaż i poziom 0–4 dla każdego dnia",
+					params:    []openapi.Parameter{queryParam("year", "integer", "rok (domyślnie bieżący)")},
+					responses: map[int]any{http.StatusOK: models.HeatmapReport{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/stats/powerlifting",
+			path:    "/stats/powerlifting",
+			handler: handlers.NewPowerliftingStatsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Punkty Wilksa, DOTS i IPF GL z najlepszych wyników trójboju",
+					params: []openapi.Parameter{
+						queryParam("sex", "string", "male lub female"),
+						queryParam("bodyweight", "number", "masa ciała w kg (domyślnie najnowszy pomiar z /bodyweight)"),
+						queryParam("source", "string", "e1rm (domyślnie) albo actual – najcięższy podniesiony ciężar"),
+					},
+					responses: map[int]any{http.StatusOK: models.PowerliftingReport{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/stats/rest",
+			path:    "/stats/rest",
+			handler: handlers.NewRestStatsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Przerwy po seriach każdego ćwiczenia i ich porównanie z planem",
+					params: []openapi.Parameter{
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: []models.ExerciseRest{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/stats/hr-zones",
+			path:    "/stats/hr-zones",
+			handler: handlers.NewHRZonesStatsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Tygodniowy czas w strefach tętna",
+					params: []openapi.Parameter{
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: []models.HRZoneWeek{}, http.StatusBadRequest: apiErr, http.StatusConflict: apiErr}},
+			},
+		},
+		{
+			pattern: "/calendar/{month}",
+			path:    "/calendar/{month}",
+			handler: handlers.NewCalendarHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Widok miesiąca: treningi i tonaż w każdym dniu",
+					params: []openapi.Parameter{{Name: "month", In: "path", Required: true, Description: "miesiąc (YYYY-MM)",
+						Schema: &openapi.Schema{Type: "string"}}},
+					responses: map[int]any{http.StatusOK: models.CalendarMonth{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			// Cele użytkownika z postępem liczonym z treningów.
+			pattern: "/goals",
+			path:    "/goals",
+			handler: handlers.NewGoalsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Lista celów z postępem",
+					responses: map[int]any{http.StatusOK: []models.Goal{}}},
+				{method: http.MethodPost, summary: "Dodanie celu (ciężar w ćwiczeniu albo treningi w tygodniu)",
+					body:      models.GoalRequest{},
+					responses: map[int]any{http.StatusCreated: models.Goal{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/goals/{id}",
+			path:    "/goals/{id}",
+			handler: handlers.NewGoalByIDHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Pobranie celu z postępem", params: []openapi.Parameter{goalID},
+					responses: map[int]any{http.StatusOK: models.Goal{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Zastąpienie celu", params: []openapi.Parameter{goalID},
+					body:      models.GoalRequest{},
+					responses: map[int]any{http.StatusOK: models.Goal{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+				{method: http.MethodDelete, summary: "Usunięcie celu", params: []openapi.Parameter{goalID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Dziennik masy ciała.
+			pattern: "/bodyweight",
+			path:    "/bodyweight",
+			handler: handlers.NewBodyweightHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Pomiary masy ciała od najnowszego",
+					params: []openapi.Parameter{
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: []models.BodyweightEntry{}, http.StatusBadRequest: apiErr}},
+				{method: http.MethodPost, summary: "Dodanie pomiaru masy ciała",
+					body:      models.BodyweightRequest{},
+					responses: map[int]any{http.StatusCreated: models.BodyweightEntry{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			// Import treningów kardio z plików zegarków i aplikacji.
+			pattern: "/import/gpx",
+			path:    "/import/gpx",
+			handler: handlers.NewGPXImportHandler(srv),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Import śladów GPX jako treningów kardio",
+					params: []openapi.Parameter{
+						queryParam("exercise", "string", "nazwa ćwiczenia (domyślnie z typu aktywności w pliku)"),
+						queryParam("tz", "string", "strefa czasowa IANA dla daty treningu (np. Europe/Warsaw); domyślnie ze znacznika czasu w pliku"),
+					},
+					body: "", bodyType: "application/gpx+xml",
+					responses: map[int]any{
+						http.StatusCreated:               []models.Workout{},
+						http.StatusBadRequest:            apiErr,
+						http.StatusRequestEntityTooLarge: apiErr,
+						http.StatusUnsupportedMediaType:  apiErr,
+					}},
+			},
+		},
+		{
+			pattern: "/import/fit",
+			path:    "/import/fit",
+			handler: handlers.NewFITImportHandler(srv),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Import sesji z pliku FIT (kardio i serie siłowe z zegarka)",
+					params: []openapi.Parameter{
+						queryParam("tz", "string", "strefa czasowa IANA, gdy plik nie podaje strefy zegarka (np. Europe/Warsaw)"),
+					},
+					body: "", bodyType: "application/octet-stream",
+					responses: map[int]any{
+						http.StatusCreated:               []models.Workout{},
+						http.StatusBadRequest:            apiErr,
+						http.StatusRequestEntityTooLarge: apiErr,
+						http.StatusUnsupportedMediaType:  apiErr,
+					}},
+			},
+		},
+		{
+			pattern: "/import/apple-health",
+			path:    "/import/apple-health",
+			handler: handlers.NewAppleHealthImportHandler(srv),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Import eksportu Apple Health (treningi i masa ciała z export.xml albo export.zip)",
+					body: "", bodyType: "application/zip",
+					responses: map[int]any{
+						http.StatusOK:                    models.AppleHealthImportResult{},
+						http.StatusBadRequest:            apiErr,
+						http.StatusRequestEntityTooLarge: apiErr,
+						http.StatusUnsupportedMediaType:  apiErr,
+					}},
+			},
+		},
+		{
+			// Synchronizacja treningów kardio ze Stravą (OAuth, harmonogram i webhooki).
+			pattern: "/integrations/strava",
+			path:    "/integrations/strava",
+			handler: handlers.NewIntegrationHandler(srv, "strava"),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Stan połączenia ze Stravą",
+					responses: map[int]any{http.StatusOK: models.Integration{}, http.StatusServiceUnavailable: apiErr}},
+				{method: http.MethodDelete, summary: "Rozłączenie konta Strava",
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr, http.StatusServiceUnavailable: apiErr}},
+			},
+		},
+		{
+			pattern: "/integrations/strava/connect",
+			path:    "/integrations/strava/connect",
+			handler: handlers.NewIntegrationConnectHandler(srv, "strava"),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Rozpoczęcie autoryzacji OAuth w Stravie (adres strony zgody)",
+					responses: map[int]any{http.StatusOK: models.IntegrationConnect{}, http.StatusServiceUnavailable: apiErr}},
+			},
+		},
+		{
+			pattern: "/integrations/strava/callback",
+			path:    "/integrations/strava/callback",
+			handler: handlers.NewIntegrationCallbackHandler(srv, "strava"),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Powrót z autoryzacji Stravy (połączenie konta)",
+					params: []openapi.Parameter{
+						queryParam("code", "string", "kod autoryzacji"),
+						queryParam("state", "string", "state z POST /integrations/strava/connect"),
+						queryParam("scope", "string", "przyznane uprawnienia"),
+						queryParam("error", "string", "ustawiony, gdy użytkownik odmówił dostępu"),
+					},
+					responses: map[int]any{
+						http.StatusOK:                 models.Integration{},
+						http.StatusBadRequest:         apiErr,
+						http.StatusConflict:           apiErr,
+						http.StatusBadGateway:         apiErr,
+						http.StatusServiceUnavailable: apiErr,
+					}},
+			},
+		},
+		{
+			pattern: "/integrations/strava/sync",
+			path:    "/integrations/strava/sync",
+			handler: handlers.NewIntegrationSyncHandler(srv, "strava"),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Natychmiastowa synchronizacja aktywności ze Stravy",
+					responses: map[int]any{
+						http.StatusOK:                 models.SyncResult{},
+						http.StatusNotFound:           apiErr,
+						http.StatusConflict:           apiErr,
+						http.StatusBadGateway:         apiErr,
+						http.StatusServiceUnavailable: apiErr,
+					}},
+			},
+		},
+		{
+			pattern: "/integrations/strava/webhook",
+			path:    "/integrations/strava/webhook",
+			handler: handlers.NewStravaWebhookHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Potwierdzenie subskrypcji webhooka Stravy",
+					params: []openapi.Parameter{
+						queryParam("hub.mode", "string", "subscribe"),
+						queryParam("hub.verify_token", "string", "token z konfiguracji (GYM_STRAVA_VERIFY_TOKEN)"),
+						queryParam("hub.challenge", "string", "wartość do odesłania"),
+					},
+					responses: map[int]any{http.StatusOK: map[string]string{}, http.StatusForbidden: apiErr, http.StatusServiceUnavailable: apiErr}},
+				{method: http.MethodPost, summary: "Zdarzenie webhooka Stravy (nowa aktywność, odwołanie dostępu)",
+					body:      strava.Event{},
+					responses: map[int]any{http.StatusOK: nil, http.StatusBadRequest: apiErr, http.StatusServiceUnavailable: apiErr}},
+			},
+		},
+		{
+			// Synchronizacja treningów kardio i siłowych z Garmin Connect.
+			pattern: "/integrations/garmin",
+			path:    "/integrations/garmin",
+			handler: handlers.NewIntegrationHandler(srv, "garmin"),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Stan połączenia z Garmin Connect i ustawienia importu",
+					responses: map[int]any{http.StatusOK: models.Integration{}, http.StatusServiceUnavailable: apiErr}},
+				{method: http.MethodPut, summary: "Ustawienia importu z Garmin Connect (kardio, treningi siłowe)",
+					body: models.IntegrationSettings{},
+					responses: map[int]any{
+						http.StatusOK:                 models.Integration{},
+						http.StatusBadRequest:         apiErr,
+						http.StatusNotFound:           apiErr,
+						http.StatusServiceUnavailable: apiErr,
+					}},
+				{method: http.MethodDelete, summary: "Rozłączenie konta Garmin",
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr, http.StatusServiceUnavailable: apiErr}},
+			},
+		},
+		{
+			pattern: "/integrations/garmin/connect",
+			path:    "/integrations/garmin/connect",
+			handler: handlers.NewIntegrationConnectHandler(srv, "garmin"),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Rozpoczęcie autoryzacji OAuth w Garmin Connect (adres strony zgody)",
+					responses: map[int]any{http.StatusOK: models.IntegrationConnect{}, http.StatusServiceUnavailable: apiErr}},
+			},
+		},
+		{
+			pattern: "/integrations/garmin/callback",
+			path:    "/integrations/garmin/callback",
+			handler: handlers.NewIntegrationCallbackHandler(srv, "garmin"),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Powrót z autoryzacji Garmin Connect (połączenie konta)",
+					params: []openapi.Parameter{
+						queryParam("code", "string", "kod autoryzacji"),
+						queryParam("state", "string", "state z POST /integrations/garmin/connect"),
+						queryParam("error", "string", "ustawiony, gdy użytkownik odmówił dostępu"),
+					},
+					responses: map[int]any{
+						http.StatusOK:                 models.Integration{},
+						http.StatusBadRequest:         apiErr,
+						http.StatusConflict:           apiErr,
+						http.StatusBadGateway:         apiErr,
+						http.StatusServiceUnavailable: apiErr,
+					}},
+			},
+		},
+		{
+			pattern: "/integrations/garmin/sync",
+			path:    "/integrations/garmin/sync",
+			handler: handlers.NewIntegrationSyncHandler(srv, "garmin"),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Natychmiastowa synchronizacja aktywności z Garmin Connect",
+					responses: map[int]any{
+						http.StatusOK:                 models.SyncResult{},
+						http.StatusNotFound:           apiErr,
+						http.StatusConflict:           apiErr,
+						http.StatusBadGateway:         apiErr,
+						http.StatusServiceUnavailable: apiErr,
+					}},
+			},
+		},
+		{
+			// Synchronizacja sesji aktywności i masy ciała z Google Fit.
+			pattern: "/integrations/googlefit",
+			path:    "/integrations/googlefit",
+			handler: handlers.NewIntegrationHandler(srv, "googlefit"),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Stan połączenia z Google Fit",
+					responses: map[int]any{http.StatusOK: models.Integration{}, http.StatusServiceUnavailable: apiErr}},
+				{method: http.MethodDelete, summary: "Rozłączenie konta Google Fit",
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr, http.StatusServiceUnavailable: apiErr}},
+			},
+		},
+		{
+			pattern: "/integrations/googlefit/connect",
+			path:    "/integrations/googlefit/connect",
+			handler: handlers.NewIntegrationConnectHandler(srv, "googlefit"),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Rozpoczęcie autoryzacji OAuth w Google (adres strony zgody)",
+					responses: map[int]any{http.StatusOK: models.IntegrationConnect{}, http.StatusServiceUnavailable: apiErr}},
+			},
+		},
+		{
+			pattern: "/integrations/googlefit/callback",
+			path:    "/integrations/googlefit/callback",
+			handler: handlers.NewIntegrationCallbackHandler(srv, "googlefit"),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Powrót z autoryzacji Google (połączenie konta Google Fit)",
+					params: []openapi.Parameter{
+						queryParam("code", "string", "kod autoryzacji"),
+						queryParam("state", "string", "state z POST /integrations/googlefit/connect"),
+						queryParam("scope", "string", "przyznane uprawnienia"),
+						queryParam("error", "string", "ustawiony, gdy użytkownik odmówił dostępu"),
+					},
+					responses: map[int]any{
+						http.StatusOK:                 models.Integration{},
+						http.StatusBadRequest:         apiErr,
+						http.StatusConflict:           apiErr,
+						http.StatusBadGateway:         apiErr,
+						http.StatusServiceUnavailable: apiErr,
+					}},
+			},
+		},
+		{
+			pattern: "/integrations/googlefit/sync",
+			path:    "/integrations/googlefit/sync",
+			handler: handlers.NewIntegrationSyncHandler(srv, "googlefit"),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Natychmiastowa synchronizacja sesji i masy ciała z Google Fit",
+					responses: map[int]any{
+						http.StatusOK:                 models.SyncResult{},
+						http.StatusNotFound:           apiErr,
+						http.StatusConflict:           apiErr,
+						http.StatusBadGateway:         apiErr,
+						http.StatusServiceUnavailable: apiErr,
+					}},
+			},
+		},
+		{
+			// Synchronizacja aktywności (z tętnem) i masy ciała z Fitbit.
+			pattern: "/integrations/fitbit",
+			path:    "/integrations/fitbit",
+			handler: handlers.NewIntegrationHandler(srv, "fitbit"),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Stan połączenia z Fitbit",
+					responses: map[int]any{http.StatusOK: models.Integration{}, http.StatusServiceUnavailable: apiErr}},
+				{method: http.MethodDelete, summary: "Rozłączenie konta Fitbit",
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr, http.StatusServiceUnavailable: apiErr}},
+			},
+		},
+		{
+			pattern: "/integrations/fitbit/connect",
+			path:    "/integrations/fitbit/connect",
+			handler: handlers.NewIntegrationConnectHandler(srv, "fitbit"),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Rozpoczęcie autoryzacji OAuth w Fitbit (adres strony zgody)",
+					responses: map[int]any{http.StatusOK: models.IntegrationConnect{}, http.StatusServiceUnavailable: apiErr}},
+			},
+		},
+		{
+			pattern: "/integrations/fitbit/callback",
+			path:    "/integrations/fitbit/callback",
+			handler: handlers.NewIntegrationCallbackHandler(srv, "fitbit"),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Powrót z autoryzacji Fitbit (połączenie konta)",
+					params: []openapi.Parameter{
+						queryParam("code", "string", "kod autoryzacji"),
+						queryParam("state", "string", "state z POST /integrations/fitbit/connect"),
+						queryParam("error", "string", "ustawiony, gdy użytkownik odmówił dostępu"),
+					},
+					responses: map[int]any{
+						http.StatusOK:                 models.Integration{},
+						http.StatusBadRequest:         apiErr,
+						http.StatusConflict:           apiErr,
+						http.StatusBadGateway:         apiErr,
+						http.StatusServiceUnavailable: apiErr,
+					}},
+			},
+		},
+		{
+			pattern: "/integrations/fitbit/sync",
+			path:    "/integrations/fitbit/sync",
+			handler: handlers.NewIntegrationSyncHandler(srv, "fitbit"),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Natychmiastowa synchronizacja aktywności i masy ciała z Fitbit",
+					responses: map[int]any{
+						http.StatusOK:                 models.SyncResult{},
+						http.StatusNotFound:           apiErr,
+						http.StatusConflict:           apiErr,
+						http.StatusBadGateway:         apiErr,
+						http.StatusServiceUnavailable: apiErr,
+					}},
+			},
+		},
+		{
+			pattern: "/bodyweight/import",
+			path:    "/bodyweight/import",
+			handler: handlers.NewBodyweightImportHandler(srv),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Import pomiarów z wagi (CSV z Withings albo Renpho)",
+					params: []openapi.Parameter{
+						queryParam("format", "string", "withings albo renpho (domyślnie rozpoznawany z nagłówka)"),
+					},
+					body: "", bodyType: "text/csv",
+					responses: map[int]any{
+						http.StatusOK:                    models.BodyweightImportResult{},
+						http.StatusBadRequest:            apiErr,
+						http.StatusRequestEntityTooLarge: apiErr,
+						http.StatusUnsupportedMediaType:  apiErr,
+					}},
+			},
+		},
+		{
+			pattern: "/bodyweight/trend",
+			path:    "/bodyweight/trend",
+			handler: handlers.NewBodyweightTrendHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Masa ciała ze średnią kroczącą do wykresu",
+					params: []openapi.Parameter{
+						queryParam("window", "integer", "długość średniej w dniach (1–90, domyślnie 7)"),
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: models.BodyweightTrend{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/bodyweight/{id}",
+			path:    "/bodyweight/{id}",
+			handler: handlers.NewBodyweightByIDHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Pobranie pomiaru masy ciała", params: []openapi.Parameter{bodyweightID},
+					responses: map[int]any{http.StatusOK: models.BodyweightEntry{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Zastąpienie pomiaru masy ciała", params: []openapi.Parameter{bodyweightID},
+					body:      models.BodyweightRequest{},
+					responses: map[int]any{http.StatusOK: models.BodyweightEntry{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+				{method: http.MethodDelete, summary: "Usunięcie pomiaru masy ciała", params: []openapi.Parameter{bodyweightID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			pattern: "/body-composition",
+			path:    "/body-composition",
+			handler: handlers.NewBodyCompositionHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "BMI, tłuszcz (US Navy) i FFMI z pomiarów masy i obwodów",
+					params: []openapi.Parameter{
+						queryParam("sex", "string", "male lub female"),
+						queryParam("height", "number", "wzrost w cm"),
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: models.BodyCompositionSeries{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			// Dziennik żywienia.
+			pattern: "/nutrition",
+			path:    "/nutrition",
+			handler: handlers.NewNutritionHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Wpisy dziennika żywienia od najnowszego",
+					params: []openapi.Parameter{
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: []models.NutritionEntry{}, http.StatusBadRequest: apiErr}},
+				{method: http.MethodPost, summary: "Dodanie posiłku albo sumy dnia",
+					body:      models.NutritionRequest{},
+					responses: map[int]any{http.StatusCreated: models.NutritionEntry{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/nutrition/weekly",
+			path:    "/nutrition/weekly",
+			handler: handlers.NewNutritionWeeklyHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Średnie dzienne kalorie i makroskładniki w tygodniach",
+					params: []openapi.Parameter{
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: []models.NutritionWeek{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/nutrition/{id}",
+			path:    "/nutrition/{id}",
+			handler: handlers.NewNutritionByIDHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Pobranie wpisu dziennika żywienia", params: []openapi.Parameter{nutritionID},
+					responses: map[int]any{http.StatusOK: models.NutritionEntry{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Zastąpienie wpisu dziennika żywienia", params: []openapi.Parameter{nutritionID},
+					body:      models.NutritionRequest{},
+					responses: map[int]any{http.StatusOK: models.NutritionEntry{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+				{method: http.MethodDelete, summary: "Usunięcie wpisu dziennika żywienia", params: []openapi.Parameter{nutritionID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Dziennik nawodnienia.
+			pattern: "/water",
+			path:    "/water",
+			handler: handlers.NewWaterHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Porcje wody od najnowszej",
+					params: []openapi.Parameter{
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: []models.WaterEntry{}, http.StatusBadRequest: apiErr}},
+				{method: http.MethodPost, summary: "Dodanie wypitej porcji wody",
+					body:      models.WaterRequest{},
+					responses: map[int]any{http.StatusCreated: models.WaterEntry{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/water/daily",
+			path:    "/water/daily",
+			handler: handlers.NewWaterDailyHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Dzienne sumy nawodnienia z postępem względem celu",
+					params: []openapi.Parameter{
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD, domyślnie dziś)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD, domyślnie dziś)"),
+					},
+					responses: map[int]any{http.StatusOK: []models.WaterDay{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/water/target",
+			path:    "/water/target",
+			handler: handlers.NewWaterTargetHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Dzienny cel nawodnienia",
+					responses: map[int]any{http.StatusOK: models.WaterTarget{}}},
+				{method: http.MethodPut, summary: "Ustawienie dziennego celu nawodnienia",
+					body:      models.WaterTarget{},
+					responses: map[int]any{http.StatusOK: models.WaterTarget{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/water/{id}",
+			path:    "/water/{id}",
+			handler: handlers.NewWaterByIDHandler(srv),
+			ops: []operation{
+				{method: http.MethodDelete, summary: "Usunięcie porcji wody", params: []openapi.Parameter{waterID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Ustawienia użytkownika.
+			pattern: "/profile",
+			path:    "/profile",
+			handler: handlers.NewProfileHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Profil użytkownika (jednostka ciężaru)",
+					responses: map[int]any{http.StatusOK: models.Profile{}}},
+				{method: http.MethodPut, summary: "Zmiana profilu użytkownika",
+					body:      models.Profile{},
+					responses: map[int]any{http.StatusOK: models.Profile{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			// Tętno maksymalne i strefy tętna.
+			pattern: "/heart-rate/max",
+			path:    "/heart-rate/max",
+			handler: handlers.NewHeartRateMaxHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Tętno maksymalne i strefy tętna",
+					responses: map[int]any{http.StatusOK: models.HeartRateSettings{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Ustawienie tętna maksymalnego",
+					body:      models.HeartRateSettings{},
+					responses: map[int]any{http.StatusOK: models.HeartRateSettings{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			// Dziennik snu.
+			pattern: "/sleep",
+			path:    "/sleep",
+			handler: handlers.NewSleepHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Noce od najnowszej",
+					params: []openapi.Parameter{
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: []models.SleepEntry{}, http.StatusBadRequest: apiErr}},
+				{method: http.MethodPost, summary: "Dodanie nocy (godziny albo czas snu, jakość 1–5)",
+					body:      models.SleepRequest{},
+					responses: map[int]any{http.StatusCreated: models.SleepEntry{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/sleep/summary",
+			path:    "/sleep/summary",
+			handler: handlers.NewSleepSummaryHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Średni sen i dług snu z ostatnich dni",
+					params: []openapi.Parameter{
+						queryParam("days", "integer", "długość okna w dniach (1–90, domyślnie 7)"),
+					},
+					responses: map[int]any{http.StatusOK: models.SleepSummary{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/sleep/{id}",
+			path:    "/sleep/{id}",
+			handler: handlers.NewSleepByIDHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Pobranie nocy", params: []openapi.Parameter{sleepID},
+					responses: map[int]any{http.StatusOK: models.SleepEntry{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Zastąpienie nocy", params: []openapi.Parameter{sleepID},
+					body:      models.SleepRequest{},
+					responses: map[int]any{http.StatusOK: models.SleepEntry{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+				{method: http.MethodDelete, summary: "Usunięcie nocy", params: []openapi.Parameter{sleepID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Codzienne oceny samopoczucia.
+			pattern: "/checkins",
+			path:    "/checkins",
+			handler: handlers.NewCheckinsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Oceny samopoczucia od najnowszej",
+					params: []openapi.Parameter{
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: []models.Checkin{}, http.StatusBadRequest: apiErr}},
+				{method: http.MethodPost, summary: "Dodanie oceny samopoczucia (1–5) z wyliczeniem gotowości",
+					body:      models.CheckinRequest{},
+					responses: map[int]any{http.StatusCreated: models.Checkin{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/checkins/{id}",
+			path:    "/checkins/{id}",
+			handler: handlers.NewCheckinByIDHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Pobranie oceny samopoczucia", params: []openapi.Parameter{checkinID},
+					responses: map[int]any{http.StatusOK: models.Checkin{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Zastąpienie oceny samopoczucia", params: []openapi.Parameter{checkinID},
+					body:      models.CheckinRequest{},
+					responses: map[int]any{http.StatusOK: models.Checkin{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+				{method: http.MethodDelete, summary: "Usunięcie oceny samopoczucia", params: []openapi.Parameter{checkinID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Dziennik urazów.
+			pattern: "/injuries",
+			path:    "/injuries",
+			handler: handlers.NewInjuriesHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Urazy od najnowszego",
+					params: []openapi.Parameter{
+						queryParam("status", "string", "active, recovering albo resolved"),
+					},
+					responses: map[int]any{http.StatusOK: []models.Injury{}, http.StatusBadRequest: apiErr}},
+				{method: http.MethodPost, summary: "Dodanie urazu z wykluczonymi ćwiczeniami",
+					body:      models.InjuryRequest{},
+					responses: map[int]any{http.StatusCreated: models.Injury{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/injuries/{id}",
+			path:    "/injuries/{id}",
+			handler: handlers.NewInjuryByIDHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Pobranie urazu", params: []openapi.Parameter{injuryID},
+					responses: map[int]any{http.StatusOK: models.Injury{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Zastąpienie urazu", params: []openapi.Parameter{injuryID},
+					body:      models.InjuryRequest{},
+					responses: map[int]any{http.StatusOK: models.Injury{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+				{method: http.MethodDelete, summary: "Usunięcie urazu", params: []openapi.Parameter{injuryID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Dziennik suplementacji.
+			pattern: "/supplements",
+			path:    "/supplements",
+			handler: handlers.NewSupplementsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Dawki suplementów od najnowszej",
+					params: []openapi.Parameter{
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: []models.SupplementIntake{}, http.StatusBadRequest: apiErr}},
+				{method: http.MethodPost, summary: "Dodanie przyjętej dawki suplementu",
+					body:      models.SupplementIntakeRequest{},
+					responses: map[int]any{http.StatusCreated: models.SupplementIntake{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/supplements/adherence",
+			path:    "/supplements/adherence",
+			handler: handlers.NewSupplementAdherenceHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Regularność przyjmowania suplementów",
+					params: []openapi.Parameter{
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD, domyślnie 30 dni przed to)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD, domyślnie dziś)"),
+					},
+					responses: map[int]any{http.StatusOK: []models.SupplementAdherence{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/supplements/{id}",
+			path:    "/supplements/{id}",
+			handler: handlers.NewSupplementByIDHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Pobranie dawki suplementu", params: []openapi.Parameter{supplementID},
+					responses: map[int]any{http.StatusOK: models.SupplementIntake{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Zastąpienie dawki suplementu", params: []openapi.Parameter{supplementID},
+					body:      models.SupplementIntakeRequest{},
+					responses: map[int]any{http.StatusOK: models.SupplementIntake{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+				{method: http.MethodDelete, summary: "Usunięcie dawki suplementu", params: []openapi.Parameter{supplementID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Pomiary obwodów ciała.
+			pattern: "/measurements",
+			path:    "/measurements",
+			handler: handlers.NewMeasurementsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Pomiary obwodów od najnowszego",
+					params: []openapi.Parameter{
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: []models.MeasurementEntry{}, http.StatusBadRequest: apiErr}},
+				{method: http.MethodPost, summary: "Dodanie pomiarów obwodów z jednego dnia",
+					body:      models.MeasurementRequest{},
+					responses: map[int]any{http.StatusCreated: models.MeasurementEntry{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/measurements/sites",
+			path:    "/measurements/sites",
+			handler: handlers.NewMeasurementSitesHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Miejsca pomiaru z ostatnim obwodem",
+					responses: map[int]any{http.StatusOK: []models.MeasurementSite{}}},
+			},
+		},
+		{
+			pattern: "/measurements/sites/{site}",
+			path:    "/measurements/sites/{site}",
+			handler: handlers.NewMeasurementHistoryHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Historia obwodu jednego miejsca",
+					params: []openapi.Parameter{
+						{Name: "site", In: "path", Required: true, Description: "ID miejsca pomiaru (np. waist)", Schema: &openapi.Schema{Type: "string"}},
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: models.MeasurementHistory{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			pattern: "/measurements/{id}",
+			path:    "/measurements/{id}",
+			handler: handlers.NewMeasurementByIDHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Pobranie pomiarów obwodów", params: []openapi.Parameter{measurementID},
+					responses: map[int]any{http.StatusOK: models.MeasurementEntry{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Zastąpienie pomiarów obwodów", params: []openapi.Parameter{measurementID},
+					body:      models.MeasurementRequest{},
+					responses: map[int]any{http.StatusOK: models.MeasurementEntry{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+				{method: http.MethodDelete, summary: "Usunięcie pomiarów obwodów", params: []openapi.Parameter{measurementID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Zdjęcia sylwetki (pliki w magazynie blob: pamięć, dysk albo S3).
+			pattern: "/photos",
+			path:    "/photos",
+			handler: handlers.NewPhotosHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Zdjęcia sylwetki od najnowszego",
+					params: []openapi.Parameter{
+						queryParam("from", "string", "data początkowa (YYYY-MM-DD)"),
+						queryParam("to", "string", "data końcowa (YYYY-MM-DD)"),
+						queryParam("pose", "string", "front, side albo back"),
+					},
+					responses: map[int]any{http.StatusOK: []models.Photo{}, http.StatusBadRequest: apiErr}},
+				{method: http.MethodPost, summary: "Upload zdjęcia (multipart/form-data: photo, date, pose, note)",
+					responses: map[int]any{http.StatusCreated: models.Photo{}, http.StatusBadRequest: apiErr,
+						http.StatusRequestEntityTooLarge: apiErr, http.StatusUnsupportedMediaType: apiErr}},
+			},
+		},
+		{
+			pattern: "/photos/{id}",
+			path:    "/photos/{id}",
+			handler: handlers.NewPhotoByIDHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Metadane zdjęcia", params: []openapi.Parameter{photoID},
+					responses: map[int]any{http.StatusOK: models.Photo{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodDelete, summary: "Usunięcie zdjęcia razem z plikiem", params: []openapi.Parameter{photoID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			pattern: "/photos/{id}/image",
+			path:    "/photos/{id}/image",
+			handler: handlers.NewPhotoImageHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Plik zdjęcia", params: []openapi.Parameter{photoID},
+					responses: map[int]any{http.StatusOK: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			pattern: "/achievements",
+			path:    "/achievements",
+			handler: handlers.NewAchievementsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Odznaki zdobyte i zablokowane, z postępem",
+					responses: map[int]any{http.StatusOK: models.AchievementList{}}},
+			},
+		},
+		{
+			// Wnioski z analizy treningów (zmęczenie, deload).
+			pattern: "/insights",
+			path:    "/insights",
+			handler: handlers.NewInsightsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Oznaki zmęczenia i propozycje deloadu",
+					params: []openapi.Parameter{
+						queryParam("window", "integer", "ile ostatnich sesji ćwiczenia analizować (2–10, domyślnie 3)"),
+						queryParam("refresh", "boolean", "policz raport od razu zamiast brać wynik zadania w tle"),
+					},
+					responses: map[int]any{http.StatusOK: models.InsightReport{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			// Ta sama zmiana dla wielu treningów naraz (wszystkie albo żaden).
+			pattern: "/workouts/batch",
+			path:    "/workouts/batch",
+			handler: handlers.NewWorkoutsBatchHandler(srv),
+			ops: []operation{
+				{method: http.MethodPatch, summary: "Zmiana wielu treningów naraz (JSON Merge Patch)",
+					body:      models.BatchUpdateRequest{},
+					responses: map[int]any{http.StatusOK: []models.Workout{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Pojedynczy trening po ID: GET, PUT, PATCH, DELETE.
+			pattern: "/workouts/",
+			path:    "/workouts/{id}",
+			handler: handlers.NewWorkoutByIDHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Pobranie treningu", params: []openapi.Parameter{workoutID, fieldsParam},
+					responses: map[int]any{http.StatusOK: models.Workout{}, http.StatusNotModified: nil, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Zastąpienie treningu", params: []openapi.Parameter{workoutID},
+					body:      models.ReplaceWorkoutRequest{},
+					responses: map[int]any{http.StatusOK: models.Workout{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr, http.StatusConflict: apiErr}},
+				{method: http.MethodPatch, summary: "Częściowa aktualizacja treningu (JSON Merge Patch)", params: []openapi.Parameter{workoutID},
+					body: models.UpdateWorkoutRequest{}, bodyType: handlers.MergePatchType,
+					responses: map[int]any{http.StatusOK: models.Workout{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr,
+						http.StatusConflict: apiErr, http.StatusUnsupportedMediaType: apiErr}},
+				{method: http.MethodDelete, summary: "Usunięcie treningu (przeniesienie do kosza)", params: []openapi.Parameter{workoutID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Katalog ćwiczeń.
+			pattern: "/exercises",
+			path:    "/exercises",
+			handler: handlers.NewExercisesHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Lista ćwiczeń z katalogu",
+					params: []openapi.Parameter{
+						queryParam("q", "string", "fragment nazwy lub aliasu"),
+						queryParam("muscleGroup", "string", "tylko ćwiczenia angażujące tę grupę mięśni (ID z /muscle-groups)"),
+						queryParam("primaryOnly", "boolean", "muscleGroup tylko wśród mięśni głównych"),
+						queryParam("equipment", "string", "tylko ćwiczenia z tym sprzętem"),
+						queryParam("type", "string", "strength, cardio lub mobility"),
+						queryParam("archived", "boolean", "pokazuje też zarchiwizowane ćwiczenia własne"),
+					},
+					responses: map[int]any{http.StatusOK: []models.CatalogExercise{}}},
+				{method: http.MethodPost, summary: "Dodanie własnego ćwiczenia do katalogu",
+					body:      models.CatalogExerciseRequest{},
+					responses: map[int]any{http.StatusCreated: models.CatalogExercise{}, http.StatusBadRequest: apiErr, http.StatusConflict: apiErr}},
+			},
+		},
+		{
+			// Podpowiedzi nazw przy wpisywaniu ćwiczenia.
+			pattern: "/exercises/suggest",
+			path:    "/exercises/suggest",
+			handler: handlers.NewExerciseSuggestHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Podpowiedzi nazw ćwiczeń (katalog i historia)",
+					params: []openapi.Parameter{
+						queryParam("q", "string", "wpisany fragment nazwy lub aliasu (wymagany)"),
+						queryParam("limit", "integer", "liczba podpowiedzi (1–50, domyślnie 10)"),
+					},
+					responses: map[int]any{http.StatusOK: []models.ExerciseSuggestion{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/exercises/{id}",
+			path:    "/exercises/{id}",
+			handler: handlers.NewExerciseByIDHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Pobranie ćwiczenia z katalogu", params: []openapi.Parameter{exerciseID},
+					responses: map[int]any{http.StatusOK: models.CatalogExercise{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Zastąpienie własnego ćwiczenia", params: []openapi.Parameter{exerciseID},
+					body: models.CatalogExerciseRequest{},
+					responses: map[int]any{http.StatusOK: models.CatalogExercise{}, http.StatusBadRequest: apiErr, http.StatusForbidden: apiErr,
+						http.StatusNotFound: apiErr, http.StatusConflict: apiErr}},
+				{method: http.MethodDelete, summary: "Usunięcie własnego ćwiczenia", params: []openapi.Parameter{exerciseID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusForbidden: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			pattern: "/exercises/{id}/archive",
+			path:    "/exercises/{id}/archive",
+			handler: handlers.NewExerciseArchiveHandler(srv, true),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Archiwizacja własnego ćwiczenia", params: []openapi.Parameter{exerciseID},
+					responses: map[int]any{http.StatusOK: models.CatalogExercise{}, http.StatusForbidden: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			pattern: "/exercises/{id}/unarchive",
+			path:    "/exercises/{id}/unarchive",
+			handler: handlers.NewExerciseArchiveHandler(srv, false),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Przywrócenie zarchiwizowanego ćwiczenia", params: []openapi.Parameter{exerciseID},
+					responses: map[int]any{http.StatusOK: models.CatalogExercise{}, http.StatusForbidden: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Opis i wskazówki techniczne wyświetlane obok ćwiczenia w treningu.
+			pattern: "/exercises/{id}/instructions",
+			path:    "/exercises/{id}/instructions",
+			handler: handlers.NewExerciseInstructionsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Instrukcje i multimedia ćwiczenia", params: []openapi.Parameter{exerciseID},
+					responses: map[int]any{http.StatusOK: models.ExerciseInstructions{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Zastąpienie instrukcji własnego ćwiczenia", params: []openapi.Parameter{exerciseID},
+					body: models.ExerciseInstructions{},
+					responses: map[int]any{http.StatusOK: models.ExerciseInstructions{}, http.StatusBadRequest: apiErr, http.StatusForbidden: apiErr,
+						http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Reguła progresji ćwiczenia dla /workouts/next-suggestion.
+			pattern: "/exercises/{id}/progression",
+			path:    "/exercises/{id}/progression",
+			handler: handlers.NewExerciseProgressionHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Reguła progresji ćwiczenia", params: []openapi.Parameter{exerciseID},
+					responses: map[int]any{http.StatusOK: models.ProgressionRule{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Ustawienie reguły progresji (liniowa lub zakres powtórzeń)", params: []openapi.Parameter{exerciseID},
+					body:      models.ProgressionRuleRequest{},
+					responses: map[int]any{http.StatusOK: models.ProgressionRule{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+				{method: http.MethodDelete, summary: "Powrót do domyślnej progresji liniowej", params: []openapi.Parameter{exerciseID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Wszystkie serie ćwiczenia (ocena postępów).
+			pattern: "/exercises/{id}/history",
+			path:    "/exercises/{id}/history",
+			handler: handlers.NewExerciseHistoryHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Historia serii ćwiczenia",
+					params: []openapi.Parameter{
+						exerciseID,
+						queryParam("limit", "integer", "rozmiar strony (1–200, domyślnie 50)"),
+						queryParam("offset", "integer", "liczba pominiętych serii"),
+						queryParam("from", "string", "początek zakresu (YYYY-MM-DD)"),
+						queryParam("to", "string", "koniec zakresu (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: models.ExerciseHistoryPage{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// "Ostatnio" podczas logowania treningu.
+			pattern: "/exercises/{id}/last",
+			path:    "/exercises/{id}/last",
+			handler: handlers.NewExerciseLastHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Serie z ostatniego wykonania ćwiczenia",
+					params: []openapi.Parameter{
+						exerciseID,
+						queryParam("excludeWorkoutId", "integer", "pomiń ten trening (np. właśnie wypełniany)"),
+					},
+					responses: map[int]any{http.StatusOK: models.LastExerciseSession{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Szacowany 1RM sesja po sesji (wykres postępów).
+			pattern: "/exercises/{id}/e1rm",
+			path:    "/exercises/{id}/e1rm",
+			handler: handlers.NewExerciseE1RMHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Szacowany 1RM ćwiczenia w czasie",
+					params: []openapi.Parameter{
+						exerciseID,
+						queryParam("formula", "string", "wzór: epley (domyślnie), brzycki lub lombardi"),
+						queryParam("from", "string", "początek zakresu (YYYY-MM-DD)"),
+						queryParam("to", "string", "koniec zakresu (YYYY-MM-DD)"),
+					},
+					responses: map[int]any{http.StatusOK: models.E1RMSeries{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Analiza stagnacji szacowanego 1RM.
+			pattern: "/exercises/{id}/plateau",
+			path:    "/exercises/{id}/plateau",
+			handler: handlers.NewExercisePlateauHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Stagnacja ćwiczenia i trend szacowanego 1RM",
+					params:    []openapi.Parameter{exerciseID, queryParam("weeks", "integer", "długość okna w tygodniach (2–26, domyślnie 4)")},
+					responses: map[int]any{http.StatusOK: models.PlateauReport{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Maks treningowy (TM) ćwiczenia dla programów procentowych.
+			pattern: "/exercises/{id}/training-max",
+			path:    "/exercises/{id}/training-max",
+			handler: handlers.NewExerciseTrainingMaxHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Maks treningowy ćwiczenia z historią i propozycją", params: []openapi.Parameter{exerciseID},
+					responses: map[int]any{http.StatusOK: models.TrainingMaxDetail{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Ustawienie maksa treningowego", params: []openapi.Parameter{exerciseID},
+					body:      models.TrainingMaxRequest{},
+					responses: map[int]any{http.StatusCreated: models.TrainingMax{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			pattern: "/exercises/{id}/training-max/adjust",
+			path:    "/exercises/{id}/training-max/adjust",
+			handler: handlers.NewTrainingMaxAdjustHandler(srv),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Korekta maksa treningowego o ciężar lub procent", params: []openapi.Parameter{exerciseID},
+					body:      models.TrainingMaxAdjustRequest{},
+					responses: map[int]any{http.StatusCreated: models.TrainingMax{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Taksonomia grup mięśni dla katalogu ćwiczeń.
+			pattern: "/muscle-groups",
+			path:    "/muscle-groups",
+			handler: handlers.NewMuscleGroupsHandler(),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Lista grup mięśni",
+					responses: map[int]any{http.StatusOK: []models.MuscleGroup{}}},
+			},
+		},
+		{
+			// Szablony treningów.
+			pattern: "/templates",
+			path:    "/templates",
+			handler: handlers.NewTemplatesHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Lista szablonów treningów",
+					responses: map[int]any{http.StatusOK: []models.WorkoutTemplate{}}},
+				{method: http.MethodPost, summary: "Dodanie szablonu treningu",
+					body:      models.TemplateRequest{},
+					responses: map[int]any{http.StatusCreated: models.WorkoutTemplate{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/templates/{id}",
+			path:    "/templates/{id}",
+			handler: handlers.NewTemplateByIDHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Pobranie szablonu", params: []openapi.Parameter{templateID},
+					responses: map[int]any{http.StatusOK: models.WorkoutTemplate{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Zastąpienie szablonu", params: []openapi.Parameter{templateID},
+					body:      models.TemplateRequest{},
+					responses: map[int]any{http.StatusOK: models.WorkoutTemplate{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+				{method: http.MethodDelete, summary: "Usunięcie szablonu", params: []openapi.Parameter{templateID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Rozpoczęcie treningu z szablonu z ciężarami z poprzedniej sesji.
+			pattern: "/templates/{id}/start",
+			path:    "/templates/{id}/start",
+			handler: handlers.NewTemplateStartHandler(srv),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Rozpoczęcie treningu z szablonu",
+					params: []openapi.Parameter{templateID,
+						queryParam("date", "string", "data treningu (YYYY-MM-DD, domyślnie dzisiaj)"),
+					},
+					responses: map[int]any{http.StatusCreated: models.Workout{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			pattern: "/training-maxes",
+			path:    "/training-maxes",
+			handler: handlers.NewTrainingMaxesHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Bieżące maksy treningowe",
+					responses: map[int]any{http.StatusOK: []models.TrainingMax{}}},
+			},
+		},
+		{
+			// Programy treningowe: tygodnie z sesjami opartymi o szablony.
+			pattern: "/programs",
+			path:    "/programs",
+			handler: handlers.NewProgramsHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Lista programów treningowych",
+					responses: map[int]any{http.StatusOK: []models.Program{}}},
+				{method: http.MethodPost, summary: "Dodanie programu treningowego",
+					body:      models.ProgramRequest{},
+					responses: map[int]any{http.StatusCreated: models.Program{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			// Wbudowane generatory programów (5/3/1, GZCLP).
+			pattern: "/programs/generate",
+			path:    "/programs/generate",
+			handler: handlers.NewProgramGenerateHandler(srv),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Wygenerowanie programu z maksów treningowych",
+					params: []openapi.Parameter{
+						queryParam("scheme", "string", "schemat programu: 531 lub gzclp (wymagany)"),
+					},
+					body:      models.GenerateProgramRequest{},
+					responses: map[int]any{http.StatusCreated: models.GeneratedProgram{}, http.StatusBadRequest: apiErr}},
+			},
+		},
+		{
+			pattern: "/programs/{id}",
+			path:    "/programs/{id}",
+			handler: handlers.NewProgramByIDHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Pobranie programu", params: []openapi.Parameter{programID},
+					responses: map[int]any{http.StatusOK: models.Program{}, http.StatusNotFound: apiErr}},
+				{method: http.MethodPut, summary: "Zastąpienie programu", params: []openapi.Parameter{programID},
+					body:      models.ProgramRequest{},
+					responses: map[int]any{http.StatusOK: models.Program{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+				{method: http.MethodDelete, summary: "Usunięcie programu", params: []openapi.Parameter{programID},
+					responses: map[int]any{http.StatusNoContent: nil, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Rozpisanie programu na kalendarz jako zaplanowane treningi.
+			pattern: "/programs/{id}/schedule",
+			path:    "/programs/{id}/schedule",
+			handler: handlers.NewProgramScheduleHandler(srv),
+			ops: []operation{
+				{method: http.MethodPost, summary: "Zaplanowanie programu od wybranego dnia", params: []openapi.Parameter{programID},
+					body:      models.ScheduleProgramRequest{},
+					responses: map[int]any{http.StatusCreated: []models.Workout{}, http.StatusBadRequest: apiErr, http.StatusNotFound: apiErr}},
+			},
+		},
+		{
+			// Dziennik zmian – tylko z tokenem administratora (GYM_ADMIN_TOKEN).
+			// Nie ma jeszcze kont, więc wszystkie wpisy mają aktora "anonymous".
+			pattern: "/audit",
+			path:    "/audit",
+			handler: handlers.NewAuditHandler(srv),
+			ops: []operation{
+				{method: http.MethodGet, summary: "Dziennik audytu",
+					params: []openapi.Parameter{
+						queryParam("limit", "integer", "rozmiar strony (1–200, domyślnie 50)"),
+						queryParam("offset", "integer", "liczba pominiętych wpisów"),
+						queryParam("resource", "string", "tylko wpisy dla danego typu zasobu, np. workout"),
+						queryParam("resourceId", "integer", "tylko wpisy dla zasobu o tym ID"),
+					},
+					responses: map[int]any{http.StatusOK: models.AuditPage{}, http.StatusBadRequest: apiErr, http.StatusUnauthorized: apiErr, http.StatusForbidden: apiErr}},
+			},
+		},
+	})
+}
@@ -0,0 +1,293 @@
+// Package applehealth czyta eksport Apple Health (export.xml z aplikacji Zdrowie,
+// także spakowany w export.zip) w zakresie potrzebnym do przeniesienia historii:
+// treningi oraz pomiary masy ciała i tkanki tłuszczowej. Plik z kilku lat ma setki MB,
+// więc czytamy go strumieniowo, element po elemencie.
+package applehealth
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// ErrNotExport oznacza plik XML, który nie jest eksportem Apple Health.
+var ErrNotExport = errors.New("applehealth: not an Apple Health export")
+
+// ErrNoExport oznacza archiwum ZIP bez pliku export.xml.
+var ErrNoExport = errors.New("applehealth: archive contains no export.xml")
+
+// timeLayout to format dat w eksporcie, np. "2024-03-01 07:15:42 +0100".
+const timeLayout = "2006-01-02 15:04:05 -0700"
+
+// Typy rekordów i statystyk HealthKit, które czytamy.
+const (
+	typeBodyMass  = "HKQuantityTypeIdentifierBodyMass"
+	typeBodyFat   = "HKQuantityTypeIdentifierBodyFatPercentage"
+	typeHeartRate = "HKQuantityTypeIdentifierHeartRate"
+	// Statystyki dystansu mają typy DistanceWalkingRunning, DistanceCycling itd.
+	typeDistancePrefix = "HKQuantityTypeIdentifierDistance"
+	activityPrefix     = "HKWorkoutActivityType"
+)
+
+// Workout to jeden trening z eksportu.
+type Workout struct {
+	Type          string    // typ aktywności bez prefiksu HKWorkoutActivityType, np. "Running"
+	Source        string    // aplikacja albo urządzenie, które zapisało trening
+	Start         time.Time // czas lokalny urządzenia zapisany jako UTC
+	Duration      time.Duration
+	Distance      float64 // m; 0 = brak
+	ElevationGain float64 // m; 0 = brak
+	AvgHR         float64 // uderzenia/min; 0 = brak (starsze eksporty)
+}
+
+// BodyMass to pomiar masy ciała, z tkanką tłuszczową zapisaną w tej samej chwili.
+type BodyMass struct {
+	At      time.Time // czas lokalny pomiaru zapisany jako UTC
+	Source  string
+	Weight  float64  // kg
+	BodyFat *float64 // %
+}
+
+// Export to dane odczytane z eksportu, w kolejności z pliku (od najstarszych).
+type Export struct {
+	Workouts []Workout
+	BodyMass []BodyMass
+}
+
+type workoutXML struct {
+	ActivityType      string  `xml:"workoutActivityType,attr"`
+	Duration          float64 `xml:"duration,attr"`
+	DurationUnit      string  `xml:"durationUnit,attr"`
+	TotalDistance     float64 `xml:"totalDistance,attr"`
+	TotalDistanceUnit string  `xml:"totalDistanceUnit,attr"`
+	SourceName        string  `xml:"sourceName,attr"`
+	StartDate         string  `xml:"startDate,attr"`
+	EndDate           string  `xml:"endDate,attr"`
+	Metadata          []struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:"value,attr"`
+	} `xml:"MetadataEntry"`
+	// Od iOS 16 dystans i tętno są tylko w statystykach treningu.
+	Statistics []struct {
+		Type    string  `xml:"type,attr"`
+		Sum     float64 `xml:"sum,attr"`
+		Average float64 `xml:"average,attr"`
+		Unit    string  `xml:"unit,attr"`
+	} `xml:"WorkoutStatistics"`
+}
+
+type recordXML struct {
+	Type       string  `xml:"type,attr"`
+	SourceName string  `xml:"sourceName,attr"`
+	Unit       string  `xml:"unit,attr"`
+	StartDate  string  `xml:"startDate,attr"`
+	Value      float64 `xml:"value,attr"`
+}
+
+// Parse czyta export.xml. Treningi i pomiary z nieczytelną datą pomijamy; błąd
+// zwracamy tylko dla pliku, który nie jest poprawnym eksportem.
+func Parse(r io.Reader) (Export, error) {
+	var out Export
+	fat := make(map[time.Time]float64)
+	d := xml.NewDecoder(r)
+	root := true
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Export{}, fmt.Errorf("applehealth: %w", err)
+		}
+		el, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root {
+			if el.Name.Local != "HealthData" {
+				return Export{}, ErrNotExport
+			}
+			root = false
+			continue
+		}
+
+		switch el.Name.Local {
+		case "Workout":
+			var wx workoutXML
+			if err := d.DecodeElement(&wx, &el); err != nil {
+				return Export{}, fmt.Errorf("applehealth: %w", err)
+			}
+			if w, ok := workout(wx); ok {
+				out.Workouts = append(out.Workouts, w)
+			}
+		case "Record":
+			switch attr(el, "type") {
+			case typeBodyMass, typeBodyFat:
+			default:
+				// Rekordów (kroki, tętno...) są miliony; pozostałych nie dekodujemy.
+				if err := d.Skip(); err != nil {
+					return Export{}, fmt.Errorf("applehealth: %w", err)
+				}
+				continue
+			}
+			var rx recordXML
+			if err := d.DecodeElement(&rx, &el); err != nil {
+				return Export{}, fmt.Errorf("applehealth: %w", err)
+			}
+			at, err := localTime(rx.StartDate)
+			if err != nil {
+				continue
+			}
+			if rx.Type == typeBodyFat {
+				// Apple zapisuje ułamek (0.18 = 18%).
+				fat[at] = rx.Value * 100
+				continue
+			}
+			weight, ok := kilograms(rx.Value, rx.Unit)
+			if !ok {
+				continue
+			}
+			out.BodyMass = append(out.BodyMass, BodyMass{At: at, Source: rx.SourceName, Weight: weight})
+		default:
+			// Me, ActivitySummary, ClinicalRecord... – pomijamy całe poddrzewo.
+			if err := d.Skip(); err != nil {
+				return Export{}, fmt.Errorf("applehealth: %w", err)
+			}
+		}
+	}
+	if root {
+		return Export{}, ErrNotExport
+	}
+	for i := range out.BodyMass {
+		if v, ok := fat[out.BodyMass[i].At]; ok {
+			out.BodyMass[i].BodyFat = &v
+		}
+	}
+	return out, nil
+}
+
+// ParseZip czyta export.xml z archiwum export.zip (katalog apple_health_export/).
+func ParseZip(r io.ReaderAt, size int64) (Export, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return Export{}, fmt.Errorf("applehealth: %w", err)
+	}
+	for _, f := range zr.File {
+		// export_cda.xml (dokumenty kliniczne) ma inną nazwę, więc go nie złapiemy.
+		if path.Base(f.Name) != "export.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return Export{}, fmt.Errorf("applehealth: %w", err)
+		}
+		defer rc.Close()
+		return Parse(rc)
+	}
+	return Export{}, ErrNoExport
+}
+
+func workout(wx workoutXML) (Workout, bool) {
+	start, err := localTime(wx.StartDate)
+	if err != nil {
+		return Workout{}, false
+	}
+	w := Workout{
+		Type:     strings.TrimPrefix(wx.ActivityType, activityPrefix),
+		Source:   wx.SourceName,
+		Start:    start,
+		Duration: duration(wx.Duration, wx.DurationUnit),
+		Distance: meters(wx.TotalDistance, wx.TotalDistanceUnit),
+	}
+	if w.Duration <= 0 {
+		if end, err := localTime(wx.EndDate); err == nil {
+			w.Duration = end.Sub(start)
+		}
+	}
+	for _, st := range wx.Statistics {
+		switch {
+		case st.Type == typeHeartRate:
+			w.AvgHR = st.Average
+		case strings.HasPrefix(st.Type, typeDistancePrefix) && w.Distance == 0:
+			w.Distance = meters(st.Sum, st.Unit)
+		}
+	}
+	for _, m := range wx.Metadata {
+		if m.Key != "HKElevationAscended" {
+			continue
+		}
+		// Wartość z jednostką, np. "4520 cm".
+		num, unit, _ := strings.Cut(m.Value, " ")
+		if v, err := strconv.ParseFloat(num, 64); err == nil {
+			w.ElevationGain = meters(v, unit)
+		}
+	}
+	return w, true
+}
+
+// localTime czyta datę z eksportu i zwraca czas lokalny urządzenia zapisany jako UTC,
+// tak jak pomiary z innych importów.
+func localTime(s string) (time.Time, error) {
+	t, err := time.Parse(timeLayout, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC), nil
+}
+
+func duration(v float64, unit string) time.Duration {
+	switch unit {
+	case "s":
+		return time.Duration(v * float64(time.Second))
+	case "hr":
+		return time.Duration(v * float64(time.Hour))
+	default: // min
+		return time.Duration(v * float64(time.Minute))
+	}
+}
+
+func meters(v float64, unit string) float64 {
+	switch unit {
+	case "km":
+		return v * 1000
+	case "mi":
+		return v * 1609.344
+	case "yd":
+		return v * 0.9144
+	case "ft":
+		return v * 0.3048
+	case "cm":
+		return v / 100
+	default: // m
+		return v
+	}
+}
+
+func kilograms(v float64, unit string) (float64, bool) {
+	switch unit {
+	case "kg":
+		return v, true
+	case "lb":
+		return v * models.KgPerLb, true
+	case "g":
+		return v / 1000, true
+	}
+	return 0, false
+}
+
+func attr(el xml.StartElement, name string) string {
+	for _, a := range el.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+// Package blob przechowuje pliki binarne (na razie zdjęcia sylwetki) poza magazynami
+// danych. Implementacje: pamięć (domyślna, jak reszta danych), dysk lokalny i S3
+// (albo zgodny z S3 serwer, np. MinIO). Wybór backendu należy do konfiguracji (patrz main).
+package blob
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrNotFound zwraca Get dla nieistniejącego klucza.
+var ErrNotFound = errors.New("blob not found")
+
+// Store zapisuje pliki pod kluczami w postaci ścieżek, np. "photos/3f2a.jpg".
+type Store interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	// Get zwraca zawartość i typ MIME pliku; ErrNotFound, gdy go nie ma.
+	Get(ctx context.Context, key string) ([]byte, string, error)
+	// Delete usuwa plik; brak pliku nie jest błędem.
+	Delete(ctx context.Context, key string) error
+}
+
+type memoryBlob struct {
+	data        []byte
+	contentType string
+}
+
+// Memory trzyma pliki w pamięci procesu; znikają po restarcie, tak jak pozostałe dane.
+type Memory struct {
+	mu    sync.RWMutex
+	items map[string]memoryBlob
+}
+
+// NewMemory tworzy pusty magazyn w pamięci.
+func NewMemory() *Memory {
+	return &Memory{items: map[string]memoryBlob{}}
+}
+
+func (m *Memory) Put(_ context.Context, key, contentType string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.items[key] = memoryBlob{data: append([]byte(nil), data...), contentType: contentType}
+	return nil
+}
+
+func (m *Memory) Get(_ context.Context, key string) ([]byte, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, ok := m.items[key]
+	if !ok {
+		return nil, "", ErrNotFound
+	}
+	return b.data, b.contentType, nil
+}
+
+func (m *Memory) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.items, key)
+	return nil
+}
@@ -0,0 +1,53 @@
+// Package bodycomp liczy wskaźniki składu ciała: BMI, tłuszcz wg wzoru US Navy
+// (z obwodów) i FFMI (indeks beztłuszczowej masy ciała). Masa w kg, obwody i wzrost w cm.
+package bodycomp
+
+import "math"
+
+// Płeć używana we wzorach (jak w pakiecie standards).
+const (
+	Male   = "male"
+	Female = "female"
+)
+
+// BMI zwraca wskaźnik masy ciała.
+func BMI(weight, height float64) float64 {
+	m := height / 100
+	return weight / (m * m)
+}
+
+// NavyBodyFat zwraca procent tkanki tłuszczowej wg wzoru US Navy. Kobiety wymagają
+// też obwodu bioder. ok = false, gdy brakuje obwodu albo obwody nie pozwalają policzyć
+// wyniku (np. talia nie większa od szyi).
+func NavyBodyFat(sex string, height, neck, waist, hips float64) (float64, bool) {
+	if neck <= 0 || waist <= 0 || height <= 0 {
+		return 0, false
+	}
+	var bf float64
+	switch sex {
+	case Male:
+		if waist <= neck {
+			return 0, false
+		}
+		bf = 495/(1.0324-0.19077*math.Log10(waist-neck)+0.15456*math.Log10(height)) - 450
+	case Female:
+		if hips <= 0 || waist+hips <= neck {
+			return 0, false
+		}
+		bf = 495/(1.29579-0.35004*math.Log10(waist+hips-neck)+0.22100*math.Log10(height)) - 450
+	default:
+		return 0, false
+	}
+	if bf <= 0 || bf >= 75 {
+		return 0, false
+	}
+	return bf, true
+}
+
+// FFMI zwraca indeks beztłuszczowej masy ciała i jego wersję znormalizowaną do 180 cm
+// (porównywalną między osobami różnego wzrostu).
+func FFMI(weight, height, bodyFat float64) (ffmi, normalized float64) {
+	m := height / 100
+	ffmi = weight * (1 - bodyFat/100) / (m * m)
+	return ffmi, ffmi + 6.1*(1.8-m)
+}
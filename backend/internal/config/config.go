@@ -0,0 +1,560 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config zbiera ustawienia uruchomieniowe aplikacji.
+// Kolejność (od najsłabszej): wartości domyślne, plik konfiguracyjny,
+// zmienne środowiskowe, flagi.
+type Config struct {
+	BindAddr    string   // adres interfejsu, np. "0.0.0.0"; pusty = wszystkie
+	Port        int      // port HTTP API
+	CORSOrigins []string // dozwolone originy; "*" = dowolny
+	// CORSCredentials pozwala na żądania z ciasteczkami/Authorization z dozwolonych originów.
+	CORSCredentials bool
+	MaxBodyBytes    int64         // maksymalny rozmiar body żądania JSON
+	Storage         string        // backend magazynu danych (obecnie tylko "memory")
+	TrashRetention  time.Duration // jak długo usunięte treningi czekają w koszu; 0 = bez limitu
+	ExerciseSeed    string        // zrzut wger do zasilenia katalogu; pusty = zestaw wbudowany, "none" = bez importu
+	LogLevel        slog.Level    // minimalny poziom logów
+	PprofAddr       string        // adres serwera pprof; pusty = wyłączony
+	AdminToken      string        // token (Authorization: Bearer) do endpointów administracyjnych; pusty = wyłączone
+	TLS             TLSConfig
+	Security        SecurityConfig
+	Blob            BlobConfig
+	Strava          StravaConfig
+	Garmin          IntegrationConfig
+	GoogleFit       IntegrationConfig
+	Fitbit          IntegrationConfig
+}
+
+// IntegrationConfig opisuje aplikację OAuth zarejestrowaną w zewnętrznym serwisie;
+// bez ClientID integracja jest wyłączona.
+type IntegrationConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string        // publiczny adres GET /api/v1/integrations/{serwis}/callback
+	SyncInterval time.Duration // co ile synchronizujemy połączone konta; 0 = tylko ręcznie
+}
+
+// Enabled informuje, czy integracja jest skonfigurowana.
+func (c IntegrationConfig) Enabled() bool {
+	return c.ClientID != ""
+}
+
+// StravaConfig włącza synchronizację treningów ze Stravą (aplikacja zarejestrowana
+// na https://www.strava.com/settings/api).
+type StravaConfig struct {
+	IntegrationConfig
+	VerifyToken string // token potwierdzenia subskrypcji webhooka; pusty = bez webhooków
+}
+
+// BlobConfig wybiera magazyn plików (zdjęcia sylwetki): "memory", "local" (katalog Dir)
+// albo "s3" (bucket S3 lub zgodnego serwera).
+type BlobConfig struct {
+	Backend     string
+	Dir         string // katalog dla backendu local
+	S3Endpoint  string // pusty = AWS
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+}
+
+// SecurityConfig steruje nagłówkami bezpieczeństwa; pusty tekst wyłącza dany nagłówek.
+type SecurityConfig struct {
+	FrameOptions          string // X-Frame-Options
+	ContentSecurityPolicy string // Content-Security-Policy
+	HSTSMaxAge            int    // sekundy; 0 = bez HSTS (wysyłany tylko przy TLS)
+	HSTSIncludeSubdomains bool
+}
+
+// TLSConfig opisuje HTTPS: albo własny certyfikat (CertFile/KeyFile),
+// albo automatyczne certyfikaty Let's Encrypt dla podanych domen (autocert).
+type TLSConfig struct {
+	CertFile         string
+	KeyFile          string
+	AutocertDomains  []string
+	AutocertCacheDir string // katalog na pobrane certyfikaty i klucz konta ACME
+	AutocertEmail    string // opcjonalny kontakt dla Let's Encrypt
+	ACMEHTTPAddr     string // adres serwera HTTP dla wyzwań ACME i przekierowań na HTTPS
+}
+
+// Enabled informuje, czy serwer ma działać po HTTPS.
+func (t TLSConfig) Enabled() bool {
+	return t.CertFile != "" || t.KeyFile != "" || t.Autocert()
+}
+
+// Autocert informuje, czy certyfikaty mają być pobierane automatycznie.
+func (t TLSConfig) Autocert() bool {
+	return len(t.AutocertDomains) > 0
+}
+
+// Addr zwraca adres nasłuchu w formacie host:port.
+func (c Config) Addr() string {
+	return net.JoinHostPort(c.BindAddr, strconv.Itoa(c.Port))
+}
+
+// Obsługiwane backendy magazynu danych.
+var storageBackends = []string{"memory"}
+
+// Obsługiwane backendy magazynu plików.
+var blobBackends = []string{"memory", "local", "s3"}
+
+// Default zwraca konfigurację domyślną (zgodną z dotychczasowym zachowaniem).
+func Default() Config {
+	return Config{
+		Port:         8080,
+		CORSOrigins:  []string{"*"},
+		MaxBodyBytes: 1 << 20,
+		Storage:      "memory",
+		Blob:         BlobConfig{Backend: "memory", Dir: "blobs"},
+		Strava:       StravaConfig{IntegrationConfig: IntegrationConfig{SyncInterval: time.Hour}},
+		Garmin:       IntegrationConfig{SyncInterval: time.Hour},
+		GoogleFit:    IntegrationConfig{SyncInterval: time.Hour},
+		Fitbit:       IntegrationConfig{SyncInterval: time.Hour},
+		// Miesiąc wystarcza, żeby zauważyć i cofnąć przypadkowe usunięcie.
+		TrashRetention: 30 * 24 * time.Hour,
+		LogLevel:       slog.LevelInfo,
+		TLS: TLSConfig{
+			AutocertCacheDir: "autocert-cache",
+			ACMEHTTPAddr:     ":80",
+		},
+		// API zwraca tylko JSON, więc domyślnie niczego nie pozwalamy ładować ani osadzać.
+		Security: SecurityConfig{
+			FrameOptions:          "DENY",
+			ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+			HSTSMaxAge:            365 * 24 * 60 * 60,
+		},
+	}
+}
+
+// Load buduje konfigurację z wartości domyślnych, opcjonalnego pliku
+// (--config lub GYM_CONFIG), zmiennych środowiskowych i flag wiersza poleceń
+// (args bez nazwy programu).
+func Load(args []string) (Config, error) {
+	// Flagi parsujemy na początku, bo wskazują plik, ale stosujemy je na końcu.
+	var flagged Config
+	var configPath string
+	fs := newFlagSet(&flagged, &configPath)
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	cfg := Default()
+	if configPath == "" {
+		configPath = os.Getenv("GYM_CONFIG")
+	}
+	if configPath != "" {
+		if err := applyFile(&cfg, configPath); err != nil {
+			return Config{}, err
+		}
+	}
+	if err := applyEnv(&cfg, os.Getenv); err != nil {
+		return Config{}, err
+	}
+	applyFlags(&cfg, fs, flagged)
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// applyEnv nadpisuje pola konfiguracji ustawionymi zmiennymi środowiskowymi.
+// PORT nie ma prefiksu, bo tak ustawiają go platformy kontenerowe.
+func applyEnv(cfg *Config, getenv func(string) string) error {
+	if v := getenv("PORT"); v != "" {
+		port, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("PORT: %q is not a number", v)
+		}
+		cfg.Port = port
+	}
+	if v := getenv("GYM_BIND_ADDR"); v != "" {
+		cfg.BindAddr = v
+	}
+	if v := getenv("GYM_CORS_ORIGINS"); v != "" {
+		cfg.CORSOrigins = splitList(v)
+	}
+	if v := getenv("GYM_CORS_CREDENTIALS"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("GYM_CORS_CREDENTIALS: %q is not a boolean", v)
+		}
+		cfg.CORSCredentials = b
+	}
+	if v := getenv("GYM_MAX_BODY_BYTES"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("GYM_MAX_BODY_BYTES: %q is not a number", v)
+		}
+		cfg.MaxBodyBytes = n
+	}
+	if v := getenv("GYM_STORAGE"); v != "" {
+		cfg.Storage = v
+	}
+	if v := getenv("GYM_TRASH_RETENTION"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("GYM_TRASH_RETENTION: %q is not a duration", v)
+		}
+		cfg.TrashRetention = d
+	}
+	if v := getenv("GYM_EXERCISE_SEED"); v != "" {
+		cfg.ExerciseSeed = v
+	}
+	if v := getenv("GYM_BLOB_BACKEND"); v != "" {
+		cfg.Blob.Backend = v
+	}
+	if v := getenv("GYM_BLOB_DIR"); v != "" {
+		cfg.Blob.Dir = v
+	}
+	if v := getenv("GYM_S3_ENDPOINT"); v != "" {
+		cfg.Blob.S3Endpoint = v
+	}
+	if v := getenv("GYM_S3_REGION"); v != "" {
+		cfg.Blob.S3Region = v
+	}
+	if v := getenv("GYM_S3_BUCKET"); v != "" {
+		cfg.Blob.S3Bucket = v
+	}
+	// Klucze S3 czytamy ze standardowych zmiennych AWS (bez flag, żeby nie trafiały do ps).
+	if v := getenv("AWS_ACCESS_KEY_ID"); v != "" {
+		cfg.Blob.S3AccessKey = v
+	}
+	if v := getenv("AWS_SECRET_ACCESS_KEY"); v != "" {
+		cfg.Blob.S3SecretKey = v
+	}
+	if v := getenv("GYM_STRAVA_CLIENT_ID"); v != "" {
+		cfg.Strava.ClientID = v
+	}
+	// Sekret i token webhooka tylko ze środowiska albo pliku (bez flag, żeby nie trafiały do ps).
+	if v := getenv("GYM_STRAVA_CLIENT_SECRET"); v != "" {
+		cfg.Strava.ClientSecret = v
+	}
+	if v := getenv("GYM_STRAVA_VERIFY_TOKEN"); v != "" {
+		cfg.Strava.VerifyToken = v
+	}
+	if v := getenv("GYM_STRAVA_REDIRECT_URL"); v != "" {
+		cfg.Strava.RedirectURL = v
+	}
+	if v := getenv("GYM_STRAVA_SYNC_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("GYM_STRAVA_SYNC_INTERVAL: %q is not a duration", v)
+		}
+		cfg.Strava.SyncInterval = d
+	}
+	if v := getenv("GYM_GARMIN_CLIENT_ID"); v != "" {
+		cfg.Garmin.ClientID = v
+	}
+	if v := getenv("GYM_GARMIN_CLIENT_SECRET"); v != "" {
+		cfg.Garmin.ClientSecret = v
+	}
+	if v := getenv("GYM_GARMIN_REDIRECT_URL"); v != "" {
+		cfg.Garmin.RedirectURL = v
+	}
+	if v := getenv("GYM_GARMIN_SYNC_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("GYM_GARMIN_SYNC_INTERVAL: %q is not a duration", v)
+		}
+		cfg.Garmin.SyncInterval = d
+	}
+	if v := getenv("GYM_GOOGLEFIT_CLIENT_ID"); v != "" {
+		cfg.GoogleFit.ClientID = v
+	}
+	if v := getenv("GYM_GOOGLEFIT_CLIENT_SECRET"); v != "" {
+		cfg.GoogleFit.ClientSecret = v
+	}
+	if v := getenv("GYM_GOOGLEFIT_REDIRECT_URL"); v != "" {
+		cfg.GoogleFit.RedirectURL = v
+	}
+	if v := getenv("GYM_GOOGLEFIT_SYNC_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("GYM_GOOGLEFIT_SYNC_INTERVAL: %q is not a duration", v)
+		}
+		cfg.GoogleFit.SyncInterval = d
+	}
+	if v := getenv("GYM_FITBIT_CLIENT_ID"); v != "" {
+		cfg.Fitbit.ClientID = v
+	}
+	if v := getenv("GYM_FITBIT_CLIENT_SECRET"); v != "" {
+		cfg.Fitbit.ClientSecret = v
+	}
+	if v := getenv("GYM_FITBIT_REDIRECT_URL"); v != "" {
+		cfg.Fitbit.RedirectURL = v
+	}
+	if v := getenv("GYM_FITBIT_SYNC_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("GYM_FITBIT_SYNC_INTERVAL: %q is not a duration", v)
+		}
+		cfg.Fitbit.SyncInterval = d
+	}
+	if v := getenv("GYM_LOG_LEVEL"); v != "" {
+		if err := cfg.LogLevel.UnmarshalText([]byte(v)); err != nil {
+			return fmt.Errorf("GYM_LOG_LEVEL: %w", err)
+		}
+	}
+	if v := getenv("GYM_PPROF_ADDR"); v != "" {
+		cfg.PprofAddr = v
+	}
+	if v := getenv("GYM_ADMIN_TOKEN"); v != "" {
+		cfg.AdminToken = v
+	}
+	if v := getenv("GYM_TLS_CERT"); v != "" {
+		cfg.TLS.CertFile = v
+	}
+	if v := getenv("GYM_TLS_KEY"); v != "" {
+		cfg.TLS.KeyFile = v
+	}
+	if v := getenv("GYM_AUTOCERT_DOMAINS"); v != "" {
+		cfg.TLS.AutocertDomains = splitList(v)
+	}
+	if v := getenv("GYM_AUTOCERT_CACHE"); v != "" {
+		cfg.TLS.AutocertCacheDir = v
+	}
+	if v := getenv("GYM_AUTOCERT_EMAIL"); v != "" {
+		cfg.TLS.AutocertEmail = v
+	}
+	if v := getenv("GYM_ACME_HTTP_ADDR"); v != "" {
+		cfg.TLS.ACMEHTTPAddr = v
+	}
+	if v := getenv("GYM_FRAME_OPTIONS"); v != "" {
+		cfg.Security.FrameOptions = v
+	}
+	if v := getenv("GYM_CSP"); v != "" {
+		cfg.Security.ContentSecurityPolicy = v
+	}
+	if v := getenv("GYM_HSTS_MAX_AGE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("GYM_HSTS_MAX_AGE: %q is not a number", v)
+		}
+		cfg.Security.HSTSMaxAge = n
+	}
+	if v := getenv("GYM_HSTS_INCLUDE_SUBDOMAINS"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("GYM_HSTS_INCLUDE_SUBDOMAINS: %q is not a boolean", v)
+		}
+		cfg.Security.HSTSIncludeSubdomains = b
+	}
+	return nil
+}
+
+// newFlagSet definiuje flagi zapisujące wartości do dst; path dostaje ścieżkę --config.
+func newFlagSet(dst *Config, path *string) *flag.FlagSet {
+	def := Default()
+	fs := flag.NewFlagSet("gym-api", flag.ContinueOnError)
+	fs.StringVar(path, "config", "", "ścieżka do pliku konfiguracyjnego (.yaml, .yml lub .toml)")
+	fs.StringVar(&dst.BindAddr, "bind", def.BindAddr, "adres interfejsu do nasłuchu (pusty = wszystkie)")
+	fs.IntVar(&dst.Port, "port", def.Port, "port HTTP API")
+	fs.Func("cors-origins", "lista dozwolonych originów CORS rozdzielona przecinkami (\"*\" = dowolny)", func(v string) error {
+		dst.CORSOrigins = splitList(v)
+		return nil
+	})
+	fs.BoolVar(&dst.CORSCredentials, "cors-credentials", def.CORSCredentials, "zezwól na żądania CORS z ciasteczkami (wymaga jawnej listy originów)")
+	fs.Int64Var(&dst.MaxBodyBytes, "max-body-bytes", def.MaxBodyBytes, "maksymalny rozmiar body żądania w bajtach")
+	fs.StringVar(&dst.Storage, "storage", def.Storage, "backend magazynu danych: "+strings.Join(storageBackends, ", "))
+	fs.DurationVar(&dst.TrashRetention, "trash-retention", def.TrashRetention, "po jakim czasie usunięte treningi znikają z kosza (0 = nigdy)")
+	fs.StringVar(&dst.ExerciseSeed, "exercise-seed", def.ExerciseSeed, `plik JSON z eksportem wger (/api/v2/exerciseinfo/) do zasilenia katalogu ćwiczeń; pusty = zestaw wbudowany, "none" = bez importu`)
+	fs.StringVar(&dst.Blob.Backend, "blob-backend", def.Blob.Backend, "magazyn plików (zdjęcia): "+strings.Join(blobBackends, ", "))
+	fs.StringVar(&dst.Blob.Dir, "blob-dir", def.Blob.Dir, "katalog plików dla magazynu local")
+	fs.StringVar(&dst.Blob.S3Endpoint, "s3-endpoint", def.Blob.S3Endpoint, "adres serwera zgodnego z S3 (pusty = AWS)")
+	fs.StringVar(&dst.Blob.S3Region, "s3-region", def.Blob.S3Region, "region S3, np. eu-central-1")
+	fs.StringVar(&dst.Blob.S3Bucket, "s3-bucket", def.Blob.S3Bucket, "bucket S3 na pliki")
+	fs.StringVar(&dst.Strava.ClientID, "strava-client-id", def.Strava.ClientID, "Client ID aplikacji Stravy (pusty = integracja wyłączona)")
+	fs.StringVar(&dst.Strava.RedirectURL, "strava-redirect-url", def.Strava.RedirectURL, "publiczny adres powrotu z autoryzacji Stravy (.../api/v1/integrations/strava/callback)")
+	fs.DurationVar(&dst.Strava.SyncInterval, "strava-sync-interval", def.Strava.SyncInterval, "co ile synchronizować połączone konta Strava (0 = tylko ręcznie i z webhooków)")
+	fs.StringVar(&dst.Garmin.ClientID, "garmin-client-id", def.Garmin.ClientID, "Client ID aplikacji Garmin Connect (pusty = integracja wyłączona)")
+	fs.StringVar(&dst.Garmin.RedirectURL, "garmin-redirect-url", def.Garmin.RedirectURL, "publiczny adres powrotu z autoryzacji Garmina (.../api/v1/integrations/garmin/callback)")
+	fs.DurationVar(&dst.Garmin.SyncInterval, "garmin-sync-interval", def.Garmin.SyncInterval, "co ile synchronizować połączone konta Garmin (0 = tylko ręcznie)")
+	fs.StringVar(&dst.GoogleFit.ClientID, "googlefit-client-id", def.GoogleFit.ClientID, "Client ID aplikacji Google (Fitness API; pusty = integracja wyłączona)")
+	fs.StringVar(&dst.GoogleFit.RedirectURL, "googlefit-redirect-url", def.GoogleFit.RedirectURL, "publiczny adres powrotu z autoryzacji Google (.../api/v1/integrations/googlefit/callback)")
+	fs.DurationVar(&dst.GoogleFit.SyncInterval, "googlefit-sync-interval", def.GoogleFit.SyncInterval, "co ile synchronizować połączone konta Google Fit (0 = tylko ręcznie)")
+	fs.StringVar(&dst.Fitbit.ClientID, "fitbit-client-id", def.Fitbit.ClientID, "Client ID aplikacji Fitbit (pusty = integracja wyłączona)")
+	fs.StringVar(&dst.Fitbit.RedirectURL, "fitbit-redirect-url", def.Fitbit.RedirectURL, "publiczny adres powrotu z autoryzacji Fitbit (.../api/v1/integrations/fitbit/callback)")
+	fs.DurationVar(&dst.Fitbit.SyncInterval, "fitbit-sync-interval", def.Fitbit.SyncInterval, "co ile synchronizować połączone konta Fitbit (0 = tylko ręcznie)")
+	fs.TextVar(&dst.LogLevel, "log-level", def.LogLevel, "poziom logów: debug, info, warn, error")
+	fs.StringVar(&dst.PprofAddr, "pprof-addr", def.PprofAddr, "adres (np. localhost:6060) osobnego serwera z endpointami pprof; pusty = wyłączone")
+	fs.StringVar(&dst.TLS.CertFile, "tls-cert", def.TLS.CertFile, "ścieżka do certyfikatu TLS (PEM)")
+	fs.StringVar(&dst.TLS.KeyFile, "tls-key", def.TLS.KeyFile, "ścieżka do klucza prywatnego TLS (PEM)")
+	fs.Func("autocert-domains", "domeny rozdzielone przecinkami, dla których pobieramy certyfikaty Let's Encrypt", func(v string) error {
+		dst.TLS.AutocertDomains = splitList(v)
+		return nil
+	})
+	fs.StringVar(&dst.TLS.AutocertCacheDir, "autocert-cache", def.TLS.AutocertCacheDir, "katalog cache certyfikatów autocert")
+	fs.StringVar(&dst.TLS.AutocertEmail, "autocert-email", def.TLS.AutocertEmail, "adres e-mail konta Let's Encrypt")
+	fs.StringVar(&dst.TLS.ACMEHTTPAddr, "acme-http-addr", def.TLS.ACMEHTTPAddr, "adres serwera HTTP dla wyzwań ACME (autocert)")
+	fs.StringVar(&dst.Security.FrameOptions, "frame-options", def.Security.FrameOptions, "wartość X-Frame-Options (pusta = bez nagłówka)")
+	fs.StringVar(&dst.Security.ContentSecurityPolicy, "csp", def.Security.ContentSecurityPolicy, "wartość Content-Security-Policy (pusta = bez nagłówka)")
+	fs.IntVar(&dst.Security.HSTSMaxAge, "hsts-max-age", def.Security.HSTSMaxAge, "max-age nagłówka HSTS w sekundach, wysyłanego przy TLS (0 = wyłączony)")
+	fs.BoolVar(&dst.Security.HSTSIncludeSubdomains, "hsts-include-subdomains", def.Security.HSTSIncludeSubdomains, "dodaj includeSubDomains do HSTS")
+	return fs
+}
+
+// applyFlags kopiuje do cfg tylko te flagi, które faktycznie podano,
+// więc nieużyte flagi nie nadpisują ustawień z pliku i środowiska.
+func applyFlags(cfg *Config, fs *flag.FlagSet, flagged Config) {
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "bind":
+			cfg.BindAddr = flagged.BindAddr
+		case "port":
+			cfg.Port = flagged.Port
+		case "cors-origins":
+			cfg.CORSOrigins = flagged.CORSOrigins
+		case "cors-credentials":
+			cfg.CORSCredentials = flagged.CORSCredentials
+		case "max-body-bytes":
+			cfg.MaxBodyBytes = flagged.MaxBodyBytes
+		case "storage":
+			cfg.Storage = flagged.Storage
+		case "trash-retention":
+			cfg.TrashRetention = flagged.TrashRetention
+		case "exercise-seed":
+			cfg.ExerciseSeed = flagged.ExerciseSeed
+		case "blob-backend":
+			cfg.Blob.Backend = flagged.Blob.Backend
+		case "blob-dir":
+			cfg.Blob.Dir = flagged.Blob.Dir
+		case "s3-endpoint":
+			cfg.Blob.S3Endpoint = flagged.Blob.S3Endpoint
+		case "s3-region":
+			cfg.Blob.S3Region = flagged.Blob.S3Region
+		case "s3-bucket":
+			cfg.Blob.S3Bucket = flagged.Blob.S3Bucket
+		case "strava-client-id":
+			cfg.Strava.ClientID = flagged.Strava.ClientID
+		case "strava-redirect-url":
+			cfg.Strava.RedirectURL = flagged.Strava.RedirectURL
+		case "strava-sync-interval":
+			cfg.Strava.SyncInterval = flagged.Strava.SyncInterval
+		case "garmin-client-id":
+			cfg.Garmin.ClientID = flagged.Garmin.ClientID
+		case "garmin-redirect-url":
+			cfg.Garmin.RedirectURL = flagged.Garmin.RedirectURL
+		case "garmin-sync-interval":
+			cfg.Garmin.SyncInterval = flagged.Garmin.SyncInterval
+		case "googlefit-client-id":
+			cfg.GoogleFit.ClientID = flagged.GoogleFit.ClientID
+		case "googlefit-redirect-url":
+			cfg.GoogleFit.RedirectURL = flagged.GoogleFit.RedirectURL
+		case "googlefit-sync-interval":
+			cfg.GoogleFit.SyncInterval = flagged.GoogleFit.SyncInterval
+		case "fitbit-client-id":
+			cfg.Fitbit.ClientID = flagged.Fitbit.ClientID
+		case "fitbit-redirect-url":
+			cfg.Fitbit.RedirectURL = flagged.Fitbit.RedirectURL
+		case "fitbit-sync-interval":
+			cfg.Fitbit.SyncInterval = flagged.Fitbit.SyncInterval
+		case "log-level":
+			cfg.LogLevel = flagged.LogLevel
+		case "pprof-addr":
+			cfg.PprofAddr = flagged.PprofAddr
+		case "tls-cert":
+			cfg.TLS.CertFile = flagged.TLS.CertFile
+		case "tls-key":
+			cfg.TLS.KeyFile = flagged.TLS.KeyFile
+		case "autocert-domains":
+			cfg.TLS.AutocertDomains = flagged.TLS.AutocertDomains
+		case "autocert-cache":
+			cfg.TLS.AutocertCacheDir = flagged.TLS.AutocertCacheDir
+		case "autocert-email":
+			cfg.TLS.AutocertEmail = flagged.TLS.AutocertEmail
+		case "acme-http-addr":
+			cfg.TLS.ACMEHTTPAddr = flagged.TLS.ACMEHTTPAddr
+		case "frame-options":
+			cfg.Security.FrameOptions = flagged.Security.FrameOptions
+		case "csp":
+			cfg.Security.ContentSecurityPolicy = flagged.Security.ContentSecurityPolicy
+		case "hsts-max-age":
+			cfg.Security.HSTSMaxAge = flagged.Security.HSTSMaxAge
+		case "hsts-include-subdomains":
+			cfg.Security.HSTSIncludeSubdomains = flagged.Security.HSTSIncludeSubdomains
+		}
+	})
+}
+
+// Validate sprawdza spójność konfiguracji i zwraca czytelny błąd.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Port < 1 || c.Port > 65535 {
+		errs = append(errs, fmt.Errorf("port must be between 1 and 65535, got %d", c.Port))
+	}
+	if c.MaxBodyBytes <= 0 {
+		errs = append(errs, fmt.Errorf("max body bytes must be positive, got %d", c.MaxBodyBytes))
+	}
+	if !slices.Contains(storageBackends, c.Storage) {
+		errs = append(errs, fmt.Errorf("unknown storage backend %q (supported: %s)", c.Storage, strings.Join(storageBackends, ", ")))
+	}
+	if !slices.Contains(blobBackends, c.Blob.Backend) {
+		errs = append(errs, fmt.Errorf("unknown blob backend %q (supported: %s)", c.Blob.Backend, strings.Join(blobBackends, ", ")))
+	}
+	if c.Blob.Backend == "local" && c.Blob.Dir == "" {
+		errs = append(errs, errors.New("blob: local backend requires a directory"))
+	}
+	if c.Blob.Backend == "s3" && (c.Blob.S3Region == "" || c.Blob.S3Bucket == "" || c.Blob.S3AccessKey == "" || c.Blob.S3SecretKey == "") {
+		errs = append(errs, errors.New("blob: s3 backend requires region, bucket and AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY"))
+	}
+	errs = append(errs, c.Strava.validate("strava", "GYM_STRAVA_CLIENT_SECRET")...)
+	errs = append(errs, c.Garmin.validate("garmin", "GYM_GARMIN_CLIENT_SECRET")...)
+	errs = append(errs, c.GoogleFit.validate("googlefit", "GYM_GOOGLEFIT_CLIENT_SECRET")...)
+	errs = append(errs, c.Fitbit.validate("fitbit", "GYM_FITBIT_CLIENT_SECRET")...)
+	if c.TrashRetention < 0 {
+		errs = append(errs, errors.New("storage: trash retention cannot be negative"))
+	}
+	if len(c.CORSOrigins) == 0 {
+		errs = append(errs, errors.New("at least one CORS origin is required"))
+	}
+	if c.CORSCredentials && slices.Contains(c.CORSOrigins, "*") {
+		errs = append(errs, errors.New(`cors: credentials cannot be combined with the "*" origin, list origins explicitly`))
+	}
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		errs = append(errs, errors.New("tls: both cert and key files must be set"))
+	}
+	if c.TLS.CertFile != "" && c.TLS.Autocert() {
+		errs = append(errs, errors.New("tls: use either cert/key files or autocert domains, not both"))
+	}
+	if c.TLS.Autocert() && c.TLS.AutocertCacheDir == "" {
+		errs = append(errs, errors.New("tls: autocert requires a cache directory"))
+	}
+	if c.Security.HSTSMaxAge < 0 {
+		errs = append(errs, errors.New("security: hsts max age cannot be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+func (c IntegrationConfig) validate(name, secretEnv string) []error {
+	var errs []error
+	if c.Enabled() && (c.ClientSecret == "" || c.RedirectURL == "") {
+		errs = append(errs, fmt.Errorf("%s: client id requires %s and a redirect URL", name, secretEnv))
+	}
+	if c.SyncInterval < 0 {
+		errs = append(errs, fmt.Errorf("%s: sync interval cannot be negative", name))
+	}
+	return errs
+}
+
+func splitList(v string) []string {
+	var out []string
+	for _, part := range strings.Split(v, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
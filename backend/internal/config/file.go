@@ -0,0 +1,333 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig odwzorowuje plik konfiguracyjny (YAML lub TOML).
+// Pola są wskaźnikami, żeby odróżnić "nie podano" od wartości zerowej.
+type fileConfig struct {
+	Server    fileServer   `yaml:"server" toml:"server"`
+	Storage   fileStorage  `yaml:"storage" toml:"storage"`
+	Log       fileLog      `yaml:"log" toml:"log"`
+	TLS       fileTLS      `yaml:"tls" toml:"tls"`
+	Security  fileSecurity `yaml:"security" toml:"security"`
+	Blob      fileBlob     `yaml:"blob" toml:"blob"`
+	Strava    fileStrava   `yaml:"strava" toml:"strava"`
+	Garmin    fileOAuthApp `yaml:"garmin" toml:"garmin"`
+	GoogleFit fileOAuthApp `yaml:"googlefit" toml:"googlefit"`
+	Fitbit    fileOAuthApp `yaml:"fitbit" toml:"fitbit"`
+}
+
+type fileServer struct {
+	Bind            *string  `yaml:"bind" toml:"bind"`
+	Port            *int     `yaml:"port" toml:"port"`
+	CORSOrigins     []string `yaml:"cors_origins" toml:"cors_origins"`
+	CORSCredentials *bool    `yaml:"cors_credentials" toml:"cors_credentials"`
+	PprofAddr       *string  `yaml:"pprof_addr" toml:"pprof_addr"`
+	AdminToken      *string  `yaml:"admin_token" toml:"admin_token"`
+	MaxBodyBytes    *int64   `yaml:"max_body_bytes" toml:"max_body_bytes"`
+}
+
+type fileStorage struct {
+	Backend        *string `yaml:"backend" toml:"backend"`
+	TrashRetention *string `yaml:"trash_retention" toml:"trash_retention"` // np. "720h"
+	ExerciseSeed   *string `yaml:"exercise_seed" toml:"exercise_seed"`
+}
+
+type fileBlob struct {
+	Backend *string `yaml:"backend" toml:"backend"`
+	Dir     *string `yaml:"dir" toml:"dir"`
+	S3      fileS3  `yaml:"s3" toml:"s3"`
+}
+
+type fileS3 struct {
+	Endpoint  *string `yaml:"endpoint" toml:"endpoint"`
+	Region    *string `yaml:"region" toml:"region"`
+	Bucket    *string `yaml:"bucket" toml:"bucket"`
+	AccessKey *string `yaml:"access_key" toml:"access_key"`
+	SecretKey *string `yaml:"secret_key" toml:"secret_key"`
+}
+
+type fileOAuthApp struct {
+	ClientID     *string `yaml:"client_id" toml:"client_id"`
+	ClientSecret *string `yaml:"client_secret" toml:"client_secret"`
+	RedirectURL  *string `yaml:"redirect_url" toml:"redirect_url"`
+	SyncInterval *string `yaml:"sync_interval" toml:"sync_interval"` // np. "1h"
+}
+
+// apply nadpisuje ustawienia aplikacji OAuth podane w sekcji section pliku.
+func (f fileOAuthApp) apply(dst *IntegrationConfig, section string) error {
+	if v := f.ClientID; v != nil {
+		dst.ClientID = *v
+	}
+	if v := f.ClientSecret; v != nil {
+		dst.ClientSecret = *v
+	}
+	if v := f.RedirectURL; v != nil {
+		dst.RedirectURL = *v
+	}
+	if v := f.SyncInterval; v != nil {
+		d, err := time.ParseDuration(*v)
+		if err != nil {
+			return fmt.Errorf("%s.sync_interval: %q is not a duration", section, *v)
+		}
+		dst.SyncInterval = d
+	}
+	return nil
+}
+
+type fileStrava struct {
+	ClientID     *string `yaml:"client_id" toml:"client_id"`
+	ClientSecret *string `yaml:"client_secret" toml:"client_secret"`
+	RedirectURL  *string `yaml:"redirect_url" toml:"redirect_url"`
+	SyncInterval *string `yaml:"sync_interval" toml:"sync_interval"`
+	VerifyToken  *string `yaml:"verify_token" toml:"verify_token"`
+}
+
+type fileLog struct {
+	Level *string `yaml:"level" toml:"level"`
+}
+
+type fileTLS struct {
+	CertFile *string      `yaml:"cert_file" toml:"cert_file"`
+	KeyFile  *string      `yaml:"key_file" toml:"key_file"`
+	Autocert fileAutocert `yaml:"autocert" toml:"autocert"`
+}
+
+type fileAutocert struct {
+	Domains  []string `yaml:"domains" toml:"domains"`
+	CacheDir *string  `yaml:"cache_dir" toml:"cache_dir"`
+	Email    *string  `yaml:"email" toml:"email"`
+	HTTPAddr *string  `yaml:"http_addr" toml:"http_addr"`
+}
+
+type fileSecurity struct {
+	FrameOptions          *string `yaml:"frame_options" toml:"frame_options"`
+	ContentSecurityPolicy *string `yaml:"content_security_policy" toml:"content_security_policy"`
+	HSTSMaxAge            *int    `yaml:"hsts_max_age" toml:"hsts_max_age"`
+	HSTSIncludeSubdomains *bool   `yaml:"hsts_include_subdomains" toml:"hsts_include_subdomains"`
+}
+
+// applyFile wczytuje plik konfiguracyjny i nadpisuje podane w nim pola cfg.
+// Format wybieramy po rozszerzeniu (.yaml/.yml lub .toml).
+// Nieznane klucze są błędem, a komunikat podpowiada najbliższą poprawną nazwę.
+func applyFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+
+	var raw map[string]any
+	var fc fileConfig
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+		if err := checkKeys(raw); err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+		if err := yaml.Unmarshal(data, &fc); err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+	case ".toml":
+		if err := toml.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+		if err := checkKeys(raw); err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+		if err := toml.Unmarshal(data, &fc); err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("config file %s: unsupported extension %q (use .yaml, .yml or .toml)", path, ext)
+	}
+
+	if v := fc.Server.Bind; v != nil {
+		cfg.BindAddr = *v
+	}
+	if v := fc.Server.Port; v != nil {
+		cfg.Port = *v
+	}
+	if fc.Server.CORSOrigins != nil {
+		cfg.CORSOrigins = fc.Server.CORSOrigins
+	}
+	if v := fc.Server.CORSCredentials; v != nil {
+		cfg.CORSCredentials = *v
+	}
+	if v := fc.Server.MaxBodyBytes; v != nil {
+		cfg.MaxBodyBytes = *v
+	}
+	if v := fc.Server.PprofAddr; v != nil {
+		cfg.PprofAddr = *v
+	}
+	if v := fc.Server.AdminToken; v != nil {
+		cfg.AdminToken = *v
+	}
+	if v := fc.Storage.Backend; v != nil {
+		cfg.Storage = *v
+	}
+	if v := fc.Storage.TrashRetention; v != nil {
+		d, err := time.ParseDuration(*v)
+		if err != nil {
+			return fmt.Errorf("storage.trash_retention: %q is not a duration", *v)
+		}
+		cfg.TrashRetention = d
+	}
+	if v := fc.Storage.ExerciseSeed; v != nil {
+		cfg.ExerciseSeed = *v
+	}
+	if v := fc.Blob.Backend; v != nil {
+		cfg.Blob.Backend = *v
+	}
+	if v := fc.Blob.Dir; v != nil {
+		cfg.Blob.Dir = *v
+	}
+	if v := fc.Blob.S3.Endpoint; v != nil {
+		cfg.Blob.S3Endpoint = *v
+	}
+	if v := fc.Blob.S3.Region; v != nil {
+		cfg.Blob.S3Region = *v
+	}
+	if v := fc.Blob.S3.Bucket; v != nil {
+		cfg.Blob.S3Bucket = *v
+	}
+	if v := fc.Blob.S3.AccessKey; v != nil {
+		cfg.Blob.S3AccessKey = *v
+	}
+	if v := fc.Blob.S3.SecretKey; v != nil {
+		cfg.Blob.S3SecretKey = *v
+	}
+	strava := fileOAuthApp{fc.Strava.ClientID, fc.Strava.ClientSecret, fc.Strava.RedirectURL, fc.Strava.SyncInterval}
+	if err := strava.apply(&cfg.Strava.IntegrationConfig, "strava"); err != nil {
+		return err
+	}
+	if v := fc.Strava.VerifyToken; v != nil {
+		cfg.Strava.VerifyToken = *v
+	}
+	if err := fc.Garmin.apply(&cfg.Garmin, "garmin"); err != nil {
+		return err
+	}
+	if err := fc.GoogleFit.apply(&cfg.GoogleFit, "googlefit"); err != nil {
+		return err
+	}
+	if err := fc.Fitbit.apply(&cfg.Fitbit, "fitbit"); err != nil {
+		return err
+	}
+	if v := fc.TLS.CertFile; v != nil {
+		cfg.TLS.CertFile = *v
+	}
+	if v := fc.TLS.KeyFile; v != nil {
+		cfg.TLS.KeyFile = *v
+	}
+	if fc.TLS.Autocert.Domains != nil {
+		cfg.TLS.AutocertDomains = fc.TLS.Autocert.Domains
+	}
+	if v := fc.TLS.Autocert.CacheDir; v != nil {
+		cfg.TLS.AutocertCacheDir = *v
+	}
+	if v := fc.TLS.Autocert.Email; v != nil {
+		cfg.TLS.AutocertEmail = *v
+	}
+	if v := fc.TLS.Autocert.HTTPAddr; v != nil {
+		cfg.TLS.ACMEHTTPAddr = *v
+	}
+	if v := fc.Security.FrameOptions; v != nil {
+		cfg.Security.FrameOptions = *v
+	}
+	if v := fc.Security.ContentSecurityPolicy; v != nil {
+		cfg.Security.ContentSecurityPolicy = *v
+	}
+	if v := fc.Security.HSTSMaxAge; v != nil {
+		cfg.Security.HSTSMaxAge = *v
+	}
+	if v := fc.Security.HSTSIncludeSubdomains; v != nil {
+		cfg.Security.HSTSIncludeSubdomains = *v
+	}
+	if v := fc.Log.Level; v != nil {
+		if err := cfg.LogLevel.UnmarshalText([]byte(*v)); err != nil {
+			return fmt.Errorf("config file %s: log.level: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// checkKeys porównuje klucze z pliku ze znanymi polami fileConfig
+// i zwraca błąd wymieniający wszystkie literówki.
+func checkKeys(raw map[string]any) error {
+	var unknown []string
+	walkKeys(raw, reflect.TypeOf(fileConfig{}), "", &unknown)
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("unknown keys: %s", strings.Join(unknown, "; "))
+}
+
+func walkKeys(raw map[string]any, t reflect.Type, prefix string, unknown *[]string) {
+	known := make(map[string]reflect.Type, t.NumField())
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("yaml")
+		known[name] = f.Type
+		names = append(names, name)
+	}
+
+	for key, val := range raw {
+		ft, ok := known[key]
+		if !ok {
+			msg := prefix + key
+			if s := closest(key, names); s != "" {
+				msg += " (did you mean " + prefix + s + "?)"
+			}
+			*unknown = append(*unknown, msg)
+			continue
+		}
+		if nested, isMap := val.(map[string]any); isMap && ft.Kind() == reflect.Struct {
+			walkKeys(nested, ft, prefix+key+".", unknown)
+		}
+	}
+}
+
+// closest zwraca najbardziej podobną nazwę (odległość Levenshteina <= 2) lub "".
+func closest(key string, names []string) string {
+	best, bestDist := "", 3
+	for _, n := range names {
+		if d := levenshtein(key, n); d < bestDist {
+			best, bestDist = n, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
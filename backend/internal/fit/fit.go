@@ -0,0 +1,388 @@
+// Package fit dekoduje pliki FIT (format zegarków Garmina i zgodnych) w zakresie
+// potrzebnym do importu treningów: podsumowania sesji (sport, czas, dystans, tętno,
+// przewyższenie), zapis tętna i serie ćwiczeń siłowych zapisane na zegarku.
+package fit
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Błędy dekodowania.
+var (
+	ErrNotFIT   = errors.New("fit: not a FIT file")
+	ErrChecksum = errors.New("fit: checksum mismatch")
+)
+
+// Numery globalnych wiadomości i pól z profilu FIT, których używamy.
+const (
+	mesgRecord   = 20
+	mesgSession  = 18
+	mesgActivity = 34
+	mesgSet      = 225
+
+	fieldTimestamp = 253
+
+	sessionStartTime     = 2
+	sessionSport         = 5
+	sessionSubSport      = 6
+	sessionElapsedTime   = 7 // ms
+	sessionTimerTime     = 8 // ms
+	sessionTotalDistance = 9 // cm
+	sessionAvgHeartRate  = 16
+	sessionTotalAscent   = 22 // m
+
+	recordHeartRate = 3
+
+	activityLocalTimestamp = 5 // czas lokalny urządzenia w chwili zapisu
+
+	setDuration    = 0 // ms
+	setRepetitions = 3
+	setWeight      = 4 // kg × 16
+	setType        = 5 // 0 = odpoczynek, 1 = seria
+	setStartTime   = 6
+	setCategory    = 7
+)
+
+// Sporty z profilu FIT (enum sport), które rozpoznajemy.
+const (
+	SportGeneric  = 0
+	SportRunning  = 1
+	SportCycling  = 2
+	SportTraining = 10
+	SportWalking  = 11
+	SportRowing   = 15
+	SportHiking   = 17
+)
+
+// fitEpoch to początek skali czasu FIT (31.12.1989 UTC).
+var fitEpoch = time.Date(1989, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// File to zdekodowana zawartość pliku.
+type File struct {
+	Sessions []Session
+	Sets     []Set      // tylko serie robocze (bez przerw), w kolejności z pliku
+	HR       []HRSample // tętno z zapisu co sekundę (wiadomości record)
+	// Location = strefa urządzenia (przesunięcie z wiadomości activity); nil, gdy plik jej nie podaje.
+	Location *time.Location
+}
+
+// HRSample to pomiar tętna z zapisu aktywności.
+type HRSample struct {
+	At  time.Time
+	BPM int
+}
+
+// Session to podsumowanie jednej aktywności.
+type Session struct {
+	Sport    int
+	SubSport int
+	Start    time.Time
+	Elapsed  time.Duration
+	Timer    time.Duration // czas bez pauz
+	Distance float64       // m
+	AvgHR    int           // 0 = brak
+	Ascent   float64       // m; 0 = brak
+}
+
+// Set to seria ćwiczenia siłowego.
+type Set struct {
+	Start    time.Time
+	Duration time.Duration
+	Reps     int      // 0 = nie zapisano
+	Weight   *float64 // kg
+	Category int      // enum exercise_category z profilu FIT; -1 = brak
+}
+
+type fieldDef struct {
+	num, size, baseType byte
+}
+
+type definition struct {
+	global    uint16
+	order     binary.ByteOrder
+	fields    []fieldDef
+	devFields int // łączny rozmiar pól deweloperskich do pominięcia
+}
+
+// Decode czyta cały plik FIT. Nieznane wiadomości i pola pomija.
+func Decode(r io.Reader) (*File, error) {
+	br := bufio.NewReader(r)
+	head := make([]byte, 12)
+	if _, err := io.ReadFull(br, head); err != nil {
+		return nil, ErrNotFIT
+	}
+	size := int(head[0])
+	if (size != 12 && size != 14) || string(head[8:12]) != ".FIT" {
+		return nil, ErrNotFIT
+	}
+	if size == 14 {
+		extra := make([]byte, 2)
+		if _, err := io.ReadFull(br, extra); err != nil {
+			return nil, ErrNotFIT
+		}
+		head = append(head, extra...)
+	}
+	// Rozmiar z nagłówka nie jest wiarygodny: nie rezerwujemy go z góry, tylko czytamy
+	// tyle, ile faktycznie przyszło (długość strumienia ogranicza wywołujący).
+	n := int64(binary.LittleEndian.Uint32(head[4:8]))
+	data, err := io.ReadAll(io.LimitReader(br, n))
+	if err != nil {
+		return nil, fmt.Errorf("fit: read: %w", err)
+	}
+	if int64(len(data)) < n {
+		return nil, fmt.Errorf("fit: truncated file: %w", io.ErrUnexpectedEOF)
+	}
+	var crc [2]byte
+	if _, err := io.ReadFull(br, crc[:]); err != nil {
+		return nil, fmt.Errorf("fit: truncated file: %w", err)
+	}
+	// CRC obejmuje nagłówek i dane; 0 oznacza, że urządzenie go nie policzyło.
+	if want := binary.LittleEndian.Uint16(crc[:]); want != 0 && checksum(checksum(0, head), data) != want {
+		return nil, ErrChecksum
+	}
+	return decodeRecords(data)
+}
+
+func decodeRecords(data []byte) (*File, error) {
+	var (
+		out       File
+		defs      [16]*definition
+		timestamp uint32
+		pos       int
+	)
+	read := func(n int) ([]byte, error) {
+		if pos+n > len(data) {
+			return nil, errors.New("fit: truncated record")
+		}
+		b := data[pos : pos+n]
+		pos += n
+		return b, nil
+	}
+	for pos < len(data) {
+		hdr := data[pos]
+		pos++
+		local := hdr & 0x0F
+		compressed := hdr&0x80 != 0
+		if compressed {
+			// Nagłówek ze skompresowanym czasem: 2 bity typu lokalnego, 5 bitów przesunięcia.
+			local = (hdr >> 5) & 0x03
+			offset := uint32(hdr & 0x1F)
+			if offset >= timestamp&0x1F {
+				timestamp = timestamp&^0x1F + offset
+			} else {
+				timestamp = timestamp&^0x1F + offset + 0x20
+			}
+		} else if hdr&0x40 != 0 {
+			def, err := readDefinition(read, hdr&0x20 != 0)
+			if err != nil {
+				return nil, err
+			}
+			defs[local] = def
+			continue
+		}
+
+		def := defs[local]
+		if def == nil {
+			return nil, fmt.Errorf("fit: data message without definition (local type %d)", local)
+		}
+		values := map[byte]uint64{}
+		for _, f := range def.fields {
+			b, err := read(int(f.size))
+			if err != nil {
+				return nil, err
+			}
+			if v, ok := decodeValue(b, f.baseType, def.order); ok {
+				values[f.num] = v
+			}
+		}
+		if _, err := read(def.devFields); err != nil {
+			return nil, err
+		}
+		if ts, ok := values[fieldTimestamp]; ok {
+			timestamp = uint32(ts)
+		} else if compressed {
+			values[fieldTimestamp] = uint64(timestamp)
+		}
+
+		switch def.global {
+		case mesgSession:
+			out.Sessions = append(out.Sessions, session(values))
+		case mesgSet:
+			if s, ok := set(values); ok {
+				out.Sets = append(out.Sets, s)
+			}
+		case mesgActivity:
+			local, ok := values[activityLocalTimestamp]
+			if ts, tsOK := values[fieldTimestamp]; ok && tsOK {
+				out.Location = time.FixedZone("", int(int64(local)-int64(ts)))
+			}
+		case mesgRecord:
+			hr, ok := values[recordHeartRate]
+			if ts, tsOK := values[fieldTimestamp]; ok && tsOK && hr > 0 {
+				out.HR = append(out.HR, HRSample{At: toTime(ts), BPM: int(hr)})
+			}
+		}
+	}
+	return &out, nil
+}
+
+func readDefinition(read func(int) ([]byte, error), developer bool) (*definition, error) {
+	b, err := read(5)
+	if err != nil {
+		return nil, err
+	}
+	def := &definition{order: binary.LittleEndian}
+	if b[1] == 1 {
+		def.order = binary.BigEndian
+	}
+	def.global = def.order.Uint16(b[2:4])
+	for range int(b[4]) {
+		f, err := read(3)
+		if err != nil {
+			return nil, err
+		}
+		def.fields = append(def.fields, fieldDef{num: f[0], size: f[1], baseType: f[2]})
+	}
+	if developer {
+		n, err := read(1)
+		if err != nil {
+			return nil, err
+		}
+		for range int(n[0]) {
+			f, err := read(3)
+			if err != nil {
+				return nil, err
+			}
+			def.devFields += int(f[1])
+		}
+	}
+	return def, nil
+}
+
+// decodeValue czyta pierwszy element pola całkowitoliczbowego (tablice, np. kategorie
+// ćwiczenia, mają kilka). ok = false dla wartości "brak danych" i typów, których nie
+// potrzebujemy (tekst, liczby zmiennoprzecinkowe).
+func decodeValue(b []byte, baseType byte, order binary.ByteOrder) (uint64, bool) {
+	var (
+		v       uint64
+		invalid uint64
+		width   int
+	)
+	zeroInvalid := false
+	switch baseType & 0x1F {
+	case 0x00, 0x02: // enum, uint8
+		width, invalid = 1, 0xFF
+	case 0x0A: // uint8z
+		width, zeroInvalid = 1, true
+	case 0x04: // uint16
+		width, invalid = 2, 0xFFFF
+	case 0x0B: // uint16z
+		width, zeroInvalid = 2, true
+	case 0x06: // uint32
+		width, invalid = 4, 0xFFFFFFFF
+	case 0x0C: // uint32z
+		width, zeroInvalid = 4, true
+	default:
+		return 0, false
+	}
+	if len(b) < width {
+		return 0, false
+	}
+	switch width {
+	case 1:
+		v = uint64(b[0])
+	case 2:
+		v = uint64(order.Uint16(b))
+	case 4:
+		v = uint64(order.Uint32(b))
+	}
+	if zeroInvalid && v == 0 || !zeroInvalid && v == invalid {
+		return 0, false
+	}
+	return v, true
+}
+
+func session(v map[byte]uint64) Session {
+	s := Session{Sport: SportGeneric}
+	if x, ok := v[sessionSport]; ok {
+		s.Sport = int(x)
+	}
+	if x, ok := v[sessionSubSport]; ok {
+		s.SubSport = int(x)
+	}
+	if x, ok := v[sessionStartTime]; ok {
+		s.Start = toTime(x)
+	} else if x, ok := v[fieldTimestamp]; ok {
+		s.Start = toTime(x)
+	}
+	if x, ok := v[sessionElapsedTime]; ok {
+		s.Elapsed = time.Duration(x) * time.Millisecond
+	}
+	if x, ok := v[sessionTimerTime]; ok {
+		s.Timer = time.Duration(x) * time.Millisecond
+	}
+	if x, ok := v[sessionTotalDistance]; ok {
+		s.Distance = float64(x) / 100
+	}
+	if x, ok := v[sessionAvgHeartRate]; ok {
+		s.AvgHR = int(x)
+	}
+	if x, ok := v[sessionTotalAscent]; ok {
+		s.Ascent = float64(x)
+	}
+	return s
+}
+
+// set zwraca serię roboczą; ok = false dla przerw między seriami.
+func set(v map[byte]uint64) (Set, bool) {
+	if t, ok := v[setType]; ok && t == 0 {
+		return Set{}, false
+	}
+	s := Set{Category: -1}
+	if x, ok := v[setStartTime]; ok {
+		s.Start = toTime(x)
+	} else if x, ok := v[fieldTimestamp]; ok {
+		s.Start = toTime(x)
+	}
+	if x, ok := v[setDuration]; ok {
+		s.Duration = time.Duration(x) * time.Millisecond
+	}
+	if x, ok := v[setRepetitions]; ok {
+		s.Reps = int(x)
+	}
+	if x, ok := v[setWeight]; ok {
+		kg := float64(x) / 16
+		s.Weight = &kg
+	}
+	if x, ok := v[setCategory]; ok {
+		s.Category = int(x)
+	}
+	return s, true
+}
+
+func toTime(v uint64) time.Time {
+	return fitEpoch.Add(time.Duration(v) * time.Second)
+}
+
+var crcTable = [16]uint16{
+	0x0000, 0xCC01, 0xD801, 0x1400, 0xF001, 0x3C00, 0x2800, 0xE401,
+	0xA001, 0x6C00, 0x7800, 0xB401, 0x5000, 0x9C01, 0x8801, 0x4400,
+}
+
+// checksum liczy CRC-16 z dokumentacji FIT.
+func checksum(crc uint16, data []byte) uint16 {
+	for _, b := range data {
+		tmp := crcTable[crc&0xF]
+		crc = (crc >> 4) & 0x0FFF
+		crc = crc ^ tmp ^ crcTable[b&0xF]
+		tmp = crcTable[crc&0xF]
+		crc = (crc >> 4) & 0x0FFF
+		crc = crc ^ tmp ^ crcTable[(b>>4)&0xF]
+	}
+	return crc
+}
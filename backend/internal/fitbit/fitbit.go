@@ -0,0 +1,252 @@
+// Package fitbit to klient Fitbit Web API w zakresie potrzebnym do synchronizacji:
+// autoryzacja OAuth 2.0 z PKCE, dziennik aktywności (z podsumowaniem tętna) i pomiary
+// masy ciała. Jednostki są metryczne, bo nie wysyłamy nagłówka Accept-Language.
+package fitbit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Domyślne adresy usług Fitbit.
+const (
+	DefaultAuthURL = "https://www.fitbit.com/oauth2/authorize"
+	DefaultAPIURL  = "https://api.fitbit.com"
+)
+
+// Scopes to uprawnienia, o które prosimy: aktywności, tętno i masa ciała.
+var Scopes = []string{"activity", "heartrate", "weight"}
+
+// maxWeightDays to najdłuższy zakres dni jednego zapytania o pomiary masy ciała.
+const maxWeightDays = 31
+
+// ErrUnauthorized oznacza odrzucony token albo kod autoryzacji (np. użytkownik
+// odwołał dostęp aplikacji w ustawieniach konta Fitbit).
+var ErrUnauthorized = errors.New("fitbit: unauthorized")
+
+// Client wywołuje Fitbit Web API w imieniu aplikacji o podanym ClientID.
+type Client struct {
+	ClientID     string
+	ClientSecret string
+	// RedirectURL to adres powrotu z autoryzacji (GET /api/v1/integrations/fitbit/callback).
+	RedirectURL string
+	// Adresy usług; puste = Default*. Endpoint tokenów to APIURL + /oauth2/token.
+	AuthURL string
+	APIURL  string
+	HTTP    *http.Client // nil = klient z 30-sekundowym timeoutem
+}
+
+// Token to tokeny dostępu konta połączonego przez OAuth. Token odświeżania jest
+// jednorazowy – po Refresh trzeba zapisać nowy.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+	UserID       string // ID użytkownika Fitbit, stały dla konta
+}
+
+// Expired informuje, czy token trzeba odświeżyć (z minutowym zapasem).
+func (t Token) Expired(now time.Time) bool {
+	return !now.Add(time.Minute).Before(t.ExpiresAt)
+}
+
+// Activity to wpis dziennika aktywności z GET /1/user/-/activities/list.json.
+type Activity struct {
+	LogID        int64   `json:"logId"`
+	ActivityName string  `json:"activityName"` // np. Run, Walk, Outdoor Bike, Weights
+	StartTime    string  `json:"startTime"`    // czas lokalny z przesunięciem, np. 2024-03-01T07:15:00.000+01:00
+	Duration     int64   `json:"duration"`     // ms, z pauzami
+	ActiveTime   int64   `json:"activeDuration"`
+	Distance     float64 `json:"distance"` // km
+	AvgHR        float64 `json:"averageHeartRate"`
+	Elevation    float64 `json:"elevationGain"` // m
+	LogType      string  `json:"logType"`       // manual, auto_detected, tracker, mobile_run
+}
+
+// LocalStart zwraca czas rozpoczęcia w strefie użytkownika (zapisany jak UTC);
+// ok = false przy nieczytelnym czasie.
+func (a Activity) LocalStart() (time.Time, bool) {
+	t, err := time.Parse("2006-01-02T15:04:05.000-07:00", a.StartTime)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC), true
+}
+
+// Weight to pomiar masy ciała z dziennika Fitbit (ręczny albo z wagi Aria).
+type Weight struct {
+	LogID   int64   `json:"logId"`
+	Date    string  `json:"date"` // YYYY-MM-DD, czas lokalny
+	Time    string  `json:"time"` // HH:MM:SS
+	Weight  float64 `json:"weight"`
+	BodyFat float64 `json:"fat"` // %; 0 = brak
+	Source  string  `json:"source"`
+}
+
+// At zwraca czas pomiaru (czas lokalny zapisany jako UTC); ok = false przy nieczytelnej dacie.
+func (w Weight) At() (time.Time, bool) {
+	t, err := time.Parse("2006-01-02 15:04:05", w.Date+" "+w.Time)
+	return t, err == nil
+}
+
+// AuthorizeURL zwraca adres strony zgody Fitbit; po akceptacji Fitbit przekierowuje
+// na RedirectURL z ?code= i przekazanym state. verifier to losowy code_verifier PKCE,
+// który trzeba potem podać do Exchange.
+func (c *Client) AuthorizeURL(state, verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	q := url.Values{
+		"client_id":             {c.ClientID},
+		"response_type":         {"code"},
+		"redirect_uri":          {c.RedirectURL},
+		"scope":                 {strings.Join(Scopes, " ")},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(sum[:])},
+		"code_challenge_method": {"S256"},
+	}
+	return orDefault(c.AuthURL, DefaultAuthURL) + "?" + q.Encode()
+}
+
+// Exchange wymienia kod z przekierowania OAuth na tokeny.
+func (c *Client) Exchange(ctx context.Context, code, verifier string) (Token, error) {
+	return c.token(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"code_verifier": {verifier},
+		"redirect_uri":  {c.RedirectURL},
+	})
+}
+
+// Refresh odświeża wygasły token dostępu; zwrócony token odświeżania zastępuje poprzedni.
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (Token, error) {
+	return c.token(ctx, url.Values{"grant_type": {"refresh_token"}, "refresh_token": {refreshToken}})
+}
+
+func (c *Client) token(ctx context.Context, form url.Values) (Token, error) {
+	form.Set("client_id", c.ClientID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, orDefault(c.APIURL, DefaultAPIURL)+"/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return Token{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(c.ClientID, c.ClientSecret)
+
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"` // s
+		UserID       string `json:"user_id"`
+	}
+	if err := c.do(req, true, &body); err != nil {
+		return Token{}, err
+	}
+	return Token{
+		AccessToken:  body.AccessToken,
+		RefreshToken: body.RefreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(body.ExpiresIn) * time.Second),
+		UserID:       body.UserID,
+	}, nil
+}
+
+// Activities zwraca wpisy dziennika aktywności rozpoczęte po after (czas lokalny
+// użytkownika), od najstarszych, przechodząc po wszystkich stronach.
+func (c *Client) Activities(ctx context.Context, accessToken string, after time.Time) ([]Activity, error) {
+	q := url.Values{
+		"afterDate": {after.Format("2006-01-02T15:04:05")},
+		"sort":      {"asc"},
+		"offset":    {"0"},
+		"limit":     {"100"},
+	}
+	next := orDefault(c.APIURL, DefaultAPIURL) + "/1/user/-/activities/list.json?" + q.Encode()
+	var out []Activity
+	for next != "" {
+		var body struct {
+			Activities []Activity `json:"activities"`
+			Pagination struct {
+				Next string `json:"next"` // pełny adres kolejnej strony; pusty na końcu
+			} `json:"pagination"`
+		}
+		if err := c.get(ctx, accessToken, next, &body); err != nil {
+			return nil, err
+		}
+		out = append(out, body.Activities...)
+		next = body.Pagination.Next
+		if len(body.Activities) == 0 {
+			break
+		}
+	}
+	return out, nil
+}
+
+// Weights zwraca pomiary masy ciała z dni [from, to], odpytując API w zakresach po 31 dni.
+func (c *Client) Weights(ctx context.Context, accessToken string, from, to time.Time) ([]Weight, error) {
+	var out []Weight
+	for start := from; !start.After(to); start = start.AddDate(0, 0, maxWeightDays) {
+		end := start.AddDate(0, 0, maxWeightDays-1)
+		if end.After(to) {
+			end = to
+		}
+		var body struct {
+			Weight []Weight `json:"weight"`
+		}
+		path := "/1/user/-/body/log/weight/date/" + start.Format("2006-01-02") + "/" + end.Format("2006-01-02") + ".json"
+		if err := c.get(ctx, accessToken, orDefault(c.APIURL, DefaultAPIURL)+path, &body); err != nil {
+			return nil, err
+		}
+		out = append(out, body.Weight...)
+	}
+	return out, nil
+}
+
+func (c *Client) get(ctx context.Context, accessToken, rawURL string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	return c.do(req, false, dst)
+}
+
+func (c *Client) do(req *http.Request, tokenEndpoint bool, dst any) error {
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("fitbit: %w", err)
+	}
+	defer resp.Body.Close()
+	// Nieważny kod albo token odświeżania to 400 invalid_grant z endpointu tokenów.
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusBadRequest && tokenEndpoint {
+		return ErrUnauthorized
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		// Limit to 150 żądań na godzinę na użytkownika; nagłówek mówi, za ile sekund się odnowi.
+		return fmt.Errorf("fitbit: rate limited, retry in %ss", resp.Header.Get("Fitbit-Rate-Limit-Reset"))
+	}
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("fitbit: %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("fitbit: decode %s: %w", req.URL.Path, err)
+	}
+	return nil
+}
+
+// orDefault zwraca adres v bez końcowego ukośnika albo def, gdy v jest pusty.
+func orDefault(v, def string) string {
+	if v != "" {
+		return strings.TrimRight(v, "/")
+	}
+	return def
+}
@@ -0,0 +1,242 @@
+// Package garmin to klient Garmin Connect Developer Program (Health/Activity API)
+// w zakresie potrzebnym do synchronizacji treningów: autoryzacja OAuth 2.0 z PKCE,
+// podsumowania aktywności i pobieranie plików FIT (serie treningów siłowych).
+package garmin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Domyślne adresy usług Garmina.
+const (
+	DefaultAuthURL  = "https://connect.garmin.com/oauth2Confirm"
+	DefaultTokenURL = "https://diauth.garmin.com/di-oauth2-service/oauth/token"
+	DefaultAPIURL   = "https://apis.garmin.com"
+)
+
+// maxWindow to najdłuższy zakres czasu wgrania, o jaki można zapytać w jednym żądaniu.
+const maxWindow = 24 * time.Hour
+
+// maxFileBytes ogranicza rozmiar pobieranego pliku aktywności.
+const maxFileBytes = 10 << 20
+
+// ErrUnauthorized oznacza odrzucony token albo kod autoryzacji (np. użytkownik
+// odwołał dostęp w Garmin Connect).
+var ErrUnauthorized = errors.New("garmin: unauthorized")
+
+// Client wywołuje API Garmina w imieniu aplikacji o podanym ClientID.
+type Client struct {
+	ClientID     string
+	ClientSecret string
+	// RedirectURL to adres powrotu z autoryzacji (GET /api/v1/integrations/garmin/callback).
+	RedirectURL string
+	// Adresy usług; puste = Default*.
+	AuthURL  string
+	TokenURL string
+	APIURL   string
+	HTTP     *http.Client // nil = klient z 30-sekundowym timeoutem
+}
+
+// Token to tokeny dostępu konta połączonego przez OAuth.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// Expired informuje, czy token trzeba odświeżyć (z minutowym zapasem).
+func (t Token) Expired(now time.Time) bool {
+	return !now.Add(time.Minute).Before(t.ExpiresAt)
+}
+
+// Activity to podsumowanie aktywności z GET /wellness-api/rest/activities.
+type Activity struct {
+	SummaryID    string `json:"summaryId"`
+	ActivityID   int64  `json:"activityId"`
+	ActivityName string `json:"activityName"`
+	ActivityType string `json:"activityType"` // np. RUNNING, CYCLING, STRENGTH_TRAINING
+	StartTime    int64  `json:"startTimeInSeconds"`
+	// StartTimeOffset to przesunięcie strefy czasowej urządzenia względem UTC.
+	StartTimeOffset int64   `json:"startTimeOffsetInSeconds"`
+	Duration        int     `json:"durationInSeconds"`
+	Distance        float64 `json:"distanceInMeters"`
+	AvgHR           float64 `json:"averageHeartRateInBeatsPerMinute"`
+	ElevationGain   float64 `json:"totalElevationGainInMeters"`
+}
+
+// LocalStart zwraca czas rozpoczęcia w strefie urządzenia (zapisany jak UTC).
+func (a Activity) LocalStart() time.Time {
+	return time.Unix(a.StartTime+a.StartTimeOffset, 0).UTC()
+}
+
+// AuthorizeURL zwraca adres strony zgody Garmin Connect; po akceptacji Garmin
+// przekierowuje na RedirectURL z ?code= i przekazanym state. verifier to losowy
+// code_verifier PKCE, który trzeba potem podać do Exchange.
+func (c *Client) AuthorizeURL(state, verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	q := url.Values{
+		"client_id":             {c.ClientID},
+		"response_type":         {"code"},
+		"redirect_uri":          {c.RedirectURL},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(sum[:])},
+		"code_challenge_method": {"S256"},
+	}
+	return orDefault(c.AuthURL, DefaultAuthURL) + "?" + q.Encode()
+}
+
+// Exchange wymienia kod z przekierowania OAuth na tokeny.
+func (c *Client) Exchange(ctx context.Context, code, verifier string) (Token, error) {
+	return c.token(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"code_verifier": {verifier},
+		"redirect_uri":  {c.RedirectURL},
+	})
+}
+
+// Refresh odświeża wygasły token dostępu.
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (Token, error) {
+	return c.token(ctx, url.Values{"grant_type": {"refresh_token"}, "refresh_token": {refreshToken}})
+}
+
+func (c *Client) token(ctx context.Context, form url.Values) (Token, error) {
+	form.Set("client_id", c.ClientID)
+	form.Set("client_secret", c.ClientSecret)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, orDefault(c.TokenURL, DefaultTokenURL), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Token{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"` // s
+	}
+	if err := c.do(req, true, &body); err != nil {
+		return Token{}, err
+	}
+	return Token{
+		AccessToken:  body.AccessToken,
+		RefreshToken: body.RefreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(body.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// UserID zwraca stały identyfikator użytkownika Garmina (nie zmienia się przy
+// ponownej autoryzacji).
+func (c *Client) UserID(ctx context.Context, accessToken string) (string, error) {
+	var body struct {
+		UserID string `json:"userId"`
+	}
+	err := c.get(ctx, accessToken, "/wellness-api/rest/user/id", &body)
+	return body.UserID, err
+}
+
+// Activities zwraca aktywności wgrane do Garmin Connect w zakresie [from, to),
+// odpytując API w oknach po 24 godziny.
+func (c *Client) Activities(ctx context.Context, accessToken string, from, to time.Time) ([]Activity, error) {
+	var out []Activity
+	for start := from; start.Before(to); start = start.Add(maxWindow) {
+		end := start.Add(maxWindow)
+		if end.After(to) {
+			end = to
+		}
+		q := url.Values{
+			"uploadStartTimeInSeconds": {strconv.FormatInt(start.Unix(), 10)},
+			"uploadEndTimeInSeconds":   {strconv.FormatInt(end.Unix(), 10)},
+		}
+		var batch []Activity
+		if err := c.get(ctx, accessToken, "/wellness-api/rest/activities?"+q.Encode(), &batch); err != nil {
+			return nil, err
+		}
+		out = append(out, batch...)
+	}
+	return out, nil
+}
+
+// ActivityFile pobiera oryginalny plik FIT aktywności (z seriami treningu siłowego).
+func (c *Client) ActivityFile(ctx context.Context, accessToken, summaryID string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, orDefault(c.APIURL, DefaultAPIURL)+"/wellness-api/rest/activityFile?id="+url.QueryEscape(summaryID), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	resp, err := c.send(req, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFileBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("garmin: activity file: %w", err)
+	}
+	if len(data) > maxFileBytes {
+		return nil, fmt.Errorf("garmin: activity file exceeds %d MB", maxFileBytes>>20)
+	}
+	return data, nil
+}
+
+func (c *Client) get(ctx context.Context, accessToken, path string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, orDefault(c.APIURL, DefaultAPIURL)+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	return c.do(req, false, dst)
+}
+
+func (c *Client) do(req *http.Request, tokenEndpoint bool, dst any) error {
+	resp, err := c.send(req, tokenEndpoint)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("garmin: decode %s: %w", req.URL.Path, err)
+	}
+	return nil
+}
+
+// send wykonuje żądanie i zamienia odpowiedzi błędów na błędy; przy sukcesie
+// wywołujący zamyka body.
+func (c *Client) send(req *http.Request, tokenEndpoint bool) (*http.Response, error) {
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("garmin: %w", err)
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	// Nieważny kod albo token odświeżania to 400 z endpointu tokenów.
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusBadRequest && tokenEndpoint {
+		return nil, ErrUnauthorized
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return nil, fmt.Errorf("garmin: %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(msg)))
+}
+
+// orDefault zwraca adres v bez końcowego ukośnika albo def, gdy v jest pusty.
+func orDefault(v, def string) string {
+	if v != "" {
+		return strings.TrimRight(v, "/")
+	}
+	return def
+}
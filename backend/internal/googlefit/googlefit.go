@@ -0,0 +1,326 @@
+// Package googlefit to klient Google Fitness REST API w zakresie potrzebnym do
+// synchronizacji: autoryzacja OAuth 2.0 Google, sesje aktywności (z dystansem
+// i tętnem) oraz pomiary masy ciała.
+package googlefit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Domyślne adresy usług Google.
+const (
+	DefaultAuthURL  = "https://accounts.google.com/o/oauth2/v2/auth"
+	DefaultTokenURL = "https://oauth2.googleapis.com/token"
+	DefaultAPIURL   = "https://www.googleapis.com"
+)
+
+// Scopes to uprawnienia, o które prosimy: sesje i dystans (activity, location),
+// tętno, masa ciała oraz identyfikator konta (openid).
+var Scopes = []string{
+	"openid",
+	"https://www.googleapis.com/auth/fitness.activity.read",
+	"https://www.googleapis.com/auth/fitness.location.read",
+	"https://www.googleapis.com/auth/fitness.heart_rate.read",
+	"https://www.googleapis.com/auth/fitness.body.read",
+}
+
+// maxWindow to najdłuższy zakres czasu jednego zapytania o agregaty sesji.
+const maxWindow = 30 * 24 * time.Hour
+
+// weightSource to scalone pomiary masy ciała ze wszystkich aplikacji i wag.
+const weightSource = "derived:com.google.weight:com.google.android.gms:merge_weight"
+
+// ErrUnauthorized oznacza odrzucony token albo kod autoryzacji (np. użytkownik
+// odebrał aplikacji dostęp na koncie Google).
+var ErrUnauthorized = errors.New("googlefit: unauthorized")
+
+// Client wywołuje API Google w imieniu aplikacji o podanym ClientID.
+type Client struct {
+	ClientID     string
+	ClientSecret string
+	// RedirectURL to adres powrotu z autoryzacji (GET /api/v1/integrations/googlefit/callback).
+	RedirectURL string
+	// Adresy usług; puste = Default*.
+	AuthURL  string
+	TokenURL string
+	APIURL   string
+	HTTP     *http.Client // nil = klient z 30-sekundowym timeoutem
+}
+
+// Token to tokeny dostępu konta połączonego przez OAuth.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// Expired informuje, czy token trzeba odświeżyć (z minutowym zapasem).
+func (t Token) Expired(now time.Time) bool {
+	return !now.Add(time.Minute).Before(t.ExpiresAt)
+}
+
+// Session to sesja aktywności z Google Fit z metrykami z jej czasu trwania.
+type Session struct {
+	ID           string
+	Name         string
+	ActivityType int // kod typu aktywności Google Fit, np. 8 = bieg
+	Start, End   time.Time
+	// Active to czas aktywności bez pauz; 0, gdy aplikacja go nie zapisała.
+	Active   time.Duration
+	Distance float64 // m; 0 = brak
+	AvgHR    float64 // uderzenia/min; 0 = brak
+}
+
+// Weight to pomiar masy ciała.
+type Weight struct {
+	At     time.Time
+	Weight float64 // kg
+}
+
+// AuthorizeURL zwraca adres strony zgody Google; po akceptacji Google przekierowuje
+// na RedirectURL z ?code= i przekazanym state. access_type=offline z prompt=consent
+// sprawia, że dostajemy token odświeżania także przy ponownym połączeniu.
+func (c *Client) AuthorizeURL(state string) string {
+	q := url.Values{
+		"client_id":     {c.ClientID},
+		"response_type": {"code"},
+		"redirect_uri":  {c.RedirectURL},
+		"scope":         {strings.Join(Scopes, " ")},
+		"state":         {state},
+		"access_type":   {"offline"},
+		"prompt":        {"consent"},
+	}
+	return orDefault(c.AuthURL, DefaultAuthURL) + "?" + q.Encode()
+}
+
+// Exchange wymienia kod z przekierowania OAuth na tokeny.
+func (c *Client) Exchange(ctx context.Context, code string) (Token, error) {
+	return c.token(ctx, url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {c.RedirectURL},
+	})
+}
+
+// Refresh odświeża wygasły token dostępu. Google nie zwraca nowego tokenu
+// odświeżania, więc zostaje dotychczasowy.
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (Token, error) {
+	t, err := c.token(ctx, url.Values{"grant_type": {"refresh_token"}, "refresh_token": {refreshToken}})
+	if t.RefreshToken == "" {
+		t.RefreshToken = refreshToken
+	}
+	return t, err
+}
+
+func (c *Client) token(ctx context.Context, form url.Values) (Token, error) {
+	form.Set("client_id", c.ClientID)
+	form.Set("client_secret", c.ClientSecret)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, orDefault(c.TokenURL, DefaultTokenURL), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Token{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"` // s
+	}
+	if err := c.do(req, true, &body); err != nil {
+		return Token{}, err
+	}
+	return Token{
+		AccessToken:  body.AccessToken,
+		RefreshToken: body.RefreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(body.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// UserID zwraca stały identyfikator konta Google (sub z OpenID Connect).
+func (c *Client) UserID(ctx context.Context, accessToken string) (string, error) {
+	var body struct {
+		Sub string `json:"sub"`
+	}
+	err := c.send(ctx, accessToken, http.MethodGet, "/oauth2/v3/userinfo", nil, &body)
+	return body.Sub, err
+}
+
+type aggregateRequest struct {
+	AggregateBy     []dataType      `json:"aggregateBy"`
+	BucketBySession bucketBySession `json:"bucketBySession"`
+	StartTimeMillis int64           `json:"startTimeMillis"`
+	EndTimeMillis   int64           `json:"endTimeMillis"`
+}
+
+type dataType struct {
+	DataTypeName string `json:"dataTypeName"`
+}
+
+type bucketBySession struct {
+	MinDurationMillis int64 `json:"minDurationMillis"`
+}
+
+type dataPoint struct {
+	StartTimeNanos int64 `json:"startTimeNanos,string"`
+	Value          []struct {
+		FpVal float64 `json:"fpVal"`
+	} `json:"value"`
+}
+
+// Sessions zwraca sesje rozpoczęte w zakresie [from, to) z dystansem i średnim tętnem,
+// odpytując API o agregaty w oknach po 30 dni.
+func (c *Client) Sessions(ctx context.Context, accessToken string, from, to time.Time) ([]Session, error) {
+	var out []Session
+	for start := from; start.Before(to); start = start.Add(maxWindow) {
+		end := start.Add(maxWindow)
+		if end.After(to) {
+			end = to
+		}
+		req := aggregateRequest{
+			AggregateBy: []dataType{
+				{"com.google.distance.delta"},
+				{"com.google.heart_rate.bpm"},
+			},
+			// Sesje krótsze niż minuta to zwykle przypadkowo uruchomione nagrania.
+			BucketBySession: bucketBySession{MinDurationMillis: time.Minute.Milliseconds()},
+			StartTimeMillis: start.UnixMilli(),
+			EndTimeMillis:   end.UnixMilli(),
+		}
+		var body struct {
+			Bucket []struct {
+				Session struct {
+					ID              string `json:"id"`
+					Name            string `json:"name"`
+					ActivityType    int    `json:"activityType"`
+					StartTimeMillis int64  `json:"startTimeMillis,string"`
+					EndTimeMillis   int64  `json:"endTimeMillis,string"`
+					ActiveMillis    int64  `json:"activeTimeMillis,string"`
+				} `json:"session"`
+				Dataset []struct {
+					Point []dataPoint `json:"point"`
+				} `json:"dataset"`
+			} `json:"bucket"`
+		}
+		if err := c.send(ctx, accessToken, http.MethodPost, "/fitness/v1/users/me/dataset:aggregate", req, &body); err != nil {
+			return nil, err
+		}
+		for _, b := range body.Bucket {
+			s := Session{
+				ID:           b.Session.ID,
+				Name:         b.Session.Name,
+				ActivityType: b.Session.ActivityType,
+				Start:        time.UnixMilli(b.Session.StartTimeMillis).UTC(),
+				End:          time.UnixMilli(b.Session.EndTimeMillis).UTC(),
+				Active:       time.Duration(b.Session.ActiveMillis) * time.Millisecond,
+			}
+			// Zbiory są w kolejności aggregateBy; tętno to [średnia, maks., min.].
+			for i, ds := range b.Dataset {
+				for _, p := range ds.Point {
+					if len(p.Value) == 0 {
+						continue
+					}
+					switch i {
+					case 0:
+						s.Distance += p.Value[0].FpVal
+					case 1:
+						s.AvgHR = p.Value[0].FpVal
+					}
+				}
+			}
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// Weights zwraca pomiary masy ciała z zakresu [from, to).
+func (c *Client) Weights(ctx context.Context, accessToken string, from, to time.Time) ([]Weight, error) {
+	dataset := strconv.FormatInt(from.UnixNano(), 10) + "-" + strconv.FormatInt(to.UnixNano(), 10)
+	path := "/fitness/v1/users/me/dataSources/" + url.PathEscape(weightSource) + "/datasets/" + dataset
+	var out []Weight
+	pageToken := ""
+	for {
+		p := path
+		if pageToken != "" {
+			p += "?pageToken=" + url.QueryEscape(pageToken)
+		}
+		var body struct {
+			Point         []dataPoint `json:"point"`
+			NextPageToken string      `json:"nextPageToken"`
+		}
+		if err := c.send(ctx, accessToken, http.MethodGet, p, nil, &body); err != nil {
+			return nil, err
+		}
+		for _, pt := range body.Point {
+			if len(pt.Value) > 0 {
+				out = append(out, Weight{At: time.Unix(0, pt.StartTimeNanos).UTC(), Weight: pt.Value[0].FpVal})
+			}
+		}
+		if body.NextPageToken == "" {
+			return out, nil
+		}
+		pageToken = body.NextPageToken
+	}
+}
+
+func (c *Client) send(ctx context.Context, accessToken, method, path string, in, dst any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, orDefault(c.APIURL, DefaultAPIURL)+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.do(req, false, dst)
+}
+
+func (c *Client) do(req *http.Request, tokenEndpoint bool, dst any) error {
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("googlefit: %w", err)
+	}
+	defer resp.Body.Close()
+	// Nieważny kod albo token odświeżania to 400 invalid_grant z endpointu tokenów.
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusBadRequest && tokenEndpoint {
+		return ErrUnauthorized
+	}
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("googlefit: %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("googlefit: decode %s: %w", req.URL.Path, err)
+	}
+	return nil
+}
+
+// orDefault zwraca adres v bez końcowego ukośnika albo def, gdy v jest pusty.
+func orDefault(v, def string) string {
+	if v != "" {
+		return strings.TrimRight(v, "/")
+	}
+	return def
+}
@@ -0,0 +1,137 @@
+// Package gpx czyta ślady GPX (zegarki, Strava, Garmin) i liczy z nich dystans, czas
+// i przewyższenie, żeby zamienić je na treningi kardio.
+package gpx
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// ErrNoTracks oznacza plik bez śladu z punktami.
+var ErrNoTracks = errors.New("gpx: no track points")
+
+// earthRadius to średni promień Ziemi w metrach (do wzoru haversine).
+const earthRadius = 6371008.8
+
+// elevationThreshold tłumi szum wysokości z GPS: zmiany mniejsze niż tyle metrów
+// nie liczą się do przewyższenia.
+const elevationThreshold = 2.0
+
+// Track to podsumowanie jednego śladu.
+type Track struct {
+	Name          string
+	Type          string    // typ aktywności z pliku, np. "running"; może być pusty
+	Start         time.Time // czas pierwszego punktu (zero, gdy punkty nie mają czasu)
+	Duration      time.Duration
+	Distance      float64    // m
+	ElevationGain float64    // m; 0, gdy punkty nie mają wysokości
+	AvgHR         int        // uderzenia/min z rozszerzeń Garmina; 0 = brak
+	HR            []HRSample // pomiary tętna z czasem, w kolejności punktów
+}
+
+// HRSample to pomiar tętna w punkcie śladu.
+type HRSample struct {
+	At  time.Time
+	BPM int
+}
+
+type document struct {
+	Tracks []struct {
+		Name     string `xml:"name"`
+		Type     string `xml:"type"`
+		Segments []struct {
+			Points []point `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+type point struct {
+	Lat  float64  `xml:"lat,attr"`
+	Lon  float64  `xml:"lon,attr"`
+	Ele  *float64 `xml:"ele"`
+	Time string   `xml:"time"`
+	// Tętno z rozszerzenia Garmin TrackPointExtension (gpxtpx:hr).
+	HR int `xml:"extensions>TrackPointExtension>hr"`
+}
+
+// Parse czyta plik GPX i zwraca podsumowanie każdego śladu z punktami. Segmenty
+// jednego śladu (np. po pauzie) łączymy, ale przerwy między nimi nie liczą się do dystansu.
+func Parse(r io.Reader) ([]Track, error) {
+	var doc document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("gpx: %w", err)
+	}
+	var out []Track
+	for _, trk := range doc.Tracks {
+		t := Track{Name: strings.TrimSpace(trk.Name), Type: strings.ToLower(strings.TrimSpace(trk.Type))}
+		var (
+			first, last time.Time
+			points      int
+			hrSum, hrN  int
+		)
+		for _, seg := range trk.Segments {
+			var prev *point
+			var anchor *float64
+			for i := range seg.Points {
+				p := &seg.Points[i]
+				points++
+				if prev != nil {
+					t.Distance += haversine(prev.Lat, prev.Lon, p.Lat, p.Lon)
+				}
+				prev = p
+				if p.Ele != nil {
+					switch {
+					case anchor == nil || *p.Ele < *anchor-elevationThreshold:
+						anchor = p.Ele
+					case *p.Ele > *anchor+elevationThreshold:
+						t.ElevationGain += *p.Ele - *anchor
+						anchor = p.Ele
+					}
+				}
+				if p.HR > 0 {
+					hrSum += p.HR
+					hrN++
+				}
+				ts, err := time.Parse(time.RFC3339, strings.TrimSpace(p.Time))
+				if err != nil {
+					continue
+				}
+				if p.HR > 0 {
+					t.HR = append(t.HR, HRSample{At: ts, BPM: p.HR})
+				}
+				if first.IsZero() {
+					first = ts
+				}
+				last = ts
+			}
+		}
+		if points == 0 {
+			continue
+		}
+		t.Start = first
+		if !first.IsZero() {
+			t.Duration = last.Sub(first)
+		}
+		if hrN > 0 {
+			t.AvgHR = int(math.Round(float64(hrSum) / float64(hrN)))
+		}
+		out = append(out, t)
+	}
+	if len(out) == 0 {
+		return nil, ErrNoTracks
+	}
+	return out, nil
+}
+
+// haversine zwraca odległość po powierzchni Ziemi między dwoma punktami w metrach.
+func haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	rad := math.Pi / 180
+	dLat, dLon := (lat2-lat1)*rad, (lon2-lon1)*rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
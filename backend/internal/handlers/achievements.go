@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gym-api/internal/achievements"
+	"gym-api/internal/httpjson"
+	"gym-api/internal/server"
+)
+
+type AchievementsHandler struct {
+	srv *server.Server
+}
+
+// NewAchievementsHandler obsługuje GET /achievements: zdobyte odznaki (od najnowszej)
+// i zablokowane z postępem. Reguły sprawdzamy przy każdym żądaniu, a nowo spełnione
+// zapisujemy z bieżącym czasem.
+func NewAchievementsHandler(srv *server.Server) *AchievementsHandler {
+	return &AchievementsHandler{srv: srv}
+}
+
+func (h *AchievementsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, achievements.Evaluate(r.Context(), h.srv.Workouts, h.srv.Achievements))
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+type AuditHandler struct {
+	srv *server.Server
+}
+
+// NewAuditHandler obsługuje GET /audit: stronę dziennika zmian (od najnowszych),
+// opcjonalnie zawężoną do zasobu (?resource=workout&resourceId=12). Dziennik widzi
+// każdego użytkownika, więc wymaga tokenu administratora (Authorization: Bearer).
+func NewAuditHandler(srv *server.Server) *AuditHandler {
+	return &AuditHandler{srv: srv}
+}
+
+func (h *AuditHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if !requireAdmin(w, r, h.srv.AdminToken) {
+		return
+	}
+
+	var errs validationErrors
+	q := r.URL.Query()
+	limit := queryInt(&errs, q, "limit", defaultPageSize)
+	offset := queryInt(&errs, q, "offset", 0)
+	if limit < 1 || limit > maxPageSize {
+		errs.add("limit", "limit must be between 1 and 200")
+	}
+	if offset < 0 {
+		errs.add("offset", "offset must be >= 0")
+	}
+	query := store.AuditQuery{
+		Limit:      limit,
+		Offset:     offset,
+		Resource:   strings.TrimSpace(q.Get("resource")),
+		ResourceID: queryInt(&errs, q, "resourceId", 0),
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	items, total := h.srv.Audit.List(r.Context(), query)
+	httpjson.WriteJSON(w, http.StatusOK, models.AuditPage{Items: items, Total: total, Limit: limit, Offset: offset})
+}
+
+// requireAdmin sprawdza token administratora z nagłówka Authorization. Bez
+// skonfigurowanego tokenu endpoint jest zamknięty (403), przy złym tokenie odpowiada 401.
+func requireAdmin(w http.ResponseWriter, r *http.Request, token string) bool {
+	if token == "" {
+		httpjson.WriteError(w, r, http.StatusForbidden, "admin endpoints are disabled")
+		return false
+	}
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+		httpjson.WriteError(w, r, http.StatusUnauthorized, "admin token is missing or invalid")
+		return false
+	}
+	return true
+}
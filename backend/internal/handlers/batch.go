@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+type WorkoutsBatchHandler struct {
+	srv *server.Server
+}
+
+// NewWorkoutsBatchHandler obsługuje PATCH /workouts/batch: nakłada tę samą łatkę
+// (JSON Merge Patch) na wiele treningów, np. poprawia datę kilku sesji naraz.
+// Zmiana jest atomowa – albo zapisujemy ją we wszystkich treningach, albo w żadnym.
+func NewWorkoutsBatchHandler(srv *server.Server) *WorkoutsBatchHandler {
+	return &WorkoutsBatchHandler{srv: srv}
+}
+
+// batchValidationError przerywa UpdateMany, gdy któryś trening po zmianie jest niepoprawny.
+type batchValidationError struct {
+	errs validationErrors
+}
+
+func (e *batchValidationError) Error() string { return "batch validation failed" }
+
+func (h *WorkoutsBatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req models.BatchUpdateRequest
+	if err := httpjson.ReadJSON(w, r, &req); err != nil {
+		httpjson.WriteReadError(w, r, err)
+		return
+	}
+	var errs validationErrors
+	ids := slices.Compact(slices.Sorted(slices.Values(req.IDs)))
+	if len(ids) == 0 || len(ids) > maxBulkWorkouts {
+		errs.addf("ids", "ids must contain between 1 and %d workout IDs", maxBulkWorkouts)
+	}
+	if req.Patch == nil {
+		errs.add("patch", "patch is required")
+	}
+	// Ciężary w łatce są, jak przy PATCH /workouts/{id}, w jednostce z weightUnit łatki
+	// albo z profilu.
+	unit := patchUnit(r.Context(), h.srv, &errs, req.Patch)
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	// Łatkę nakładamy w store, pod blokadą, na bieżący stan każdego treningu.
+	// Błąd dekodowania łatki jest taki sam dla każdego treningu, więc zgłaszamy go raz.
+	updated, err := h.srv.Workouts.UpdateMany(r.Context(), ids, func(cur models.Workout) (models.Workout, error) {
+		var patched models.CreateWorkoutRequest
+		if err := applyMergePatch(editableWorkout(workoutInUnit(cur, unit, false)), clonePatch(req.Patch), &patched); err != nil {
+			return cur, err
+		}
+		wk := workoutFromRequest(cur, patched)
+		var verrs validationErrors
+		exercisesToKg(wk.Exercises, unit)
+		if verrs = append(verrs, checkWorkout(r.Context(), h.srv, wk)...); len(verrs) > 0 {
+			// Prefiks ścieżki pola to ID treningu, np. "[7].title".
+			var prefixed validationErrors
+			for _, e := range verrs {
+				prefixed.addf(fmt.Sprintf("[%d].%s", cur.ID, e.Field), e.Message, e.Args...)
+			}
+			return cur, &batchValidationError{errs: prefixed}
+		}
+		return wk, nil
+	})
+
+	var bve *batchValidationError
+	switch {
+	case err == nil:
+		httpjson.WriteJSON(w, http.StatusOK, workoutsInUnit(r.Context(), h.srv, updated))
+	case errors.As(err, &bve):
+		httpjson.WriteValidationErrors(w, r, bve.errs)
+	case errors.Is(err, store.ErrNotFound):
+		httpjson.WriteError(w, r, http.StatusNotFound, "Workout not found")
+	default:
+		httpjson.WriteReadError(w, r, err)
+	}
+}
+
+// clonePatch kopiuje łatkę, bo mergePatch modyfikuje zagnieżdżone obiekty w miejscu.
+func clonePatch(p map[string]any) map[string]any {
+	out := make(map[string]any, len(p))
+	for k, v := range p {
+		if m, ok := v.(map[string]any); ok {
+			v = clonePatch(m)
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+
+	"gym-api/internal/bodycomp"
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+type BodyCompositionHandler struct {
+	srv *server.Server
+}
+
+// NewBodyCompositionHandler obsługuje GET /body-composition?sex=&height=: BMI, procent
+// tłuszczu (wzór US Navy z obwodów szyi, talii i – u kobiet – bioder, a bez obwodów
+// odczyt z wagi) oraz FFMI w każdym dniu z pomiarem masy ciała albo obwodów. Brakujące
+// dane bierzemy z ostatniego wcześniejszego pomiaru; wskaźnik, którego nie da się
+// policzyć, pomijamy.
+func NewBodyCompositionHandler(srv *server.Server) *BodyCompositionHandler {
+	return &BodyCompositionHandler{srv: srv}
+}
+
+func (h *BodyCompositionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	sex := q.Get("sex")
+	height := queryFloat(&errs, q, "height", 0)
+	from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+	if sex != bodycomp.Male && sex != bodycomp.Female {
+		errs.add("sex", "sex must be one of: male, female")
+	}
+	if height < 100 || height > 250 {
+		errs.add("height", "height must be between 100 and 250 cm")
+	}
+	if from != "" && to != "" && to < from {
+		errs.add("to", "to must not be before from")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	// Pomiary sprzed from też są potrzebne jako ostatnie znane wartości.
+	weights := h.srv.Bodyweight.List(r.Context(), "", to)
+	measurements := h.srv.Measurements.List(r.Context(), "", to)
+	slices.Reverse(weights)
+	slices.Reverse(measurements)
+	var dates []string
+	for _, e := range weights {
+		dates = append(dates, e.Date)
+	}
+	for _, m := range measurements {
+		dates = append(dates, m.Date)
+	}
+	slices.Sort(dates)
+	dates = slices.Compact(dates)
+
+	out := models.BodyCompositionSeries{Sex: sex, Height: height, Points: []models.BodyCompositionPoint{}}
+	var weight float64
+	var scaleFat *float64
+	sites := map[string]float64{}
+	wi, mi := 0, 0
+	for _, date := range dates {
+		for ; wi < len(weights) && weights[wi].Date <= date; wi++ {
+			weight = weights[wi].Weight
+			if weights[wi].BodyFat != nil {
+				scaleFat = weights[wi].BodyFat
+			}
+		}
+		for ; mi < len(measurements) && measurements[mi].Date <= date; mi++ {
+			for site, v := range measurements[mi].Values {
+				sites[site] = v
+			}
+		}
+		if from != "" && date < from {
+			continue
+		}
+		p := models.BodyCompositionPoint{Date: date}
+		if weight > 0 {
+			p.Weight = ptr(weight)
+			p.BMI = ptr(round1(bodycomp.BMI(weight, height)))
+		}
+		bf, ok := bodycomp.NavyBodyFat(sex, height, sites["neck"], sites["waist"], sites["hips"])
+		source := "navy"
+		if !ok && scaleFat != nil {
+			bf, ok, source = *scaleFat, true, "scale"
+		}
+		if ok {
+			p.BodyFat, p.BodyFatSource = ptr(round1(bf)), source
+			if weight > 0 {
+				ffmi, normalized := bodycomp.FFMI(weight, height, bf)
+				p.LeanMass = ptr(round1(weight * (1 - bf/100)))
+				p.FFMI, p.NormalizedFFMI = ptr(round1(ffmi)), ptr(round1(normalized))
+			}
+		}
+		out.Points = append(out.Points, p)
+	}
+	httpjson.WriteJSON(w, http.StatusOK, out)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,398 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/scale"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+// defaultTrendWindow to domyślna długość średniej kroczącej masy ciała w dniach.
+const defaultTrendWindow = 7
+
+// maxScaleImportBytes ogranicza rozmiar importowanego pliku z wagi (lata pomiarów to
+// kilkaset KB).
+const maxScaleImportBytes = 5 << 20
+
+type BodyweightHandler struct {
+	srv *server.Server
+}
+
+// NewBodyweightHandler obsługuje dziennik masy ciała:
+//   - GET /bodyweight: pomiary od najnowszego, opcjonalnie z zakresu ?from=&to=
+//   - POST /bodyweight: nowy pomiar (bez daty – na dziś)
+func NewBodyweightHandler(srv *server.Server) *BodyweightHandler {
+	return &BodyweightHandler{srv: srv}
+}
+
+func (h *BodyweightHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var errs validationErrors
+		q := r.URL.Query()
+		from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+		if from != "" && to != "" && to < from {
+			errs.add("to", "to must not be before from")
+		}
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		entries := h.srv.Bodyweight.List(r.Context(), from, to)
+		for i := range entries {
+			entries[i] = bodyweightInUnit(r.Context(), h.srv, entries[i])
+		}
+		httpjson.WriteJSON(w, http.StatusOK, entries)
+
+	case http.MethodPost:
+		var req models.BodyweightRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		e, errs := bodyweightFromRequest(r.Context(), h.srv, req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		created := h.srv.Bodyweight.Create(r.Context(), e)
+		refreshBodyweightLoads(r.Context(), h.srv)
+		httpjson.WriteJSON(w, http.StatusCreated, bodyweightInUnit(r.Context(), h.srv, created))
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type BodyweightByIDHandler struct {
+	srv *server.Server
+}
+
+// NewBodyweightByIDHandler obsługuje GET, PUT (pełna zamiana) i DELETE /bodyweight/{id}.
+func NewBodyweightByIDHandler(srv *server.Server) *BodyweightByIDHandler {
+	return &BodyweightByIDHandler{srv: srv}
+}
+
+func (h *BodyweightByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		e, found := h.srv.Bodyweight.Get(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Bodyweight entry not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, bodyweightInUnit(r.Context(), h.srv, e))
+
+	case http.MethodPut:
+		var req models.BodyweightRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		e, errs := bodyweightFromRequest(r.Context(), h.srv, req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		updated, err := h.srv.Bodyweight.Update(r.Context(), id, e)
+		if err != nil {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Bodyweight entry not found")
+			return
+		}
+		refreshBodyweightLoads(r.Context(), h.srv)
+		httpjson.WriteJSON(w, http.StatusOK, bodyweightInUnit(r.Context(), h.srv, updated))
+
+	case http.MethodDelete:
+		if !h.srv.Bodyweight.Delete(r.Context(), id) {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Bodyweight entry not found")
+			return
+		}
+		refreshBodyweightLoads(r.Context(), h.srv)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type BodyweightTrendHandler struct {
+	srv *server.Server
+}
+
+// NewBodyweightTrendHandler obsługuje GET /bodyweight/trend: dzienną masę ciała (średnia
+// z pomiarów dnia) ze średnią kroczącą z ?window= dni (domyślnie 7), do wykresu.
+// Średnia obejmuje dni kalendarzowe, więc dni bez pomiaru nie zaniżają okna.
+func NewBodyweightTrendHandler(srv *server.Server) *BodyweightTrendHandler {
+	return &BodyweightTrendHandler{srv: srv}
+}
+
+func (h *BodyweightTrendHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	window := queryInt(&errs, q, "window", defaultTrendWindow)
+	from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+	if window < 1 || window > 90 {
+		errs.add("window", "window must be between 1 and 90 days")
+	}
+	if from != "" && to != "" && to < from {
+		errs.add("to", "to must not be before from")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	// Średnia na początku zakresu potrzebuje pomiarów sprzed from.
+	since := ""
+	if from != "" {
+		d, _ := time.Parse(dateLayout, from)
+		since = d.AddDate(0, 0, 1-window).Format(dateLayout)
+	}
+	entries := h.srv.Bodyweight.List(r.Context(), since, to)
+	var days []string
+	sums, counts := map[string]float64{}, map[string]int{}
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if counts[e.Date] == 0 {
+			days = append(days, e.Date)
+		}
+		sums[e.Date] += e.Weight
+		counts[e.Date]++
+	}
+
+	trend := models.BodyweightTrend{Window: window, Points: []models.BodyweightPoint{}}
+	for i, day := range days {
+		d, _ := time.Parse(dateLayout, day)
+		start := d.AddDate(0, 0, 1-window).Format(dateLayout)
+		total, n := 0.0, 0
+		for j := i; j >= 0 && days[j] >= start; j-- {
+			total += sums[days[j]] / float64(counts[days[j]])
+			n++
+		}
+		if from != "" && day < from {
+			continue
+		}
+		trend.Points = append(trend.Points, models.BodyweightPoint{
+			Date:    day,
+			Weight:  round1(sums[day] / float64(counts[day])),
+			Average: round1(total / float64(n)),
+		})
+	}
+	if n := len(trend.Points); n > 1 {
+		change := round1(trend.Points[n-1].Average - trend.Points[0].Average)
+		trend.Change = &change
+	}
+	httpjson.WriteJSON(w, http.StatusOK, trend)
+}
+
+type BodyweightImportHandler struct {
+	srv *server.Server
+}
+
+// NewBodyweightImportHandler obsługuje POST /bodyweight/import: import eksportu CSV
+// z wagi Withings albo Renpho (body text/csv, format z nagłówka albo ?format=).
+// Pomiary o czasie, który już jest w dzienniku, pomijamy, więc ten sam plik można
+// wgrać ponownie. Błędne wiersze trafiają do errors, reszta pliku się importuje.
+func NewBodyweightImportHandler(srv *server.Server) *BodyweightImportHandler {
+	return &BodyweightImportHandler{srv: srv}
+}
+
+func (h *BodyweightImportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt != "text/csv" && mt != "text/plain" {
+		httpjson.WriteErrorCode(w, r, http.StatusUnsupportedMediaType, httpjson.CodeMediaType, "Content-Type must be %s", "text/csv")
+		return
+	}
+	var errs validationErrors
+	format := r.URL.Query().Get("format")
+	if format != "" && !slices.Contains(scale.Formats, format) {
+		errs.add("format", "format must be one of: withings, renpho")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxScaleImportBytes)
+	format, readings, lineErrs, err := scale.Parse(r.Body, format)
+	if err != nil {
+		var mbe *http.MaxBytesError
+		switch {
+		case errors.As(err, &mbe):
+			httpjson.WriteErrorCode(w, r, http.StatusRequestEntityTooLarge, httpjson.CodeBodyTooLarge, "file must not exceed %d MB", maxScaleImportBytes>>20)
+		case errors.Is(err, scale.ErrUnknownFormat):
+			httpjson.WriteError(w, r, http.StatusBadRequest, "unrecognized smart-scale CSV header")
+		default:
+			httpjson.WriteError(w, r, http.StatusBadRequest, "malformed CSV file")
+		}
+		return
+	}
+
+	result := models.BodyweightImportResult{Format: format, Errors: []models.BodyweightImportError{}}
+	for _, le := range lineErrs {
+		result.Errors = append(result.Errors, models.BodyweightImportError{Line: le.Line, Message: le.Err.Error()})
+	}
+	entries := make([]models.BodyweightEntry, 0, len(readings))
+	for _, rd := range readings {
+		if rd.Weight < 20 || rd.Weight > 300 {
+			result.Errors = append(result.Errors, models.BodyweightImportError{Line: rd.Line, Message: "weight must be between 20 and 300 kg"})
+			continue
+		}
+		e := models.BodyweightEntry{Date: rd.At.Format(dateLayout), Weight: round1(rd.Weight), Source: format, MeasuredAt: &rd.At}
+		if rd.BodyFat != nil && *rd.BodyFat >= 2 && *rd.BodyFat <= 75 {
+			e.BodyFat = ptr(round1(*rd.BodyFat))
+		}
+		entries = append(entries, e)
+	}
+	result.Imported, result.Duplicates = h.srv.Bodyweight.Import(r.Context(), entries)
+	if result.Imported > 0 {
+		refreshBodyweightLoads(r.Context(), h.srv)
+	}
+	httpjson.WriteJSON(w, http.StatusOK, result)
+}
+
+// bodyweightFromRequest waliduje pomiar i zamienia masę na kg; brak daty oznacza dzisiaj.
+func bodyweightFromRequest(ctx context.Context, srv *server.Server, req models.BodyweightRequest) (models.BodyweightEntry, validationErrors) {
+	var errs validationErrors
+	weight := kgFrom(req.Weight, inputUnit(ctx, srv, &errs, req.WeightUnit))
+	e := models.BodyweightEntry{Date: req.Date, Weight: weight, BodyFat: req.BodyFat, Note: strings.TrimSpace(req.Note), Source: "manual"}
+	if e.Date == "" {
+		e.Date = time.Now().Format(dateLayout)
+	} else if _, err := time.Parse(dateLayout, e.Date); err != nil {
+		errs.add("date", "date must be YYYY-MM-DD")
+	}
+	if e.Weight < 20 || e.Weight > 300 {
+		errs.add("weight", "weight must be between 20 and 300 kg")
+	}
+	if e.BodyFat != nil && (*e.BodyFat < 2 || *e.BodyFat > 75) {
+		errs.add("bodyFat", "bodyFat must be between 2 and 75%")
+	}
+	if utf8.RuneCountInString(e.Note) > 500 {
+		errs.add("note", "note must not exceed 500 characters")
+	}
+	return e, errs
+}
+
+// resolveBodyweight wylicza ciężar serii z masą ciała: pomiar masy ciała z dnia date
+// plus dociążenie (ujemne przy odciążeniu) i opór gumy. Ciężar podany przez klienta
+// zastępujemy; bez pomiaru seria zostaje bez ciężaru. Odciążenie większe od masy ciała
+// daje ujemny ciężar, który odrzuca walidacja.
+func resolveBodyweight(ctx context.Context, bws *store.BodyweightStore, date string, exs []models.Exercise) {
+	var bw float64
+	looked, found := false, false
+	for i := range exs {
+		for j := range exs[i].Sets {
+			set := &exs[i].Sets[j]
+			if !set.Bodyweight {
+				continue
+			}
+			if !looked {
+				if e, ok := bws.At(ctx, date); ok {
+					bw, found = e.Weight, true
+				}
+				looked = true
+			}
+			if !found {
+				set.Weight = nil
+				continue
+			}
+			weight := bw
+			if set.AddedWeight != nil {
+				weight += *set.AddedWeight
+			}
+			if b := set.Band; b != nil && b.Assisted {
+				weight -= b.Resistance
+			} else if b != nil {
+				weight += b.Resistance
+			}
+			weight = roundKg(weight)
+			set.Weight = &weight
+		}
+	}
+}
+
+// refreshBodyweightLoads przelicza ciężar serii z masą ciała po zmianie dziennika masy
+// ciała. Pomiar liczy się nie tylko dla treningu z jego dnia, ale dla każdego bez bliższego
+// pomiaru (patrz BodyweightStore.At), więc sprawdzamy wszystkie treningi. Ciężar jest
+// pochodny, więc zapisujemy go przez RefreshWeights: bez nowej wersji, wpisu w historii
+// (undo cofa dalej edycję użytkownika) i w dzienniku audytu.
+func refreshBodyweightLoads(ctx context.Context, srv *server.Server) {
+	all, _, _ := srv.Workouts.List(ctx, store.WorkoutQuery{})
+	for _, wk := range all {
+		refreshWorkoutBodyweight(ctx, srv, wk)
+	}
+}
+
+// refreshWorkoutBodyweight przelicza ciężar serii z masą ciała jednego treningu od
+// bieżącego dziennika, np. po cofnięciu zmiany albo przywróceniu z kosza, kiedy trening
+// wraca z ciężarami z chwili zapisu. Zwraca trening po przeliczeniu.
+func refreshWorkoutBodyweight(ctx context.Context, srv *server.Server, wk models.Workout) models.Workout {
+	exs, changed := withBodyweightLoads(ctx, srv.Bodyweight, wk)
+	if !changed {
+		return wk
+	}
+	if refreshed, ok := srv.Workouts.RefreshWeights(ctx, wk.ID, wk.Version, exs); ok {
+		return refreshed
+	}
+	return wk
+}
+
+// withBodyweightLoads zwraca ćwiczenia treningu z ciężarem serii z masą ciała policzonym
+// od bieżącego dziennika; changed = false, gdy żaden ciężar się nie zmienił.
+func withBodyweightLoads(ctx context.Context, bws *store.BodyweightStore, wk models.Workout) (exs []models.Exercise, changed bool) {
+	// Serie kopiujemy, bo resolveBodyweight podmienia w nich wskaźnik ciężaru.
+	exs = slices.Clone(wk.Exercises)
+	for i := range exs {
+		exs[i].Sets = slices.Clone(exs[i].Sets)
+	}
+	resolveBodyweight(ctx, bws, wk.Date, exs)
+	for i := range exs {
+		for j, set := range exs[i].Sets {
+			prev := wk.Exercises[i].Sets[j].Weight
+			if set.Bodyweight && ((set.Weight == nil) != (prev == nil) || set.Weight != nil && *set.Weight != *prev) {
+				changed = true
+			}
+		}
+	}
+	return exs, changed
+}
+
+// queryBodyweight czyta ?bodyweight=, a bez niego bierze najnowszy pomiar z dziennika.
+func queryBodyweight(ctx context.Context, srv *server.Server, errs *validationErrors, q url.Values) float64 {
+	if !q.Has("bodyweight") {
+		if e, ok := srv.Bodyweight.Latest(ctx); ok {
+			return e.Weight
+		}
+		errs.add("bodyweight", "bodyweight is required when no bodyweight is logged")
+		return 0
+	}
+	bw := queryFloat(errs, q, "bodyweight", 0)
+	if bw < 20 || bw > 300 {
+		errs.add("bodyweight", "bodyweight must be between 20 and 300 kg")
+	}
+	return bw
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+// maxBulkWorkouts ogranicza liczbę treningów w jednym żądaniu bulk.
+const maxBulkWorkouts = 500
+
+type WorkoutsBulkHandler struct {
+	srv *server.Server
+}
+
+// NewWorkoutsBulkHandler obsługuje POST /workouts/bulk: tworzy wiele treningów
+// naraz (np. import z innej aplikacji). Zapis jest atomowy – jeśli którykolwiek
+// trening jest niepoprawny, nie zapisujemy żadnego.
+func NewWorkoutsBulkHandler(srv *server.Server) *WorkoutsBulkHandler {
+	return &WorkoutsBulkHandler{srv: srv}
+}
+
+func (h *WorkoutsBulkHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqs []models.CreateWorkoutRequest
+	if err := httpjson.ReadJSON(w, r, &reqs); err != nil {
+		httpjson.WriteReadError(w, r, err)
+		return
+	}
+	if len(reqs) == 0 || len(reqs) > maxBulkWorkouts {
+		httpjson.WriteError(w, r, http.StatusBadRequest, "bulk request must contain between 1 and %d workouts", maxBulkWorkouts)
+		return
+	}
+
+	// Walidujemy wszystkie treningi przed zapisem; ścieżki pól mają prefiks
+	// z indeksem w tablicy, np. "[3].exercises[0].name".
+	var errs validationErrors
+	wks := make([]models.Workout, 0, len(reqs))
+	for i, req := range reqs {
+		wk := workoutFromRequest(models.Workout{}, req)
+		var werrs validationErrors
+		exercisesToKg(wk.Exercises, inputUnit(r.Context(), h.srv, &werrs, req.WeightUnit))
+		for _, e := range append(werrs, checkWorkout(r.Context(), h.srv, wk)...) {
+			errs.addf(fmt.Sprintf("[%d].%s", i, e.Field), e.Message, e.Args...)
+		}
+		wks = append(wks, wk)
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	created := h.srv.Workouts.CreateMany(r.Context(), wks)
+	httpjson.WriteJSON(w, http.StatusCreated, workoutsInUnit(r.Context(), h.srv, created))
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+// monthLayout to format miesiąca w ścieżce kalendarza (YYYY-MM).
+const monthLayout = "2006-01"
+
+type CalendarHandler struct {
+	srv *server.Server
+}
+
+// NewCalendarHandler obsługuje GET /calendar/{month} (np. /calendar/2026-01): każdy dzień
+// miesiąca z treningami (tytuł, liczba serii, tonaż), żeby aplikacja narysowała widok
+// miesiąca jednym żądaniem. Zaplanowane treningi są w dniach, ale nie liczą się do sum,
+// a serie rozgrzewkowe nie liczą się do tonażu.
+// Dni z oceną samopoczucia mają też gotowość do treningu.
+func NewCalendarHandler(srv *server.Server) *CalendarHandler {
+	return &CalendarHandler{srv: srv}
+}
+
+func (h *CalendarHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	month, err := time.Parse(monthLayout, r.PathValue("month"))
+	if err != nil {
+		var errs validationErrors
+		errs.add("month", "month must be YYYY-MM")
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	cal := models.CalendarMonth{Month: month.Format(monthLayout)}
+	index := map[string]int{}
+	for d := month; d.Month() == month.Month(); d = d.AddDate(0, 0, 1) {
+		index[d.Format(dateLayout)] = len(cal.Days)
+		cal.Days = append(cal.Days, models.CalendarDay{Date: d.Format(dateLayout), Workouts: []models.CalendarWorkout{}})
+	}
+	workouts, _, _ := h.srv.Workouts.List(r.Context(), store.WorkoutQuery{
+		From: cal.Days[0].Date,
+		To:   cal.Days[len(cal.Days)-1].Date,
+		Sort: []store.SortKey{{Field: "date"}, {Field: "id"}},
+	})
+	for _, wk := range workouts {
+		day := &cal.Days[index[wk.Date]]
+		cw := models.CalendarWorkout{ID: wk.ID, Title: wk.Title, Planned: wk.Planned}
+		for _, ex := range wk.Exercises {
+			for _, set := range ex.Sets {
+				cw.Sets++
+				if set.Weight != nil && set.Type != models.SetWarmup {
+					cw.Tonnage += *set.Weight * float64(set.Reps)
+				}
+			}
+		}
+		cw.Tonnage = round1(cw.Tonnage)
+		day.Workouts = append(day.Workouts, cw)
+		if wk.Planned {
+			continue
+		}
+		if !day.Trained {
+			day.Trained = true
+			cal.TrainingDays++
+		}
+		day.Tonnage += cw.Tonnage
+		cal.Workouts++
+		cal.Tonnage += cw.Tonnage
+	}
+	// Lista jest od najnowszej oceny, więc dzień dostaje najnowszą ze swoich.
+	checkins := h.srv.Checkins.List(r.Context(), cal.Days[0].Date, cal.Days[len(cal.Days)-1].Date)
+	for i := len(checkins) - 1; i >= 0; i-- {
+		cal.Days[index[checkins[i].Date]].Readiness = &checkins[i].Readiness
+	}
+	for i := range cal.Days {
+		cal.Days[i].Tonnage = round1(cal.Days[i].Tonnage)
+	}
+	cal.Tonnage = round1(cal.Tonnage)
+	httpjson.WriteJSON(w, http.StatusOK, cal)
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/progression"
+	"gym-api/internal/server"
+)
+
+type CheckinsHandler struct {
+	srv *server.Server
+}
+
+// NewCheckinsHandler obsługuje codzienne oceny samopoczucia:
+//   - GET /checkins: oceny od najnowszej, opcjonalnie z zakresu ?from=&to=
+//   - POST /checkins: energia, zakwasy, stres i jakość snu w skali 1–5; odpowiedź ma
+//     wyliczoną gotowość do treningu, której używa GET /workouts/next-suggestion
+func NewCheckinsHandler(srv *server.Server) *CheckinsHandler {
+	return &CheckinsHandler{srv: srv}
+}
+
+func (h *CheckinsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var errs validationErrors
+		q := r.URL.Query()
+		from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+		if from != "" && to != "" && to < from {
+			errs.add("to", "to must not be before from")
+		}
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, h.srv.Checkins.List(r.Context(), from, to))
+
+	case http.MethodPost:
+		var req models.CheckinRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		c, errs := checkinFromRequest(r.Context(), h.srv, req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusCreated, h.srv.Checkins.Create(r.Context(), c))
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type CheckinByIDHandler struct {
+	srv *server.Server
+}
+
+// NewCheckinByIDHandler obsługuje GET, PUT (pełna zamiana) i DELETE /checkins/{id}.
+func NewCheckinByIDHandler(srv *server.Server) *CheckinByIDHandler {
+	return &CheckinByIDHandler{srv: srv}
+}
+
+func (h *CheckinByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		c, found := h.srv.Checkins.Get(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Check-in not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, c)
+
+	case http.MethodPut:
+		var req models.CheckinRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		c, errs := checkinFromRequest(r.Context(), h.srv, req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		updated, err := h.srv.Checkins.Update(r.Context(), id, c)
+		if err != nil {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Check-in not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, updated)
+
+	case http.MethodDelete:
+		if !h.srv.Checkins.Delete(r.Context(), id) {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Check-in not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// checkinFromRequest waliduje ocenę i liczy gotowość; brak daty oznacza dzisiaj.
+// Bez jakości snu bierzemy ocenę nocy z tego dnia z dziennika snu.
+func checkinFromRequest(ctx context.Context, srv *server.Server, req models.CheckinRequest) (models.Checkin, validationErrors) {
+	var errs validationErrors
+	c := models.Checkin{
+		Date:         req.Date,
+		Energy:       req.Energy,
+		Soreness:     req.Soreness,
+		Stress:       req.Stress,
+		SleepQuality: req.SleepQuality,
+		Note:         strings.TrimSpace(req.Note),
+	}
+	if c.Date == "" {
+		c.Date = time.Now().Format(dateLayout)
+	} else if _, err := time.Parse(dateLayout, c.Date); err != nil {
+		errs.add("date", "date must be YYYY-MM-DD")
+	}
+	if c.SleepQuality == 0 {
+		for _, night := range srv.Sleep.List(ctx, c.Date, c.Date) {
+			if night.Quality > 0 {
+				c.SleepQuality = night.Quality
+				break
+			}
+		}
+	}
+	if c.Energy < 1 || c.Energy > 5 {
+		errs.add("energy", "energy must be between 1 and 5")
+	}
+	if c.Soreness < 1 || c.Soreness > 5 {
+		errs.add("soreness", "soreness must be between 1 and 5")
+	}
+	if c.Stress < 1 || c.Stress > 5 {
+		errs.add("stress", "stress must be between 1 and 5")
+	}
+	if c.SleepQuality < 1 || c.SleepQuality > 5 {
+		errs.add("sleepQuality", "sleepQuality must be between 1 and 5 (or logged in /sleep)")
+	}
+	if utf8.RuneCountInString(c.Note) > 500 {
+		errs.add("note", "note must not exceed 500 characters")
+	}
+	c.Readiness = progression.Readiness(c.Energy, c.Soreness, c.Stress, c.SleepQuality)
+	return c, errs
+}
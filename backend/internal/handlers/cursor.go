@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"gym-api/internal/store"
+)
+
+// cursorToken to zawartość kursora przed zakodowaniem. Zapamiętujemy też
+// sortowanie, bo kursor ma sens tylko dla tej samej kolejności listy.
+type cursorToken struct {
+	Sort      string    `json:"s"`
+	ID        int       `json:"i"`
+	Date      string    `json:"d"`
+	Title     string    `json:"t,omitempty"`
+	CreatedAt time.Time `json:"c"`
+	UpdatedAt time.Time `json:"u"`
+}
+
+// encodeCursor koduje pozycję na liście treningów jako nieprzezroczysty token.
+// Klient nie powinien polegać na jego formacie – może się zmienić.
+func encodeCursor(c store.WorkoutCursor, sort string) string {
+	raw, _ := json.Marshal(cursorToken{
+		Sort: sort, ID: c.ID, Date: c.Date, Title: c.Title, CreatedAt: c.CreatedAt, UpdatedAt: c.UpdatedAt,
+	})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor odwraca encodeCursor; ok = false dla uszkodzonego tokenu
+// albo kursora utworzonego dla innego sortowania.
+func decodeCursor(token, sort string) (store.WorkoutCursor, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return store.WorkoutCursor{}, false
+	}
+	var t cursorToken
+	if err := json.Unmarshal(raw, &t); err != nil || t.ID <= 0 || t.Sort != sort {
+		return store.WorkoutCursor{}, false
+	}
+	return store.WorkoutCursor{ID: t.ID, Date: t.Date, Title: t.Title, CreatedAt: t.CreatedAt, UpdatedAt: t.UpdatedAt}, true
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"gym-api/internal/models"
+)
+
+// diffFields to pola treningu porównywane w historii zmian; pola techniczne
+// (updatedAt, version) zmieniają się przy każdym zapisie, więc je pomijamy.
+var diffFields = []string{"title", "date", "notes", "planned", "exercises"}
+
+// diffWorkouts zwraca zmiany między wersjami treningu jako listę operacji na ścieżkach
+// pól (np. "exercises[0].sets[1].weight"), liczonych na reprezentacji JSON.
+func diffWorkouts(prev, next models.Workout) []models.FieldChange {
+	a, b := toJSONValue(prev).(map[string]any), toJSONValue(next).(map[string]any)
+	changes := []models.FieldChange{}
+	for _, f := range diffFields {
+		changes = diffValues(changes, f, a[f], b[f])
+	}
+	return changes
+}
+
+func toJSONValue(v any) any {
+	raw, _ := json.Marshal(v)
+	var out any
+	_ = json.Unmarshal(raw, &out)
+	return out
+}
+
+// diffValues porównuje rekurencyjnie obiekty i tablice; inne wartości porównuje w całości.
+func diffValues(out []models.FieldChange, path string, a, b any) []models.FieldChange {
+	switch {
+	case reflect.DeepEqual(a, b):
+		return out
+	case a == nil:
+		return append(out, models.FieldChange{Op: "add", Path: path, New: b})
+	case b == nil:
+		return append(out, models.FieldChange{Op: "remove", Path: path, Old: a})
+	}
+
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(av)+len(bv))
+		for k := range av {
+			keys = append(keys, k)
+		}
+		for k := range bv {
+			if _, dup := av[k]; !dup {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			out = diffValues(out, path+"."+k, av[k], bv[k])
+		}
+		return out
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			break
+		}
+		for i := range max(len(av), len(bv)) {
+			var x, y any
+			if i < len(av) {
+				x = av[i]
+			}
+			if i < len(bv) {
+				y = bv[i]
+			}
+			out = diffValues(out, fmt.Sprintf("%s[%d]", path, i), x, y)
+		}
+		return out
+	}
+	return append(out, models.FieldChange{Op: "replace", Path: path, Old: a, New: b})
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+type WorkoutDuplicateHandler struct {
+	srv *server.Server
+}
+
+// NewWorkoutDuplicateHandler obsługuje POST /workouts/{id}/duplicate: kopiuje trening
+// na dzisiejszą datę (lub ?date=), żeby "powtórz ostatnią sesję" było jednym kliknięciem.
+// Z ?clearWeights=true kopia ma te same serie, ale bez ciężarów.
+func NewWorkoutDuplicateHandler(srv *server.Server) *WorkoutDuplicateHandler {
+	return &WorkoutDuplicateHandler{srv: srv}
+}
+
+func (h *WorkoutDuplicateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	var errs validationErrors
+	q := r.URL.Query()
+	date := queryDate(&errs, q, "date")
+	clearWeights := queryBool(&errs, q, "clearWeights")
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+	if date == "" {
+		date = time.Now().Format(dateLayout)
+	}
+
+	src, found := h.srv.Workouts.Get(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Workout not found")
+		return
+	}
+
+	copied := models.Workout{
+		Title:     src.Title,
+		Date:      date,
+		Notes:     src.Notes,
+		Exercises: cloneExercises(src.Exercises, clearWeights),
+	}
+	// Serie procentowe z wyczyszczonym ciężarem dostają go z bieżącego TM.
+	resolvePercentOfTM(r.Context(), h.srv.TrainingMaxes, copied.Exercises, false)
+	// Ciężar serii z masą ciała liczymy od masy z dnia kopii.
+	resolveBodyweight(r.Context(), h.srv.Bodyweight, copied.Date, copied.Exercises)
+	writeWorkout(w, r, h.srv, http.StatusCreated, h.srv.Workouts.Create(r.Context(), copied))
+}
+
+// cloneExercises kopiuje ćwiczenia wraz z seriami, wynikiem kardio i blokiem interwałowym,
+// żeby kopia nie dzieliła z oryginałem slice'ów ani wskaźników na ciężar. RPE, RIR,
+// faktyczna przerwa i notatki serii opisują wykonanie, więc ich nie kopiujemy (notatki
+// ćwiczenia to zwykle wskazówki techniczne, więc zostają).
+func cloneExercises(exs []models.Exercise, clearWeights bool) []models.Exercise {
+	if exs == nil {
+		return nil
+	}
+	out := make([]models.Exercise, len(exs))
+	for i, ex := range exs {
+		sets := make([]models.Set, len(ex.Sets))
+		for j, s := range ex.Sets {
+			if s.Weight != nil && !clearWeights {
+				weight := *s.Weight
+				s.Weight = &weight
+			} else {
+				s.Weight = nil
+			}
+			if s.AddedWeight != nil && !clearWeights {
+				added := *s.AddedWeight
+				s.AddedWeight = &added
+			} else {
+				s.AddedWeight = nil
+			}
+			if s.PercentOfTM != nil {
+				pct := *s.PercentOfTM
+				s.PercentOfTM = &pct
+			}
+			s.Band = clonePtr(s.Band)
+			s.PlannedRestSeconds = clonePtr(s.PlannedRestSeconds)
+			s.RPE, s.RIR, s.RestSeconds, s.Notes = nil, nil, nil, ""
+			sets[j] = s
+		}
+		out[i] = models.Exercise{ExerciseID: ex.ExerciseID, Name: ex.Name, Type: ex.Type, Sets: sets, Notes: ex.Notes, GroupID: ex.GroupID, GroupType: ex.GroupType}
+		if c := ex.Cardio; c != nil {
+			// Tętno, jak RPE, opisuje wykonanie – kopiujemy tylko trasę, czas i tempo.
+			out[i].Cardio = &models.Cardio{Duration: c.Duration, Distance: clonePtr(c.Distance), AvgPace: clonePtr(c.AvgPace), ElevationGain: clonePtr(c.ElevationGain)}
+		}
+		out[i].Interval = clonePtr(ex.Interval)
+	}
+	return out
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// pathID czyta dodatnie {id} ze wzorca trasy (np. /workouts/{id}/duplicate).
+func pathID(r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	return id, err == nil && id > 0
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/progression"
+	"gym-api/internal/server"
+)
+
+type ExerciseE1RMHandler struct {
+	srv *server.Server
+}
+
+// NewExerciseE1RMHandler obsługuje GET /exercises/{id}/e1rm: najlepszy szacowany 1RM
+// z każdej sesji ćwiczenia (serie 1–10 powtórzeń) do wykresu postępów. Wzór wybiera
+// ?formula=epley|brzycki|lombardi (domyślnie epley), zakres – ?from=&to=. Ciężary są
+// w jednostce z profilu.
+func NewExerciseE1RMHandler(srv *server.Server) *ExerciseE1RMHandler {
+	return &ExerciseE1RMHandler{srv: srv}
+}
+
+func (h *ExerciseE1RMHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	formula := cmp.Or(q.Get("formula"), progression.FormulaEpley)
+	from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+	if !slices.Contains(progression.Formulas, formula) {
+		errs.add("formula", "formula must be one of: epley, brzycki, lombardi")
+	}
+	if from != "" && to != "" && to < from {
+		errs.add("to", "to must not be before from")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+	ex, found := h.srv.Exercises.Get(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Exercise not found")
+		return
+	}
+
+	unit := weightUnit(r.Context(), h.srv)
+	out := models.E1RMSeries{ExerciseID: id, Name: ex.Name, Formula: formula, Points: []models.E1RMPoint{}, WeightUnit: unit}
+	for _, s := range h.srv.Workouts.ExerciseHistory(r.Context(), id, ex.Name) {
+		if from != "" && s.Date < from || to != "" && s.Date > to {
+			continue
+		}
+		e1rm, set, ok := progression.BestE1RMWith(progression.Session{Date: s.Date, Sets: s.Sets}, formula)
+		if !ok {
+			continue
+		}
+		out.Points = append(out.Points, models.E1RMPoint{Date: s.Date, E1RM: kgTo(round1(e1rm), unit, true), Weight: kgTo(*set.Weight, unit, true), Reps: set.Reps})
+	}
+	// Historia jest od najnowszej sesji, wykres – od najstarszej.
+	slices.Reverse(out.Points)
+	for i, pt := range out.Points {
+		if out.Best == nil || pt.E1RM > out.Best.E1RM {
+			out.Best = &out.Points[i]
+		}
+	}
+	httpjson.WriteJSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+
+	"gym-api/internal/models"
+)
+
+// ETagi są słabe (W/"..."): reprezentacja może być skompresowana lub zawężona
+// przez ?fields=, a znacznik ma mówić tylko o tym, czy dane się zmieniły.
+
+// workoutETag wylicza ETag treningu na podstawie ID i czasu ostatniej zmiany. Odpowiedź
+// w lb ma inny znacznik, żeby po zmianie jednostki w profilu nie dostać 304 z kg.
+func workoutETag(wk models.Workout) string {
+	if wk.WeightUnit == models.UnitLb {
+		return fmt.Sprintf(`W/"%d-%d-lb"`, wk.ID, wk.UpdatedAt.UnixNano())
+	}
+	return fmt.Sprintf(`W/"%d-%d"`, wk.ID, wk.UpdatedAt.UnixNano())
+}
+
+// pageETag wylicza ETag strony listy: zmienia się, gdy zmieni się którykolwiek
+// trening na stronie, ich kolejność albo łączna liczba (np. po usunięciu).
+func pageETag(page models.WorkoutPage) string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d|%s", page.Total, page.NextCursor)
+	for _, wk := range page.Items {
+		fmt.Fprintf(h, "|%d-%d-%s", wk.ID, wk.UpdatedAt.UnixNano(), wk.WeightUnit)
+	}
+	return fmt.Sprintf(`W/"%x"`, h.Sum64())
+}
+
+// notModified ustawia nagłówek ETag i, jeśli klient ma już aktualną wersję
+// (If-None-Match), odpowiada 304 bez body. Zwraca true, gdy odpowiedź została wysłana.
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	if !etagMatches(r.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// etagMatches porównuje listę znaczników z If-None-Match z bieżącym ETagiem
+// (porównanie słabe, RFC 9110 §13.1.2).
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	for _, t := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(t), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
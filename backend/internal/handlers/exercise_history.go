@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+type ExerciseHistoryHandler struct {
+	srv *server.Server
+}
+
+// NewExerciseHistoryHandler obsługuje GET /exercises/{id}/history: każdą zapisaną serię
+// ćwiczenia (data, ciężar, powtórzenia, RPE, RIR) z wykonanych treningów, od najnowszego
+// treningu, stronicowaną przez ?limit=&offset= i zawężaną przez ?from=&to=.
+func NewExerciseHistoryHandler(srv *server.Server) *ExerciseHistoryHandler {
+	return &ExerciseHistoryHandler{srv: srv}
+}
+
+func (h *ExerciseHistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	limit := queryInt(&errs, q, "limit", defaultPageSize)
+	offset := queryInt(&errs, q, "offset", 0)
+	from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+	if limit < 1 || limit > maxPageSize {
+		errs.add("limit", "limit must be between 1 and 200")
+	}
+	if offset < 0 {
+		errs.add("offset", "offset must be >= 0")
+	}
+	if from != "" && to != "" && to < from {
+		errs.add("to", "to must not be before from")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+	ex, found := h.srv.Exercises.Get(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Exercise not found")
+		return
+	}
+
+	unit := weightUnit(r.Context(), h.srv)
+	var sets []models.ExerciseSetEntry
+	for _, s := range h.srv.Workouts.ExerciseHistory(r.Context(), id, ex.Name) {
+		if from != "" && s.Date < from || to != "" && s.Date > to {
+			continue
+		}
+		for i, set := range setsInUnit(s.Sets, unit) {
+			sets = append(sets, models.ExerciseSetEntry{
+				WorkoutID:       s.WorkoutID,
+				Date:            s.Date,
+				Set:             i + 1,
+				Type:            set.Type,
+				Reps:            set.Reps,
+				DurationSeconds: set.DurationSeconds,
+				Weight:          set.Weight,
+				Bodyweight:      set.Bodyweight,
+				AddedWeight:     set.AddedWeight,
+				Band:            set.Band,
+				RPE:             set.RPE,
+				RIR:             set.RIR,
+			})
+		}
+	}
+	page := models.ExerciseHistoryPage{ExerciseID: id, Name: ex.Name, Total: len(sets), Limit: limit, Offset: offset, WeightUnit: unit}
+	start := min(offset, len(sets))
+	page.Items = append([]models.ExerciseSetEntry{}, sets[start:min(start+limit, len(sets))]...)
+	httpjson.WriteJSON(w, http.StatusOK, page)
+}
+
+type ExerciseLastHandler struct {
+	srv *server.Server
+}
+
+// NewExerciseLastHandler obsługuje GET /exercises/{id}/last: serie z ostatniego wykonanego
+// treningu z tym ćwiczeniem, żeby w trakcie logowania pokazać "ostatnio: 3x8 @ 80 kg".
+// ?excludeWorkoutId= pomija trening, który właśnie jest wypełniany.
+func NewExerciseLastHandler(srv *server.Server) *ExerciseLastHandler {
+	return &ExerciseLastHandler{srv: srv}
+}
+
+func (h *ExerciseLastHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	var errs validationErrors
+	exclude := queryInt(&errs, r.URL.Query(), "excludeWorkoutId", 0)
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+	ex, found := h.srv.Exercises.Get(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Exercise not found")
+		return
+	}
+	unit := weightUnit(r.Context(), h.srv)
+	for _, s := range h.srv.Workouts.ExerciseHistory(r.Context(), id, ex.Name) {
+		if s.WorkoutID == exclude {
+			continue
+		}
+		sets := setsInUnit(s.Sets, unit)
+		httpjson.WriteJSON(w, http.StatusOK, models.LastExerciseSession{
+			ExerciseID: id,
+			Name:       ex.Name,
+			WorkoutID:  s.WorkoutID,
+			Date:       s.Date,
+			Sets:       sets,
+			Summary:    setsSummary(sets, unit),
+			WeightUnit: unit,
+		})
+		return
+	}
+	httpjson.WriteError(w, r, http.StatusNotFound, "exercise has not been logged yet")
+}
+
+// setsSummary opisuje serie zwięźle, łącząc kolejne takie same: "3x8 @ 80 kg, 1x6 @ 85 kg"
+// (ciężary w jednostce unit); serie na czas bez powtórzeń to np. "3x60 s".
+func setsSummary(sets []models.Set, unit string) string {
+	var parts []string
+	for i := 0; i < len(sets); {
+		j := i + 1
+		for j < len(sets) && sets[j].Reps == sets[i].Reps && sets[j].DurationSeconds == sets[i].DurationSeconds && sameWeight(sets[j].Weight, sets[i].Weight) {
+			j++
+		}
+		part := strconv.Itoa(j-i) + "x" + strconv.Itoa(sets[i].Reps)
+		if sets[i].Reps == 0 {
+			part = strconv.Itoa(j-i) + "x" + strconv.Itoa(sets[i].DurationSeconds) + " s"
+		}
+		if sets[i].Weight != nil {
+			part += " @ " + strconv.FormatFloat(*sets[i].Weight, 'f', -1, 64) + " " + unit
+		}
+		parts = append(parts, part)
+		i = j
+	}
+	return strings.Join(parts, ", ")
+}
+
+func sameWeight(a, b *float64) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/i18n"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+type ExercisesHandler struct {
+	srv *server.Server
+}
+
+// NewExercisesHandler obsługuje katalog ćwiczeń:
+//   - GET /exercises: katalog globalny i ćwiczenia własne (alfabetycznie), z filtrami
+//     ?q=&muscleGroup=&equipment=&type=; ?archived=true pokazuje też zarchiwizowane
+//   - POST /exercises: dodanie własnego ćwiczenia (np. "landmine press from pins")
+func NewExercisesHandler(srv *server.Server) *ExercisesHandler {
+	return &ExercisesHandler{srv: srv}
+}
+
+func (h *ExercisesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var errs validationErrors
+		q := r.URL.Query()
+		query := store.ExerciseQuery{
+			Search:          strings.TrimSpace(q.Get("q")),
+			MuscleGroup:     strings.TrimSpace(q.Get("muscleGroup")),
+			PrimaryOnly:     queryBool(&errs, q, "primaryOnly"),
+			Equipment:       strings.TrimSpace(q.Get("equipment")),
+			Type:            strings.TrimSpace(q.Get("type")),
+			IncludeArchived: queryBool(&errs, q, "archived"),
+		}
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, h.srv.Exercises.List(r.Context(), query))
+
+	case http.MethodPost:
+		var req models.CatalogExerciseRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		e := catalogExerciseFromRequest(req)
+		if errs := validateCatalogExercise(e); len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		// Ćwiczenia z API są zawsze własne; katalog globalny wypełnia import przy starcie.
+		e.Owner = store.ActorFrom(r.Context())
+		created, err := h.srv.Exercises.Create(r.Context(), e)
+		if err != nil {
+			writeExerciseError(w, r, err)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusCreated, created)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type ExerciseByIDHandler struct {
+	srv *server.Server
+}
+
+// NewExerciseByIDHandler obsługuje pojedyncze ćwiczenie katalogowe:
+// GET, PUT (pełna zamiana) i DELETE /exercises/{id}. Zmieniać można tylko ćwiczenia własne.
+func NewExerciseByIDHandler(srv *server.Server) *ExerciseByIDHandler {
+	return &ExerciseByIDHandler{srv: srv}
+}
+
+func (h *ExerciseByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		e, found := h.srv.Exercises.Get(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Exercise not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, e)
+
+	case http.MethodPut:
+		var req models.CatalogExerciseRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		e := catalogExerciseFromRequest(req)
+		if errs := validateCatalogExercise(e); len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		updated, err := h.srv.Exercises.Update(r.Context(), id, e)
+		if err != nil {
+			writeExerciseError(w, r, err)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, updated)
+
+	case http.MethodDelete:
+		// Treningi zachowują nazwę usuniętego ćwiczenia, więc historia nie znika.
+		if err := h.srv.Exercises.Delete(r.Context(), id); err != nil {
+			writeExerciseError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type ExerciseArchiveHandler struct {
+	srv      *server.Server
+	archived bool
+}
+
+// NewExerciseArchiveHandler obsługuje POST /exercises/{id}/archive (archived = true)
+// i POST /exercises/{id}/unarchive (archived = false) dla ćwiczeń własnych.
+func NewExerciseArchiveHandler(srv *server.Server, archived bool) *ExerciseArchiveHandler {
+	return &ExerciseArchiveHandler{srv: srv, archived: archived}
+}
+
+func (h *ExerciseArchiveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	e, err := h.srv.Exercises.SetArchived(r.Context(), id, h.archived)
+	if err != nil {
+		writeExerciseError(w, r, err)
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, e)
+}
+
+type ExerciseInstructionsHandler struct {
+	srv *server.Server
+}
+
+// NewExerciseInstructionsHandler obsługuje GET i PUT /exercises/{id}/instructions:
+// opis, wskazówki techniczne i zdjęcie/film ćwiczenia. Zmieniać można tylko ćwiczenia własne.
+func NewExerciseInstructionsHandler(srv *server.Server) *ExerciseInstructionsHandler {
+	return &ExerciseInstructionsHandler{srv: srv}
+}
+
+func (h *ExerciseInstructionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		e, found := h.srv.Exercises.Get(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Exercise not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, e.ExerciseInstructions)
+
+	case http.MethodPut:
+		var req models.ExerciseInstructions
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		in := instructionsFromRequest(req)
+		var errs validationErrors
+		validateInstructions(&errs, in)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		e, err := h.srv.Exercises.SetInstructions(r.Context(), id, in)
+		if err != nil {
+			writeExerciseError(w, r, err)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, e.ExerciseInstructions)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// catalogExerciseFromRequest przycina białe znaki; ID grup mięśni zapisujemy małymi literami.
+func catalogExerciseFromRequest(req models.CatalogExerciseRequest) models.CatalogExercise {
+	return models.CatalogExercise{
+		Name:                 strings.TrimSpace(req.Name),
+		Aliases:              normalizeAliases(req.Aliases),
+		PrimaryMuscles:       normalizeMuscles(req.PrimaryMuscles),
+		SecondaryMuscles:     normalizeMuscles(req.SecondaryMuscles),
+		Equipment:            strings.TrimSpace(req.Equipment),
+		Type:                 strings.TrimSpace(req.Type),
+		ExerciseInstructions: instructionsFromRequest(req.ExerciseInstructions),
+	}
+}
+
+// instructionsFromRequest przycina białe znaki w opisie, wskazówkach i adresach.
+func instructionsFromRequest(in models.ExerciseInstructions) models.ExerciseInstructions {
+	cues := make([]string, 0, len(in.Cues))
+	for _, c := range in.Cues {
+		cues = append(cues, strings.TrimSpace(c))
+	}
+	return models.ExerciseInstructions{
+		Description: strings.TrimSpace(in.Description),
+		Cues:        cues,
+		ImageURL:    strings.TrimSpace(in.ImageURL),
+		VideoURL:    strings.TrimSpace(in.VideoURL),
+	}
+}
+
+// normalizeAliases przycina aliasy i usuwa powtórzenia (bez rozróżniania wielkości liter).
+func normalizeAliases(aliases []string) []string {
+	out := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		a = strings.TrimSpace(a)
+		if !slices.ContainsFunc(out, func(b string) bool { return strings.EqualFold(a, b) }) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func normalizeMuscles(ids []string) []string {
+	out := make([]string, 0, len(ids))
+	for _, m := range ids {
+		out = append(out, strings.ToLower(strings.TrimSpace(m)))
+	}
+	return out
+}
+
+// writeExerciseError odpowiada na błędy zapisu do katalogu.
+func writeExerciseError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, store.ErrDuplicateName):
+		httpjson.WriteError(w, r, http.StatusConflict, "an exercise with this name or alias already exists")
+	case errors.Is(err, store.ErrReadOnly):
+		httpjson.WriteError(w, r, http.StatusForbidden, "global catalog exercises cannot be modified")
+	default:
+		httpjson.WriteError(w, r, http.StatusNotFound, "Exercise not found")
+	}
+}
+
+type MuscleGroupsHandler struct{}
+
+// NewMuscleGroupsHandler obsługuje GET /muscle-groups: taksonomię grup mięśni
+// z nazwami przetłumaczonymi według Accept-Language.
+func NewMuscleGroupsHandler() *MuscleGroupsHandler {
+	return &MuscleGroupsHandler{}
+}
+
+func (h *MuscleGroupsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	lang := i18n.FromRequest(r)
+	groups := store.MuscleGroups()
+	for i := range groups {
+		groups[i].Name = i18n.T(lang, groups[i].Name)
+	}
+	httpjson.WriteJSON(w, http.StatusOK, groups)
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gym-api/internal/models"
+)
+
+// workoutFields to nazwy pól JSON treningu, które można wybrać przez ?fields=.
+var workoutFields = jsonFieldNames(reflect.TypeFor[models.Workout]())
+
+// jsonFieldNames zwraca nazwy pól struktury tak, jak widzi je encoding/json.
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// parseFields czyta parametr fields (np. "id,title,date"). Pusty parametr
+// oznacza pełną reprezentację (nil).
+func parseFields(errs *validationErrors, q url.Values) []string {
+	v := strings.TrimSpace(q.Get("fields"))
+	if v == "" {
+		return nil
+	}
+	var fields []string
+	for _, f := range strings.Split(v, ",") {
+		f = strings.TrimSpace(f)
+		if !slices.Contains(workoutFields, f) {
+			errs.add("fields", "fields must be a comma-separated list of workout fields")
+			return nil
+		}
+		if !slices.Contains(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// selectFields zostawia w treningu tylko wybrane pola. Idziemy przez JSON,
+// żeby nazwy i formaty pól były dokładnie takie jak w pełnej odpowiedzi.
+func selectFields(wk models.Workout, fields []string) map[string]any {
+	raw, _ := json.Marshal(wk)
+	var all map[string]any
+	_ = json.Unmarshal(raw, &all)
+	out := make(map[string]any, len(fields))
+	for _, f := range fields {
+		if v, ok := all[f]; ok {
+			out[f] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gym-api/internal/fitbit"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+// fitbitProvider to nazwa integracji w magazynie połączeń.
+const fitbitProvider = "fitbit"
+
+// fitbitOAuth podłącza Fitbit do wspólnego przepływu OAuth (integrations.go, OAuth 2.0
+// z PKCE). Kolejne synchronizacje zaczynają tydzień przed poprzednią, bo opaska wysyła
+// dane dopiero przy synchronizacji z telefonem.
+var fitbitOAuth = &oauthProvider{
+	name:         fitbitProvider,
+	title:        "Fitbit",
+	pkce:         true,
+	initialSync:  30 * 24 * time.Hour,
+	overlap:      7 * 24 * time.Hour,
+	unauthorized: fitbit.ErrUnauthorized,
+	configured:   func(srv *server.Server) bool { return srv.Fitbit != nil },
+	authorizeURL: func(srv *server.Server, state, verifier string) string {
+		return srv.Fitbit.AuthorizeURL(state, verifier)
+	},
+	exchange: func(ctx context.Context, srv *server.Server, code, verifier string) (models.Integration, error) {
+		t, err := srv.Fitbit.Exchange(ctx, code, verifier)
+		if err != nil {
+			return models.Integration{}, err
+		}
+		return models.Integration{AthleteID: t.UserID, AccessToken: t.AccessToken, RefreshToken: t.RefreshToken, TokenExpiresAt: t.ExpiresAt}, nil
+	},
+	refresh: func(ctx context.Context, srv *server.Server, refreshToken string) (models.Integration, error) {
+		t, err := srv.Fitbit.Refresh(ctx, refreshToken)
+		return models.Integration{AccessToken: t.AccessToken, RefreshToken: t.RefreshToken, TokenExpiresAt: t.ExpiresAt}, err
+	},
+	sync: syncFitbit,
+}
+
+// fitbitActivities mapuje nazwę aktywności Fitbit na ćwiczenie kardio z katalogu.
+var fitbitActivities = map[string]string{
+	"Run":            "Running",
+	"Treadmill":      "Running",
+	"Walk":           "Walking",
+	"Bike":           "Cycling",
+	"Outdoor Bike":   "Cycling",
+	"Spinning":       "Cycling",
+	"Hike":           "Hiking",
+	"Swim":           "Swimming",
+	"Rowing Machine": "Rowing Machine",
+}
+
+// syncFitbit zapisuje aktywności i pomiary masy ciała od from jako treningi kardio
+// i wpisy dziennika masy ciała; pomiary o znanym czasie są pomijane.
+func syncFitbit(ctx context.Context, srv *server.Server, _ models.Integration, token string, from, now time.Time) (models.SyncResult, error) {
+	activities, err := srv.Fitbit.Activities(ctx, token, from)
+	if err != nil {
+		return models.SyncResult{}, err
+	}
+	weights, err := srv.Fitbit.Weights(ctx, token, from, now)
+	if err != nil {
+		return models.SyncResult{}, err
+	}
+
+	res := models.SyncResult{Fetched: len(activities), Imported: []models.Workout{}, Bodyweight: &models.ImportCounts{}}
+	for _, a := range activities {
+		id := strconv.FormatInt(a.LogID, 10)
+		if _, ok := srv.Integrations.ImportedWorkout(ctx, fitbitProvider, id); ok {
+			res.Duplicates++
+			continue
+		}
+		wk, ok := fitbitWorkout(a)
+		if !ok {
+			res.Skipped++
+			continue
+		}
+		importWorkout(ctx, srv, fitbitProvider, id, wk, &res)
+	}
+
+	entries := make([]models.BodyweightEntry, 0, len(weights))
+	for _, wt := range weights {
+		at, ok := wt.At()
+		if !ok || wt.Weight < 20 || wt.Weight > 300 {
+			res.Bodyweight.Skipped++
+			continue
+		}
+		e := models.BodyweightEntry{Date: at.Format(dateLayout), Weight: round1(wt.Weight), Source: fitbitProvider, MeasuredAt: &at}
+		if wt.BodyFat >= 2 && wt.BodyFat <= 75 {
+			e.BodyFat = ptr(round1(wt.BodyFat))
+		}
+		entries = append(entries, e)
+	}
+	res.Bodyweight.Imported, res.Bodyweight.Duplicates = srv.Bodyweight.Import(ctx, entries)
+	if res.Bodyweight.Imported > 0 {
+		refreshBodyweightLoads(ctx, srv)
+	}
+	return res, nil
+}
+
+// fitbitWorkout zamienia aktywność na trening z jednym ćwiczeniem kardio; średnie tętno
+// pochodzi z podsumowania tętna aktywności. Aktywności spoza katalogu (np. Weights, Yoga)
+// to ćwiczenie "Cardio" z tytułem z nazwy aktywności. ok = false przy nieczytelnym czasie.
+func fitbitWorkout(a fitbit.Activity) (models.Workout, bool) {
+	start, ok := a.LocalStart()
+	if !ok {
+		return models.Workout{}, false
+	}
+	name := fitbitActivities[a.ActivityName]
+	title := name
+	if name == "" {
+		name, title = "Cardio", strings.TrimSpace(a.ActivityName)
+	}
+	if title == "" {
+		title = name
+	}
+	duration := a.ActiveTime
+	if duration <= 0 {
+		duration = a.Duration
+	}
+	c := &models.Cardio{Duration: int(math.Round(float64(duration) / 1000))}
+	if a.Distance > 0 {
+		c.Distance = ptr(math.Round(a.Distance * 1000))
+	}
+	if a.Elevation > 0 {
+		c.ElevationGain = ptr(math.Round(a.Elevation))
+	}
+	if a.AvgHR > 0 {
+		c.AvgHR = ptr(int(math.Round(a.AvgHR)))
+	}
+	return models.Workout{
+		Title:     title,
+		Date:      start.Format(dateLayout),
+		Exercises: []models.Exercise{{Name: name, Type: models.ExerciseCardio, Sets: []models.Set{}, Cardio: c}},
+	}, true
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"gym-api/internal/fit"
+	"gym-api/internal/garmin"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+// garminProvider to nazwa integracji w magazynie połączeń.
+const garminProvider = "garmin"
+
+// garminStrength to typ aktywności treningu siłowego; jego serie są tylko w pliku FIT.
+const garminStrength = "STRENGTH_TRAINING"
+
+// garminActivities mapuje activityType z Garmin Connect na ćwiczenie kardio z katalogu.
+var garminActivities = map[string]string{
+	"RUNNING":           "Running",
+	"STREET_RUNNING":    "Running",
+	"TRAIL_RUNNING":     "Running",
+	"TREADMILL_RUNNING": "Running",
+	"INDOOR_RUNNING":    "Running",
+	"CYCLING":           "Cycling",
+	"ROAD_BIKING":       "Cycling",
+	"MOUNTAIN_BIKING":   "Cycling",
+	"GRAVEL_CYCLING":    "Cycling",
+	"INDOOR_CYCLING":    "Cycling",
+	"VIRTUAL_RIDE":      "Cycling",
+	"ROWING":            "Rowing Machine",
+	"INDOOR_ROWING":     "Rowing Machine",
+	"WALKING":           "Walking",
+	"HIKING":            "Hiking",
+}
+
+// defaultGarminSettings: po połączeniu importujemy wszystkie rodzaje treningów.
+var defaultGarminSettings = models.IntegrationSettings{Cardio: true, Strength: true}
+
+// garminOAuth podłącza Garmin Connect do wspólnego przepływu OAuth (integrations.go,
+// OAuth 2.0 z PKCE). API filtruje aktywności po czasie wgrania, więc kolejnym
+// synchronizacjom wystarcza mały zapas na opóźnienia zegara.
+var garminOAuth = &oauthProvider{
+	name:         garminProvider,
+	title:        "Garmin",
+	pkce:         true,
+	initialSync:  30 * 24 * time.Hour,
+	overlap:      time.Hour,
+	settings:     &defaultGarminSettings,
+	unauthorized: garmin.ErrUnauthorized,
+	configured:   func(srv *server.Server) bool { return srv.Garmin != nil },
+	authorizeURL: func(srv *server.Server, state, verifier string) string {
+		return srv.Garmin.AuthorizeURL(state, verifier)
+	},
+	exchange: func(ctx context.Context, srv *server.Server, code, verifier string) (models.Integration, error) {
+		t, err := srv.Garmin.Exchange(ctx, code, verifier)
+		if err != nil {
+			return models.Integration{}, err
+		}
+		userID, err := srv.Garmin.UserID(ctx, t.AccessToken)
+		if err != nil {
+			return models.Integration{}, err
+		}
+		return models.Integration{AthleteID: userID, AccessToken: t.AccessToken, RefreshToken: t.RefreshToken, TokenExpiresAt: t.ExpiresAt}, nil
+	},
+	refresh: func(ctx context.Context, srv *server.Server, refreshToken string) (models.Integration, error) {
+		t, err := srv.Garmin.Refresh(ctx, refreshToken)
+		return models.Integration{AccessToken: t.AccessToken, RefreshToken: t.RefreshToken, TokenExpiresAt: t.ExpiresAt}, err
+	},
+	sync: syncGarmin,
+}
+
+// syncGarmin zapisuje aktywności wgrane do Garmin Connect od from według ustawień
+// połączenia. Treningi siłowe dostają serie z pliku FIT aktywności.
+func syncGarmin(ctx context.Context, srv *server.Server, conn models.Integration, token string, from, now time.Time) (models.SyncResult, error) {
+	activities, err := srv.Garmin.Activities(ctx, token, from, now)
+	if err != nil {
+		return models.SyncResult{}, err
+	}
+
+	settings := defaultGarminSettings
+	if conn.Settings != nil {
+		settings = *conn.Settings
+	}
+	res := models.SyncResult{Fetched: len(activities), Imported: []models.Workout{}}
+	for _, a := range activities {
+		if _, ok := srv.Integrations.ImportedWorkout(ctx, garminProvider, a.SummaryID); ok {
+			res.Duplicates++
+			continue
+		}
+		strength := a.ActivityType == garminStrength
+		if strength && !settings.Strength || !strength && !settings.Cardio {
+			res.Skipped++
+			continue
+		}
+		var wk models.Workout
+		if strength {
+			var ok bool
+			wk, ok, err = garminStrengthWorkout(ctx, srv, token, a)
+			if err != nil {
+				return res, err
+			}
+			if !ok {
+				res.Skipped++
+				continue
+			}
+		} else {
+			wk = garminCardioWorkout(a)
+		}
+		importWorkout(ctx, srv, garminProvider, a.SummaryID, wk, &res)
+	}
+	return res, nil
+}
+
+// garminStrengthWorkout buduje trening siłowy z serii zapisanych w pliku FIT aktywności.
+// ok = false, gdy pliku nie udało się pobrać albo odczytać (spróbujemy przy kolejnej
+// synchronizacji); błąd zwracamy tylko przy odrzuconym tokenie.
+func garminStrengthWorkout(ctx context.Context, srv *server.Server, token string, a garmin.Activity) (models.Workout, bool, error) {
+	data, err := srv.Garmin.ActivityFile(ctx, token, a.SummaryID)
+	if errors.Is(err, garmin.ErrUnauthorized) {
+		return models.Workout{}, false, err
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "nie udało się pobrać pliku aktywności z Garmina", "summary", a.SummaryID, "err", err)
+		return models.Workout{}, false, nil
+	}
+	file, err := fit.Decode(bytes.NewReader(data))
+	if err != nil || len(file.Sessions) == 0 {
+		slog.WarnContext(ctx, "niepoprawny plik aktywności z Garmina", "summary", a.SummaryID, "err", err)
+		return models.Workout{}, false, nil
+	}
+	wk := fitWorkout(file.Sessions[0], file.Sets, file.HR, time.FixedZone("", int(a.StartTimeOffset)))
+	if name := strings.TrimSpace(a.ActivityName); name != "" {
+		wk.Title = name
+	}
+	return wk, true, nil
+}
+
+// garminCardioWorkout zamienia aktywność na trening z jednym ćwiczeniem kardio.
+func garminCardioWorkout(a garmin.Activity) models.Workout {
+	name := garminActivities[a.ActivityType]
+	if name == "" {
+		name = "Cardio"
+	}
+	c := &models.Cardio{Duration: a.Duration}
+	if a.Distance > 0 {
+		c.Distance = ptr(math.Round(a.Distance))
+	}
+	if a.ElevationGain > 0 {
+		c.ElevationGain = ptr(math.Round(a.ElevationGain))
+	}
+	if a.AvgHR > 0 {
+		c.AvgHR = ptr(int(math.Round(a.AvgHR)))
+	}
+	title := strings.TrimSpace(a.ActivityName)
+	if title == "" {
+		title = name
+	}
+	return models.Workout{
+		Title:     title,
+		Date:      a.LocalStart().Format(dateLayout),
+		Exercises: []models.Exercise{{Name: name, Type: models.ExerciseCardio, Sets: []models.Set{}, Cardio: c}},
+	}
+}
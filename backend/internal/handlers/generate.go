@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/programs"
+	"gym-api/internal/server"
+)
+
+// defaultRounding to domyślny krok zaokrąglania ciężarów (najmniejsza para talerzy 1,25 kg).
+const defaultRounding = 2.5
+
+type ProgramGenerateHandler struct {
+	srv *server.Server
+}
+
+// NewProgramGenerateHandler obsługuje POST /programs/generate?scheme=531|gzclp: z maksów
+// treningowych generuje gotowy program z ciężarami każdej serii. Dla każdej sesji powstaje
+// szablon treningu, a program wskazuje te szablony, więc od razu można go rozpisać (/schedule).
+// Pominięte maksy uzupełniamy zapisanymi TM użytkownika. Maksy i krok zaokrąglania są
+// w jednostce weightUnit (domyślnie z profilu), a szablony zwracamy jak GET /templates.
+func NewProgramGenerateHandler(srv *server.Server) *ProgramGenerateHandler {
+	return &ProgramGenerateHandler{srv: srv}
+}
+
+func (h *ProgramGenerateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var req models.GenerateProgramRequest
+	if err := httpjson.ReadJSON(w, r, &req); err != nil {
+		httpjson.WriteReadError(w, r, err)
+		return
+	}
+
+	var errs validationErrors
+	scheme := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("scheme")))
+	if !slices.Contains(programs.Schemes, scheme) {
+		errs.add("scheme", "scheme must be one of: 531, gzclp")
+	}
+	unit := inputUnit(r.Context(), h.srv, &errs, req.WeightUnit)
+	// Brakujące maksy bierzemy z zapisanych TM (GET /training-maxes).
+	tms := make(map[string]float64, len(programs.Lifts()))
+	for _, lift := range programs.Lifts() {
+		tms[lift] = kgFrom(req.TrainingMaxes[lift], unit)
+		if tms[lift] == 0 {
+			if ex, ok := h.srv.Exercises.FindByName(r.Context(), programs.LiftName(lift)); ok {
+				if tm, ok := h.srv.TrainingMaxes.Latest(r.Context(), ex.ID); ok {
+					tms[lift] = tm.Weight
+				}
+			}
+		}
+		if tms[lift] <= 0 {
+			errs.add("trainingMaxes."+lift, "training max must be > 0")
+		}
+	}
+	rounding := roundingIn(unit)
+	if req.Rounding != nil {
+		rounding = kgFrom(*req.Rounding, unit)
+		if rounding < 0 {
+			errs.add("rounding", "rounding must be >= 0")
+		}
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	plan, err := programs.Generate(scheme, tms, rounding)
+	switch {
+	case errors.Is(err, programs.ErrUnknownScheme):
+		httpjson.WriteError(w, r, http.StatusBadRequest, "scheme must be one of: 531, gzclp")
+		return
+	case errors.Is(err, programs.ErrMissingTrainingMax):
+		httpjson.WriteError(w, r, http.StatusBadRequest, "training max must be > 0")
+		return
+	case err != nil:
+		httpjson.WriteError(w, r, http.StatusInternalServerError, "program could not be generated")
+		return
+	}
+	ctx := r.Context()
+	out := models.GeneratedProgram{
+		Program: models.Program{
+			Name:        cmp.Or(strings.TrimSpace(req.Name), plan.Name),
+			Description: plan.Description,
+		},
+	}
+	for _, week := range plan.Weeks {
+		var pw models.ProgramWeek
+		for _, s := range week {
+			// Nazwy bojów pochodzą z katalogu globalnego, więc linkCatalog nie zgłasza błędów.
+			linkCatalog(ctx, h.srv.Exercises, s.Exercises, &errs)
+			t := h.srv.Templates.Create(ctx, models.WorkoutTemplate{Name: s.Name, Notes: s.Notes, Exercises: s.Exercises})
+			out.Templates = append(out.Templates, t)
+			pw.Sessions = append(pw.Sessions, models.ProgramSession{TemplateID: t.ID})
+		}
+		out.Program.Weeks = append(out.Program.Weeks, pw)
+	}
+	out.Program = h.srv.Programs.Create(ctx, out.Program)
+	profileUnit := weightUnit(ctx, h.srv)
+	for i, t := range out.Templates {
+		out.Templates[i] = templateInUnit(t, profileUnit)
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, out)
+}
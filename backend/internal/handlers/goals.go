@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+type GoalsHandler struct {
+	srv *server.Server
+}
+
+// NewGoalsHandler obsługuje cele użytkownika:
+//   - GET /goals: cele z postępem w kolejności dodania
+//   - POST /goals: nowy cel – ciężar w ćwiczeniu (lift) albo treningi w tygodniu (frequency)
+//
+// Postęp liczymy przy każdym odczycie z zapisanych treningów: dla lift to najcięższa
+// seria ćwiczenia, dla frequency – liczba wykonanych treningów w bieżącym tygodniu.
+// Ciężary celu lift przyjmujemy i zwracamy jak w treningach (weightUnit, patrz Profile).
+func NewGoalsHandler(srv *server.Server) *GoalsHandler {
+	return &GoalsHandler{srv: srv}
+}
+
+func (h *GoalsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		goals := h.srv.Goals.List(r.Context())
+		p := newGoalProgress(r.Context(), h.srv)
+		for i := range goals {
+			p.apply(&goals[i])
+			goals[i] = goalInUnit(goals[i], p.unit)
+		}
+		httpjson.WriteJSON(w, http.StatusOK, goals)
+
+	case http.MethodPost:
+		var req models.GoalRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		g, errs := goalFromRequest(r.Context(), h.srv, req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		created := h.srv.Goals.Create(r.Context(), g)
+		httpjson.WriteJSON(w, http.StatusCreated, newGoalProgress(r.Context(), h.srv).view(created))
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type GoalByIDHandler struct {
+	srv *server.Server
+}
+
+// NewGoalByIDHandler obsługuje GET, PUT (pełna zamiana) i DELETE /goals/{id}.
+func NewGoalByIDHandler(srv *server.Server) *GoalByIDHandler {
+	return &GoalByIDHandler{srv: srv}
+}
+
+func (h *GoalByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		g, found := h.srv.Goals.Get(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Goal not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, newGoalProgress(r.Context(), h.srv).view(g))
+
+	case http.MethodPut:
+		var req models.GoalRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		g, errs := goalFromRequest(r.Context(), h.srv, req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		updated, err := h.srv.Goals.Update(r.Context(), id, g)
+		if err != nil {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Goal not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, newGoalProgress(r.Context(), h.srv).view(updated))
+
+	case http.MethodDelete:
+		if !h.srv.Goals.Delete(r.Context(), id) {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Goal not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// goalFromRequest waliduje cel, zamienia ciężar docelowy na kg i uzupełnia pusty tytuł
+// (z ciężarem w jednostce z żądania).
+func goalFromRequest(ctx context.Context, srv *server.Server, req models.GoalRequest) (models.Goal, validationErrors) {
+	var errs validationErrors
+	g := models.Goal{Type: req.Type, Title: strings.TrimSpace(req.Title), Deadline: req.Deadline}
+	switch req.Type {
+	case models.GoalLift:
+		unit := inputUnit(ctx, srv, &errs, req.WeightUnit)
+		g.ExerciseID, g.TargetWeight = req.ExerciseID, kgFrom(req.TargetWeight, unit)
+		ex, ok := srv.Exercises.Get(ctx, req.ExerciseID)
+		switch {
+		case req.ExerciseID == 0:
+			errs.add("exerciseId", "exerciseId is required for lift goals")
+		case !ok:
+			errs.add("exerciseId", "exercise not found in catalog")
+		}
+		if g.TargetWeight <= 0 || g.TargetWeight > 1000 {
+			errs.add("targetWeight", "targetWeight must be > 0 and at most 1000 kg")
+		}
+		if g.Title == "" {
+			g.Title = ex.Name + " " + strconv.FormatFloat(req.TargetWeight, 'f', -1, 64) + " " + unit
+		}
+	case models.GoalFrequency:
+		g.PerWeek = req.PerWeek
+		if req.PerWeek < 1 || req.PerWeek > 7 {
+			errs.add("perWeek", "perWeek must be between 1 and 7")
+		}
+		if g.Title == "" {
+			g.Title = strconv.Itoa(req.PerWeek) + "× w tygodniu"
+		}
+	default:
+		errs.add("type", "type must be one of: lift, frequency")
+	}
+	if req.Deadline != "" {
+		if _, err := time.Parse(dateLayout, req.Deadline); err != nil {
+			errs.add("deadline", "date must be YYYY-MM-DD")
+		}
+	}
+	if utf8.RuneCountInString(g.Title) > 200 {
+		errs.add("title", "title must not exceed 200 characters")
+	}
+	return g, errs
+}
+
+// goalProgress to dane potrzebne do postępu celów, pobierane raz na żądanie.
+type goalProgress struct {
+	heaviest map[int]float64 // najcięższa seria per ćwiczenie z katalogu
+	thisWeek int             // wykonane treningi w bieżącym tygodniu
+	today    string
+	unit     string // jednostka ciężarów w odpowiedzi
+}
+
+func newGoalProgress(ctx context.Context, srv *server.Server) goalProgress {
+	p := goalProgress{heaviest: map[int]float64{}, today: time.Now().Format(dateLayout), unit: weightUnit(ctx, srv)}
+	for _, rec := range srv.Workouts.Records(ctx) {
+		if rec.ExerciseID != 0 && rec.HeaviestWeight != nil {
+			p.heaviest[rec.ExerciseID] = rec.HeaviestWeight.Value
+		}
+	}
+	week := store.PeriodStart(p.today, store.PeriodWeek)
+	for _, v := range srv.Workouts.VolumeByPeriod(ctx, week, p.today, store.PeriodWeek, false) {
+		p.thisWeek += v.Workouts
+	}
+	return p
+}
+
+// view zwraca cel z postępem w jednostce z profilu.
+func (p goalProgress) view(g models.Goal) models.Goal {
+	p.apply(&g)
+	return goalInUnit(g, p.unit)
+}
+
+// apply uzupełnia postęp i status celu (ciężary w kg).
+func (p goalProgress) apply(g *models.Goal) {
+	switch g.Type {
+	case models.GoalLift:
+		g.Current, g.Target = p.heaviest[g.ExerciseID], g.TargetWeight
+	case models.GoalFrequency:
+		g.Current, g.Target = float64(p.thisWeek), float64(g.PerWeek)
+	}
+	if g.Target > 0 {
+		g.Percent = int(min(g.Current/g.Target, 1) * 100)
+	}
+	switch {
+	case g.Current >= g.Target:
+		g.Status = models.GoalAchieved
+	case g.Deadline != "" && g.Deadline < p.today:
+		g.Status = models.GoalMissed
+	default:
+		g.Status = models.GoalActive
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"gym-api/internal/googlefit"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+// googleFitProvider to nazwa integracji w magazynie połączeń.
+const googleFitProvider = "googlefit"
+
+// googleFitOAuth podłącza Google Fit do wspólnego przepływu OAuth (integrations.go).
+// Kolejne synchronizacje zaczynają tydzień przed poprzednią, bo telefon wysyła sesje
+// do Google Fit z opóźnieniem (np. po powrocie do zasięgu).
+var googleFitOAuth = &oauthProvider{
+	name:         googleFitProvider,
+	title:        "Google Fit",
+	initialSync:  30 * 24 * time.Hour,
+	overlap:      7 * 24 * time.Hour,
+	unauthorized: googlefit.ErrUnauthorized,
+	configured:   func(srv *server.Server) bool { return srv.GoogleFit != nil },
+	authorizeURL: func(srv *server.Server, state, _ string) string { return srv.GoogleFit.AuthorizeURL(state) },
+	// Google pozwala odznaczyć pojedyncze uprawnienia; bez sesji import nie ma sensu.
+	granted: func(scope string) bool { return scope == "" || strings.Contains(scope, "fitness.activity.read") },
+	exchange: func(ctx context.Context, srv *server.Server, code, _ string) (models.Integration, error) {
+		t, err := srv.GoogleFit.Exchange(ctx, code)
+		if err != nil {
+			return models.Integration{}, err
+		}
+		userID, err := srv.GoogleFit.UserID(ctx, t.AccessToken)
+		if err != nil {
+			return models.Integration{}, err
+		}
+		return models.Integration{AthleteID: userID, AccessToken: t.AccessToken, RefreshToken: t.RefreshToken, TokenExpiresAt: t.ExpiresAt}, nil
+	},
+	refresh: func(ctx context.Context, srv *server.Server, refreshToken string) (models.Integration, error) {
+		t, err := srv.GoogleFit.Refresh(ctx, refreshToken)
+		return models.Integration{AccessToken: t.AccessToken, RefreshToken: t.RefreshToken, TokenExpiresAt: t.ExpiresAt}, err
+	},
+	sync: syncGoogleFit,
+}
+
+// googleFitActivities mapuje typ aktywności Google Fit na ćwiczenie kardio z katalogu.
+var googleFitActivities = map[int]string{
+	1:   "Cycling", // rower
+	14:  "Cycling", // rower górski
+	15:  "Cycling", // rower szosowy
+	16:  "Cycling", // spinning
+	17:  "Cycling", // rower stacjonarny
+	7:   "Walking",
+	93:  "Walking", // marsz
+	94:  "Walking", // nordic walking
+	95:  "Walking", // bieżnia, marsz
+	8:   "Running",
+	56:  "Running", // jogging
+	57:  "Running", // bieg po piasku
+	58:  "Running", // bieżnia
+	35:  "Hiking",
+	53:  "Rowing Machine", // wioślarstwo
+	103: "Rowing Machine",
+	82:  "Swimming",
+}
+
+// googleFitIgnored to typy sesji, które nie są treningami (sen, jazda samochodem,
+// bezruch), więc w ogóle ich nie liczymy.
+var googleFitIgnored = map[int]bool{0: true, 3: true, 4: true, 5: true, 72: true, 109: true, 110: true, 111: true, 112: true}
+
+// syncGoogleFit zapisuje sesje i pomiary masy ciała od from jako treningi i wpisy
+// dziennika masy ciała; pomiary o znanym czasie są pomijane.
+func syncGoogleFit(ctx context.Context, srv *server.Server, _ models.Integration, token string, from, now time.Time) (models.SyncResult, error) {
+	sessions, err := srv.GoogleFit.Sessions(ctx, token, from, now)
+	if err != nil {
+		return models.SyncResult{}, err
+	}
+	weights, err := srv.GoogleFit.Weights(ctx, token, from, now)
+	if err != nil {
+		return models.SyncResult{}, err
+	}
+
+	res := models.SyncResult{Imported: []models.Workout{}, Bodyweight: &models.ImportCounts{}}
+	for _, s := range sessions {
+		if googleFitIgnored[s.ActivityType] {
+			continue
+		}
+		res.Fetched++
+		if _, ok := srv.Integrations.ImportedWorkout(ctx, googleFitProvider, s.ID); ok {
+			res.Duplicates++
+			continue
+		}
+		importWorkout(ctx, srv, googleFitProvider, s.ID, googleFitWorkout(s), &res)
+	}
+
+	entries := make([]models.BodyweightEntry, 0, len(weights))
+	for _, wt := range weights {
+		if wt.Weight < 20 || wt.Weight > 300 {
+			res.Bodyweight.Skipped++
+			continue
+		}
+		entries = append(entries, models.BodyweightEntry{Date: wt.At.Format(dateLayout), Weight: round1(wt.Weight), Source: googleFitProvider, MeasuredAt: &wt.At})
+	}
+	res.Bodyweight.Imported, res.Bodyweight.Duplicates = srv.Bodyweight.Import(ctx, entries)
+	if res.Bodyweight.Imported > 0 {
+		refreshBodyweightLoads(ctx, srv)
+	}
+	return res, nil
+}
+
+// googleFitWorkout zamienia sesję na trening z jednym ćwiczeniem kardio. Google Fit nie
+// podaje strefy czasowej sesji, więc datę liczymy w UTC.
+func googleFitWorkout(s googlefit.Session) models.Workout {
+	name := googleFitActivities[s.ActivityType]
+	if name == "" {
+		name = "Cardio"
+	}
+	duration := s.Active
+	if duration <= 0 {
+		duration = s.End.Sub(s.Start)
+	}
+	c := &models.Cardio{Duration: int(duration.Round(time.Second).Seconds())}
+	if s.Distance > 0 {
+		c.Distance = ptr(math.Round(s.Distance))
+	}
+	if s.AvgHR > 0 {
+		c.AvgHR = ptr(int(math.Round(s.AvgHR)))
+	}
+	title := strings.TrimSpace(s.Name)
+	if title == "" {
+		title = name
+	}
+	return models.Workout{
+		Title:     title,
+		Date:      s.Start.Format(dateLayout),
+		Exercises: []models.Exercise{{Name: name, Type: models.ExerciseCardio, Sets: []models.Set{}, Cardio: c}},
+	}
+}
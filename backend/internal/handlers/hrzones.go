@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+// hrZonePercents to dolne granice stref 1–5 jako ułamek tętna maksymalnego.
+var hrZonePercents = []float64{0.5, 0.6, 0.7, 0.8, 0.9}
+
+// maxHRGap to najdłuższa przerwa między pomiarami tętna, którą traktujemy jako ciągły
+// zapis; dłuższa to pauza (np. zatrzymany zegarek) i jej czasu nie liczymy.
+const maxHRGap = 30 * time.Second
+
+// hrSample to pomiar tętna z pliku GPX albo FIT.
+type hrSample struct {
+	at  time.Time
+	bpm int
+}
+
+// hrHistogram zamienia pomiary tętna (rosnąco po czasie) na histogram: czas do
+// następnego pomiaru należy do tętna bieżącego. Suma nie przekracza duration (w s).
+func hrHistogram(samples []hrSample, duration int) []models.HRBucket {
+	secs := map[int]float64{}
+	total := 0.0
+	for i := 1; i < len(samples); i++ {
+		dt := samples[i].at.Sub(samples[i-1].at)
+		if dt <= 0 || dt > maxHRGap {
+			continue
+		}
+		secs[samples[i-1].bpm] += dt.Seconds()
+		total += dt.Seconds()
+	}
+	scale := 1.0
+	if total > float64(duration) && total > 0 {
+		// Czas ćwiczenia bywa bez pauz, a zapis tętna je obejmuje.
+		scale = float64(duration) / total
+	}
+	out := []models.HRBucket{}
+	for bpm, s := range secs {
+		if n := int(math.Floor(s * scale)); n > 0 {
+			out = append(out, models.HRBucket{BPM: bpm, Seconds: n})
+		}
+	}
+	slices.SortFunc(out, func(a, b models.HRBucket) int { return a.BPM - b.BPM })
+	return out
+}
+
+// hrZoneRanges wylicza granice stref z tętna maksymalnego.
+func hrZoneRanges(maxHR int) []models.HRZoneRange {
+	zones := make([]models.HRZoneRange, len(hrZonePercents))
+	for i, p := range hrZonePercents {
+		zones[i] = models.HRZoneRange{Zone: i + 1, Min: int(math.Round(float64(maxHR) * p)), Max: maxHR}
+		if i > 0 {
+			zones[i-1].Max = zones[i].Min - 1
+		}
+	}
+	return zones
+}
+
+// hrZone zwraca strefę tętna bpm (0 = poniżej strefy 1). Tętno ponad maksymalne
+// to wciąż strefa 5 – ustawione maksimum bywa zaniżone.
+func hrZone(zones []models.HRZoneRange, bpm int) int {
+	zone := 0
+	for _, z := range zones {
+		if bpm >= z.Min {
+			zone = z.Zone
+		}
+	}
+	return zone
+}
+
+// sessionHRZones liczy czas w strefach ćwiczenia kardio: z histogramu tętna, a bez
+// niego cały czas ćwiczenia przypisuje strefie średniego tętna. ok = false, gdy
+// ćwiczenie nie ma żadnych danych o tętnie.
+func sessionHRZones(zones []models.HRZoneRange, c *models.Cardio) (below int, secs []int, estimated, ok bool) {
+	secs = make([]int, len(zones))
+	add := func(bpm, s int) {
+		if z := hrZone(zones, bpm); z == 0 {
+			below += s
+		} else {
+			secs[z-1] += s
+		}
+	}
+	switch {
+	case c == nil:
+		return 0, nil, false, false
+	case len(c.HRHistogram) > 0:
+		for _, b := range c.HRHistogram {
+			add(b.BPM, b.Seconds)
+		}
+	case c.AvgHR != nil && *c.AvgHR > 0 && c.Duration > 0:
+		add(*c.AvgHR, c.Duration)
+		estimated = true
+	default:
+		return 0, nil, false, false
+	}
+	return below, secs, estimated, true
+}
+
+// hrZoneTimes zamienia sekundy w strefach na odpowiedź z udziałem procentowym
+// w całym czasie z tętnem (łącznie z czasem poniżej strefy 1).
+func hrZoneTimes(below int, secs []int) []models.HRZoneTime {
+	total := below
+	for _, s := range secs {
+		total += s
+	}
+	out := make([]models.HRZoneTime, len(secs))
+	for i, s := range secs {
+		out[i] = models.HRZoneTime{Zone: i + 1, Seconds: s}
+		if total > 0 {
+			out[i].Percent = round1(float64(s) * 100 / float64(total))
+		}
+	}
+	return out
+}
+
+type HeartRateMaxHandler struct {
+	srv *server.Server
+}
+
+// NewHeartRateMaxHandler obsługuje GET i PUT /heart-rate/max: tętno maksymalne
+// użytkownika i wynikające z niego strefy (50–60–70–80–90% tętna maksymalnego).
+func NewHeartRateMaxHandler(srv *server.Server) *HeartRateMaxHandler {
+	return &HeartRateMaxHandler{srv: srv}
+}
+
+func (h *HeartRateMaxHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		maxHR, ok := h.srv.HeartRate.MaxHR(r.Context())
+		if !ok {
+			httpjson.WriteError(w, r, http.StatusNotFound, "max heart rate is not set")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, models.HeartRateSettings{MaxHR: maxHR, Zones: hrZoneRanges(maxHR)})
+
+	case http.MethodPut:
+		var req models.HeartRateSettings
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		if req.MaxHR < 100 || req.MaxHR > 230 {
+			var errs validationErrors
+			errs.add("maxHr", "maxHr must be between 100 and 230")
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		h.srv.HeartRate.SetMaxHR(r.Context(), req.MaxHR)
+		httpjson.WriteJSON(w, http.StatusOK, models.HeartRateSettings{MaxHR: req.MaxHR, Zones: hrZoneRanges(req.MaxHR)})
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type WorkoutHRZonesHandler struct {
+	srv *server.Server
+}
+
+// NewWorkoutHRZonesHandler obsługuje GET /workouts/{id}/hr-zones: czas w strefach tętna
+// każdego ćwiczenia kardio treningu, które ma histogram tętna albo średnie tętno.
+func NewWorkoutHRZonesHandler(srv *server.Server) *WorkoutHRZonesHandler {
+	return &WorkoutHRZonesHandler{srv: srv}
+}
+
+func (h *WorkoutHRZonesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	wk, found := h.srv.Workouts.Get(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Workout not found")
+		return
+	}
+	maxHR, ok := h.srv.HeartRate.MaxHR(r.Context())
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusConflict, "max heart rate is not set")
+		return
+	}
+
+	zones := hrZoneRanges(maxHR)
+	out := models.WorkoutHRZones{WorkoutID: wk.ID, Date: wk.Date, MaxHR: maxHR, Sessions: []models.SessionHRZones{}}
+	for i, ex := range wk.Exercises {
+		below, secs, estimated, ok := sessionHRZones(zones, ex.Cardio)
+		if !ok {
+			continue
+		}
+		out.Sessions = append(out.Sessions, models.SessionHRZones{
+			Exercise:  i,
+			Name:      ex.Name,
+			Estimated: estimated,
+			Below:     below,
+			Zones:     hrZoneTimes(below, secs),
+		})
+	}
+	httpjson.WriteJSON(w, http.StatusOK, out)
+}
+
+type HRZonesStatsHandler struct {
+	srv *server.Server
+}
+
+// NewHRZonesStatsHandler obsługuje GET /stats/hr-zones: łączny czas w strefach tętna
+// w kolejnych tygodniach (od poniedziałku) z zakresu ?from=&to=, od najstarszego.
+// Tygodnie bez ćwiczeń kardio z tętnem pomijamy; zaplanowane treningi się nie liczą.
+func NewHRZonesStatsHandler(srv *server.Server) *HRZonesStatsHandler {
+	return &HRZonesStatsHandler{srv: srv}
+}
+
+func (h *HRZonesStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+	if from != "" && to != "" && to < from {
+		errs.add("to", "to must not be before from")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+	maxHR, ok := h.srv.HeartRate.MaxHR(r.Context())
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusConflict, "max heart rate is not set")
+		return
+	}
+
+	zones := hrZoneRanges(maxHR)
+	workouts, _, _ := h.srv.Workouts.List(r.Context(), store.WorkoutQuery{
+		From: from,
+		To:   to,
+		Sort: []store.SortKey{{Field: "date"}, {Field: "id"}},
+	})
+	weeks := []models.HRZoneWeek{}
+	var secs [][]int
+	for _, wk := range workouts {
+		if wk.Planned {
+			continue
+		}
+		for _, ex := range wk.Exercises {
+			below, s, estimated, ok := sessionHRZones(zones, ex.Cardio)
+			if !ok {
+				continue
+			}
+			start := store.PeriodStart(wk.Date, store.PeriodWeek)
+			if n := len(weeks); n == 0 || weeks[n-1].WeekStart != start {
+				weeks = append(weeks, models.HRZoneWeek{WeekStart: start})
+				secs = append(secs, make([]int, len(zones)))
+			}
+			week, total := &weeks[len(weeks)-1], secs[len(secs)-1]
+			week.Sessions++
+			if estimated {
+				week.Estimated++
+			}
+			week.Below += below
+			for i := range s {
+				total[i] += s[i]
+			}
+		}
+	}
+	for i := range weeks {
+		weeks[i].Zones = hrZoneTimes(weeks[i].Below, secs[i])
+	}
+	httpjson.WriteJSON(w, http.StatusOK, weeks)
+}
@@ -0,0 +1,495 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gym-api/internal/applehealth"
+	"gym-api/internal/fit"
+	"gym-api/internal/gpx"
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+// maxGPXBytes ogranicza rozmiar pliku GPX (kilkugodzinny ślad z zapisem co sekundę
+// to kilka MB).
+const maxGPXBytes = 20 << 20
+
+// gpxTypes to media type plików GPX wysyłanych przez klientów.
+var gpxTypes = []string{"application/gpx+xml", "application/xml", "text/xml"}
+
+// gpxActivities mapuje typ aktywności z pliku GPX (Garmin, Strava, Komoot) na ćwiczenie
+// z katalogu.
+var gpxActivities = map[string]string{
+	"running":         "Running",
+	"run":             "Running",
+	"trail_running":   "Running",
+	"cycling":         "Cycling",
+	"biking":          "Cycling",
+	"ride":            "Cycling",
+	"road_biking":     "Cycling",
+	"mountain_biking": "Cycling",
+	"rowing":          "Rowing Machine",
+}
+
+// maxFITBytes ogranicza rozmiar pliku FIT (zapis binarny jest kilka razy mniejszy niż GPX).
+const maxFITBytes = 10 << 20
+
+// fitSports mapuje sport sesji FIT na ćwiczenie kardio z katalogu.
+var fitSports = map[int]string{
+	fit.SportRunning: "Running",
+	fit.SportCycling: "Cycling",
+	fit.SportRowing:  "Rowing Machine",
+	fit.SportWalking: "Walking",
+	fit.SportHiking:  "Hiking",
+}
+
+// fitExercises mapuje kategorię ćwiczenia z serii FIT (exercise_category) na ćwiczenie
+// z katalogu; zegarek zna tylko kategorię, więc wybieramy jej typowy wariant.
+var fitExercises = map[int]string{
+	0:  "Bench Press",
+	1:  "Standing Calf Raise",
+	7:  "Barbell Curl",
+	8:  "Deadlift",
+	9:  "Dumbbell Fly",
+	10: "Hip Thrust",
+	12: "Kettlebell Swing",
+	14: "Lateral Raise",
+	15: "Leg Curl",
+	16: "Hanging Leg Raise",
+	17: "Lunges",
+	19: "Plank",
+	21: "Pull-Up",
+	22: "Push-Up",
+	23: "Bent Over Row",
+	24: "Overhead Press",
+	26: "Shrugs",
+	28: "Squat",
+	30: "Skull Crusher",
+}
+
+type GPXImportHandler struct {
+	srv *server.Server
+}
+
+// NewGPXImportHandler obsługuje POST /import/gpx: każdy ślad z pliku GPX (body
+// application/gpx+xml) staje się treningiem z jednym ćwiczeniem kardio – dystans, czas,
+// przewyższenie i tętno (z rozszerzeń Garmina). Ćwiczenie wynika z typu aktywności
+// w pliku; ?exercise= podaje je wprost (np. gdy plik typu nie ma). Czasy w GPX są zwykle
+// w UTC, więc ?tz= (strefa IANA) pozwala przypisać trening do lokalnego dnia.
+func NewGPXImportHandler(srv *server.Server) *GPXImportHandler {
+	return &GPXImportHandler{srv: srv}
+}
+
+func (h *GPXImportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); !slices.Contains(gpxTypes, mt) {
+		httpjson.WriteErrorCode(w, r, http.StatusUnsupportedMediaType, httpjson.CodeMediaType, "Content-Type must be %s", "application/gpx+xml")
+		return
+	}
+	exercise := strings.TrimSpace(r.URL.Query().Get("exercise"))
+	var errs validationErrors
+	loc := queryLocation(&errs, r.URL.Query(), "tz")
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxGPXBytes)
+	tracks, err := gpx.Parse(r.Body)
+	if err != nil {
+		var mbe *http.MaxBytesError
+		switch {
+		case errors.As(err, &mbe):
+			httpjson.WriteErrorCode(w, r, http.StatusRequestEntityTooLarge, httpjson.CodeBodyTooLarge, "file must not exceed %d MB", maxGPXBytes>>20)
+		case errors.Is(err, gpx.ErrNoTracks):
+			httpjson.WriteError(w, r, http.StatusBadRequest, "GPX file contains no track points")
+		default:
+			httpjson.WriteError(w, r, http.StatusBadRequest, "malformed GPX file")
+		}
+		return
+	}
+
+	workouts := make([]models.Workout, 0, len(tracks))
+	for i, t := range tracks {
+		wk := gpxWorkout(t, exercise, loc)
+		for _, e := range checkWorkout(r.Context(), h.srv, wk) {
+			errs.addf("tracks["+strconv.Itoa(i)+"]."+e.Field, e.Message, e.Args...)
+		}
+		if t.Start.IsZero() {
+			errs.add("tracks["+strconv.Itoa(i)+"]", "track points must have timestamps")
+		}
+		workouts = append(workouts, wk)
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	out := make([]models.Workout, 0, len(workouts))
+	for _, wk := range workouts {
+		out = append(out, h.srv.Workouts.Create(r.Context(), wk))
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, workoutsInUnit(r.Context(), h.srv, out))
+}
+
+// gpxWorkout zamienia ślad na trening; bez znanego typu aktywności i ?exercise=
+// ćwiczenie nazywa się po prostu "Cardio".
+func gpxWorkout(t gpx.Track, exercise string, loc *time.Location) models.Workout {
+	name := exercise
+	if name == "" {
+		name = gpxActivities[t.Type]
+	}
+	if name == "" {
+		name = "Cardio"
+	}
+	c := &models.Cardio{Duration: int(t.Duration.Seconds())}
+	if t.Distance > 0 {
+		c.Distance = ptr(math.Round(t.Distance))
+	}
+	if t.ElevationGain > 0 {
+		c.ElevationGain = ptr(math.Round(t.ElevationGain))
+	}
+	if t.AvgHR > 0 {
+		c.AvgHR = ptr(t.AvgHR)
+	}
+	if len(t.HR) > 1 {
+		samples := make([]hrSample, len(t.HR))
+		for i, s := range t.HR {
+			samples[i] = hrSample{at: s.At, bpm: s.BPM}
+		}
+		c.HRHistogram = hrHistogram(samples, c.Duration)
+	}
+	title := t.Name
+	if title == "" {
+		title = name
+	}
+	return models.Workout{
+		Title:     title,
+		Date:      localDate(t.Start, loc),
+		Exercises: []models.Exercise{{Name: name, Type: models.ExerciseCardio, Sets: []models.Set{}, Cardio: c}},
+	}
+}
+
+type FITImportHandler struct {
+	srv *server.Server
+}
+
+// NewFITImportHandler obsługuje POST /import/fit: każda sesja z pliku FIT (body
+// application/octet-stream, np. z zegarka Garmin) staje się treningiem. Serie siłowe
+// zapisane na zegarku trafiają do ćwiczeń (kolejne serie jednej kategorii to jedno
+// ćwiczenie), a aktywności z dystansem albo bez serii – do ćwiczenia kardio. Datę
+// liczymy w strefie zegarka, a gdy plik jej nie podaje – w strefie z ?tz=.
+func NewFITImportHandler(srv *server.Server) *FITImportHandler {
+	return &FITImportHandler{srv: srv}
+}
+
+func (h *FITImportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt != "application/octet-stream" && mt != "application/vnd.ant.fit" {
+		httpjson.WriteErrorCode(w, r, http.StatusUnsupportedMediaType, httpjson.CodeMediaType, "Content-Type must be %s", "application/octet-stream")
+		return
+	}
+	var errs validationErrors
+	loc := queryLocation(&errs, r.URL.Query(), "tz")
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxFITBytes)
+	file, err := fit.Decode(r.Body)
+	if err != nil {
+		var mbe *http.MaxBytesError
+		switch {
+		case errors.As(err, &mbe):
+			httpjson.WriteErrorCode(w, r, http.StatusRequestEntityTooLarge, httpjson.CodeBodyTooLarge, "file must not exceed %d MB", maxFITBytes>>20)
+		case errors.Is(err, fit.ErrChecksum):
+			httpjson.WriteError(w, r, http.StatusBadRequest, "FIT file checksum mismatch")
+		default:
+			httpjson.WriteError(w, r, http.StatusBadRequest, "malformed FIT file")
+		}
+		return
+	}
+	if len(file.Sessions) == 0 {
+		httpjson.WriteError(w, r, http.StatusBadRequest, "FIT file contains no sessions")
+		return
+	}
+
+	if file.Location != nil {
+		loc = file.Location
+	}
+
+	workouts := make([]models.Workout, 0, len(file.Sessions))
+	for i, s := range file.Sessions {
+		// Przy jednej sesji wszystkie serie są jej; przy kilku dzielimy je po czasie.
+		sets := file.Sets
+		if len(file.Sessions) > 1 {
+			sets = slices.DeleteFunc(slices.Clone(sets), func(set fit.Set) bool {
+				return set.Start.Before(s.Start) || set.Start.After(s.Start.Add(s.Elapsed))
+			})
+		}
+		wk := fitWorkout(s, sets, file.HR, loc)
+		for _, e := range checkWorkout(r.Context(), h.srv, wk) {
+			errs.addf("sessions["+strconv.Itoa(i)+"]."+e.Field, e.Message, e.Args...)
+		}
+		workouts = append(workouts, wk)
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	out := make([]models.Workout, 0, len(workouts))
+	for _, wk := range workouts {
+		out = append(out, h.srv.Workouts.Create(r.Context(), wk))
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, workoutsInUnit(r.Context(), h.srv, out))
+}
+
+// localDate zwraca dzień startu w strefie loc; nil zostawia strefę z pliku.
+func localDate(t time.Time, loc *time.Location) string {
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return t.Format(dateLayout)
+}
+
+// fitWorkout zamienia sesję FIT z jej seriami na trening. Serie bez powtórzeń stają się
+// seriami na czas. Pomiary tętna z czasu sesji trafiają do histogramu ćwiczenia kardio.
+func fitWorkout(s fit.Session, sets []fit.Set, hr []fit.HRSample, loc *time.Location) models.Workout {
+	wk := models.Workout{Title: "Strength Training", Date: localDate(s.Start, loc), Exercises: []models.Exercise{}}
+	last := -2
+	for _, set := range sets {
+		secs := int(set.Duration.Round(time.Second).Seconds())
+		if set.Reps <= 0 && (secs < 1 || secs > maxSetDuration) {
+			continue
+		}
+		if set.Category != last || len(wk.Exercises) == 0 {
+			name := fitExercises[set.Category]
+			if name == "" {
+				name = "Strength exercise"
+			}
+			wk.Exercises = append(wk.Exercises, models.Exercise{Name: name})
+			last = set.Category
+		}
+		ex := &wk.Exercises[len(wk.Exercises)-1]
+		ms := models.Set{Reps: set.Reps}
+		if set.Reps <= 0 {
+			ms = models.Set{DurationSeconds: secs}
+		}
+		if set.Weight != nil {
+			ms.Weight = ptr(math.Round(*set.Weight*10) / 10)
+		}
+		ex.Sets = append(ex.Sets, ms)
+	}
+
+	if len(wk.Exercises) == 0 || s.Distance > 0 || s.Sport != fit.SportTraining {
+		name := fitSports[s.Sport]
+		if name == "" {
+			name = "Cardio"
+		}
+		duration := s.Timer
+		if duration == 0 {
+			duration = s.Elapsed
+		}
+		c := &models.Cardio{Duration: int(duration.Round(time.Second).Seconds())}
+		if s.Distance > 0 {
+			c.Distance = ptr(math.Round(s.Distance))
+		}
+		if s.AvgHR > 0 {
+			c.AvgHR = ptr(s.AvgHR)
+		}
+		if s.Ascent > 0 {
+			c.ElevationGain = ptr(s.Ascent)
+		}
+		var samples []hrSample
+		for _, h := range hr {
+			if !h.At.Before(s.Start) && !h.At.After(s.Start.Add(s.Elapsed)) {
+				samples = append(samples, hrSample{at: h.At, bpm: h.BPM})
+			}
+		}
+		if len(samples) > 1 {
+			c.HRHistogram = hrHistogram(samples, c.Duration)
+		}
+		wk.Exercises = append(wk.Exercises, models.Exercise{Name: name, Type: models.ExerciseCardio, Sets: []models.Set{}, Cardio: c})
+		if len(wk.Exercises) == 1 {
+			wk.Title = name
+		}
+	}
+	return wk
+}
+
+// appleHealthProvider to nazwa, pod którą pamiętamy zaimportowane treningi Apple Health.
+const appleHealthProvider = "applehealth"
+
+// maxAppleHealthBytes ogranicza rozmiar eksportu Apple Health (export.xml z kilku lat
+// to setki MB; czytamy go strumieniowo).
+const maxAppleHealthBytes = 2 << 30
+
+// appleActivities mapuje typ treningu HealthKit (bez prefiksu HKWorkoutActivityType)
+// na ćwiczenie kardio z katalogu.
+var appleActivities = map[string]string{
+	"Running":  "Running",
+	"Cycling":  "Cycling",
+	"Rowing":   "Rowing Machine",
+	"Walking":  "Walking",
+	"Hiking":   "Hiking",
+	"Swimming": "Swimming",
+}
+
+type AppleHealthImportHandler struct {
+	srv *server.Server
+}
+
+// NewAppleHealthImportHandler obsługuje POST /import/apple-health: import eksportu
+// z aplikacji Zdrowie – samego export.xml (application/xml) albo całego archiwum
+// export.zip (application/zip). Treningi stają się treningami z jednym ćwiczeniem kardio
+// (siłowe z Apple Watch mają tylko czas), a pomiary masy ciała trafiają do dziennika.
+// Treningi i pomiary zaimportowane wcześniej albo wpisane ręcznie pomijamy, więc
+// kolejny eksport można wgrać w całości.
+func NewAppleHealthImportHandler(srv *server.Server) *AppleHealthImportHandler {
+	return &AppleHealthImportHandler{srv: srv}
+}
+
+func (h *AppleHealthImportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mt != "application/zip" && mt != "application/xml" && mt != "text/xml" {
+		httpjson.WriteErrorCode(w, r, http.StatusUnsupportedMediaType, httpjson.CodeMediaType, "Content-Type must be %s", "application/zip")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxAppleHealthBytes)
+	var (
+		export applehealth.Export
+		err    error
+	)
+	if mt == "application/zip" {
+		export, err = parseAppleHealthZip(r.Body)
+	} else {
+		export, err = applehealth.Parse(r.Body)
+	}
+	if err != nil {
+		var mbe *http.MaxBytesError
+		switch {
+		case errors.As(err, &mbe):
+			httpjson.WriteErrorCode(w, r, http.StatusRequestEntityTooLarge, httpjson.CodeBodyTooLarge, "file must not exceed %d MB", maxAppleHealthBytes>>20)
+		case errors.Is(err, applehealth.ErrNoExport):
+			httpjson.WriteError(w, r, http.StatusBadRequest, "archive contains no Apple Health export.xml")
+		default:
+			httpjson.WriteError(w, r, http.StatusBadRequest, "malformed Apple Health export")
+		}
+		return
+	}
+
+	ctx := r.Context()
+	var result models.AppleHealthImportResult
+	var res models.SyncResult
+	integrationMu.Lock()
+	for _, aw := range export.Workouts {
+		id := aw.Start.Format(time.RFC3339) + "/" + aw.Type
+		if _, ok := h.srv.Integrations.ImportedWorkout(ctx, appleHealthProvider, id); ok {
+			res.Duplicates++
+			continue
+		}
+		importWorkout(ctx, h.srv, appleHealthProvider, id, appleWorkout(aw), &res)
+	}
+	integrationMu.Unlock()
+	result.Workouts = models.ImportCounts{Imported: len(res.Imported), Duplicates: res.Duplicates, Skipped: res.Skipped}
+
+	entries := make([]models.BodyweightEntry, 0, len(export.BodyMass))
+	for _, m := range export.BodyMass {
+		if m.Weight < 20 || m.Weight > 300 {
+			result.Bodyweight.Skipped++
+			continue
+		}
+		e := models.BodyweightEntry{Date: m.At.Format(dateLayout), Weight: round1(m.Weight), Source: appleHealthProvider, MeasuredAt: &m.At}
+		if m.BodyFat != nil && *m.BodyFat >= 2 && *m.BodyFat <= 75 {
+			e.BodyFat = ptr(round1(*m.BodyFat))
+		}
+		entries = append(entries, e)
+	}
+	result.Bodyweight.Imported, result.Bodyweight.Duplicates = h.srv.Bodyweight.Import(ctx, entries)
+	if result.Bodyweight.Imported > 0 {
+		refreshBodyweightLoads(ctx, h.srv)
+	}
+	httpjson.WriteJSON(w, http.StatusOK, result)
+}
+
+// parseAppleHealthZip zapisuje archiwum do pliku tymczasowego, bo ZIP czyta się od
+// końca (katalog plików), a całe archiwum nie musi mieścić się w pamięci.
+func parseAppleHealthZip(body io.Reader) (applehealth.Export, error) {
+	f, err := os.CreateTemp("", "applehealth-*.zip")
+	if err != nil {
+		return applehealth.Export{}, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	size, err := io.Copy(f, body)
+	if err != nil {
+		return applehealth.Export{}, err
+	}
+	return applehealth.ParseZip(f, size)
+}
+
+// appleWorkout zamienia trening Apple Health na trening z ćwiczeniem kardio; typy bez
+// odpowiednika w katalogu (np. TraditionalStrengthTraining) to ćwiczenie "Cardio"
+// z tytułem z nazwy typu.
+func appleWorkout(aw applehealth.Workout) models.Workout {
+	name := appleActivities[aw.Type]
+	title := name
+	if name == "" {
+		name, title = "Cardio", splitCamel(aw.Type)
+	}
+	if title == "" {
+		title = name
+	}
+	c := &models.Cardio{Duration: int(aw.Duration.Round(time.Second).Seconds())}
+	if aw.Distance > 0 {
+		c.Distance = ptr(math.Round(aw.Distance))
+	}
+	if aw.ElevationGain > 0 {
+		c.ElevationGain = ptr(math.Round(aw.ElevationGain))
+	}
+	if aw.AvgHR > 0 {
+		c.AvgHR = ptr(int(math.Round(aw.AvgHR)))
+	}
+	return models.Workout{
+		Title:     title,
+		Date:      aw.Start.Format(dateLayout),
+		Exercises: []models.Exercise{{Name: name, Type: models.ExerciseCardio, Sets: []models.Set{}, Cardio: c}},
+	}
+}
+
+// splitCamel rozdziela nazwę typu na słowa: "HighIntensityIntervalTraining" →
+// "High Intensity Interval Training".
+func splitCamel(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+var (
+	injurySeverities = []string{models.InjuryMild, models.InjuryModerate, models.InjurySevere}
+	injuryStatuses   = []string{models.InjuryActive, models.InjuryRecovering, models.InjuryResolved}
+)
+
+type InjuriesHandler struct {
+	srv *server.Server
+}
+
+// NewInjuriesHandler obsługuje dziennik urazów:
+//   - GET /injuries: urazy od najnowszego, opcjonalnie tylko o statusie ?status=
+//   - POST /injuries: nowy uraz z listą ćwiczeń, których nie można wykonywać
+func NewInjuriesHandler(srv *server.Server) *InjuriesHandler {
+	return &InjuriesHandler{srv: srv}
+}
+
+func (h *InjuriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		status := r.URL.Query().Get("status")
+		if status != "" && !slices.Contains(injuryStatuses, status) {
+			var errs validationErrors
+			errs.add("status", "status must be one of: active, recovering, resolved")
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, h.srv.Injuries.List(r.Context(), status))
+
+	case http.MethodPost:
+		var req models.InjuryRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		e, errs := injuryFromRequest(r.Context(), h.srv, req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusCreated, h.srv.Injuries.Create(r.Context(), e))
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type InjuryByIDHandler struct {
+	srv *server.Server
+}
+
+// NewInjuryByIDHandler obsługuje GET, PUT (pełna zamiana, np. zmiana statusu na resolved)
+// i DELETE /injuries/{id}.
+func NewInjuryByIDHandler(srv *server.Server) *InjuryByIDHandler {
+	return &InjuryByIDHandler{srv: srv}
+}
+
+func (h *InjuryByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		e, found := h.srv.Injuries.Get(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Injury not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, e)
+
+	case http.MethodPut:
+		var req models.InjuryRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		e, errs := injuryFromRequest(r.Context(), h.srv, req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		updated, err := h.srv.Injuries.Update(r.Context(), id, e)
+		if err != nil {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Injury not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, updated)
+
+	case http.MethodDelete:
+		if !h.srv.Injuries.Delete(r.Context(), id) {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Injury not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// injuryFromRequest waliduje uraz; brak daty oznacza dzisiaj, brak statusu – active.
+// Ćwiczenia muszą być w katalogu; powtórzone ID zapisujemy raz.
+func injuryFromRequest(ctx context.Context, srv *server.Server, req models.InjuryRequest) (models.Injury, validationErrors) {
+	var errs validationErrors
+	e := models.Injury{
+		BodyPart:          strings.TrimSpace(req.BodyPart),
+		Severity:          req.Severity,
+		OnsetDate:         req.OnsetDate,
+		Status:            req.Status,
+		AffectedExercises: []int{},
+		Note:              strings.TrimSpace(req.Note),
+	}
+	if e.BodyPart == "" {
+		errs.add("bodyPart", "bodyPart is required")
+	} else if utf8.RuneCountInString(e.BodyPart) > 100 {
+		errs.add("bodyPart", "bodyPart must not exceed 100 characters")
+	}
+	if !slices.Contains(injurySeverities, e.Severity) {
+		errs.add("severity", "severity must be one of: mild, moderate, severe")
+	}
+	if e.OnsetDate == "" {
+		e.OnsetDate = time.Now().Format(dateLayout)
+	} else if _, err := time.Parse(dateLayout, e.OnsetDate); err != nil {
+		errs.add("onsetDate", "onsetDate must be YYYY-MM-DD")
+	}
+	if e.Status == "" {
+		e.Status = models.InjuryActive
+	} else if !slices.Contains(injuryStatuses, e.Status) {
+		errs.add("status", "status must be one of: active, recovering, resolved")
+	}
+	for i, id := range req.AffectedExercises {
+		if _, ok := srv.Exercises.Get(ctx, id); !ok {
+			errs.add("affectedExercises["+strconv.Itoa(i)+"]", "exercise not found in catalog")
+		} else if !slices.Contains(e.AffectedExercises, id) {
+			e.AffectedExercises = append(e.AffectedExercises, id)
+		}
+	}
+	if utf8.RuneCountInString(e.Note) > 500 {
+		errs.add("note", "note must not exceed 500 characters")
+	}
+	return e, errs
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/insights"
+	"gym-api/internal/server"
+)
+
+type InsightsHandler struct {
+	srv *server.Server
+}
+
+// NewInsightsHandler obsługuje GET /insights: raport zmęczenia z propozycjami deloadu.
+// Raport dla domyślnego okna liczy okresowo zadanie w tle; ?refresh=true albo inne
+// ?window= liczy go od razu (a przy domyślnym oknie także zapisuje).
+func NewInsightsHandler(srv *server.Server) *InsightsHandler {
+	return &InsightsHandler{srv: srv}
+}
+
+func (h *InsightsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	window := queryInt(&errs, q, "window", insights.DefaultWindow)
+	refresh := queryBool(&errs, q, "refresh")
+	if window < 2 || window > 10 {
+		errs.add("window", "window must be between 2 and 10 sessions")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	if window == insights.DefaultWindow && !refresh {
+		if report, ok := h.srv.Insights.Latest(r.Context()); ok {
+			httpjson.WriteJSON(w, http.StatusOK, report)
+			return
+		}
+	}
+	report := insights.Deload(r.Context(), h.srv.Workouts, window)
+	if window == insights.DefaultWindow {
+		h.srv.Insights.Set(r.Context(), report)
+	}
+	httpjson.WriteJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,375 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+// integrationTimeout ogranicza import uruchomiony poza żądaniem (po połączeniu konta
+// i z webhooka, na który serwisy czekają najwyżej kilka sekund).
+const integrationTimeout = 2 * time.Minute
+
+// integrationMu szereguje importy z zewnętrznych serwisów (ręczna synchronizacja,
+// harmonogram, webhooki), żeby ta sama aktywność nie trafiła do treningów dwa razy.
+var integrationMu sync.Mutex
+
+// errNotConnected: wykonawca nie połączył konta w serwisie.
+var errNotConnected = errors.New("integration is not connected")
+
+// oauthProvider opisuje serwis łączony przez OAuth 2.0. Wspólny przepływ (stan połączenia,
+// connect, callback, sync) obsługują handlery z tego pliku, a plik serwisu dostarcza
+// tylko wywołania klienta i zamianę jego danych na treningi.
+type oauthProvider struct {
+	name  string // nazwa w magazynie połączeń i w ścieżkach, np. "googlefit"
+	title string // nazwa w komunikatach, np. "Google Fit"
+	pkce  bool   // autoryzacja z PKCE (verifier zapamiętany przy state)
+	// Pierwsza synchronizacja pobiera dane z ostatnich initialSync, kolejne zaczynają
+	// overlap przed poprzednią (dane wysyłane z opóźnieniem); powtórki odsiewa pamięć
+	// zaimportowanych aktywności.
+	initialSync, overlap time.Duration
+	// settings to domyślne ustawienia importu; nil = serwis ich nie ma (bez PUT).
+	settings *models.IntegrationSettings
+	// unauthorized to błąd klienta dla odrzuconego tokenu.
+	unauthorized error
+
+	configured   func(srv *server.Server) bool
+	authorizeURL func(srv *server.Server, state, verifier string) string
+	// granted sprawdza uprawnienia z powrotu OAuth (parametr scope); nil = nie sprawdzamy.
+	granted func(scope string) bool
+	// exchange wymienia kod na tokeny i zwraca połączenie z identyfikatorem konta.
+	exchange func(ctx context.Context, srv *server.Server, code, verifier string) (models.Integration, error)
+	// refresh odświeża token; zwraca połączenie z nowymi tokenami.
+	refresh func(ctx context.Context, srv *server.Server, refreshToken string) (models.Integration, error)
+	// sync importuje dane z okresu od from do now tokenem token.
+	sync func(ctx context.Context, srv *server.Server, conn models.Integration, token string, from, now time.Time) (models.SyncResult, error)
+}
+
+// oauthProviders to serwisy dostępne pod /integrations/{provider}.
+var oauthProviders = map[string]*oauthProvider{
+	stravaProvider:    stravaOAuth,
+	garminProvider:    garminOAuth,
+	googleFitProvider: googleFitOAuth,
+	fitbitProvider:    fitbitOAuth,
+}
+
+func mustProvider(name string) *oauthProvider {
+	p, ok := oauthProviders[name]
+	if !ok {
+		panic(fmt.Sprintf("handlers: unknown integration %q", name))
+	}
+	return p
+}
+
+type IntegrationHandler struct {
+	srv *server.Server
+	p   *oauthProvider
+}
+
+// NewIntegrationHandler obsługuje połączenie z serwisem provider (np. "strava"):
+//   - GET /integrations/{provider}: stan połączenia, ostatnia synchronizacja i jej błąd
+//   - PUT /integrations/{provider}: ustawienia importu (kardio, treningi siłowe), gdy serwis je ma
+//   - DELETE /integrations/{provider}: rozłączenie konta (zaimportowane dane zostają)
+func NewIntegrationHandler(srv *server.Server, provider string) *IntegrationHandler {
+	return &IntegrationHandler{srv: srv, p: mustProvider(provider)}
+}
+
+func (h *IntegrationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !integrationConfigured(w, r, h.srv, h.p) {
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet:
+		conn, _ := h.srv.Integrations.Get(r.Context(), h.p.name)
+		httpjson.WriteJSON(w, http.StatusOK, conn)
+
+	case r.Method == http.MethodPut && h.p.settings != nil:
+		var req models.IntegrationSettings
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		if !req.Cardio && !req.Strength {
+			var errs validationErrors
+			errs.add("cardio", "enable at least one of cardio and strength (or disconnect the account)")
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		conn, ok := h.srv.Integrations.SetSettings(r.Context(), h.p.name, req)
+		if !ok {
+			httpjson.WriteError(w, r, http.StatusNotFound, "%s is not connected", h.p.title)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, conn)
+
+	case r.Method == http.MethodDelete:
+		if !h.srv.Integrations.Disconnect(r.Context(), h.p.name) {
+			httpjson.WriteError(w, r, http.StatusNotFound, "%s is not connected", h.p.title)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type IntegrationConnectHandler struct {
+	srv *server.Server
+	p   *oauthProvider
+}
+
+// NewIntegrationConnectHandler obsługuje POST /integrations/{provider}/connect: zwraca adres
+// strony zgody serwisu, na który klient przekierowuje użytkownika. Po akceptacji serwis
+// wraca na GET /integrations/{provider}/callback.
+func NewIntegrationConnectHandler(srv *server.Server, provider string) *IntegrationConnectHandler {
+	return &IntegrationConnectHandler{srv: srv, p: mustProvider(provider)}
+}
+
+func (h *IntegrationConnectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !integrationConfigured(w, r, h.srv, h.p) {
+		return
+	}
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	state, verifier := randomHex(16), ""
+	if h.p.pkce {
+		verifier = randomHex(32)
+	}
+	h.srv.Integrations.AddState(r.Context(), h.p.name, state, verifier)
+	httpjson.WriteJSON(w, http.StatusOK, models.IntegrationConnect{AuthorizeURL: h.p.authorizeURL(h.srv, state, verifier)})
+}
+
+type IntegrationCallbackHandler struct {
+	srv *server.Server
+	p   *oauthProvider
+}
+
+// NewIntegrationCallbackHandler obsługuje GET /integrations/{provider}/callback (powrót ze
+// strony zgody): sprawdza przyznane uprawnienia, wymienia kod na tokeny, zapisuje
+// połączenie i w tle importuje dane z ostatnich 30 dni.
+func NewIntegrationCallbackHandler(srv *server.Server, provider string) *IntegrationCallbackHandler {
+	return &IntegrationCallbackHandler{srv: srv, p: mustProvider(provider)}
+}
+
+func (h *IntegrationCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !integrationConfigured(w, r, h.srv, h.p) {
+		return
+	}
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	q := r.URL.Query()
+	if q.Get("error") != "" {
+		httpjson.WriteError(w, r, http.StatusBadRequest, "%s authorization was denied", h.p.title)
+		return
+	}
+	owner, verifier, ok := h.srv.Integrations.TakeState(r.Context(), h.p.name, q.Get("state"))
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusBadRequest, "invalid or expired OAuth state")
+		return
+	}
+	if q.Get("code") == "" {
+		httpjson.WriteError(w, r, http.StatusBadRequest, "code is required")
+		return
+	}
+	// Użytkownik może odznaczyć uprawnienia na stronie zgody; bez nich nie ma czego synchronizować.
+	if h.p.granted != nil && !h.p.granted(q.Get("scope")) {
+		httpjson.WriteError(w, r, http.StatusBadRequest, "%s access to activities was not granted", h.p.title)
+		return
+	}
+
+	in, err := h.p.exchange(r.Context(), h.srv, q.Get("code"), verifier)
+	if err != nil {
+		writeIntegrationError(w, r, h.p, err)
+		return
+	}
+	ctx := store.WithActor(r.Context(), owner)
+	in.Provider = h.p.name
+	if h.p.settings != nil {
+		// Przy ponownym połączeniu zostawiamy ustawienia wybrane wcześniej.
+		settings := *h.p.settings
+		if prev, _ := h.srv.Integrations.Get(ctx, h.p.name); prev.Settings != nil {
+			settings = *prev.Settings
+		}
+		in.Settings = &settings
+	}
+	conn := h.srv.Integrations.Connect(ctx, in)
+	inBackground(ctx, func(ctx context.Context) {
+		if _, err := SyncIntegration(ctx, h.srv, h.p.name); err != nil {
+			slog.WarnContext(ctx, "synchronizacja po połączeniu nie powiodła się", "provider", h.p.name, "owner", owner, "err", err)
+		}
+	})
+	httpjson.WriteJSON(w, http.StatusOK, conn)
+}
+
+type IntegrationSyncHandler struct {
+	srv *server.Server
+	p   *oauthProvider
+}
+
+// NewIntegrationSyncHandler obsługuje POST /integrations/{provider}/sync: natychmiastową
+// synchronizację (poza harmonogramem). Aktywności już zaimportowane i pasujące do treningów
+// wpisanych ręcznie (ten sam dzień, ćwiczenie i podobny czas) są pomijane.
+func NewIntegrationSyncHandler(srv *server.Server, provider string) *IntegrationSyncHandler {
+	return &IntegrationSyncHandler{srv: srv, p: mustProvider(provider)}
+}
+
+func (h *IntegrationSyncHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !integrationConfigured(w, r, h.srv, h.p) {
+		return
+	}
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	res, err := SyncIntegration(r.Context(), h.srv, h.p.name)
+	if err != nil {
+		writeIntegrationError(w, r, h.p, err)
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, res)
+}
+
+// integrationConfigured odpowiada 503, gdy serwer nie ma klienta serwisu (brak konfiguracji).
+func integrationConfigured(w http.ResponseWriter, r *http.Request, srv *server.Server, p *oauthProvider) bool {
+	if !p.configured(srv) {
+		httpjson.WriteError(w, r, http.StatusServiceUnavailable, "%s integration is not configured", p.title)
+		return false
+	}
+	return true
+}
+
+func writeIntegrationError(w http.ResponseWriter, r *http.Request, p *oauthProvider, err error) {
+	switch {
+	case errors.Is(err, errNotConnected):
+		httpjson.WriteError(w, r, http.StatusNotFound, "%s is not connected", p.title)
+	case errors.Is(err, p.unauthorized):
+		httpjson.WriteError(w, r, http.StatusConflict, "%s rejected the authorization, connect the account again", p.title)
+	default:
+		httpjson.WriteError(w, r, http.StatusBadGateway, "%s is unavailable", p.title)
+	}
+}
+
+// SyncIntegration pobiera dane serwisu provider wykonawcy z ctx od ostatniej synchronizacji
+// i zapisuje nowe jako treningi (i pomiary masy ciała). Wołane z POST
+// /integrations/{provider}/sync, po połączeniu konta i przez synchronizację w tle (main).
+// Wynik (czas albo błąd) trafia do stanu połączenia.
+func SyncIntegration(ctx context.Context, srv *server.Server, provider string) (models.SyncResult, error) {
+	p := mustProvider(provider)
+	integrationMu.Lock()
+	defer integrationMu.Unlock()
+
+	conn, ok := srv.Integrations.Get(ctx, p.name)
+	if !ok {
+		return models.SyncResult{}, errNotConnected
+	}
+	now := time.Now()
+	res, err := func() (models.SyncResult, error) {
+		token, err := accessToken(ctx, srv, p, conn, now)
+		if err != nil {
+			return models.SyncResult{}, err
+		}
+		from := now.Add(-p.initialSync)
+		if conn.LastSyncAt != nil {
+			from = conn.LastSyncAt.Add(-p.overlap)
+		}
+		return p.sync(ctx, srv, conn, token, from, now)
+	}()
+	srv.Integrations.SyncDone(ctx, p.name, now, err)
+	return res, err
+}
+
+// accessToken zwraca ważny token dostępu, w razie potrzeby (z minutowym zapasem)
+// odświeżając go.
+func accessToken(ctx context.Context, srv *server.Server, p *oauthProvider, conn models.Integration, now time.Time) (string, error) {
+	if now.Add(time.Minute).Before(conn.TokenExpiresAt) {
+		return conn.AccessToken, nil
+	}
+	t, err := p.refresh(ctx, srv, conn.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+	srv.Integrations.SetToken(ctx, p.name, t.AccessToken, t.RefreshToken, t.TokenExpiresAt)
+	return t.AccessToken, nil
+}
+
+// inBackground uruchamia fn poza żądaniem: z wartościami ctx (wykonawca, trace), ale bez
+// jego anulowania, ograniczone integrationTimeout.
+func inBackground(ctx context.Context, fn func(context.Context)) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), integrationTimeout)
+		defer cancel()
+		fn(ctx)
+	}()
+}
+
+// importWorkout zapisuje trening z aktywności externalID serwisu provider, chyba że
+// odpowiada treningowi wpisanemu ręcznie (wtedy tylko je wiążemy). Aktywności, z których
+// nie wychodzi poprawny trening, pomijamy bez wiązania – kolejna synchronizacja spróbuje
+// ponownie. Wymaga trzymanego integrationMu.
+func importWorkout(ctx context.Context, srv *server.Server, provider, externalID string, wk models.Workout, res *models.SyncResult) {
+	if len(checkWorkout(ctx, srv, wk)) > 0 {
+		res.Skipped++
+		return
+	}
+	if match, ok := manualMatch(ctx, srv, provider, wk); ok {
+		srv.Integrations.Link(ctx, provider, externalID, match)
+		res.Duplicates++
+		return
+	}
+	created := srv.Workouts.Create(ctx, wk)
+	srv.Integrations.Link(ctx, provider, externalID, created.ID)
+	res.Imported = append(res.Imported, created)
+}
+
+// manualMatch szuka wykonanego treningu z dnia wk, który opisuje tę samą sesję wpisaną
+// ręcznie: dla kardio to samo ćwiczenie z podobnym czasem (±10%, co najmniej ±2 min),
+// dla treningu siłowego – choć jedno wspólne ćwiczenie. Treningi powiązane już z inną
+// aktywnością serwisu pomijamy.
+func manualMatch(ctx context.Context, srv *server.Server, provider string, wk models.Workout) (int, bool) {
+	linked := srv.Integrations.LinkedWorkouts(ctx, provider)
+	candidates, _, _ := srv.Workouts.List(ctx, store.WorkoutQuery{From: wk.Date, To: wk.Date})
+	for _, c := range candidates {
+		if c.Planned || linked[c.ID] {
+			continue
+		}
+		for _, want := range wk.Exercises {
+			if slices.ContainsFunc(c.Exercises, func(ex models.Exercise) bool { return sameSession(ex, want) }) {
+				return c.ID, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func sameSession(ex, want models.Exercise) bool {
+	if ex.ExerciseID == 0 || ex.ExerciseID != want.ExerciseID {
+		if !strings.EqualFold(ex.Name, want.Name) {
+			return false
+		}
+	}
+	if want.Cardio == nil {
+		return ex.Cardio == nil
+	}
+	if ex.Cardio == nil {
+		return false
+	}
+	diff := math.Abs(float64(ex.Cardio.Duration - want.Cardio.Duration))
+	return diff <= max(120, 0.1*float64(want.Cardio.Duration))
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/i18n"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+type MeasurementsHandler struct {
+	srv *server.Server
+}
+
+// NewMeasurementsHandler obsługuje pomiary obwodów ciała:
+//   - GET /measurements: pomiary od najnowszego, opcjonalnie z zakresu ?from=&to=
+//   - POST /measurements: obwody z jednego dnia (miejsca z GET /measurements/sites, w cm)
+func NewMeasurementsHandler(srv *server.Server) *MeasurementsHandler {
+	return &MeasurementsHandler{srv: srv}
+}
+
+func (h *MeasurementsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var errs validationErrors
+		q := r.URL.Query()
+		from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+		if from != "" && to != "" && to < from {
+			errs.add("to", "to must not be before from")
+		}
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, h.srv.Measurements.List(r.Context(), from, to))
+
+	case http.MethodPost:
+		var req models.MeasurementRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		e, errs := measurementFromRequest(req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusCreated, h.srv.Measurements.Create(r.Context(), e))
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type MeasurementByIDHandler struct {
+	srv *server.Server
+}
+
+// NewMeasurementByIDHandler obsługuje GET, PUT (pełna zamiana) i DELETE /measurements/{id}.
+func NewMeasurementByIDHandler(srv *server.Server) *MeasurementByIDHandler {
+	return &MeasurementByIDHandler{srv: srv}
+}
+
+func (h *MeasurementByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		e, found := h.srv.Measurements.Get(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Measurement not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, e)
+
+	case http.MethodPut:
+		var req models.MeasurementRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		e, errs := measurementFromRequest(req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		updated, err := h.srv.Measurements.Update(r.Context(), id, e)
+		if err != nil {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Measurement not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, updated)
+
+	case http.MethodDelete:
+		if !h.srv.Measurements.Delete(r.Context(), id) {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Measurement not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type MeasurementSitesHandler struct {
+	srv *server.Server
+}
+
+// NewMeasurementSitesHandler obsługuje GET /measurements/sites: miejsca pomiaru
+// (nazwy w języku z Accept-Language) z ostatnim zapisanym obwodem.
+func NewMeasurementSitesHandler(srv *server.Server) *MeasurementSitesHandler {
+	return &MeasurementSitesHandler{srv: srv}
+}
+
+func (h *MeasurementSitesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	lang := i18n.FromRequest(r)
+	sites := store.MeasurementSites()
+	entries := h.srv.Measurements.List(r.Context(), "", "")
+	for i := range sites {
+		s := &sites[i]
+		s.Name = i18n.T(lang, s.Name)
+		for _, e := range entries {
+			if v, ok := e.Values[s.ID]; ok {
+				s.Latest, s.Date = &v, e.Date
+				break
+			}
+		}
+	}
+	httpjson.WriteJSON(w, http.StatusOK, sites)
+}
+
+type MeasurementHistoryHandler struct {
+	srv *server.Server
+}
+
+// NewMeasurementHistoryHandler obsługuje GET /measurements/sites/{site}: historię obwodu
+// jednego miejsca od najstarszego pomiaru, do wykresu; zakres zawęża ?from=&to=.
+func NewMeasurementHistoryHandler(srv *server.Server) *MeasurementHistoryHandler {
+	return &MeasurementHistoryHandler{srv: srv}
+}
+
+func (h *MeasurementHistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	site, ok := store.MeasurementSite(r.PathValue("site"))
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Measurement site not found")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+	if from != "" && to != "" && to < from {
+		errs.add("to", "to must not be before from")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	out := models.MeasurementHistory{Site: site.ID, Name: i18n.T(i18n.FromRequest(r), site.Name), Unit: "cm", Points: []models.MeasurementPoint{}}
+	for _, e := range h.srv.Measurements.List(r.Context(), from, to) {
+		if v, ok := e.Values[site.ID]; ok {
+			out.Points = append(out.Points, models.MeasurementPoint{Date: e.Date, Value: v})
+		}
+	}
+	// Lista jest od najnowszego pomiaru, wykres – od najstarszego.
+	slices.Reverse(out.Points)
+	if n := len(out.Points); n > 1 {
+		change := round1(out.Points[n-1].Value - out.Points[0].Value)
+		out.Change = &change
+	}
+	httpjson.WriteJSON(w, http.StatusOK, out)
+}
+
+// measurementFromRequest waliduje pomiary; brak daty oznacza dzisiaj.
+func measurementFromRequest(req models.MeasurementRequest) (models.MeasurementEntry, validationErrors) {
+	var errs validationErrors
+	e := models.MeasurementEntry{Date: req.Date, Values: req.Values, Note: strings.TrimSpace(req.Note)}
+	if e.Date == "" {
+		e.Date = time.Now().Format(dateLayout)
+	} else if _, err := time.Parse(dateLayout, e.Date); err != nil {
+		errs.add("date", "date must be YYYY-MM-DD")
+	}
+	if len(e.Values) == 0 {
+		errs.add("values", "values must contain at least one measurement")
+	}
+	for _, site := range slices.Sorted(maps.Keys(e.Values)) {
+		if _, ok := store.MeasurementSite(site); !ok {
+			errs.add("values."+site, "unknown measurement site (see GET /measurements/sites)")
+		} else if v := e.Values[site]; v < 5 || v > 300 {
+			errs.add("values."+site, "measurement must be between 5 and 300 cm")
+		}
+	}
+	if utf8.RuneCountInString(e.Note) > 500 {
+		errs.add("note", "note must not exceed 500 characters")
+	}
+	return e, errs
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/i18n"
+)
+
+// MergePatchType to media type JSON Merge Patch (RFC 7386).
+const MergePatchType = "application/merge-patch+json"
+
+// readMergePatch czyta body PATCH jako obiekt JSON Merge Patch. Przyjmujemy też
+// application/json, bo wielu klientów nie ustawia dedykowanego typu.
+// Przy błędzie odpowiada klientowi i zwraca ok = false.
+func readMergePatch(w http.ResponseWriter, r *http.Request) (map[string]any, bool) {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mt, _, _ := mime.ParseMediaType(ct)
+		if mt != MergePatchType && mt != "application/json" {
+			httpjson.WriteErrorCode(w, r, http.StatusUnsupportedMediaType, httpjson.CodeMediaType, "Content-Type must be %s", MergePatchType)
+			return nil, false
+		}
+	}
+	var v any
+	if err := httpjson.ReadJSON(w, r, &v); err != nil {
+		httpjson.WriteReadError(w, r, err)
+		return nil, false
+	}
+	patch, isObject := v.(map[string]any)
+	if !isObject {
+		httpjson.WriteReadError(w, r, i18n.Errorf("merge patch must be a JSON object"))
+		return nil, false
+	}
+	return patch, true
+}
+
+// applyMergePatch nakłada łatkę na dokument doc i dekoduje wynik do dst,
+// odrzucając pola, których dst nie zna.
+func applyMergePatch(doc any, patch map[string]any, dst any) error {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	var target any
+	if err := json.Unmarshal(raw, &target); err != nil {
+		return err
+	}
+	raw, err = json.Marshal(mergePatch(target, patch))
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return httpjson.DecodeError(err)
+	}
+	return nil
+}
+
+// mergePatch to algorytm z RFC 7386: obiekty łączymy rekurencyjnie,
+// null usuwa klucz, a każda inna wartość (także tablica) zastępuje poprzednią.
+func mergePatch(target any, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+type NutritionHandler struct {
+	srv *server.Server
+}
+
+// NewNutritionHandler obsługuje dziennik żywienia:
+//   - GET /nutrition: wpisy od najnowszego, opcjonalnie z zakresu ?from=&to=
+//   - POST /nutrition: nowy wpis – posiłek albo suma dnia (bez daty – na dziś)
+func NewNutritionHandler(srv *server.Server) *NutritionHandler {
+	return &NutritionHandler{srv: srv}
+}
+
+func (h *NutritionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var errs validationErrors
+		q := r.URL.Query()
+		from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+		if from != "" && to != "" && to < from {
+			errs.add("to", "to must not be before from")
+		}
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, h.srv.Nutrition.List(r.Context(), from, to))
+
+	case http.MethodPost:
+		var req models.NutritionRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		e, errs := nutritionFromRequest(req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusCreated, h.srv.Nutrition.Create(r.Context(), e))
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type NutritionByIDHandler struct {
+	srv *server.Server
+}
+
+// NewNutritionByIDHandler obsługuje GET, PUT (pełna zamiana) i DELETE /nutrition/{id}.
+func NewNutritionByIDHandler(srv *server.Server) *NutritionByIDHandler {
+	return &NutritionByIDHandler{srv: srv}
+}
+
+func (h *NutritionByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		e, found := h.srv.Nutrition.Get(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Nutrition entry not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, e)
+
+	case http.MethodPut:
+		var req models.NutritionRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		e, errs := nutritionFromRequest(req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		updated, err := h.srv.Nutrition.Update(r.Context(), id, e)
+		if err != nil {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Nutrition entry not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, updated)
+
+	case http.MethodDelete:
+		if !h.srv.Nutrition.Delete(r.Context(), id) {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Nutrition entry not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type NutritionWeeklyHandler struct {
+	srv *server.Server
+}
+
+// NewNutritionWeeklyHandler obsługuje GET /nutrition/weekly: średnie dzienne kalorie
+// i makroskładniki w kolejnych tygodniach (od poniedziałku), od najstarszego. Średnią
+// liczymy z dni z wpisami – dzień bez wpisu to brak danych, a nie post.
+func NewNutritionWeeklyHandler(srv *server.Server) *NutritionWeeklyHandler {
+	return &NutritionWeeklyHandler{srv: srv}
+}
+
+func (h *NutritionWeeklyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+	if from != "" && to != "" && to < from {
+		errs.add("to", "to must not be before from")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	entries := h.srv.Nutrition.List(r.Context(), from, to)
+	weeks := []models.NutritionWeek{}
+	var day string
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		start := store.PeriodStart(e.Date, store.PeriodWeek)
+		if n := len(weeks); n == 0 || weeks[n-1].WeekStart != start {
+			weeks = append(weeks, models.NutritionWeek{WeekStart: start})
+		}
+		wk := &weeks[len(weeks)-1]
+		if e.Date != day {
+			wk.Days++
+			day = e.Date
+		}
+		wk.Calories += float64(e.Calories)
+		wk.Protein += e.Protein
+		wk.Carbs += e.Carbs
+		wk.Fat += e.Fat
+	}
+	for i := range weeks {
+		wk := &weeks[i]
+		days := float64(wk.Days)
+		wk.Calories = round1(wk.Calories / days)
+		wk.Protein, wk.Carbs, wk.Fat = round1(wk.Protein/days), round1(wk.Carbs/days), round1(wk.Fat/days)
+	}
+	httpjson.WriteJSON(w, http.StatusOK, weeks)
+}
+
+// nutritionFromRequest waliduje wpis; brak daty oznacza dzisiaj.
+func nutritionFromRequest(req models.NutritionRequest) (models.NutritionEntry, validationErrors) {
+	var errs validationErrors
+	e := models.NutritionEntry{
+		Date:     req.Date,
+		Meal:     strings.TrimSpace(req.Meal),
+		Calories: req.Calories,
+		Protein:  req.Protein,
+		Carbs:    req.Carbs,
+		Fat:      req.Fat,
+		Note:     strings.TrimSpace(req.Note),
+	}
+	if e.Date == "" {
+		e.Date = time.Now().Format(dateLayout)
+	} else if _, err := time.Parse(dateLayout, e.Date); err != nil {
+		errs.add("date", "date must be YYYY-MM-DD")
+	}
+	if e.Calories < 0 || e.Calories > 20000 {
+		errs.add("calories", "calories must be between 0 and 20000")
+	}
+	if e.Protein < 0 || e.Protein > 1000 {
+		errs.add("protein", "protein must be between 0 and 1000 g")
+	}
+	if e.Carbs < 0 || e.Carbs > 2000 {
+		errs.add("carbs", "carbs must be between 0 and 2000 g")
+	}
+	if e.Fat < 0 || e.Fat > 1000 {
+		errs.add("fat", "fat must be between 0 and 1000 g")
+	}
+	if utf8.RuneCountInString(e.Meal) > 100 {
+		errs.add("meal", "meal must not exceed 100 characters")
+	}
+	if utf8.RuneCountInString(e.Note) > 500 {
+		errs.add("note", "note must not exceed 500 characters")
+	}
+	return e, errs
+}
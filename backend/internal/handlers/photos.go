@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gym-api/internal/blob"
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+// maxPhotoBytes ogranicza rozmiar jednego zdjęcia; zdjęcia z telefonu mieszczą się z zapasem.
+const maxPhotoBytes = 10 << 20
+
+// photoTypes mapuje dozwolone typy obrazów (rozpoznawane po zawartości) na rozszerzenia plików.
+var photoTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// photoPoses to pozy zdjęć, po których można filtrować i porównywać sylwetkę.
+var photoPoses = []string{"front", "side", "back"}
+
+type PhotosHandler struct {
+	srv *server.Server
+}
+
+// NewPhotosHandler obsługuje zdjęcia sylwetki:
+//   - GET /photos: zdjęcia od najnowszego, opcjonalnie ?from=&to= i ?pose=
+//   - POST /photos: upload multipart/form-data z plikiem w polu "photo" (JPEG, PNG
+//     lub WebP, do 10 MB) i opcjonalnymi polami date, pose i note
+func NewPhotosHandler(srv *server.Server) *PhotosHandler {
+	return &PhotosHandler{srv: srv}
+}
+
+func (h *PhotosHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var errs validationErrors
+		q := r.URL.Query()
+		from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+		pose := q.Get("pose")
+		if from != "" && to != "" && to < from {
+			errs.add("to", "to must not be before from")
+		}
+		if pose != "" && !slices.Contains(photoPoses, pose) {
+			errs.add("pose", "pose must be one of: front, side, back")
+		}
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		photos := h.srv.Photos.List(r.Context(), from, to, pose)
+		for i := range photos {
+			photos[i].URL = photoURL(photos[i].ID)
+		}
+		httpjson.WriteJSON(w, http.StatusOK, photos)
+
+	case http.MethodPost:
+		h.upload(w, r)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PhotosHandler) upload(w http.ResponseWriter, r *http.Request) {
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt != "multipart/form-data" {
+		httpjson.WriteErrorCode(w, r, http.StatusUnsupportedMediaType, httpjson.CodeMediaType, "Content-Type must be %s", "multipart/form-data")
+		return
+	}
+	// Zapas na pola formularza i nagłówki części.
+	r.Body = http.MaxBytesReader(w, r.Body, maxPhotoBytes+64<<10)
+	if err := r.ParseMultipartForm(maxPhotoBytes); err != nil {
+		var mbe *http.MaxBytesError
+		if errors.As(err, &mbe) {
+			httpjson.WriteErrorCode(w, r, http.StatusRequestEntityTooLarge, httpjson.CodeBodyTooLarge, "photo must not exceed %d MB", maxPhotoBytes>>20)
+			return
+		}
+		httpjson.WriteError(w, r, http.StatusBadRequest, "malformed multipart form")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	var errs validationErrors
+	p := models.Photo{
+		Date: r.FormValue("date"),
+		Pose: r.FormValue("pose"),
+		Note: strings.TrimSpace(r.FormValue("note")),
+	}
+	if p.Date == "" {
+		p.Date = time.Now().Format(dateLayout)
+	} else if _, err := time.Parse(dateLayout, p.Date); err != nil {
+		errs.add("date", "date must be YYYY-MM-DD")
+	}
+	if p.Pose != "" && !slices.Contains(photoPoses, p.Pose) {
+		errs.add("pose", "pose must be one of: front, side, back")
+	}
+	if utf8.RuneCountInString(p.Note) > 500 {
+		errs.add("note", "note must not exceed 500 characters")
+	}
+	var data []byte
+	file, _, err := r.FormFile("photo")
+	if err != nil {
+		errs.add("photo", "photo file is required")
+	} else {
+		defer file.Close()
+		if data, err = io.ReadAll(file); err != nil {
+			httpjson.WriteError(w, r, http.StatusBadRequest, "malformed multipart form")
+			return
+		}
+		// Typ ustalamy z zawartości, nie z nagłówka klienta.
+		p.ContentType = http.DetectContentType(data)
+		if _, ok := photoTypes[p.ContentType]; !ok {
+			errs.add("photo", "photo must be a JPEG, PNG or WebP image")
+		}
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	p.Size = len(data)
+	p.Key = "photos/" + randomHex(16) + photoTypes[p.ContentType]
+	if err := h.srv.Blobs.Put(r.Context(), p.Key, p.ContentType, data); err != nil {
+		httpjson.WriteError(w, r, http.StatusInternalServerError, "photo storage is unavailable")
+		return
+	}
+	created := h.srv.Photos.Create(r.Context(), p)
+	created.URL = photoURL(created.ID)
+	httpjson.WriteJSON(w, http.StatusCreated, created)
+}
+
+type PhotoByIDHandler struct {
+	srv *server.Server
+}
+
+// NewPhotoByIDHandler obsługuje GET (metadane) i DELETE /photos/{id}; DELETE usuwa
+// też plik z magazynu.
+func NewPhotoByIDHandler(srv *server.Server) *PhotoByIDHandler {
+	return &PhotoByIDHandler{srv: srv}
+}
+
+func (h *PhotoByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		p, found := h.srv.Photos.Get(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Photo not found")
+			return
+		}
+		p.URL = photoURL(p.ID)
+		httpjson.WriteJSON(w, http.StatusOK, p)
+
+	case http.MethodDelete:
+		p, found := h.srv.Photos.Delete(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Photo not found")
+			return
+		}
+		if err := h.srv.Blobs.Delete(r.Context(), p.Key); err != nil {
+			httpjson.WriteError(w, r, http.StatusInternalServerError, "photo storage is unavailable")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type PhotoImageHandler struct {
+	srv *server.Server
+}
+
+// NewPhotoImageHandler obsługuje GET /photos/{id}/image: sam plik zdjęcia.
+func NewPhotoImageHandler(srv *server.Server) *PhotoImageHandler {
+	return &PhotoImageHandler{srv: srv}
+}
+
+func (h *PhotoImageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	p, found := h.srv.Photos.Get(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Photo not found")
+		return
+	}
+	data, _, err := h.srv.Blobs.Get(r.Context(), p.Key)
+	if errors.Is(err, blob.ErrNotFound) {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Photo not found")
+		return
+	}
+	if err != nil {
+		httpjson.WriteError(w, r, http.StatusInternalServerError, "photo storage is unavailable")
+		return
+	}
+	w.Header().Set("Content-Type", p.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	// Zdjęcie pod danym ID się nie zmienia, więc klient może je trzymać w cache.
+	w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+	_, _ = w.Write(data)
+}
+
+func photoURL(id int) string {
+	return "/api/v1/photos/" + strconv.Itoa(id) + "/image"
+}
+
+// randomHex zwraca n losowych bajtów zapisanych szesnastkowo.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/progression"
+	"gym-api/internal/server"
+)
+
+// defaultPlateauWeeks to domyślne okno analizy stagnacji.
+const defaultPlateauWeeks = 4
+
+type ExercisePlateauHandler struct {
+	srv *server.Server
+}
+
+// NewExercisePlateauHandler obsługuje GET /exercises/{id}/plateau: sprawdza, czy szacowany
+// 1RM (Epley) poprawił się w ostatnich ?weeks= tygodniach (2–26, domyślnie 4) względem
+// wcześniejszych treningów, i zwraca wyniki sesji do wykresu trendu.
+func NewExercisePlateauHandler(srv *server.Server) *ExercisePlateauHandler {
+	return &ExercisePlateauHandler{srv: srv}
+}
+
+func (h *ExercisePlateauHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	var errs validationErrors
+	weeks := queryInt(&errs, r.URL.Query(), "weeks", defaultPlateauWeeks)
+	if weeks < 2 || weeks > 26 {
+		errs.add("weeks", "weeks must be between 2 and 26")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+	ex, found := h.srv.Exercises.Get(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Exercise not found")
+		return
+	}
+
+	var history []progression.Session
+	for _, s := range h.srv.Workouts.ExerciseHistory(r.Context(), id, ex.Name) {
+		history = append(history, progression.Session{Date: s.Date, Sets: s.Sets})
+	}
+	p := progression.DetectPlateau(history, weeks)
+	report := models.PlateauReport{
+		ExerciseID:   id,
+		Name:         ex.Name,
+		Weeks:        weeks,
+		EnoughData:   p.EnoughData,
+		Plateau:      p.Plateau,
+		BestE1RM:     round1(p.BestE1RM),
+		BestDate:     p.BestDate,
+		WindowBest:   round1(p.WindowBest),
+		PriorBest:    round1(p.PriorBest),
+		TrendPerWeek: round1(p.TrendPerWeek),
+		Trend:        make([]models.E1RMPoint, len(p.Trend)),
+	}
+	for i, pt := range p.Trend {
+		report.Trend[i] = models.E1RMPoint{Date: pt.Date, E1RM: round1(pt.E1RM), Weight: pt.Weight, Reps: pt.Reps}
+	}
+	httpjson.WriteJSON(w, http.StatusOK, report)
+}
+
+// round1 zaokrągla szacunki do 0,1 kg, żeby odpowiedź nie niosła fałszywej precyzji.
+func round1(kg float64) float64 {
+	return math.Round(kg*10) / 10
+}
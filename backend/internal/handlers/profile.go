@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+type ProfileHandler struct {
+	srv *server.Server
+}
+
+// NewProfileHandler obsługuje GET i PUT /profile: ustawienia użytkownika, na razie
+// jednostkę ciężaru (kg albo lb), w której API zwraca i przyjmuje ciężary.
+func NewProfileHandler(srv *server.Server) *ProfileHandler {
+	return &ProfileHandler{srv: srv}
+}
+
+func (h *ProfileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		httpjson.WriteJSON(w, http.StatusOK, h.srv.Profiles.Get(r.Context()))
+
+	case http.MethodPut:
+		var req models.Profile
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		if req.WeightUnit != models.UnitKg && req.WeightUnit != models.UnitLb {
+			var errs validationErrors
+			errs.add("weightUnit", "weightUnit must be kg or lb")
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		h.srv.Profiles.Set(r.Context(), req)
+		httpjson.WriteJSON(w, http.StatusOK, req)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+// Limity rozmiaru programu.
+const (
+	maxProgramWeeks    = 52
+	maxSessionsPerWeek = 7
+)
+
+type ProgramsHandler struct {
+	srv *server.Server
+}
+
+// NewProgramsHandler obsługuje programy treningowe:
+//   - GET /programs: programy użytkownika w kolejności dodania
+//   - POST /programs: nowy program (tygodnie z sesjami wskazującymi szablony)
+func NewProgramsHandler(srv *server.Server) *ProgramsHandler {
+	return &ProgramsHandler{srv: srv}
+}
+
+func (h *ProgramsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		httpjson.WriteJSON(w, http.StatusOK, h.srv.Programs.List(r.Context()))
+
+	case http.MethodPost:
+		var req models.ProgramRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		p := programFromRequest(req)
+		if errs := checkProgram(r.Context(), h.srv, p); len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusCreated, h.srv.Programs.Create(r.Context(), p))
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type ProgramByIDHandler struct {
+	srv *server.Server
+}
+
+// NewProgramByIDHandler obsługuje GET, PUT (pełna zamiana) i DELETE /programs/{id}.
+func NewProgramByIDHandler(srv *server.Server) *ProgramByIDHandler {
+	return &ProgramByIDHandler{srv: srv}
+}
+
+func (h *ProgramByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		p, found := h.srv.Programs.Get(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Program not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, p)
+
+	case http.MethodPut:
+		var req models.ProgramRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		p := programFromRequest(req)
+		if errs := checkProgram(r.Context(), h.srv, p); len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		updated, err := h.srv.Programs.Update(r.Context(), id, p)
+		if err != nil {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Program not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, updated)
+
+	case http.MethodDelete:
+		if !h.srv.Programs.Delete(r.Context(), id) {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Program not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// programFromRequest przycina białe znaki; brak listy sesji traktujemy jak tydzień wolny.
+func programFromRequest(req models.ProgramRequest) models.Program {
+	weeks := make([]models.ProgramWeek, len(req.Weeks))
+	for i, wk := range req.Weeks {
+		sessions := make([]models.ProgramSession, len(wk.Sessions))
+		for j, s := range wk.Sessions {
+			sessions[j] = models.ProgramSession{TemplateID: s.TemplateID, Notes: strings.TrimSpace(s.Notes)}
+		}
+		weeks[i] = models.ProgramWeek{Sessions: sessions}
+	}
+	return models.Program{
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		Weeks:       weeks,
+	}
+}
+
+// checkProgram waliduje program; każda sesja musi wskazywać szablon użytkownika.
+func checkProgram(ctx context.Context, srv *server.Server, p models.Program) validationErrors {
+	var errs validationErrors
+	if p.Name == "" {
+		errs.add("name", "program name is required")
+	}
+	if len(p.Weeks) == 0 || len(p.Weeks) > maxProgramWeeks {
+		errs.add("weeks", "program must have between 1 and 52 weeks")
+	}
+	for i, wk := range p.Weeks {
+		field := "weeks[" + strconv.Itoa(i) + "]"
+		if len(wk.Sessions) > maxSessionsPerWeek {
+			errs.add(field+".sessions", "a week can have at most 7 sessions")
+		}
+		for j, s := range wk.Sessions {
+			if _, ok := srv.Templates.Get(ctx, s.TemplateID); !ok {
+				errs.add(field+".sessions["+strconv.Itoa(j)+"].templateId", "template not found")
+			}
+		}
+	}
+	return errs
+}
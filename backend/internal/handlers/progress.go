@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/progression"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+// Metryki wykresu postępów.
+const (
+	metricE1RM   = "e1rm"   // najlepszy szacowany 1RM (Epley) w okresie
+	metricVolume = "volume" // suma kg × powtórzenia w okresie
+	metricTopSet = "topset" // najcięższa seria w okresie
+)
+
+var progressMetrics = []string{metricE1RM, metricVolume, metricTopSet}
+
+type ProgressStatsHandler struct {
+	srv *server.Server
+}
+
+// NewProgressStatsHandler obsługuje GET /stats/progress?exercise={id}&metric=e1rm|volume|topset:
+// serię gotową do narysowania wykresu – jedna wartość na okres ?bucket=day|week|month
+// (domyślnie week), zamiast surowych treningów agregowanych po stronie aplikacji.
+// Okresy bez sesji pomijamy; zakres zawęża ?from=&to=.
+func NewProgressStatsHandler(srv *server.Server) *ProgressStatsHandler {
+	return &ProgressStatsHandler{srv: srv}
+}
+
+func (h *ProgressStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	exerciseID := queryInt(&errs, q, "exercise", 0)
+	metric := cmp.Or(q.Get("metric"), metricE1RM)
+	bucket := cmp.Or(q.Get("bucket"), store.PeriodWeek)
+	from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+	if q.Get("exercise") == "" {
+		errs.add("exercise", "exercise is required")
+	}
+	if !slices.Contains(progressMetrics, metric) {
+		errs.add("metric", "metric must be one of: e1rm, volume, topset")
+	}
+	if !slices.Contains(volumePeriods, bucket) {
+		errs.add("bucket", "bucket must be one of: day, week, month")
+	}
+	if from != "" && to != "" && to < from {
+		errs.add("to", "to must not be before from")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+	ex, found := h.srv.Exercises.Get(r.Context(), exerciseID)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Exercise not found")
+		return
+	}
+
+	out := models.ProgressSeries{ExerciseID: ex.ID, Name: ex.Name, Metric: metric, Bucket: bucket, Unit: "kg", Points: []models.ProgressPoint{}}
+	index := map[string]int{}
+	// Historia jest od najnowszej sesji, wykres – od najstarszej.
+	history := h.srv.Workouts.ExerciseHistory(r.Context(), ex.ID, ex.Name)
+	slices.Reverse(history)
+	for _, s := range history {
+		if from != "" && s.Date < from || to != "" && s.Date > to {
+			continue
+		}
+		value, ok := progressValue(metric, s)
+		if !ok {
+			continue
+		}
+		start := store.PeriodStart(s.Date, bucket)
+		i, seen := index[start]
+		if !seen {
+			i = len(out.Points)
+			index[start] = i
+			out.Points = append(out.Points, models.ProgressPoint{Start: start})
+		}
+		p := &out.Points[i]
+		p.Sessions++
+		if metric == metricVolume {
+			p.Value += value
+		} else {
+			p.Value = max(p.Value, value)
+		}
+	}
+	for i := range out.Points {
+		out.Points[i].Value = round1(out.Points[i].Value)
+	}
+	httpjson.WriteJSON(w, http.StatusOK, out)
+}
+
+// progressValue liczy metrykę jednej sesji bez serii rozgrzewkowych; false, gdy sesja
+// nie ma serii z ciężarem.
+func progressValue(metric string, s store.ExerciseSession) (float64, bool) {
+	s.Sets = slices.DeleteFunc(slices.Clone(s.Sets), func(set models.Set) bool { return set.Type == models.SetWarmup })
+	switch metric {
+	case metricE1RM:
+		e1rm, _, ok := progression.BestE1RMWith(progression.Session{Date: s.Date, Sets: s.Sets}, progression.FormulaEpley)
+		return e1rm, ok
+	case metricVolume:
+		total, ok := 0.0, false
+		for _, set := range s.Sets {
+			if set.Weight != nil {
+				total += *set.Weight * float64(set.Reps)
+				ok = true
+			}
+		}
+		return total, ok
+	}
+	top, ok := 0.0, false
+	for _, set := range s.Sets {
+		if set.Weight != nil && (!ok || *set.Weight > top) {
+			top, ok = *set.Weight, true
+		}
+	}
+	return top, ok
+}
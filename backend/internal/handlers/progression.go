@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/progression"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+type NextSuggestionHandler struct {
+	srv *server.Server
+}
+
+// NewNextSuggestionHandler obsługuje GET /workouts/next-suggestion: dla każdego ćwiczenia
+// z ostatniego wykonanego treningu (lub ?workoutId=) proponuje ciężar na kolejną sesję
+// według reguły progresji ćwiczenia (PUT /exercises/{id}/progression), domyślnie liniowej.
+// Progresję liniową można dostroić parametrami ?increment=&failures=&deload=. Gdy serie
+// mają zapisane RPE, a średnia przekracza docelowe (?targetRpe=, domyślnie 8), ciężar spada.
+// Niska gotowość z dzisiejszej oceny samopoczucia (POST /checkins) wstrzymuje podwyżki,
+// a ćwiczenia wykluczone przez niewyleczone urazy mają ich listę w injuries. Ciężary
+// zwracamy w jednostce z profilu; ?increment= i przyrost z reguły progresji są w kg.
+func NewNextSuggestionHandler(srv *server.Server) *NextSuggestionHandler {
+	return &NextSuggestionHandler{srv: srv}
+}
+
+func (h *NextSuggestionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	workoutID := queryInt(&errs, q, "workoutId", 0)
+	rule := progression.DefaultLinear
+	rule.Increment = queryFloat(&errs, q, "increment", rule.Increment)
+	rule.MaxFailures = queryInt(&errs, q, "failures", rule.MaxFailures)
+	deload := queryInt(&errs, q, "deload", int(rule.DeloadPercent*100))
+	targetRPE := queryFloat(&errs, q, "targetRpe", progression.DefaultTargetRPE)
+	if rule.Increment <= 0 || rule.Increment > 50 {
+		errs.add("increment", "increment must be between 0 and 50 kg")
+	}
+	if rule.MaxFailures < 1 || rule.MaxFailures > 10 {
+		errs.add("failures", "failures must be between 1 and 10")
+	}
+	if deload < 1 || deload > 50 {
+		errs.add("deload", "deload must be between 1 and 50 percent")
+	}
+	if !validRPE(targetRPE) || targetRPE < minTargetRPE {
+		errs.add("targetRpe", "target RPE must be between 5 and 10 in steps of 0.5")
+	}
+	rule.DeloadPercent = float64(deload) / 100
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	var (
+		wk    models.Workout
+		found bool
+	)
+	if workoutID != 0 {
+		wk, found = h.srv.Workouts.Get(r.Context(), workoutID)
+	} else {
+		wk, found = h.srv.Workouts.LastCompleted(r.Context())
+	}
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Workout not found")
+		return
+	}
+
+	unit := weightUnit(r.Context(), h.srv)
+	out := models.NextWorkoutSuggestion{WorkoutID: wk.ID, Date: wk.Date, Exercises: []models.ProgressionSuggestion{}, WeightUnit: unit}
+	if c, ok := h.srv.Checkins.ForDate(r.Context(), time.Now().Format(dateLayout)); ok {
+		out.Readiness = &c.Readiness
+	}
+	affected := h.srv.Injuries.AffectedExercises(r.Context())
+	for _, ex := range wk.Exercises {
+		history := sessionsUpTo(h.srv.Workouts.ExerciseHistory(r.Context(), ex.ExerciseID, ex.Name), wk)
+		// Własna reguła ćwiczenia ma pierwszeństwo przed parametrami zapytania.
+		var (
+			sg     progression.Suggestion
+			ok     bool
+			scheme = schemeLinear
+		)
+		custom, hasRule := h.srv.Progression.Get(r.Context(), ex.ExerciseID)
+		switch {
+		case hasRule && custom.Scheme == schemeDouble:
+			scheme = schemeDouble
+			sg, ok = progression.Double(history, progression.DoubleRule{MinReps: custom.MinReps, MaxReps: custom.MaxReps, Increment: custom.Increment})
+		case hasRule:
+			lr := rule
+			lr.Increment = custom.Increment
+			sg, ok = progression.Linear(history, lr)
+		default:
+			sg, ok = progression.Linear(history, rule)
+		}
+		if !ok {
+			continue
+		}
+		target := targetRPE
+		if hasRule && custom.TargetRPE > 0 {
+			target = custom.TargetRPE
+		}
+		sg = progression.Autoregulate(sg, history[0], target, rule.Rounding)
+		if out.Readiness != nil {
+			sg = progression.AdjustForReadiness(sg, *out.Readiness)
+		}
+		ps := models.ProgressionSuggestion{
+			ExerciseID: ex.ExerciseID,
+			Name:       ex.Name,
+			Scheme:     scheme,
+			Action:     sg.Action,
+			Weight:     kgTo(sg.Weight, unit, true),
+			Sets:       sg.Sets,
+			Reps:       sg.Reps,
+			LastWeight: kgTo(sg.LastWeight, unit, true),
+			Failures:   sg.Failures,
+			TargetRPE:  target,
+		}
+		if avg, ok := progression.AverageRPE(history[0]); ok {
+			ps.AverageRPE = &avg
+		}
+		for _, in := range affected[ex.ExerciseID] {
+			ps.Injuries = append(ps.Injuries, in.ID)
+		}
+		out.Exercises = append(out.Exercises, ps)
+	}
+	httpjson.WriteJSON(w, http.StatusOK, out)
+}
+
+// sessionsUpTo obcina historię do sesji nie nowszych niż trening wk, żeby propozycja
+// dla starszego treningu nie uwzględniała tego, co wydarzyło się później.
+func sessionsUpTo(history []store.ExerciseSession, wk models.Workout) []progression.Session {
+	var out []progression.Session
+	for _, s := range history {
+		if s.Date > wk.Date || s.Date == wk.Date && s.WorkoutID > wk.ID {
+			continue
+		}
+		out = append(out, progression.Session{Date: s.Date, Sets: s.Sets})
+	}
+	return out
+}
+
+// Schematy progresji.
+const (
+	schemeLinear = "linear"
+	schemeDouble = "double"
+)
+
+type ExerciseProgressionHandler struct {
+	srv *server.Server
+}
+
+// NewExerciseProgressionHandler obsługuje regułę progresji ćwiczenia z katalogu:
+// GET (bez własnej reguły zwraca domyślną liniową), PUT i DELETE (powrót do domyślnej)
+// /exercises/{id}/progression.
+func NewExerciseProgressionHandler(srv *server.Server) *ExerciseProgressionHandler {
+	return &ExerciseProgressionHandler{srv: srv}
+}
+
+func (h *ExerciseProgressionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	if _, found := h.srv.Exercises.Get(r.Context(), id); !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Exercise not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		rule, found := h.srv.Progression.Get(r.Context(), id)
+		if !found {
+			rule = models.ProgressionRule{ExerciseID: id, Scheme: schemeLinear, Increment: progression.DefaultLinear.Increment}
+		}
+		httpjson.WriteJSON(w, http.StatusOK, rule)
+
+	case http.MethodPut:
+		var req models.ProgressionRuleRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		rule := models.ProgressionRule{ExerciseID: id, Scheme: strings.TrimSpace(req.Scheme), Increment: req.Increment, TargetRPE: req.TargetRPE}
+		if rule.Scheme == schemeDouble {
+			rule.MinReps, rule.MaxReps = req.MinReps, req.MaxReps
+		}
+		if errs := validateProgressionRule(rule); len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, h.srv.Progression.Set(r.Context(), rule))
+
+	case http.MethodDelete:
+		h.srv.Progression.Delete(r.Context(), id)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// minTargetRPE to najniższe sensowne docelowe RPE (poniżej to już rozgrzewka).
+const minTargetRPE = 5
+
+// maxRangeReps ogranicza górną granicę zakresu powtórzeń w podwójnej progresji.
+const maxRangeReps = 50
+
+func validateProgressionRule(rule models.ProgressionRule) validationErrors {
+	var errs validationErrors
+	if rule.Scheme != schemeLinear && rule.Scheme != schemeDouble {
+		errs.add("scheme", "scheme must be one of: linear, double")
+	}
+	if rule.Increment <= 0 || rule.Increment > 50 {
+		errs.add("increment", "increment must be between 0 and 50 kg")
+	}
+	if rule.Scheme == schemeDouble && (rule.MinReps < 1 || rule.MaxReps <= rule.MinReps || rule.MaxReps > maxRangeReps) {
+		errs.add("maxReps", "rep range must satisfy 1 <= minReps < maxReps <= 50")
+	}
+	if rule.TargetRPE != 0 && (!validRPE(rule.TargetRPE) || rule.TargetRPE < minTargetRPE) {
+		errs.add("targetRpe", "target RPE must be between 5 and 10 in steps of 0.5")
+	}
+	return errs
+}
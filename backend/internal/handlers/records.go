@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+type RecordsHandler struct {
+	srv *server.Server
+}
+
+// NewRecordsHandler obsługuje GET /prs: rekordy osobiste każdego ćwiczenia (najcięższy
+// ciężar, najlepszy szacowany 1RM, najwięcej powtórzeń na danym ciężarze i największy
+// tonaż w treningu). ?exerciseId= zawęża wynik do jednego ćwiczenia z katalogu.
+// Magazyn aktualizuje rekordy przy każdej zmianie treningu; ciężary zwracamy
+// w jednostce z profilu.
+func NewRecordsHandler(srv *server.Server) *RecordsHandler {
+	return &RecordsHandler{srv: srv}
+}
+
+func (h *RecordsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	exerciseID := queryInt(&errs, r.URL.Query(), "exerciseId", 0)
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+	records := h.srv.Workouts.Records(r.Context())
+	if exerciseID != 0 {
+		filtered := []models.ExerciseRecords{}
+		for _, rec := range records {
+			if rec.ExerciseID == exerciseID {
+				filtered = append(filtered, rec)
+			}
+		}
+		records = filtered
+	}
+	httpjson.WriteJSON(w, http.StatusOK, recordsInUnit(records, weightUnit(r.Context(), h.srv)))
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+type ReorderExercisesHandler struct {
+	srv *server.Server
+}
+
+// NewReorderExercisesHandler obsługuje POST /workouts/{id}/exercises/reorder: ustawia
+// ćwiczenia w podanej kolejności, np. po przeciągnięciu w aplikacji, bez przesyłania
+// całego treningu. Superserie i obwody muszą po zmianie nadal być kolejnymi ćwiczeniami.
+func NewReorderExercisesHandler(srv *server.Server) *ReorderExercisesHandler {
+	return &ReorderExercisesHandler{srv: srv}
+}
+
+func (h *ReorderExercisesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	var req models.ReorderExercisesRequest
+	if err := httpjson.ReadJSON(w, r, &req); err != nil {
+		httpjson.WriteReadError(w, r, err)
+		return
+	}
+
+	cur, found := h.srv.Workouts.Get(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Workout not found")
+		return
+	}
+	version, ok := expectedVersion(w, r, cur, req.Version)
+	if !ok {
+		return
+	}
+	if version == 0 {
+		// Pozycje w żądaniu odnoszą się do pobranego stanu, więc nawet bez wersji
+		// od klienta nie nadpisujemy zmian zapisanych w międzyczasie.
+		version = cur.Version
+	}
+
+	var errs validationErrors
+	reordered := reorderExercises(&errs, cur.Exercises, req.Order)
+	if len(errs) == 0 {
+		validateGroups(&errs, reordered)
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	final, err := h.srv.Workouts.Update(r.Context(), id, version, func(cur models.Workout) models.Workout {
+		cur.Exercises = reordered
+		return cur
+	})
+	if errors.Is(err, store.ErrVersionConflict) {
+		writeVersionConflict(w, r, final)
+		return
+	}
+	if err != nil {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Workout not found")
+		return
+	}
+	writeWorkout(w, r, h.srv, http.StatusOK, final)
+}
+
+// reorderExercises zwraca nowy slice z ćwiczeniami w kolejności order (bieżące pozycje
+// od 1). order musi zawierać każdą pozycję dokładnie raz.
+func reorderExercises(errs *validationErrors, exs []models.Exercise, order []int) []models.Exercise {
+	if len(order) != len(exs) {
+		errs.add("order", "order must list each exercise position exactly once")
+		return nil
+	}
+	out := make([]models.Exercise, 0, len(exs))
+	seen := make([]bool, len(exs))
+	for i, pos := range order {
+		if pos < 1 || pos > len(exs) || seen[pos-1] {
+			errs.add("order["+strconv.Itoa(i)+"]", "order must list each exercise position exactly once")
+			return nil
+		}
+		seen[pos-1] = true
+		out = append(out, exs[pos-1])
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+type RevisionsHandler struct {
+	srv *server.Server
+}
+
+// NewRevisionsHandler obsługuje historię zmian treningu:
+//   - GET /workouts/{id}/revisions: lista zachowanych wersji ze zmienionymi polami
+//   - GET /workouts/{id}/revisions/{n}: stan treningu w wersji n i diff względem wersji n-1
+func NewRevisionsHandler(srv *server.Server) *RevisionsHandler {
+	return &RevisionsHandler{srv: srv}
+}
+
+func (h *RevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	revs, found := h.srv.Workouts.Revisions(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Workout not found")
+		return
+	}
+
+	// Lista wersji.
+	if r.PathValue("n") == "" {
+		out := make([]models.Revision, 0, len(revs))
+		for i, rev := range revs {
+			changed := []string{}
+			if i > 0 {
+				for _, c := range diffWorkouts(revs[i-1], rev) {
+					changed = append(changed, c.Path)
+				}
+			}
+			out = append(out, models.Revision{Version: rev.Version, UpdatedAt: rev.UpdatedAt, Changed: changed})
+		}
+		httpjson.WriteJSON(w, http.StatusOK, out)
+		return
+	}
+
+	// Pojedyncza wersja; najstarsze mogły zostać usunięte z historii.
+	n, err := strconv.Atoi(r.PathValue("n"))
+	for i, rev := range revs {
+		if err != nil || rev.Version != n {
+			continue
+		}
+		// Ciężary i ich zmiany pokazujemy w jednostce z profilu.
+		unit := weightUnit(r.Context(), h.srv)
+		detail := models.RevisionDetail{Workout: workoutInUnit(rev, unit, true), Diff: []models.FieldChange{}}
+		if i > 0 {
+			detail.Diff = diffWorkouts(workoutInUnit(revs[i-1], unit, true), detail.Workout)
+		}
+		httpjson.WriteJSON(w, http.StatusOK, detail)
+		return
+	}
+	httpjson.WriteError(w, r, http.StatusNotFound, "Revision not found")
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+// weekdays to nazwy dni tygodnia przyjmowane w ScheduleProgramRequest.Days (jak time.Weekday).
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+type ProgramScheduleHandler struct {
+	srv *server.Server
+}
+
+// NewProgramScheduleHandler obsługuje POST /programs/{id}/schedule: rozpisuje program
+// na kalendarz od dnia start. Tydzień N programu to dni start+7(N-1) … start+7N-1,
+// a jego sesje trafiają po kolei na kolejne dni treningowe z days. Każda sesja staje się
+// zaplanowanym treningiem (planned = true) z ćwiczeniami szablonu do uzupełnienia.
+func NewProgramScheduleHandler(srv *server.Server) *ProgramScheduleHandler {
+	return &ProgramScheduleHandler{srv: srv}
+}
+
+func (h *ProgramScheduleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	var req models.ScheduleProgramRequest
+	if err := httpjson.ReadJSON(w, r, &req); err != nil {
+		httpjson.WriteReadError(w, r, err)
+		return
+	}
+
+	p, found := h.srv.Programs.Get(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Program not found")
+		return
+	}
+
+	var errs validationErrors
+	start, err := time.Parse(dateLayout, strings.TrimSpace(req.Start))
+	if err != nil {
+		errs.add("start", "date must be YYYY-MM-DD")
+	}
+	days := make([]bool, len(weekdays))
+	trainingDays := 0
+	for i, d := range req.Days {
+		wd := slices.Index(weekdays, strings.ToLower(strings.TrimSpace(d)))
+		switch {
+		case wd < 0:
+			errs.add("days["+strconv.Itoa(i)+"]", "day must be one of: mon, tue, wed, thu, fri, sat, sun")
+		case !days[wd]:
+			days[wd] = true
+			trainingDays++
+		}
+	}
+	if len(req.Days) == 0 {
+		errs.add("days", "days must list at least one training day")
+	}
+	for _, wk := range p.Weeks {
+		if len(req.Days) > 0 && len(wk.Sessions) > trainingDays {
+			errs.add("days", "days must have at least as many training days as the longest program week")
+			break
+		}
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	var planned []models.Workout
+	for n, wk := range p.Weeks {
+		next := 0
+		for d := range 7 {
+			if next == len(wk.Sessions) {
+				break
+			}
+			date := start.AddDate(0, 0, 7*n+d)
+			if !days[date.Weekday()] {
+				continue
+			}
+			s := wk.Sessions[next]
+			next++
+			t, ok := h.srv.Templates.Get(r.Context(), s.TemplateID)
+			if !ok {
+				errs.add("weeks["+strconv.Itoa(n)+"].sessions["+strconv.Itoa(next-1)+"].templateId", "template not found")
+				continue
+			}
+			exs := cloneExercises(t.Exercises, false)
+			resolvePercentOfTM(r.Context(), h.srv.TrainingMaxes, exs, true)
+			resolveBodyweight(r.Context(), h.srv.Bodyweight, date.Format(dateLayout), exs)
+			planned = append(planned, models.Workout{
+				Title:     t.Name,
+				Date:      date.Format(dateLayout),
+				Notes:     cmp.Or(s.Notes, t.Notes),
+				Exercises: exs,
+				Planned:   true,
+			})
+		}
+	}
+	// Szablon mógł zostać usunięty po zapisaniu programu.
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, workoutsInUnit(r.Context(), h.srv, h.srv.Workouts.CreateMany(r.Context(), planned)))
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+const (
+	// sleepTarget to zalecany czas snu w minutach; krótsze noce liczą się do długu snu.
+	sleepTarget = 8 * 60
+	// clockLayout to format godzin zaśnięcia i pobudki.
+	clockLayout = "15:04"
+)
+
+type SleepHandler struct {
+	srv *server.Server
+}
+
+// NewSleepHandler obsługuje dziennik snu:
+//   - GET /sleep: noce od najnowszej, opcjonalnie z zakresu ?from=&to=
+//   - POST /sleep: noc z godzinami zaśnięcia i pobudki albo samym czasem snu
+func NewSleepHandler(srv *server.Server) *SleepHandler {
+	return &SleepHandler{srv: srv}
+}
+
+func (h *SleepHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var errs validationErrors
+		q := r.URL.Query()
+		from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+		if from != "" && to != "" && to < from {
+			errs.add("to", "to must not be before from")
+		}
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, h.srv.Sleep.List(r.Context(), from, to))
+
+	case http.MethodPost:
+		var req models.SleepRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		e, errs := sleepFromRequest(req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusCreated, h.srv.Sleep.Create(r.Context(), e))
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type SleepByIDHandler struct {
+	srv *server.Server
+}
+
+// NewSleepByIDHandler obsługuje GET, PUT (pełna zamiana) i DELETE /sleep/{id}.
+func NewSleepByIDHandler(srv *server.Server) *SleepByIDHandler {
+	return &SleepByIDHandler{srv: srv}
+}
+
+func (h *SleepByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		e, found := h.srv.Sleep.Get(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Sleep entry not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, e)
+
+	case http.MethodPut:
+		var req models.SleepRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		e, errs := sleepFromRequest(req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		updated, err := h.srv.Sleep.Update(r.Context(), id, e)
+		if err != nil {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Sleep entry not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, updated)
+
+	case http.MethodDelete:
+		if !h.srv.Sleep.Delete(r.Context(), id) {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Sleep entry not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type SleepSummaryHandler struct {
+	srv *server.Server
+}
+
+// NewSleepSummaryHandler obsługuje GET /sleep/summary: średni czas i jakość snu oraz dług
+// snu (względem 8 h) z ostatnich ?days= dni (domyślnie 7), z ostatnią nocą.
+func NewSleepSummaryHandler(srv *server.Server) *SleepSummaryHandler {
+	return &SleepSummaryHandler{srv: srv}
+}
+
+func (h *SleepSummaryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	days := queryInt(&errs, r.URL.Query(), "days", 7)
+	if days < 1 || days > 90 {
+		errs.add("days", "days must be between 1 and 90")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, sleepSummary(h.srv.Sleep.List(r.Context(), time.Now().AddDate(0, 0, 1-days).Format(dateLayout), ""), days))
+}
+
+// sleepSummary podsumowuje noce (od najnowszej) z okna days dni.
+func sleepSummary(nights []models.SleepEntry, days int) models.SleepSummary {
+	out := models.SleepSummary{Days: days, Nights: len(nights)}
+	if len(nights) == 0 {
+		return out
+	}
+	out.LastNight = &nights[0]
+	total, quality, rated := 0, 0, 0
+	for _, e := range nights {
+		total += e.Duration
+		out.Debt += max(0, sleepTarget-e.Duration)
+		if e.Quality > 0 {
+			quality += e.Quality
+			rated++
+		}
+	}
+	out.AvgDuration = ptr(round1(float64(total) / float64(len(nights))))
+	if rated > 0 {
+		out.AvgQuality = ptr(round1(float64(quality) / float64(rated)))
+	}
+	return out
+}
+
+// sleepFromRequest waliduje noc; z godzin zaśnięcia i pobudki liczy czas snu (także przez
+// północ). Brak daty oznacza dzisiaj.
+func sleepFromRequest(req models.SleepRequest) (models.SleepEntry, validationErrors) {
+	var errs validationErrors
+	e := models.SleepEntry{
+		Date:     req.Date,
+		Bedtime:  req.Bedtime,
+		WakeTime: req.WakeTime,
+		Duration: req.Duration,
+		Quality:  req.Quality,
+		Note:     strings.TrimSpace(req.Note),
+	}
+	if e.Date == "" {
+		e.Date = time.Now().Format(dateLayout)
+	} else if _, err := time.Parse(dateLayout, e.Date); err != nil {
+		errs.add("date", "date must be YYYY-MM-DD")
+	}
+	switch {
+	case e.Bedtime != "" || e.WakeTime != "":
+		bed, err1 := time.Parse(clockLayout, e.Bedtime)
+		if err1 != nil {
+			errs.add("bedtime", "bedtime must be HH:MM")
+		}
+		wake, err2 := time.Parse(clockLayout, e.WakeTime)
+		if err2 != nil {
+			errs.add("wakeTime", "wakeTime must be HH:MM")
+		}
+		if err1 == nil && err2 == nil {
+			if !wake.After(bed) {
+				wake = wake.Add(24 * time.Hour)
+			}
+			minutes := int(wake.Sub(bed).Minutes())
+			if e.Duration == 0 {
+				e.Duration = minutes
+			} else if e.Duration > minutes {
+				errs.add("duration", "duration must not exceed the time between bedtime and wakeTime")
+			}
+		}
+	case e.Duration == 0:
+		errs.add("duration", "duration or bedtime and wakeTime are required")
+	}
+	if e.Duration < 0 || e.Duration > 24*60 {
+		errs.add("duration", "duration must be between 0 and 1440 minutes")
+	}
+	if e.Quality < 0 || e.Quality > 5 {
+		errs.add("quality", "quality must be between 1 and 5")
+	}
+	if utf8.RuneCountInString(e.Note) > 500 {
+		errs.add("note", "note must not exceed 500 characters")
+	}
+	return e, errs
+}
@@ -0,0 +1,611 @@
+package handlers
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/programs"
+	"gym-api/internal/server"
+	"gym-api/internal/standards"
+	"gym-api/internal/store"
+)
+
+// Parametry ACWR: obciążenie przewlekłe to średnia z chronicWeeks tygodni (łącznie
+// z bieżącym), a odpowiedź domyślnie pokazuje defaultLoadWeeks tygodni.
+const (
+	chronicWeeks     = 4
+	defaultLoadWeeks = 8
+)
+
+type LoadStatsHandler struct {
+	srv *server.Server
+}
+
+// NewLoadStatsHandler obsługuje GET /stats/load: tygodniowe obciążenie treningowe
+// (ciężar × powtórzenia × RPE/10) i stosunek obciążenia ostrego (ostatnie 7 dni) do
+// przewlekłego (średnia 4 tygodni). ACWR powyżej 1,3 oznacza skok obciążenia i ryzyko
+// kontuzji. Tygodnie liczymy wstecz od ?date= (domyślnie dziś), ?weeks= (4–52) to ich liczba.
+func NewLoadStatsHandler(srv *server.Server) *LoadStatsHandler {
+	return &LoadStatsHandler{srv: srv}
+}
+
+func (h *LoadStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	date := queryDate(&errs, q, "date")
+	weeks := queryInt(&errs, q, "weeks", defaultLoadWeeks)
+	if weeks < 4 || weeks > 52 {
+		errs.add("weeks", "weeks must be between 4 and 52")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+	if date == "" {
+		date = time.Now().Format(dateLayout)
+	}
+	end, _ := time.Parse(dateLayout, date)
+
+	// Tydzień i to 7 dni kończących się end - 7i; potrzebujemy też chronicWeeks-1
+	// wcześniejszych tygodni do średniej najstarszego z pokazanych.
+	total := weeks + chronicWeeks - 1
+	from := end.AddDate(0, 0, -7*total+1).Format(dateLayout)
+	loads := make([]float64, total) // od najnowszego
+	for _, d := range h.srv.Workouts.DailyLoad(r.Context(), from, end.Format(dateLayout)) {
+		day, err := time.Parse(dateLayout, d.Date)
+		if err != nil {
+			continue
+		}
+		ago := int(end.Sub(day).Hours()) / 24
+		loads[ago/7] += d.Load
+	}
+
+	report := models.LoadReport{Date: end.Format(dateLayout), Weeks: make([]models.WeeklyLoad, weeks)}
+	for i := range weeks {
+		chronic := 0.0
+		for _, l := range loads[i : i+chronicWeeks] {
+			chronic += l
+		}
+		chronic /= chronicWeeks
+		wl := models.WeeklyLoad{
+			Start:   end.AddDate(0, 0, -7*i-6).Format(dateLayout),
+			End:     end.AddDate(0, 0, -7*i).Format(dateLayout),
+			Load:    round1(loads[i]),
+			Chronic: round1(chronic),
+		}
+		wl.ACWR, wl.Zone = acwr(loads[i], chronic)
+		report.Weeks[weeks-1-i] = wl
+	}
+	cur := report.Weeks[weeks-1]
+	report.Acute, report.Chronic, report.ACWR, report.Zone = cur.Load, cur.Chronic, cur.ACWR, cur.Zone
+	report.Warning = cur.Zone == models.LoadZoneHigh || cur.Zone == models.LoadZoneDanger
+	httpjson.WriteJSON(w, http.StatusOK, report)
+}
+
+// acwr liczy stosunek obciążenia ostrego do przewlekłego i jego strefę.
+func acwr(acute, chronic float64) (*float64, string) {
+	if chronic == 0 {
+		return nil, models.LoadZoneNoData
+	}
+	ratio := math.Round(acute/chronic*100) / 100
+	switch {
+	case ratio < 0.8:
+		return &ratio, models.LoadZoneLow
+	case ratio <= 1.3:
+		return &ratio, models.LoadZoneOptimal
+	case ratio <= 1.5:
+		return &ratio, models.LoadZoneHigh
+	}
+	return &ratio, models.LoadZoneDanger
+}
+
+// Dozwolone wartości parametrów GET /stats/volume; pusty groupBy to sumy okresów.
+var (
+	volumeGroupBy = []string{"", "muscle"}
+	volumePeriods = []string{store.PeriodDay, store.PeriodWeek, store.PeriodMonth}
+)
+
+// maxVolumePeriods ogranicza liczbę okresów w odpowiedzi (rok dni, 7 lat tygodni).
+const maxVolumePeriods = 366
+
+// secondaryShare to udział serii ćwiczenia w objętości mięśnia pomocniczego.
+const secondaryShare = 0.5
+
+type VolumeStatsHandler struct {
+	srv *server.Server
+}
+
+// NewVolumeStatsHandler obsługuje GET /stats/volume?period=day|week|month: liczbę treningów,
+// serii, powtórzeń i kilogramów (tonaż) w kolejnych okresach. Z ?groupBy=muscle zwraca
+// serie i tonaż per grupa mięśni z taksonomii katalogu, z oceną tygodniowej liczby serii
+// względem zalecanych 10–20. Zakres ?from=&to= domyślnie obejmuje 30 dni, 8 tygodni
+// albo 6 miesięcy, zależnie od okresu. Serie rozgrzewkowe liczą się tylko
+// z ?includeWarmups=true.
+func NewVolumeStatsHandler(srv *server.Server) *VolumeStatsHandler {
+	return &VolumeStatsHandler{srv: srv}
+}
+
+func (h *VolumeStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	groupBy := q.Get("groupBy")
+	period := cmp.Or(q.Get("period"), store.PeriodWeek)
+	from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+	warmups := queryBool(&errs, q, "includeWarmups")
+	if !slices.Contains(volumeGroupBy, groupBy) {
+		errs.add("groupBy", "groupBy must be one of: muscle")
+	}
+	if !slices.Contains(volumePeriods, period) {
+		errs.add("period", "period must be one of: day, week, month")
+	}
+	if from != "" && to != "" && to < from {
+		errs.add("to", "to must not be before from")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+	if to == "" {
+		to = time.Now().Format(dateLayout)
+	}
+	if from == "" {
+		end, _ := time.Parse(dateLayout, to)
+		switch period {
+		case store.PeriodDay:
+			from = end.AddDate(0, 0, -29).Format(dateLayout)
+		case store.PeriodWeek:
+			from = store.PeriodStart(end.AddDate(0, 0, -7*7).Format(dateLayout), period)
+		case store.PeriodMonth:
+			from = store.PeriodStart(end.AddDate(0, -5, 0).Format(dateLayout), period)
+		}
+	}
+
+	// Okresy bez treningów też pokazujemy (z zerami), żeby było widać przerwy.
+	var starts []string
+	for start := store.PeriodStart(from, period); start <= to; start = nextPeriod(start, period) {
+		if len(starts) == maxVolumePeriods {
+			errs.add("from", "date range must not span more than 366 periods")
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		starts = append(starts, start)
+	}
+	if groupBy == "muscle" {
+		httpjson.WriteJSON(w, http.StatusOK, h.byMuscle(r.Context(), from, to, period, warmups, starts))
+		return
+	}
+
+	report := models.VolumeReport{Period: period, From: from, To: to, Periods: make([]models.VolumeTotals, len(starts))}
+	index := map[string]int{}
+	for i, start := range starts {
+		index[start] = i
+		report.Periods[i].Start = start
+	}
+	for _, v := range h.srv.Workouts.VolumeByPeriod(r.Context(), from, to, period, warmups) {
+		p := &report.Periods[index[v.Period]]
+		p.Workouts, p.Sets, p.Reps, p.Tonnage = v.Workouts, v.Sets, v.Reps, round1(v.Tonnage)
+		report.Total.Workouts += v.Workouts
+		report.Total.Sets += v.Sets
+		report.Total.Reps += v.Reps
+		report.Total.Tonnage += v.Tonnage
+	}
+	report.Total.Tonnage = round1(report.Total.Tonnage)
+	httpjson.WriteJSON(w, http.StatusOK, report)
+}
+
+// byMuscle rozdziela objętość ćwiczeń między grupy mięśni z katalogu.
+func (h *VolumeStatsHandler) byMuscle(ctx context.Context, from, to, period string, warmups bool, starts []string) models.MuscleVolumeReport {
+	report := models.MuscleVolumeReport{GroupBy: "muscle", Period: period, From: from, To: to, Periods: make([]models.MuscleVolumePeriod, len(starts))}
+	index := map[string]int{}
+	for i, start := range starts {
+		index[start] = i
+		report.Periods[i] = newMuscleVolumePeriod(start)
+	}
+	catalog := map[int]models.CatalogExercise{}
+	for _, v := range h.srv.Workouts.ExerciseVolumeByPeriod(ctx, from, to, period, warmups) {
+		cur := &report.Periods[index[v.Period]]
+		ce, ok := catalog[v.ExerciseID]
+		if !ok && v.ExerciseID != 0 {
+			ce, _ = h.srv.Exercises.Get(ctx, v.ExerciseID)
+			catalog[v.ExerciseID] = ce
+		}
+		if len(ce.PrimaryMuscles) == 0 {
+			cur.Unassigned += v.Sets
+			continue
+		}
+		for i := range cur.Muscles {
+			mv := &cur.Muscles[i]
+			switch {
+			case slices.Contains(ce.PrimaryMuscles, mv.Muscle):
+				mv.Sets += float64(v.Sets)
+				mv.Tonnage += v.Tonnage
+			case slices.Contains(ce.SecondaryMuscles, mv.Muscle):
+				mv.Sets += float64(v.Sets) * secondaryShare
+				mv.Tonnage += v.Tonnage * secondaryShare
+			}
+		}
+	}
+	for i := range report.Periods {
+		for j := range report.Periods[i].Muscles {
+			mv := &report.Periods[i].Muscles[j]
+			mv.Tonnage = round1(mv.Tonnage)
+			if period == store.PeriodWeek {
+				switch {
+				case mv.Sets < models.WeeklySetsMin:
+					mv.Status = models.VolumeBelow
+				case mv.Sets > models.WeeklySetsMax:
+					mv.Status = models.VolumeAbove
+				default:
+					mv.Status = models.VolumeWithin
+				}
+			}
+		}
+	}
+	return report
+}
+
+// newMuscleVolumePeriod tworzy okres z zerową objętością każdej grupy mięśni,
+// żeby zaniedbane grupy też było widać.
+func newMuscleVolumePeriod(start string) models.MuscleVolumePeriod {
+	groups := store.MuscleGroups()
+	p := models.MuscleVolumePeriod{Start: start, Muscles: make([]models.MuscleVolume, len(groups))}
+	for i, g := range groups {
+		p.Muscles[i] = models.MuscleVolume{Muscle: g.ID}
+	}
+	return p
+}
+
+// nextPeriod zwraca początek okresu następującego po tym, który zaczyna się w start.
+func nextPeriod(start, period string) string {
+	d, _ := time.Parse(dateLayout, start)
+	switch period {
+	case store.PeriodDay:
+		return d.AddDate(0, 0, 1).Format(dateLayout)
+	case store.PeriodMonth:
+		return d.AddDate(0, 1, 0).Format(dateLayout)
+	}
+	return d.AddDate(0, 0, 7).Format(dateLayout)
+}
+
+type StrengthStandardsHandler struct {
+	srv *server.Server
+}
+
+// NewStrengthStandardsHandler obsługuje GET /stats/strength-standards?sex=&bodyweight=:
+// porównuje najlepszy szacowany 1RM przysiadu, wyciskania leżąc, martwego ciągu i wyciskania
+// nad głowę z normami siły (od untrained do elite) przy podanej masie ciała; bez
+// ?bodyweight= bierzemy najnowszy pomiar z dziennika masy ciała.
+func NewStrengthStandardsHandler(srv *server.Server) *StrengthStandardsHandler {
+	return &StrengthStandardsHandler{srv: srv}
+}
+
+func (h *StrengthStandardsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	sex := q.Get("sex")
+	bodyweight := queryBodyweight(r.Context(), h.srv, &errs, q)
+	if sex != standards.Male && sex != standards.Female {
+		errs.add("sex", "sex must be one of: male, female")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	best := map[int]float64{}
+	for _, rec := range h.srv.Workouts.Records(r.Context()) {
+		if rec.ExerciseID != 0 && rec.BestE1RM != nil {
+			best[rec.ExerciseID] = rec.BestE1RM.Value
+		}
+	}
+	report := models.StrengthStandardsReport{Sex: sex, Bodyweight: bodyweight}
+	for _, lift := range programs.Lifts() {
+		ls := models.LiftStandard{Lift: lift, Name: programs.LiftName(lift)}
+		e1rm := 0.0
+		if ex, ok := h.srv.Exercises.FindByName(r.Context(), ls.Name); ok {
+			ls.ExerciseID, ls.Name = ex.ID, ex.Name
+			if v, ok := best[ex.ID]; ok {
+				e1rm = v
+				ls.E1RM = &v
+			}
+		}
+		res, _ := standards.Classify(sex, lift, e1rm, bodyweight)
+		ls.Ratio = math.Round(e1rm/bodyweight*100) / 100
+		ls.Level, ls.Score, ls.NextLevel, ls.NextWeight, ls.Thresholds = res.Level, round1(res.Score), res.Next, res.NextWeight, res.Thresholds
+		report.Lifts = append(report.Lifts, ls)
+	}
+	httpjson.WriteJSON(w, http.StatusOK, report)
+}
+
+// powerliftingLifts to boje trójboju (klucze jak w programs.Lifts).
+var powerliftingLifts = []string{"squat", "bench", "deadlift"}
+
+type PowerliftingStatsHandler struct {
+	srv *server.Server
+}
+
+// NewPowerliftingStatsHandler obsługuje GET /stats/powerlifting?sex=&bodyweight=: punkty
+// Wilksa, DOTS i IPF GL z sumy najlepszych wyników przysiadu, wyciskania leżąc i martwego
+// ciągu. ?source=e1rm (domyślnie) bierze szacowany 1RM, ?source=actual – najcięższy ciężar.
+// Bez ?bodyweight= bierzemy najnowszy pomiar z dziennika masy ciała.
+func NewPowerliftingStatsHandler(srv *server.Server) *PowerliftingStatsHandler {
+	return &PowerliftingStatsHandler{srv: srv}
+}
+
+func (h *PowerliftingStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	sex := q.Get("sex")
+	bodyweight := queryBodyweight(r.Context(), h.srv, &errs, q)
+	source := cmp.Or(q.Get("source"), "e1rm")
+	if sex != standards.Male && sex != standards.Female {
+		errs.add("sex", "sex must be one of: male, female")
+	}
+	if source != "e1rm" && source != "actual" {
+		errs.add("source", "source must be one of: e1rm, actual")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	records := map[int]models.ExerciseRecords{}
+	for _, rec := range h.srv.Workouts.Records(r.Context()) {
+		if rec.ExerciseID != 0 {
+			records[rec.ExerciseID] = rec
+		}
+	}
+	report := models.PowerliftingReport{Sex: sex, Bodyweight: bodyweight, Source: source, Complete: true}
+	for _, lift := range powerliftingLifts {
+		pl := models.PowerliftingLift{Lift: lift}
+		if ex, ok := h.srv.Exercises.FindByName(r.Context(), programs.LiftName(lift)); ok {
+			pl.ExerciseID = ex.ID
+			best := records[ex.ID].BestE1RM
+			if source == "actual" {
+				best = records[ex.ID].HeaviestWeight
+			}
+			if best != nil {
+				pl.Weight, pl.Date = best.Value, best.Date
+			}
+		}
+		report.Complete = report.Complete && pl.Weight > 0
+		report.Total += pl.Weight
+		report.Lifts = append(report.Lifts, pl)
+	}
+	report.Total = round1(report.Total)
+	report.Wilks = math.Round(standards.Wilks(sex, report.Total, bodyweight)*100) / 100
+	report.DOTS = math.Round(standards.DOTS(sex, report.Total, bodyweight)*100) / 100
+	report.IPFGL = math.Round(standards.IPFGL(sex, report.Total, bodyweight)*100) / 100
+	httpjson.WriteJSON(w, http.StatusOK, report)
+}
+
+type StreaksStatsHandler struct {
+	srv *server.Server
+}
+
+// NewStreaksStatsHandler obsługuje GET /stats/streaks?perWeek=N: bieżącą i najdłuższą serię
+// kolejnych tygodni z co najmniej N wykonanymi treningami (domyślnie 1). Liczymy przy
+// każdym żądaniu, więc dodanie lub usunięcie treningu od razu zmienia wynik. Bieżący
+// tydzień, dopóki trwa, nie przerywa serii – wlicza się dopiero po zaliczeniu.
+func NewStreaksStatsHandler(srv *server.Server) *StreaksStatsHandler {
+	return &StreaksStatsHandler{srv: srv}
+}
+
+func (h *StreaksStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	perWeek := queryInt(&errs, r.URL.Query(), "perWeek", 1)
+	if perWeek < 1 || perWeek > 7 {
+		errs.add("perWeek", "perWeek must be between 1 and 7")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	today := time.Now().Format(dateLayout)
+	thisWeek := store.PeriodStart(today, store.PeriodWeek)
+	report := models.StreakReport{PerWeek: perWeek, ThisWeekStart: thisWeek}
+	counts := map[string]int{}
+	first := ""
+	for _, v := range h.srv.Workouts.VolumeByPeriod(r.Context(), "", today, store.PeriodWeek, false) {
+		counts[v.Period] = v.Workouts
+		if first == "" {
+			first = v.Period
+		}
+	}
+	report.ThisWeekWorkouts = counts[thisWeek]
+	report.ThisWeekLeft = max(perWeek-counts[thisWeek], 0)
+	if first == "" {
+		httpjson.WriteJSON(w, http.StatusOK, report)
+		return
+	}
+
+	var run models.Streak
+	for week := first; week <= thisWeek; week = nextPeriod(week, store.PeriodWeek) {
+		if counts[week] < perWeek {
+			if week != thisWeek {
+				run = models.Streak{}
+			}
+			continue
+		}
+		if run.Weeks == 0 {
+			run.Start = week
+		}
+		run.Weeks++
+		run.End = week
+		if run.Weeks > report.Longest.Weeks {
+			report.Longest = run
+		}
+	}
+	// Seria jest bieżąca, jeśli obejmuje ten albo poprzedni tydzień.
+	if run.Weeks > 0 && (run.End == thisWeek || nextPeriod(run.End, store.PeriodWeek) == thisWeek) {
+		report.Current = run
+	}
+	httpjson.WriteJSON(w, http.StatusOK, report)
+}
+
+// heatmapLevels to liczba poziomów intensywności dnia z treningiem (jak w GitHubie).
+const heatmapLevels = 4
+
+type HeatmapStatsHandler struct {
+	srv *server.Server
+}
+
+// NewHeatmapStatsHandler obsługuje GET /stats/heatmap?year=: każdy dzień roku (domyślnie
+// bieżącego) z liczbą treningów, tonażem i poziomem 0–4 do mapy aktywności w stylu
+// GitHuba. Poziom to ćwiartka tonażu najcięższego dnia; trening bez ciężarów ma poziom 1.
+func NewHeatmapStatsHandler(srv *server.Server) *HeatmapStatsHandler {
+	return &HeatmapStatsHandler{srv: srv}
+}
+
+func (h *HeatmapStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	year := queryInt(&errs, r.URL.Query(), "year", time.Now().Year())
+	if year < 1900 || year > 2100 {
+		errs.add("year", "year must be between 1900 and 2100")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	report := models.HeatmapReport{Year: year}
+	index := map[string]int{}
+	for d := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); d.Year() == year; d = d.AddDate(0, 0, 1) {
+		index[d.Format(dateLayout)] = len(report.Days)
+		report.Days = append(report.Days, models.HeatmapDay{Date: d.Format(dateLayout)})
+	}
+	from, to := report.Days[0].Date, report.Days[len(report.Days)-1].Date
+	for _, v := range h.srv.Workouts.VolumeByPeriod(r.Context(), from, to, store.PeriodDay, false) {
+		day := &report.Days[index[v.Period]]
+		day.Sessions, day.Tonnage = v.Workouts, round1(v.Tonnage)
+		report.TrainingDays++
+		report.MaxTonnage = max(report.MaxTonnage, day.Tonnage)
+	}
+	for i := range report.Days {
+		day := &report.Days[i]
+		switch {
+		case day.Sessions == 0:
+		case report.MaxTonnage == 0:
+			day.Level = 1
+		default:
+			day.Level = max(1, int(math.Ceil(day.Tonnage/report.MaxTonnage*heatmapLevels)))
+		}
+	}
+	httpjson.WriteJSON(w, http.StatusOK, report)
+}
+
+type RestStatsHandler struct {
+	srv *server.Server
+}
+
+// NewRestStatsHandler obsługuje GET /stats/rest?from=&to=: przerwy po seriach każdego
+// ćwiczenia z wykonanych treningów (średnia, najkrótsza, najdłuższa) i ich porównanie
+// z planem, po nazwie ćwiczenia. Ćwiczenia bez zapisanych przerw pomijamy.
+func NewRestStatsHandler(srv *server.Server) *RestStatsHandler {
+	return &RestStatsHandler{srv: srv}
+}
+
+func (h *RestStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+	if from != "" && to != "" && to < from {
+		errs.add("to", "to must not be before from")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	type key struct {
+		id   int
+		name string // małymi literami; tylko dla ćwiczeń spoza katalogu
+	}
+	type totals struct {
+		rest, overrun int
+	}
+	byKey := map[key]*models.ExerciseRest{}
+	sums := map[key]*totals{}
+	workouts, _, _ := h.srv.Workouts.List(r.Context(), store.WorkoutQuery{From: from, To: to})
+	for _, wk := range workouts {
+		if wk.Planned {
+			continue
+		}
+		for _, ex := range wk.Exercises {
+			k := key{id: ex.ExerciseID}
+			if ex.ExerciseID == 0 {
+				k.name = strings.ToLower(strings.TrimSpace(ex.Name))
+			}
+			for _, set := range ex.Sets {
+				if set.RestSeconds == nil {
+					continue
+				}
+				rest := *set.RestSeconds
+				er := byKey[k]
+				if er == nil {
+					er = &models.ExerciseRest{ExerciseID: ex.ExerciseID, Name: strings.TrimSpace(ex.Name), MinRest: rest, MaxRest: rest}
+					byKey[k], sums[k] = er, &totals{}
+				}
+				sum := sums[k]
+				er.Sets++
+				sum.rest += rest
+				er.MinRest, er.MaxRest = min(er.MinRest, rest), max(er.MaxRest, rest)
+				if set.PlannedRestSeconds != nil {
+					er.Planned++
+					sum.overrun += rest - *set.PlannedRestSeconds
+					if rest > *set.PlannedRestSeconds {
+						er.OverPlanned++
+					}
+				}
+			}
+		}
+	}
+	out := make([]models.ExerciseRest, 0, len(byKey))
+	for k, er := range byKey {
+		er.AvgRest = round1(float64(sums[k].rest) / float64(er.Sets))
+		if er.Planned > 0 {
+			er.AvgOverrun = round1(float64(sums[k].overrun) / float64(er.Planned))
+		}
+		out = append(out, *er)
+	}
+	slices.SortFunc(out, func(a, b models.ExerciseRest) int {
+		return cmp.Or(cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), cmp.Compare(a.ExerciseID, b.ExerciseID))
+	})
+	httpjson.WriteJSON(w, http.StatusOK, out)
+}
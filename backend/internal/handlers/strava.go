@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+	"gym-api/internal/strava"
+)
+
+// stravaProvider to nazwa integracji w magazynie połączeń.
+const stravaProvider = "strava"
+
+// stravaOAuth podłącza Stravę do wspólnego przepływu OAuth (integrations.go). Kolejne
+// synchronizacje zaczynają tydzień przed poprzednią, bo aktywność wgrana z opóźnieniem
+// ma czas startu sprzed synchronizacji.
+var stravaOAuth = &oauthProvider{
+	name:         stravaProvider,
+	title:        "Strava",
+	initialSync:  30 * 24 * time.Hour,
+	overlap:      7 * 24 * time.Hour,
+	unauthorized: strava.ErrUnauthorized,
+	configured:   func(srv *server.Server) bool { return srv.Strava != nil },
+	authorizeURL: func(srv *server.Server, state, _ string) string { return srv.Strava.AuthorizeURL(state) },
+	granted:      func(scope string) bool { return strings.Contains(scope, "activity:read") },
+	exchange: func(ctx context.Context, srv *server.Server, code, _ string) (models.Integration, error) {
+		t, err := srv.Strava.Exchange(ctx, code)
+		if err != nil {
+			return models.Integration{}, err
+		}
+		return models.Integration{
+			AthleteID:      strconv.FormatInt(t.AthleteID, 10),
+			AccessToken:    t.AccessToken,
+			RefreshToken:   t.RefreshToken,
+			TokenExpiresAt: t.ExpiresAt,
+		}, nil
+	},
+	refresh: func(ctx context.Context, srv *server.Server, refreshToken string) (models.Integration, error) {
+		t, err := srv.Strava.Refresh(ctx, refreshToken)
+		return models.Integration{AccessToken: t.AccessToken, RefreshToken: t.RefreshToken, TokenExpiresAt: t.ExpiresAt}, err
+	},
+	sync: syncStrava,
+}
+
+// stravaActivities mapuje sport_type aktywności Stravy na ćwiczenie z katalogu.
+var stravaActivities = map[string]string{
+	"Run":               "Running",
+	"TrailRun":          "Running",
+	"VirtualRun":        "Running",
+	"Ride":              "Cycling",
+	"MountainBikeRide":  "Cycling",
+	"GravelRide":        "Cycling",
+	"EBikeRide":         "Cycling",
+	"EMountainBikeRide": "Cycling",
+	"VirtualRide":       "Cycling",
+	"Rowing":            "Rowing Machine",
+	"VirtualRow":        "Rowing Machine",
+	"Walk":              "Walking",
+	"Hike":              "Hiking",
+}
+
+type StravaWebhookHandler struct {
+	srv *server.Server
+}
+
+// NewStravaWebhookHandler obsługuje webhook Stravy:
+//   - GET /integrations/strava/webhook: potwierdzenie subskrypcji (hub.challenge)
+//   - POST /integrations/strava/webhook: zdarzenia; nowa aktywność jest importowana w tle,
+//     a odwołanie dostępu rozłącza konto
+func NewStravaWebhookHandler(srv *server.Server) *StravaWebhookHandler {
+	return &StravaWebhookHandler{srv: srv}
+}
+
+func (h *StravaWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !integrationConfigured(w, r, h.srv, stravaOAuth) {
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		token := h.srv.Strava.VerifyToken
+		if q.Get("hub.mode") != "subscribe" || token == "" || q.Get("hub.verify_token") != token {
+			httpjson.WriteError(w, r, http.StatusForbidden, "invalid webhook verify token")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, map[string]string{"hub.challenge": q.Get("hub.challenge")})
+
+	case http.MethodPost:
+		var e strava.Event
+		if err := httpjson.ReadJSON(w, r, &e); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		// Zdarzenia kont, których nie znamy (np. już rozłączonych), tylko potwierdzamy –
+		// inaczej Strava ponawiałaby je.
+		owner, ok := h.srv.Integrations.OwnerOf(r.Context(), stravaProvider, strconv.FormatInt(e.OwnerID, 10))
+		if ok {
+			ctx := store.WithActor(r.Context(), owner)
+			switch {
+			case e.Deauthorized():
+				h.srv.Integrations.Disconnect(ctx, stravaProvider)
+			case e.ObjectType == "activity" && e.AspectType == "create":
+				inBackground(ctx, func(ctx context.Context) {
+					if err := importStravaEvent(ctx, h.srv, e.ObjectID); err != nil {
+						slog.WarnContext(ctx, "import aktywności ze Stravy nie powiódł się", "owner", owner, "activity", e.ObjectID, "err", err)
+					}
+				})
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// syncStrava zapisuje aktywności rozpoczęte od from jako treningi kardio.
+func syncStrava(ctx context.Context, srv *server.Server, _ models.Integration, token string, from, _ time.Time) (models.SyncResult, error) {
+	activities, err := srv.Strava.Activities(ctx, token, from)
+	if err != nil {
+		return models.SyncResult{}, err
+	}
+	res := models.SyncResult{Fetched: len(activities), Imported: []models.Workout{}}
+	for _, a := range activities {
+		importStravaActivity(ctx, srv, a, &res)
+	}
+	return res, nil
+}
+
+// importStravaEvent importuje jedną aktywność wskazaną w zdarzeniu webhooka. Błąd trafia
+// do stanu połączenia, ale czasu synchronizacji nie przesuwamy – pozostałych aktywności
+// nie sprawdzaliśmy.
+func importStravaEvent(ctx context.Context, srv *server.Server, activityID int64) error {
+	integrationMu.Lock()
+	defer integrationMu.Unlock()
+
+	conn, ok := srv.Integrations.Get(ctx, stravaProvider)
+	if !ok {
+		return errNotConnected
+	}
+	err := func() error {
+		token, err := accessToken(ctx, srv, stravaOAuth, conn, time.Now())
+		if err != nil {
+			return err
+		}
+		a, err := srv.Strava.Activity(ctx, token, activityID)
+		if err != nil {
+			return err
+		}
+		importStravaActivity(ctx, srv, a, &models.SyncResult{})
+		return nil
+	}()
+	if err != nil {
+		srv.Integrations.SyncDone(ctx, stravaProvider, time.Now(), err)
+	}
+	return err
+}
+
+// importStravaActivity zapisuje aktywność jako trening (patrz importWorkout).
+func importStravaActivity(ctx context.Context, srv *server.Server, a strava.Activity, res *models.SyncResult) {
+	id := strconv.FormatInt(a.ID, 10)
+	if _, ok := srv.Integrations.ImportedWorkout(ctx, stravaProvider, id); ok {
+		res.Duplicates++
+		return
+	}
+	importWorkout(ctx, srv, stravaProvider, id, stravaWorkout(a), res)
+}
+
+// stravaWorkout zamienia aktywność na trening z jednym ćwiczeniem kardio. Czas to czas
+// ruchu (bez pauz), a data pochodzi z lokalnego czasu startu.
+func stravaWorkout(a strava.Activity) models.Workout {
+	name := stravaActivities[a.SportType]
+	if name == "" {
+		name = "Cardio"
+	}
+	c := &models.Cardio{Duration: a.MovingTime}
+	if c.Duration == 0 {
+		c.Duration = a.ElapsedTime
+	}
+	if a.Distance > 0 {
+		c.Distance = ptr(math.Round(a.Distance))
+	}
+	if a.TotalElevationGain > 0 {
+		c.ElevationGain = ptr(math.Round(a.TotalElevationGain))
+	}
+	if a.HasHeartrate && a.AverageHeartrate > 0 {
+		c.AvgHR = ptr(int(math.Round(a.AverageHeartrate)))
+	}
+	title := strings.TrimSpace(a.Name)
+	if title == "" {
+		title = name
+	}
+	return models.Workout{
+		Title:     title,
+		Date:      a.StartDateLocal.Format(dateLayout),
+		Exercises: []models.Exercise{{Name: name, Type: models.ExerciseCardio, Sets: []models.Set{}, Cardio: c}},
+	}
+}
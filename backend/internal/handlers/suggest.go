@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strings"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+// Domyślna i maksymalna liczba podpowiedzi.
+const (
+	defaultSuggestions = 10
+	maxSuggestions     = 50
+)
+
+type ExerciseSuggestHandler struct {
+	srv *server.Server
+}
+
+// NewExerciseSuggestHandler obsługuje GET /exercises/suggest?q=ben: podpowiedzi nazw
+// ćwiczeń z katalogu (także po aliasach) i z historii treningów do szybkiego wpisywania (typeahead).
+// Kolejność: dopasowanie początku nazwy, potem początku słowa, potem dowolnego fragmentu;
+// w ramach tej samej jakości dopasowania wygrywa częściej używane ćwiczenie.
+func NewExerciseSuggestHandler(srv *server.Server) *ExerciseSuggestHandler {
+	return &ExerciseSuggestHandler{srv: srv}
+}
+
+func (h *ExerciseSuggestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	term := strings.ToLower(strings.TrimSpace(q.Get("q")))
+	limit := queryInt(&errs, q, "limit", defaultSuggestions)
+	if term == "" {
+		errs.add("q", "q is required")
+	}
+	if limit < 1 || limit > maxSuggestions {
+		errs.add("limit", "limit must be between 1 and 50")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	type candidate struct {
+		models.ExerciseSuggestion
+		rank int
+	}
+	// byName indeksuje kandydatów po nazwie i aliasach, więc "OHP" wpisane
+	// w starszych treningach dolicza użycia do "Overhead Press".
+	byName := map[string]*candidate{}
+	var all []*candidate
+	for _, e := range h.srv.Exercises.List(r.Context(), store.ExerciseQuery{}) {
+		rank := matchRank(e.Name, term)
+		for _, a := range e.Aliases {
+			rank = max(rank, matchRank(a, term))
+		}
+		if rank == 0 {
+			continue
+		}
+		c := &candidate{models.ExerciseSuggestion{Name: e.Name, ExerciseID: e.ID, Source: "catalog"}, rank}
+		all = append(all, c)
+		for _, n := range append([]string{e.Name}, e.Aliases...) {
+			byName[strings.ToLower(n)] = c
+		}
+	}
+	// Historia uzupełnia katalog o liczbę użyć i o nazwy spoza katalogu.
+	for _, u := range h.srv.Workouts.ExerciseUsage(r.Context()) {
+		if c, ok := byName[strings.ToLower(u.Name)]; ok {
+			c.Uses += u.Count
+			continue
+		}
+		if rank := matchRank(u.Name, term); rank > 0 {
+			all = append(all, &candidate{models.ExerciseSuggestion{Name: u.Name, ExerciseID: u.ExerciseID, Source: "history", Uses: u.Count}, rank})
+		}
+	}
+
+	slices.SortFunc(all, func(a, b *candidate) int {
+		return cmp.Or(
+			cmp.Compare(b.rank, a.rank),
+			cmp.Compare(b.Uses, a.Uses),
+			cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)),
+		)
+	})
+	out := make([]models.ExerciseSuggestion, 0, min(limit, len(all)))
+	for _, c := range all[:min(limit, len(all))] {
+		out = append(out, c.ExerciseSuggestion)
+	}
+	httpjson.WriteJSON(w, http.StatusOK, out)
+}
+
+// matchRank ocenia dopasowanie nazwy do wpisanego fragmentu (małymi literami):
+// 3 = początek nazwy, 2 = początek słowa, 1 = dowolny fragment, 0 = brak.
+func matchRank(name, term string) int {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasPrefix(name, term):
+		return 3
+	case strings.Contains(" "+name, " "+term) || strings.Contains("-"+name, "-"+term):
+		return 2
+	case strings.Contains(name, term):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+// defaultAdherenceDays to domyślne okno statystyk regularności suplementacji.
+const defaultAdherenceDays = 30
+
+var supplementUnits = []string{"g", "mg", "µg", "ml", "IU", "caps"}
+
+type SupplementsHandler struct {
+	srv *server.Server
+}
+
+// NewSupplementsHandler obsługuje dziennik suplementacji:
+//   - GET /supplements: dawki od najnowszej, opcjonalnie z zakresu ?from=&to=
+//   - POST /supplements: przyjęta dawka (bez daty – dziś)
+func NewSupplementsHandler(srv *server.Server) *SupplementsHandler {
+	return &SupplementsHandler{srv: srv}
+}
+
+func (h *SupplementsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var errs validationErrors
+		q := r.URL.Query()
+		from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+		if from != "" && to != "" && to < from {
+			errs.add("to", "to must not be before from")
+		}
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, h.srv.Supplements.List(r.Context(), from, to))
+
+	case http.MethodPost:
+		var req models.SupplementIntakeRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		e, errs := supplementFromRequest(req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusCreated, h.srv.Supplements.Create(r.Context(), e))
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type SupplementByIDHandler struct {
+	srv *server.Server
+}
+
+// NewSupplementByIDHandler obsługuje GET, PUT (pełna zamiana) i DELETE /supplements/{id}.
+func NewSupplementByIDHandler(srv *server.Server) *SupplementByIDHandler {
+	return &SupplementByIDHandler{srv: srv}
+}
+
+func (h *SupplementByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		e, found := h.srv.Supplements.Get(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Supplement intake not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, e)
+
+	case http.MethodPut:
+		var req models.SupplementIntakeRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		e, errs := supplementFromRequest(req)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		updated, err := h.srv.Supplements.Update(r.Context(), id, e)
+		if err != nil {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Supplement intake not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, updated)
+
+	case http.MethodDelete:
+		if !h.srv.Supplements.Delete(r.Context(), id) {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Supplement intake not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type SupplementAdherenceHandler struct {
+	srv *server.Server
+}
+
+// NewSupplementAdherenceHandler obsługuje GET /supplements/adherence: dla każdego
+// suplementu (nazwy bez względu na wielkość liter) odsetek dni z dawką i bieżącą serię
+// w zakresie ?from=&to= (domyślnie ostatnie 30 dni). Zakres zaczyna się najwcześniej
+// od pierwszej dawki suplementu, żeby nowy suplement nie miał zaniżonej regularności.
+func NewSupplementAdherenceHandler(srv *server.Server) *SupplementAdherenceHandler {
+	return &SupplementAdherenceHandler{srv: srv}
+}
+
+func (h *SupplementAdherenceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+	if to == "" {
+		to = time.Now().Format(dateLayout)
+	}
+	end, _ := time.Parse(dateLayout, to)
+	if from == "" {
+		from = end.AddDate(0, 0, 1-defaultAdherenceDays).Format(dateLayout)
+	}
+	if len(errs) == 0 && to < from {
+		errs.add("to", "to must not be before from")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	type supplement struct {
+		models.SupplementAdherence
+		first string
+		taken map[string]bool
+	}
+	byName := map[string]*supplement{}
+	// Od najstarszej dawki, więc nazwą wyświetlaną jest pisownia z pierwszego wpisu.
+	intakes := h.srv.Supplements.List(r.Context(), "", to)
+	for i := len(intakes) - 1; i >= 0; i-- {
+		e := intakes[i]
+		key := strings.ToLower(e.Name)
+		s, ok := byName[key]
+		if !ok {
+			s = &supplement{SupplementAdherence: models.SupplementAdherence{Name: e.Name}, first: e.Date, taken: map[string]bool{}}
+			byName[key] = s
+		}
+		s.LastTaken = e.Date
+		if e.Date >= from {
+			s.taken[e.Date] = true
+		}
+	}
+
+	out := []models.SupplementAdherence{}
+	for _, s := range byName {
+		if len(s.taken) == 0 {
+			continue
+		}
+		start, _ := time.Parse(dateLayout, max(from, s.first))
+		s.DaysTaken = len(s.taken)
+		s.Days = int(end.Sub(start).Hours()/24) + 1
+		s.Adherence = round1(float64(s.DaysTaken) / float64(s.Days) * 100)
+		for d := end; !d.Before(start) && s.taken[d.Format(dateLayout)]; d = d.AddDate(0, 0, -1) {
+			s.Streak++
+		}
+		out = append(out, s.SupplementAdherence)
+	}
+	slices.SortFunc(out, func(a, b models.SupplementAdherence) int {
+		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	httpjson.WriteJSON(w, http.StatusOK, out)
+}
+
+// supplementFromRequest waliduje dawkę; brak daty oznacza dzisiaj.
+func supplementFromRequest(req models.SupplementIntakeRequest) (models.SupplementIntake, validationErrors) {
+	var errs validationErrors
+	e := models.SupplementIntake{
+		Date: req.Date,
+		Name: strings.TrimSpace(req.Name),
+		Dose: req.Dose,
+		Unit: req.Unit,
+		Note: strings.TrimSpace(req.Note),
+	}
+	if e.Date == "" {
+		e.Date = time.Now().Format(dateLayout)
+	} else if _, err := time.Parse(dateLayout, e.Date); err != nil {
+		errs.add("date", "date must be YYYY-MM-DD")
+	}
+	if e.Name == "" {
+		errs.add("name", "supplement name is required")
+	} else if utf8.RuneCountInString(e.Name) > 100 {
+		errs.add("name", "supplement name must not exceed 100 characters")
+	}
+	if e.Dose < 0 || e.Dose > 100000 {
+		errs.add("dose", "dose must be between 0 and 100000")
+	}
+	if e.Unit != "" && !slices.Contains(supplementUnits, e.Unit) {
+		errs.add("unit", "unit must be one of: g, mg, µg, ml, IU, caps")
+	}
+	if e.Dose > 0 && e.Unit == "" {
+		errs.add("unit", "unit is required when dose is given")
+	}
+	if utf8.RuneCountInString(e.Note) > 500 {
+		errs.add("note", "note must not exceed 500 characters")
+	}
+	return e, errs
+}
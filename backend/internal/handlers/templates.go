@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+type TemplatesHandler struct {
+	srv *server.Server
+}
+
+// NewTemplatesHandler obsługuje szablony treningów:
+//   - GET /templates: szablony użytkownika w kolejności dodania
+//   - POST /templates: nowy szablon (ćwiczenia łączone z katalogiem jak w treningach)
+//
+// Ciężary serii przyjmujemy i zwracamy jak w treningach (weightUnit, patrz Profile).
+func NewTemplatesHandler(srv *server.Server) *TemplatesHandler {
+	return &TemplatesHandler{srv: srv}
+}
+
+func (h *TemplatesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		unit := weightUnit(r.Context(), h.srv)
+		ts := h.srv.Templates.List(r.Context())
+		for i := range ts {
+			ts[i] = templateInUnit(ts[i], unit)
+		}
+		httpjson.WriteJSON(w, http.StatusOK, ts)
+
+	case http.MethodPost:
+		var req models.TemplateRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		t, errs := templateFromRequest(r.Context(), h.srv, req)
+		if errs = append(errs, checkTemplate(r.Context(), h.srv, t)...); len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		created := h.srv.Templates.Create(r.Context(), t)
+		httpjson.WriteJSON(w, http.StatusCreated, templateInUnit(created, weightUnit(r.Context(), h.srv)))
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type TemplateByIDHandler struct {
+	srv *server.Server
+}
+
+// NewTemplateByIDHandler obsługuje GET, PUT (pełna zamiana) i DELETE /templates/{id}.
+func NewTemplateByIDHandler(srv *server.Server) *TemplateByIDHandler {
+	return &TemplateByIDHandler{srv: srv}
+}
+
+func (h *TemplateByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		t, found := h.srv.Templates.Get(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Template not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, templateInUnit(t, weightUnit(r.Context(), h.srv)))
+
+	case http.MethodPut:
+		var req models.TemplateRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		t, errs := templateFromRequest(r.Context(), h.srv, req)
+		if errs = append(errs, checkTemplate(r.Context(), h.srv, t)...); len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		updated, err := h.srv.Templates.Update(r.Context(), id, t)
+		if err != nil {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Template not found")
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, templateInUnit(updated, weightUnit(r.Context(), h.srv)))
+
+	case http.MethodDelete:
+		if !h.srv.Templates.Delete(r.Context(), id) {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Template not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type TemplateStartHandler struct {
+	srv *server.Server
+}
+
+// NewTemplateStartHandler obsługuje POST /templates/{id}/start: tworzy trening na dziś
+// (lub ?date=) z ćwiczeniami i seriami szablonu, gotowy do uzupełniania na siłowni.
+// Serie procentowe (percentOfTM) dostają ciężar z bieżącego TM, a pozostałe serie
+// bez ciężaru – ciężar z ostatniego treningu z tym ćwiczeniem.
+func NewTemplateStartHandler(srv *server.Server) *TemplateStartHandler {
+	return &TemplateStartHandler{srv: srv}
+}
+
+func (h *TemplateStartHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	var errs validationErrors
+	date := queryDate(&errs, r.URL.Query(), "date")
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+	if date == "" {
+		date = time.Now().Format(dateLayout)
+	}
+
+	t, found := h.srv.Templates.Get(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Template not found")
+		return
+	}
+
+	wk := models.Workout{
+		Title:     t.Name,
+		Date:      date,
+		Notes:     t.Notes,
+		Exercises: cloneExercises(t.Exercises, false),
+	}
+	resolvePercentOfTM(r.Context(), h.srv.TrainingMaxes, wk.Exercises, true)
+	for i := range wk.Exercises {
+		h.fillLastWeights(r.Context(), &wk.Exercises[i])
+	}
+	resolveBodyweight(r.Context(), h.srv.Bodyweight, wk.Date, wk.Exercises)
+	writeWorkout(w, r, h.srv, http.StatusCreated, h.srv.Workouts.Create(r.Context(), wk))
+}
+
+// fillLastWeights uzupełnia brakujące ciężary serią o tym samym numerze z ostatniego
+// treningu; gdy wtedy serii było mniej, bierze ciężar ostatniej z nich.
+func (h *TemplateStartHandler) fillLastWeights(ctx context.Context, ex *models.Exercise) {
+	last, _, ok := h.srv.Workouts.LastExercise(ctx, ex.ExerciseID, ex.Name)
+	if !ok || len(last.Sets) == 0 {
+		return
+	}
+	for i := range ex.Sets {
+		if ex.Sets[i].Weight != nil {
+			continue
+		}
+		prev := last.Sets[min(i, len(last.Sets)-1)]
+		if prev.Weight != nil {
+			weight := *prev.Weight
+			ex.Sets[i].Weight = &weight
+		}
+	}
+}
+
+// templateFromRequest przycina białe znaki w polach szablonu i zamienia ciężary serii na kg.
+func templateFromRequest(ctx context.Context, srv *server.Server, req models.TemplateRequest) (models.WorkoutTemplate, validationErrors) {
+	var errs validationErrors
+	exercisesToKg(req.Exercises, inputUnit(ctx, srv, &errs, req.WeightUnit))
+	return models.WorkoutTemplate{
+		Name:      strings.TrimSpace(req.Name),
+		Notes:     strings.TrimSpace(req.Notes),
+		Exercises: req.Exercises,
+	}, errs
+}
+
+// checkTemplate łączy ćwiczenia szablonu z katalogiem i waliduje szablon.
+func checkTemplate(ctx context.Context, srv *server.Server, t models.WorkoutTemplate) validationErrors {
+	var errs validationErrors
+	orderExercises(t.Exercises)
+	linkCatalog(ctx, srv.Exercises, t.Exercises, &errs)
+	normalizeIntervals(t.Exercises)
+	trimNotes(t.Exercises)
+	if t.Name == "" {
+		errs.add("name", "template name is required")
+	}
+	validateExercises(&errs, t.Exercises)
+	return errs
+}
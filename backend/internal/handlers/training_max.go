@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/progression"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+// Źródła wpisów TM.
+const (
+	tmSourceSet        = "set"
+	tmSourceAdjust     = "adjust"
+	tmSourceSuggestion = "suggestion"
+)
+
+type TrainingMaxesHandler struct {
+	srv *server.Server
+}
+
+// NewTrainingMaxesHandler obsługuje GET /training-maxes: bieżące maksy treningowe
+// użytkownika, po jednym na ćwiczenie, w jednostce z profilu.
+func NewTrainingMaxesHandler(srv *server.Server) *TrainingMaxesHandler {
+	return &TrainingMaxesHandler{srv: srv}
+}
+
+func (h *TrainingMaxesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	tms := h.srv.TrainingMaxes.Current(r.Context())
+	httpjson.WriteJSON(w, http.StatusOK, trainingMaxesInUnit(tms, weightUnit(r.Context(), h.srv)))
+}
+
+type ExerciseTrainingMaxHandler struct {
+	srv *server.Server
+}
+
+// NewExerciseTrainingMaxHandler obsługuje maks treningowy ćwiczenia z katalogu:
+//   - GET /exercises/{id}/training-max: bieżący TM, historia i propozycja z ostatnich serii
+//   - PUT /exercises/{id}/training-max: nowy TM (poprzednie zostają w historii)
+//
+// Ciężary przyjmujemy i zwracamy jak w treningach (weightUnit, patrz Profile).
+func NewExerciseTrainingMaxHandler(srv *server.Server) *ExerciseTrainingMaxHandler {
+	return &ExerciseTrainingMaxHandler{srv: srv}
+}
+
+func (h *ExerciseTrainingMaxHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	ex, found := h.srv.Exercises.Get(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Exercise not found")
+		return
+	}
+
+	unit := weightUnit(r.Context(), h.srv)
+	switch r.Method {
+	case http.MethodGet:
+		history := trainingMaxesInUnit(h.srv.TrainingMaxes.History(r.Context(), id), unit)
+		detail := models.TrainingMaxDetail{ExerciseID: id, History: history, WeightUnit: unit}
+		if len(detail.History) > 0 {
+			detail.Current = &detail.History[0]
+		}
+		var sessions []progression.Session
+		for _, s := range h.srv.Workouts.ExerciseHistory(r.Context(), id, ex.Name) {
+			sessions = append(sessions, progression.Session{Date: s.Date, Sets: s.Sets})
+		}
+		if tm, e1rm, best, ok := progression.TrainingMaxFromHistory(sessions, roundingIn(unit)); ok {
+			detail.Suggestion = &models.TrainingMaxSuggestion{
+				Weight:    kgTo(tm, unit, true),
+				E1RM:      kgTo(round1(e1rm), unit, true),
+				Date:      best.Date,
+				SetWeight: kgTo(*best.Sets[0].Weight, unit, true),
+				SetReps:   best.Sets[0].Reps,
+			}
+		}
+		httpjson.WriteJSON(w, http.StatusOK, detail)
+
+	case http.MethodPut:
+		var req models.TrainingMaxRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		var errs validationErrors
+		tm := models.TrainingMax{
+			ExerciseID: id,
+			Name:       ex.Name,
+			Weight:     kgFrom(req.Weight, inputUnit(r.Context(), h.srv, &errs, req.WeightUnit)),
+			Date:       strings.TrimSpace(req.Date),
+			Source:     strings.TrimSpace(req.Source),
+			Note:       strings.TrimSpace(req.Note),
+		}
+		if tm.Date == "" {
+			tm.Date = time.Now().Format(dateLayout)
+		}
+		if tm.Source == "" {
+			tm.Source = tmSourceSet
+		}
+		if tm.Weight <= 0 {
+			errs.add("weight", "training max must be > 0")
+		}
+		if _, err := time.Parse(dateLayout, tm.Date); err != nil {
+			errs.add("date", "date must be YYYY-MM-DD")
+		}
+		if tm.Source != tmSourceSet && tm.Source != tmSourceSuggestion {
+			errs.add("source", "source must be one of: set, suggestion")
+		}
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusCreated, trainingMaxInUnit(h.srv.TrainingMaxes.Add(r.Context(), tm), unit))
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type TrainingMaxAdjustHandler struct {
+	srv *server.Server
+}
+
+// NewTrainingMaxAdjustHandler obsługuje POST /exercises/{id}/training-max/adjust: korektę
+// bieżącego TM o ciężar (delta, w jednostce weightUnit) albo procent (percent), np. +2,5 kg
+// po cyklu 5/3/1 albo -10% po nieudanym cyklu. Wynik zaokrąglamy w dół do 2,5 kg, a w lb
+// do 5 lb, żeby dało się go złożyć z talerzy.
+func NewTrainingMaxAdjustHandler(srv *server.Server) *TrainingMaxAdjustHandler {
+	return &TrainingMaxAdjustHandler{srv: srv}
+}
+
+func (h *TrainingMaxAdjustHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	var req models.TrainingMaxAdjustRequest
+	if err := httpjson.ReadJSON(w, r, &req); err != nil {
+		httpjson.WriteReadError(w, r, err)
+		return
+	}
+	var errs validationErrors
+	unit := inputUnit(r.Context(), h.srv, &errs, req.WeightUnit)
+	if (req.Delta == nil) == (req.Percent == nil) {
+		errs.add("delta", "exactly one of delta and percent is required")
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	cur, found := h.srv.TrainingMaxes.Latest(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Training max not found")
+		return
+	}
+	weight := kgTo(cur.Weight, unit, false)
+	if req.Delta != nil {
+		weight += *req.Delta
+	} else {
+		weight *= 1 + *req.Percent/100
+	}
+	weight = kgFrom(roundWeightIn(weight, unit), unit)
+	if weight <= 0 {
+		errs.add("delta", "training max must be > 0")
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+	// Nazwa z bieżącego wpisu: ćwiczenie mogło zniknąć z katalogu, a historia TM zostaje.
+	adjusted := h.srv.TrainingMaxes.Add(r.Context(), models.TrainingMax{
+		ExerciseID: id,
+		Name:       cur.Name,
+		Weight:     weight,
+		Date:       max(time.Now().Format(dateLayout), cur.Date),
+		Source:     tmSourceAdjust,
+		Note:       strings.TrimSpace(req.Note),
+	})
+	httpjson.WriteJSON(w, http.StatusCreated, trainingMaxInUnit(adjusted, weightUnit(r.Context(), h.srv)))
+}
+
+// resolvePercentOfTM wylicza ciężar serii z percentOfTM według bieżącego TM ćwiczenia
+// (zaokrąglając w dół do 2,5 kg). Bez overwrite uzupełnia tylko serie bez ciężaru, więc
+// ciężar wpisany przy logowaniu treningu zostaje; overwrite przelicza też zapisane ciężary,
+// gdy trening powstaje z rozpiski (szablon, program), żeby uwzględnić zmiany TM.
+// Ćwiczenia spoza katalogu i bez ustawionego TM zostają bez zmian.
+func resolvePercentOfTM(ctx context.Context, tms *store.TrainingMaxStore, exs []models.Exercise, overwrite bool) {
+	for i := range exs {
+		ex := &exs[i]
+		if ex.ExerciseID == 0 {
+			continue
+		}
+		var tm *models.TrainingMax
+		for j := range ex.Sets {
+			set := &ex.Sets[j]
+			if set.PercentOfTM == nil || !validPercentOfTM(*set.PercentOfTM) || (set.Weight != nil && !overwrite) {
+				continue
+			}
+			if tm == nil {
+				cur, ok := tms.Latest(ctx, ex.ExerciseID)
+				if !ok {
+					break
+				}
+				tm = &cur
+			}
+			weight := roundWeight(tm.Weight * *set.PercentOfTM / 100)
+			set.Weight = &weight
+		}
+	}
+}
+
+// roundWeight zaokrągla ciężar w dół do wielokrotności defaultRounding.
+func roundWeight(kg float64) float64 {
+	return math.Floor(kg/defaultRounding+1e-9) * defaultRounding
+}
+
+// lbRounding to najmniejszy skok ciężaru w lb (para talerzy 2,5 lb).
+const lbRounding = 5
+
+// roundingIn zwraca w kg krok zaokrąglenia ciężaru w jednostce unit: 2,5 kg albo 5 lb.
+func roundingIn(unit string) float64 {
+	if unit == models.UnitLb {
+		return lbRounding * models.KgPerLb
+	}
+	return defaultRounding
+}
+
+// roundWeightIn zaokrągla ciężar w jednostce unit w dół: do 2,5 kg albo do 5 lb.
+func roundWeightIn(v float64, unit string) float64 {
+	if unit == models.UnitLb {
+		return math.Floor(v/lbRounding+1e-9) * lbRounding
+	}
+	return roundWeight(v)
+}
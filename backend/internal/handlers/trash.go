@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/server"
+)
+
+type TrashHandler struct {
+	srv *server.Server
+}
+
+// NewTrashHandler obsługuje GET /workouts/trash: listę usuniętych treningów,
+// które można jeszcze przywrócić (do czasu wygaśnięcia retencji kosza).
+func NewTrashHandler(srv *server.Server) *TrashHandler {
+	return &TrashHandler{srv: srv}
+}
+
+func (h *TrashHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, workoutsInUnit(r.Context(), h.srv, h.srv.Workouts.Trash(r.Context())))
+}
+
+type RestoreHandler struct {
+	srv *server.Server
+}
+
+// NewRestoreHandler obsługuje POST /workouts/{id}/restore: przywraca trening z kosza.
+func NewRestoreHandler(srv *server.Server) *RestoreHandler {
+	return &RestoreHandler{srv: srv}
+}
+
+func (h *RestoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	restored, found := h.srv.Workouts.Restore(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "workout is not in the trash")
+		return
+	}
+	writeWorkout(w, r, h.srv, http.StatusOK, refreshWorkoutBodyweight(r.Context(), h.srv, restored))
+}
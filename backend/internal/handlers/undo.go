@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+type UndoHandler struct {
+	srv *server.Server
+}
+
+// NewUndoHandler obsługuje POST /workouts/{id}/undo: cofa ostatnią zmianę treningu
+// na podstawie historii wersji (szybka poprawka po pomyłce w aplikacji mobilnej).
+// Z If-Match cofamy tylko, jeśli klient widzi bieżącą wersję.
+func NewUndoHandler(srv *server.Server) *UndoHandler {
+	return &UndoHandler{srv: srv}
+}
+
+func (h *UndoHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	cur, found := h.srv.Workouts.Get(r.Context(), id)
+	if !found {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Workout not found")
+		return
+	}
+	version, ok := expectedVersion(w, r, cur, nil)
+	if !ok {
+		return
+	}
+
+	undone, err := h.srv.Workouts.Undo(r.Context(), id, version)
+	switch {
+	case err == nil:
+		writeWorkout(w, r, h.srv, http.StatusOK, refreshWorkoutBodyweight(r.Context(), h.srv, undone))
+	case errors.Is(err, store.ErrVersionConflict):
+		writeVersionConflict(w, r, undone)
+	case errors.Is(err, store.ErrNothingToUndo):
+		httpjson.WriteError(w, r, http.StatusConflict, "workout has no earlier version to undo")
+	default:
+		httpjson.WriteError(w, r, http.StatusNotFound, "Workout not found")
+	}
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"context"
+	"math"
+
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+// weightUnit zwraca jednostkę ciężaru z profilu użytkownika.
+func weightUnit(ctx context.Context, srv *server.Server) string {
+	return srv.Profiles.Get(ctx).WeightUnit
+}
+
+// inputUnit ustala jednostkę ciężarów w body żądania: pole weightUnit, a bez niego
+// jednostkę z profilu. Nieznana jednostka to błąd pola (ciężary traktujemy wtedy jak kg).
+func inputUnit(ctx context.Context, srv *server.Server, errs *validationErrors, requested string) string {
+	switch requested {
+	case "":
+		return weightUnit(ctx, srv)
+	case models.UnitKg, models.UnitLb:
+		return requested
+	}
+	errs.add("weightUnit", "weightUnit must be kg or lb")
+	return models.UnitKg
+}
+
+// patchUnit zdejmuje z łatki pole weightUnit i zwraca jednostkę jej ciężarów (bez niego –
+// z profilu). Dokument, na który nakładamy łatkę, budujemy w tej jednostce, więc sama
+// zmiana weightUnit nie przeinacza zapisanych ciężarów.
+func patchUnit(ctx context.Context, srv *server.Server, errs *validationErrors, patch map[string]any) string {
+	v := patch["weightUnit"]
+	delete(patch, "weightUnit")
+	unit, isString := v.(string)
+	if v != nil && !isString {
+		errs.add("weightUnit", "weightUnit must be kg or lb")
+		return models.UnitKg
+	}
+	return inputUnit(ctx, srv, errs, unit)
+}
+
+// roundKg zaokrągla ciężar do grama – na tyle dokładnie, że ciężar podany w lb
+// z dokładnością do 0,1 wraca po przeliczeniu do tej samej wartości.
+func roundKg(kg float64) float64 {
+	return math.Round(kg*1000) / 1000
+}
+
+// kgFrom zamienia ciężar w jednostce unit na kg.
+func kgFrom(v float64, unit string) float64 {
+	if unit == models.UnitLb {
+		v *= models.KgPerLb
+	}
+	return roundKg(v)
+}
+
+// kgTo zamienia ciężar w kg na jednostkę unit. Z round wynik w lb zaokrąglamy do 0,1
+// (odpowiedzi); bez niego zostaje dokładny, żeby niezmienione ciężary wróciły do kg
+// bez straty (dokument, na który PATCH nakłada łatkę).
+func kgTo(kg float64, unit string, round bool) float64 {
+	if unit != models.UnitLb {
+		return kg
+	}
+	if round {
+		return round1(kg / models.KgPerLb)
+	}
+	return kg / models.KgPerLb
+}
+
+// exercisesToKg zamienia ciężary serii (ciężar, dociążenie, opór gumy) podane w unit na kg.
+func exercisesToKg(exs []models.Exercise, unit string) {
+	convertSets(exs, func(v float64) float64 { return kgFrom(v, unit) })
+}
+
+// convertSets przelicza ciężary serii funkcją f, ustawiając nowe wskaźniki, żeby
+// nie zmienić wartości współdzielonych z innym treningiem.
+func convertSets(exs []models.Exercise, f func(float64) float64) {
+	conv := func(p *float64) *float64 {
+		if p == nil {
+			return nil
+		}
+		v := f(*p)
+		return &v
+	}
+	for i := range exs {
+		for j := range exs[i].Sets {
+			set := &exs[i].Sets[j]
+			set.Weight = conv(set.Weight)
+			set.AddedWeight = conv(set.AddedWeight)
+			if set.Band != nil {
+				band := *set.Band
+				band.Resistance = f(band.Resistance)
+				set.Band = &band
+			}
+		}
+	}
+}
+
+// workoutInUnit zwraca kopię treningu z ciężarami w jednostce unit (patrz kgTo).
+// Zapisany trening zostaje bez zmian.
+func workoutInUnit(wk models.Workout, unit string, round bool) models.Workout {
+	wk.WeightUnit = unit
+	if unit != models.UnitLb || wk.Exercises == nil {
+		return wk
+	}
+	exs := make([]models.Exercise, len(wk.Exercises))
+	for i, ex := range wk.Exercises {
+		if ex.Sets != nil {
+			ex.Sets = append([]models.Set{}, ex.Sets...)
+		}
+		exs[i] = ex
+	}
+	convertSets(exs, func(kg float64) float64 { return kgTo(kg, unit, round) })
+	wk.Exercises = exs
+	return wk
+}
+
+// workoutsInUnit przelicza listę treningów do jednostki z profilu użytkownika.
+func workoutsInUnit(ctx context.Context, srv *server.Server, ws []models.Workout) []models.Workout {
+	unit := weightUnit(ctx, srv)
+	out := make([]models.Workout, len(ws))
+	for i, wk := range ws {
+		out[i] = workoutInUnit(wk, unit, true)
+	}
+	return out
+}
+
+// bodyweightInUnit zwraca pomiar masy ciała w jednostce z profilu użytkownika.
+func bodyweightInUnit(ctx context.Context, srv *server.Server, e models.BodyweightEntry) models.BodyweightEntry {
+	e.WeightUnit = weightUnit(ctx, srv)
+	e.Weight = kgTo(e.Weight, e.WeightUnit, true)
+	return e
+}
+
+// setsInUnit zwraca kopię serii z ciężarami w jednostce unit (zaokrąglonymi jak w odpowiedziach).
+func setsInUnit(sets []models.Set, unit string) []models.Set {
+	if unit != models.UnitLb || sets == nil {
+		return sets
+	}
+	exs := []models.Exercise{{Sets: append([]models.Set{}, sets...)}}
+	convertSets(exs, func(kg float64) float64 { return kgTo(kg, unit, true) })
+	return exs[0].Sets
+}
+
+// recordEntryInUnit zwraca kopię rekordu z ciężarem w jednostce unit; z weightValue
+// przelicza też Value (ciężar, e1RM, tonaż), a bez niego Value to liczba powtórzeń.
+func recordEntryInUnit(e *models.RecordEntry, unit string, weightValue bool) *models.RecordEntry {
+	if e == nil {
+		return nil
+	}
+	out := *e
+	out.Weight = kgTo(out.Weight, unit, true)
+	if weightValue {
+		out.Value = kgTo(out.Value, unit, true)
+	}
+	return &out
+}
+
+// recordsInUnit zwraca rekordy osobiste z ciężarami w jednostce unit.
+func recordsInUnit(recs []models.ExerciseRecords, unit string) []models.ExerciseRecords {
+	out := make([]models.ExerciseRecords, len(recs))
+	for i, rec := range recs {
+		rec.WeightUnit = unit
+		rec.HeaviestWeight = recordEntryInUnit(rec.HeaviestWeight, unit, true)
+		rec.BestE1RM = recordEntryInUnit(rec.BestE1RM, unit, true)
+		rec.BestVolume = recordEntryInUnit(rec.BestVolume, unit, true)
+		reps := make([]models.RecordEntry, len(rec.RepsAtWeight))
+		for j := range rec.RepsAtWeight {
+			reps[j] = *recordEntryInUnit(&rec.RepsAtWeight[j], unit, false)
+		}
+		rec.RepsAtWeight = reps
+		out[i] = rec
+	}
+	return out
+}
+
+// newRecordsInUnit przelicza rekordy pobite w treningu do jednostki unit.
+func newRecordsInUnit(recs []models.NewRecord, unit string) []models.NewRecord {
+	out := make([]models.NewRecord, len(recs))
+	for i, rec := range recs {
+		rec.Weight = kgTo(rec.Weight, unit, true)
+		if rec.Type != models.RecordRepsAtWeight {
+			rec.Value = kgTo(rec.Value, unit, true)
+			rec.Previous = kgTo(rec.Previous, unit, true)
+		}
+		out[i] = rec
+	}
+	return out
+}
+
+// trainingMaxInUnit zwraca wpis TM z ciężarem w jednostce unit.
+func trainingMaxInUnit(tm models.TrainingMax, unit string) models.TrainingMax {
+	tm.WeightUnit = unit
+	tm.Weight = kgTo(tm.Weight, unit, true)
+	return tm
+}
+
+// trainingMaxesInUnit przelicza listę wpisów TM do jednostki unit.
+func trainingMaxesInUnit(tms []models.TrainingMax, unit string) []models.TrainingMax {
+	out := make([]models.TrainingMax, len(tms))
+	for i, tm := range tms {
+		out[i] = trainingMaxInUnit(tm, unit)
+	}
+	return out
+}
+
+// templateInUnit zwraca kopię szablonu z ciężarami serii w jednostce unit.
+func templateInUnit(t models.WorkoutTemplate, unit string) models.WorkoutTemplate {
+	wk := workoutInUnit(models.Workout{Exercises: t.Exercises}, unit, true)
+	t.WeightUnit, t.Exercises = unit, wk.Exercises
+	return t
+}
+
+// goalInUnit zwraca cel z ciężarem docelowym i postępem (cel lift) w jednostce unit.
+// Procent i status liczymy wcześniej, w kg.
+func goalInUnit(g models.Goal, unit string) models.Goal {
+	if g.Type != models.GoalLift {
+		return g
+	}
+	g.WeightUnit = unit
+	g.TargetWeight = kgTo(g.TargetWeight, unit, true)
+	g.Current = kgTo(g.Current, unit, true)
+	g.Target = kgTo(g.Target, unit, true)
+	return g
+}
@@ -0,0 +1,617 @@
+package handlers
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+// dateLayout to format dat w API (YYYY-MM-DD).
+const dateLayout = "2006-01-02"
+
+// validationErrors zbiera wszystkie błędy walidacji zamiast zatrzymywać się na pierwszym.
+type validationErrors []models.FieldError
+
+func (v *validationErrors) add(field, msg string) {
+	*v = append(*v, models.FieldError{Field: field, Message: msg})
+}
+
+// addf dodaje błąd z komunikatem z argumentami; kluczem tłumaczenia jest sam format.
+func (v *validationErrors) addf(field, format string, args ...any) {
+	*v = append(*v, models.FieldError{Field: field, Message: format, Args: args})
+}
+
+// queryInt czyta liczbowy parametr zapytania; brak parametru daje def,
+// a niepoprawna wartość dopisuje błąd do errs.
+func queryInt(errs *validationErrors, q url.Values, name string, def int) int {
+	v := q.Get(name)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		errs.add(name, "must be an integer")
+		return def
+	}
+	return n
+}
+
+// queryFloat czyta parametr liczbowy (np. 2.5); brak parametru daje def.
+func queryFloat(errs *validationErrors, q url.Values, name string, def float64) float64 {
+	v := strings.TrimSpace(q.Get(name))
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		errs.add(name, "must be a number")
+		return def
+	}
+	return f
+}
+
+// queryBool czyta parametr logiczny (true/false/1/0); brak parametru daje false.
+func queryBool(errs *validationErrors, q url.Values, name string) bool {
+	v := strings.TrimSpace(q.Get(name))
+	if v == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		errs.add(name, "must be true or false")
+	}
+	return b
+}
+
+// queryDate czyta parametr zapytania w formacie YYYY-MM-DD; brak parametru daje "".
+func queryDate(errs *validationErrors, q url.Values, name string) string {
+	v := strings.TrimSpace(q.Get(name))
+	if v == "" {
+		return ""
+	}
+	if _, err := time.Parse(dateLayout, v); err != nil {
+		errs.add(name, "date must be YYYY-MM-DD")
+		return ""
+	}
+	return v
+}
+
+// queryLocation czyta strefę czasową IANA (np. Europe/Warsaw); brak parametru daje nil.
+func queryLocation(errs *validationErrors, q url.Values, name string) *time.Location {
+	v := strings.TrimSpace(q.Get(name))
+	if v == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(v)
+	if err != nil {
+		errs.add(name, "time zone must be an IANA name (e.g. Europe/Warsaw)")
+		return nil
+	}
+	return loc
+}
+
+// parseSort zamienia parametr sort (np. "date,-createdAt") na kryteria sortowania.
+// Pusty parametr oznacza domyślną kolejność magazynu.
+func parseSort(errs *validationErrors, v string) []store.SortKey {
+	if v == "" {
+		return nil
+	}
+	var keys []store.SortKey
+	for _, part := range strings.Split(v, ",") {
+		part = strings.TrimSpace(part)
+		field, desc := strings.CutPrefix(part, "-")
+		if !slices.Contains(store.SortFields, field) {
+			errs.add("sort", "sort fields must be one of: id, date, title, createdAt, updatedAt")
+			return nil
+		}
+		keys = append(keys, store.SortKey{Field: field, Desc: desc})
+	}
+	return keys
+}
+
+// checkWorkout łączy ćwiczenia treningu z katalogiem i uzupełnia ciężary serii
+// procentowych (zmieniając elementy wk.Exercises w miejscu), po czym waliduje trening.
+// Zwraca błędy wszystkich pól.
+func checkWorkout(ctx context.Context, srv *server.Server, wk models.Workout) validationErrors {
+	var errs validationErrors
+	orderExercises(wk.Exercises)
+	linkCatalog(ctx, srv.Exercises, wk.Exercises, &errs)
+	resolvePercentOfTM(ctx, srv.TrainingMaxes, wk.Exercises, false)
+	resolveBodyweight(ctx, srv.Bodyweight, wk.Date, wk.Exercises)
+	normalizeCardio(wk.Exercises)
+	normalizeIntervals(wk.Exercises)
+	trimNotes(wk.Exercises)
+	return append(errs, validateWorkout(wk)...)
+}
+
+// orderExercises układa ćwiczenia według pola order, jeśli klient podał je przy każdym
+// (inaczej decyduje kolejność na liście), i numeruje pozycje od 1. Indeksy w błędach
+// walidacji odnoszą się już do ułożonej listy.
+func orderExercises(exs []models.Exercise) {
+	ordered := len(exs) > 0
+	for _, ex := range exs {
+		if ex.Order <= 0 {
+			ordered = false
+		}
+	}
+	if ordered {
+		slices.SortStableFunc(exs, func(a, b models.Exercise) int { return a.Order - b.Order })
+	}
+	for i := range exs {
+		exs[i].Order = i + 1
+	}
+}
+
+// normalizeCardio uzupełnia średnie tempo ćwiczeń kardio z czasem i dystansem,
+// a brak serii zamienia na pustą listę (w JSON [] zamiast null).
+func normalizeCardio(exs []models.Exercise) {
+	for i, ex := range exs {
+		if ex.Type == models.ExerciseCardio && ex.Sets == nil {
+			exs[i].Sets = []models.Set{}
+		}
+		if c := ex.Cardio; c != nil && c.AvgPace == nil && c.Distance != nil && *c.Distance > 0 && c.Duration > 0 {
+			pace := math.Round(float64(c.Duration) / (*c.Distance / 1000))
+			c.AvgPace = &pace
+		}
+	}
+}
+
+// normalizeIntervals uzupełnia bloki interwałowe: domyślne czasy Tabaty, przerwę EMOM
+// do pełnej minuty i łączny czas bloku; brak serii zamienia na pustą listę.
+func normalizeIntervals(exs []models.Exercise) {
+	for i, ex := range exs {
+		if ex.Type == models.ExerciseInterval && ex.Sets == nil {
+			exs[i].Sets = []models.Set{}
+		}
+		iv := ex.Interval
+		if iv == nil {
+			continue
+		}
+		switch iv.Format {
+		case models.IntervalTabata:
+			if iv.Work == 0 && iv.Rest == 0 {
+				iv.Work, iv.Rest = 20, 10
+			}
+			if iv.Rounds == 0 {
+				iv.Rounds = 8
+			}
+		case models.IntervalEMOM:
+			if iv.Rest == 0 && iv.Work > 0 && iv.Work < 60 {
+				iv.Rest = 60 - iv.Work
+			}
+		}
+		iv.TotalDuration = iv.Rounds * (iv.Work + iv.Rest)
+	}
+}
+
+// trimNotes przycina białe znaki w notatkach ćwiczeń i serii.
+func trimNotes(exs []models.Exercise) {
+	for i := range exs {
+		exs[i].Notes = strings.TrimSpace(exs[i].Notes)
+		for j := range exs[i].Sets {
+			exs[i].Sets[j].Notes = strings.TrimSpace(exs[i].Sets[j].Notes)
+		}
+	}
+}
+
+// linkCatalog przy podanym exerciseId ustawia kanoniczną nazwę z katalogu, a ćwiczeniom
+// z samą nazwą dopisuje ID, jeśli katalog zna taką nazwę. Nieznane ID to błąd pola.
+// Ćwiczenie bez rodzaju dostaje cardio, jeśli takie jest w katalogu.
+func linkCatalog(ctx context.Context, catalog *store.ExerciseStore, exs []models.Exercise, errs *validationErrors) {
+	for i := range exs {
+		ex := &exs[i]
+		var (
+			ce models.CatalogExercise
+			ok bool
+		)
+		if ex.ExerciseID != 0 {
+			if ce, ok = catalog.Get(ctx, ex.ExerciseID); !ok {
+				errs.add("exercises["+strconv.Itoa(i)+"].exerciseId", "exercise not found in catalog")
+				continue
+			}
+		} else if ce, ok = catalog.FindByName(ctx, ex.Name); !ok {
+			continue
+		}
+		ex.ExerciseID, ex.Name = ce.ID, ce.Name
+		if ex.Type == "" && ce.Type == models.ExerciseCardio {
+			ex.Type = models.ExerciseCardio
+		}
+	}
+}
+
+// validateWorkout sprawdza trening (po przycięciu spacji) i zwraca błędy wszystkich pól.
+func validateWorkout(wk models.Workout) validationErrors {
+	var errs validationErrors
+	if wk.Title == "" {
+		errs.add("title", "title is required")
+	}
+	if wk.Date == "" {
+		errs.add("date", "date is required (YYYY-MM-DD)")
+	} else if _, err := time.Parse(dateLayout, wk.Date); err != nil {
+		errs.add("date", "date must be YYYY-MM-DD")
+	}
+	validateExercises(&errs, wk.Exercises)
+	return errs
+}
+
+func validateExercises(errs *validationErrors, exercises []models.Exercise) {
+	// dozwalamy pustą listę, ale w praktyce trening zwykle ma ćwiczenia
+	// jak chcesz wymusić minimum 1 ćwiczenie, odkomentuj:
+	// if len(exercises) == 0 { errs.add("exercises", "exercises must have at least 1 exercise") }
+	validateGroups(errs, exercises)
+
+	for i, ex := range exercises {
+		field := "exercises[" + strconv.Itoa(i) + "]"
+		if strings.TrimSpace(ex.Name) == "" {
+			errs.add(field+".name", "exercise name is required")
+		}
+		if utf8.RuneCountInString(ex.Notes) > maxExerciseNotesLen {
+			errs.add(field+".notes", "exercise notes must not exceed 1000 characters")
+		}
+		switch ex.Type {
+		case "", models.ExerciseStrength:
+			if len(ex.Sets) == 0 {
+				errs.add(field+".sets", "exercise must have at least 1 set")
+			}
+			if ex.Cardio != nil {
+				errs.add(field+".cardio", "cardio is only allowed for cardio exercises")
+			}
+			if ex.Interval != nil {
+				errs.add(field+".interval", "interval is only allowed for interval exercises")
+			}
+		case models.ExerciseCardio:
+			validateCardio(errs, field, ex)
+			continue
+		case models.ExerciseInterval:
+			validateInterval(errs, field, ex)
+			continue
+		default:
+			errs.add(field+".type", "type must be one of: strength, cardio, interval")
+			continue
+		}
+		for si, set := range ex.Sets {
+			setField := field + ".sets[" + strconv.Itoa(si) + "]"
+			if set.Type != "" && !slices.Contains(models.SetTypes, set.Type) {
+				errs.add(setField+".type", "type must be one of: warmup, working, dropset, failure, amrap, backoff")
+			}
+			switch {
+			case set.Reps < 0:
+				errs.add(setField+".reps", "reps must be >= 0")
+			case set.DurationSeconds < 0 || set.DurationSeconds > maxSetDuration:
+				errs.add(setField+".durationSeconds", "durationSeconds must be between 0 and 3600")
+			case set.Reps == 0 && set.DurationSeconds == 0:
+				errs.add(setField+".reps", "set must have reps > 0 or durationSeconds > 0")
+			}
+			switch {
+			case set.Weight == nil || *set.Weight >= 0:
+			case set.Bodyweight:
+				// Ciężar serii z masą ciała wylicza API, więc wskazujemy źródło problemu.
+				errs.add(setField+".addedWeight", "assistance must not exceed bodyweight")
+			default:
+				errs.add(setField+".weight", "weight must be >= 0")
+			}
+			if set.RPE != nil && !validRPE(*set.RPE) {
+				errs.add(setField+".rpe", "rpe must be between 0 and 10 in steps of 0.5")
+			}
+			if set.PlannedRestSeconds != nil && (*set.PlannedRestSeconds < 0 || *set.PlannedRestSeconds > maxRestSeconds) {
+				errs.add(setField+".plannedRestSeconds", "plannedRestSeconds must be between 0 and 3600")
+			}
+			if set.RestSeconds != nil && (*set.RestSeconds < 0 || *set.RestSeconds > maxRestSeconds) {
+				errs.add(setField+".restSeconds", "restSeconds must be between 0 and 3600")
+			}
+			if utf8.RuneCountInString(set.Notes) > maxSetNotesLen {
+				errs.add(setField+".notes", "set notes must not exceed 200 characters")
+			}
+			if set.Tempo != "" && !validTempo(set.Tempo) {
+				errs.add(setField+".tempo", "tempo must be 4 phases like 3-1-1-0 (seconds 0-99 or X)")
+			}
+			switch {
+			case set.RIR == nil:
+			case *set.RIR < 0 || *set.RIR > 10:
+				errs.add(setField+".rir", "rir must be between 0 and 10")
+			case set.RPE != nil && math.Abs(10-float64(*set.RIR)-*set.RPE) > 0.5:
+				// RPE 8,5 to "1–2 w zapasie", więc dopuszczamy pół punktu różnicy.
+				errs.add(setField+".rir", "rir does not match rpe (rpe should be about 10 - rir)")
+			}
+			if set.PercentOfTM != nil && !validPercentOfTM(*set.PercentOfTM) {
+				errs.add(setField+".percentOfTM", "percentOfTM must be > 0 and at most 150")
+			}
+			if set.AddedWeight != nil && !set.Bodyweight {
+				errs.add(setField+".addedWeight", "addedWeight is only allowed for bodyweight sets")
+			}
+			if set.Band != nil {
+				validateBand(errs, setField+".band", set)
+			}
+			if set.Bodyweight && set.PercentOfTM != nil {
+				errs.add(setField+".percentOfTM", "percentOfTM cannot be combined with bodyweight")
+			}
+		}
+	}
+}
+
+// maxBandResistance to górna granica szacowanego oporu gumy (kg).
+const maxBandResistance = 150
+
+// validateBand sprawdza gumę oporową serii: kolor albo opór musi ją opisywać, a guma
+// odciążająca ma sens tylko w serii z masą ciała.
+func validateBand(errs *validationErrors, field string, set models.Set) {
+	b := set.Band
+	if strings.TrimSpace(b.Color) == "" && b.Resistance == 0 {
+		errs.add(field, "band must have a color or resistance")
+	}
+	if utf8.RuneCountInString(b.Color) > 30 {
+		errs.add(field+".color", "band color must not exceed 30 characters")
+	}
+	if b.Resistance < 0 || b.Resistance > maxBandResistance {
+		errs.add(field+".resistance", "band resistance must be between 0 and 150 kg")
+	}
+	if b.Assisted && !set.Bodyweight {
+		errs.add(field+".assisted", "assisted band is only allowed for bodyweight sets")
+	}
+}
+
+// maxGroupIDLen ogranicza etykietę grupy ćwiczeń.
+const maxGroupIDLen = 20
+
+// validateGroups sprawdza superserie i obwody: grupa to co najmniej dwa kolejne ćwiczenia
+// z tym samym groupId i groupType.
+func validateGroups(errs *validationErrors, exercises []models.Exercise) {
+	size := map[string]int{}
+	for i, ex := range exercises {
+		field := "exercises[" + strconv.Itoa(i) + "]"
+		switch {
+		case ex.GroupID == "" && ex.GroupType == "":
+			continue
+		case ex.GroupID == "":
+			errs.add(field+".groupId", "groupId is required with groupType")
+			continue
+		case utf8.RuneCountInString(ex.GroupID) > maxGroupIDLen:
+			errs.add(field+".groupId", "groupId must not exceed 20 characters")
+		}
+		switch ex.GroupType {
+		case models.GroupSuperset, models.GroupCircuit:
+		case "":
+			errs.add(field+".groupType", "groupType is required with groupId")
+		default:
+			errs.add(field+".groupType", "groupType must be one of: superset, circuit")
+		}
+		size[ex.GroupID]++
+		if i == 0 || exercises[i-1].GroupID != ex.GroupID {
+			if size[ex.GroupID] > 1 {
+				errs.add(field+".groupId", "exercises of a group must be consecutive")
+			}
+			continue
+		}
+		if ex.GroupType != exercises[i-1].GroupType {
+			errs.add(field+".groupType", "all exercises of a group must have the same groupType")
+		}
+	}
+	for i, ex := range exercises {
+		if ex.GroupID != "" && size[ex.GroupID] == 1 {
+			errs.add("exercises["+strconv.Itoa(i)+"].groupId", "a group must have at least 2 exercises")
+		}
+	}
+}
+
+// Limity długości notatek ćwiczenia i serii (w znakach).
+const (
+	maxExerciseNotesLen = 1000
+	maxSetNotesLen      = 200
+)
+
+// Limity serii w sekundach: najdłuższa seria na czas i najdłuższa przerwa po serii.
+const (
+	maxSetDuration = 60 * 60
+	maxRestSeconds = 60 * 60
+)
+
+// Limity wyniku kardio.
+const (
+	maxCardioDuration = 24 * 60 * 60 // s
+	maxCardioDistance = 1_000_000    // m
+	maxElevationGain  = 20_000       // m
+)
+
+// validateCardio sprawdza ćwiczenie kardio: wynik zamiast serii.
+func validateCardio(errs *validationErrors, field string, ex models.Exercise) {
+	if len(ex.Sets) > 0 {
+		errs.add(field+".sets", "cardio exercises must not have sets")
+	}
+	if ex.Interval != nil {
+		errs.add(field+".interval", "interval is only allowed for interval exercises")
+	}
+	c := ex.Cardio
+	if c == nil {
+		errs.add(field+".cardio", "cardio is required for cardio exercises")
+		return
+	}
+	if c.Duration < 1 || c.Duration > maxCardioDuration {
+		errs.add(field+".cardio.duration", "duration must be between 1 and 86400 seconds")
+	}
+	if c.Distance != nil && (*c.Distance <= 0 || *c.Distance > maxCardioDistance) {
+		errs.add(field+".cardio.distance", "distance must be > 0 and at most 1000000 meters")
+	}
+	if c.AvgPace != nil && *c.AvgPace <= 0 {
+		errs.add(field+".cardio.avgPace", "avgPace must be > 0")
+	}
+	if c.AvgHR != nil && (*c.AvgHR < 30 || *c.AvgHR > 250) {
+		errs.add(field+".cardio.avgHr", "avgHr must be between 30 and 250")
+	}
+	if c.ElevationGain != nil && (*c.ElevationGain < 0 || *c.ElevationGain > maxElevationGain) {
+		errs.add(field+".cardio.elevationGain", "elevationGain must be between 0 and 20000 meters")
+	}
+	prev, total := 0, 0
+	for i, b := range c.HRHistogram {
+		bucket := field + ".cardio.hrHistogram[" + strconv.Itoa(i) + "]"
+		switch {
+		case b.BPM < 30 || b.BPM > 250:
+			errs.add(bucket+".bpm", "bpm must be between 30 and 250")
+		case b.BPM <= prev:
+			errs.add(bucket+".bpm", "hrHistogram must be sorted by bpm without repeats")
+		}
+		if b.Seconds < 1 {
+			errs.add(bucket+".seconds", "seconds must be > 0")
+		}
+		prev, total = b.BPM, total+b.Seconds
+	}
+	if total > c.Duration {
+		errs.add(field+".cardio.hrHistogram", "hrHistogram must not exceed the cardio duration")
+	}
+}
+
+// Limity bloku interwałowego.
+const (
+	maxIntervalPhase  = 60 * 60 // s pracy albo przerwy w rundzie
+	maxIntervalRounds = 100
+)
+
+// validateInterval sprawdza blok interwałowy: rundy zamiast serii.
+func validateInterval(errs *validationErrors, field string, ex models.Exercise) {
+	if len(ex.Sets) > 0 {
+		errs.add(field+".sets", "interval exercises must not have sets")
+	}
+	if ex.Cardio != nil {
+		errs.add(field+".cardio", "cardio is only allowed for cardio exercises")
+	}
+	iv := ex.Interval
+	if iv == nil {
+		errs.add(field+".interval", "interval is required for interval exercises")
+		return
+	}
+	switch iv.Format {
+	case "", models.IntervalCustom, models.IntervalEMOM, models.IntervalTabata:
+	default:
+		errs.add(field+".interval.format", "format must be one of: custom, emom, tabata")
+	}
+	if iv.Work < 1 || iv.Work > maxIntervalPhase {
+		errs.add(field+".interval.work", "work must be between 1 and 3600 seconds")
+	}
+	if iv.Rest < 0 || iv.Rest > maxIntervalPhase {
+		errs.add(field+".interval.rest", "rest must be between 0 and 3600 seconds")
+	}
+	if iv.Rounds < 1 || iv.Rounds > maxIntervalRounds {
+		errs.add(field+".interval.rounds", "rounds must be between 1 and 100")
+	}
+	if iv.Format == models.IntervalEMOM && iv.Work+iv.Rest != 60 {
+		errs.add(field+".interval", "emom rounds must last 60 seconds (work + rest)")
+	}
+}
+
+// validRPE sprawdza skalę RPE: 0–10 co pół punktu.
+func validRPE(rpe float64) bool {
+	return rpe >= 0 && rpe <= 10 && rpe*2 == math.Trunc(rpe*2)
+}
+
+// validTempo sprawdza zapis tempa: cztery fazy rozdzielone myślnikami, każda to
+// 0–99 sekund albo X (faza wybuchowa).
+func validTempo(tempo string) bool {
+	phases := strings.Split(tempo, "-")
+	if len(phases) != 4 {
+		return false
+	}
+	for _, p := range phases {
+		if p == "X" {
+			continue
+		}
+		if len(p) == 0 || len(p) > 2 || strings.Trim(p, "0123456789") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// maxPercentOfTM pozwala na serie ponad TM (np. pojedyncze 105% przed zawodami).
+const maxPercentOfTM = 150
+
+// validPercentOfTM sprawdza procent maksa treningowego w serii.
+func validPercentOfTM(pct float64) bool {
+	return pct > 0 && pct <= maxPercentOfTM
+}
+
+// Dozwolone wartości pól ćwiczenia katalogowego.
+var (
+	exerciseTypes     = []string{"strength", "cardio", "mobility"}
+	exerciseEquipment = []string{"barbell", "dumbbell", "kettlebell", "machine", "cable", "bodyweight", "band", "other"}
+)
+
+// validateCatalogExercise sprawdza ćwiczenie katalogowe (po przycięciu spacji).
+func validateCatalogExercise(e models.CatalogExercise) validationErrors {
+	var errs validationErrors
+	if e.Name == "" {
+		errs.add("name", "exercise name is required")
+	}
+	for i, a := range e.Aliases {
+		switch {
+		case a == "":
+			errs.add("aliases["+strconv.Itoa(i)+"]", "alias must not be empty")
+		case strings.EqualFold(a, e.Name):
+			errs.add("aliases["+strconv.Itoa(i)+"]", "alias must differ from the exercise name")
+		}
+	}
+	if !slices.Contains(exerciseTypes, e.Type) {
+		errs.add("type", "type must be one of: strength, cardio, mobility")
+	}
+	if !slices.Contains(exerciseEquipment, e.Equipment) {
+		errs.add("equipment", "equipment must be one of: barbell, dumbbell, kettlebell, machine, cable, bodyweight, band, other")
+	}
+	validateInstructions(&errs, e.ExerciseInstructions)
+	validateMuscles(&errs, "primaryMuscles", e.PrimaryMuscles)
+	validateMuscles(&errs, "secondaryMuscles", e.SecondaryMuscles)
+	for _, m := range e.SecondaryMuscles {
+		if slices.Contains(e.PrimaryMuscles, m) {
+			errs.add("secondaryMuscles", "a muscle group cannot be both primary and secondary")
+			break
+		}
+	}
+	return errs
+}
+
+// Limity instrukcji ćwiczenia.
+const (
+	maxDescriptionLen = 2000
+	maxCues           = 20
+	maxCueLen         = 200
+)
+
+func validateInstructions(errs *validationErrors, in models.ExerciseInstructions) {
+	if utf8.RuneCountInString(in.Description) > maxDescriptionLen {
+		errs.add("description", "description must not exceed 2000 characters")
+	}
+	if len(in.Cues) > maxCues {
+		errs.add("cues", "cues must contain at most 20 entries")
+	}
+	for i, c := range in.Cues {
+		if c == "" || utf8.RuneCountInString(c) > maxCueLen {
+			errs.add("cues["+strconv.Itoa(i)+"]", "cue must be between 1 and 200 characters")
+		}
+	}
+	if in.ImageURL != "" && !isWebURL(in.ImageURL) {
+		errs.add("imageUrl", "must be an absolute http(s) URL")
+	}
+	if in.VideoURL != "" && !isWebURL(in.VideoURL) {
+		errs.add("videoUrl", "must be an absolute http(s) URL")
+	}
+}
+
+// isWebURL sprawdza, czy raw to bezwzględny adres http lub https.
+func isWebURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func validateMuscles(errs *validationErrors, field string, ids []string) {
+	for i, m := range ids {
+		if !store.IsMuscleGroup(m) {
+			errs.add(field+"["+strconv.Itoa(i)+"]", "unknown muscle group (see GET /muscle-groups)")
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"gym-api/internal/httpjson"
+	"gym-api/internal/models"
+	"gym-api/internal/server"
+)
+
+// maxWaterDays ogranicza zakres GET /water/daily (zwraca każdy dzień, także bez wpisów).
+const maxWaterDays = 366
+
+type WaterHandler struct {
+	srv *server.Server
+}
+
+// NewWaterHandler obsługuje dziennik nawodnienia:
+//   - GET /water: porcje od najnowszej, opcjonalnie z zakresu ?from=&to=
+//   - POST /water: wypita porcja w ml (bez daty – dziś)
+func NewWaterHandler(srv *server.Server) *WaterHandler {
+	return &WaterHandler{srv: srv}
+}
+
+func (h *WaterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var errs validationErrors
+		q := r.URL.Query()
+		from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+		if from != "" && to != "" && to < from {
+			errs.add("to", "to must not be before from")
+		}
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, h.srv.Water.List(r.Context(), from, to))
+
+	case http.MethodPost:
+		var req models.WaterRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		var errs validationErrors
+		e := models.WaterEntry{Date: req.Date, Amount: req.Amount}
+		if e.Date == "" {
+			e.Date = time.Now().Format(dateLayout)
+		} else if _, err := time.Parse(dateLayout, e.Date); err != nil {
+			errs.add("date", "date must be YYYY-MM-DD")
+		}
+		if e.Amount < 1 || e.Amount > 5000 {
+			errs.add("amount", "amount must be between 1 and 5000 ml")
+		}
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusCreated, h.srv.Water.Create(r.Context(), e))
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+type WaterByIDHandler struct {
+	srv *server.Server
+}
+
+// NewWaterByIDHandler obsługuje DELETE /water/{id} (cofnięcie omyłkowo dodanej porcji).
+func NewWaterByIDHandler(srv *server.Server) *WaterByIDHandler {
+	return &WaterByIDHandler{srv: srv}
+}
+
+func (h *WaterByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+	if r.Method != http.MethodDelete {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if !h.srv.Water.Delete(r.Context(), id) {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Water entry not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type WaterDailyHandler struct {
+	srv *server.Server
+}
+
+// NewWaterDailyHandler obsługuje GET /water/daily: sumę każdego dnia z zakresu ?from=&to=
+// (domyślnie dziś) z postępem względem dziennego celu, od najstarszego dnia.
+func NewWaterDailyHandler(srv *server.Server) *WaterDailyHandler {
+	return &WaterDailyHandler{srv: srv}
+}
+
+func (h *WaterDailyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var errs validationErrors
+	q := r.URL.Query()
+	from, to := queryDate(&errs, q, "from"), queryDate(&errs, q, "to")
+	today := time.Now().Format(dateLayout)
+	if to == "" {
+		to = today
+	}
+	if from == "" {
+		from = min(to, today)
+	}
+	start, _ := time.Parse(dateLayout, from)
+	end, _ := time.Parse(dateLayout, to)
+	if len(errs) == 0 {
+		if to < from {
+			errs.add("to", "to must not be before from")
+		} else if end.Sub(start) >= maxWaterDays*24*time.Hour {
+			errs.add("from", "date range must not exceed 366 days")
+		}
+	}
+	if len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	totals := map[string]int{}
+	for _, e := range h.srv.Water.List(r.Context(), from, to) {
+		totals[e.Date] += e.Amount
+	}
+	target := h.srv.Water.Target(r.Context())
+	days := []models.WaterDay{}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format(dateLayout)
+		days = append(days, models.WaterDay{Date: date, Total: totals[date], Target: target, Progress: totals[date] * 100 / target})
+	}
+	httpjson.WriteJSON(w, http.StatusOK, days)
+}
+
+type WaterTargetHandler struct {
+	srv *server.Server
+}
+
+// NewWaterTargetHandler obsługuje GET i PUT /water/target: dzienny cel nawodnienia w ml
+// (domyślnie 2500).
+func NewWaterTargetHandler(srv *server.Server) *WaterTargetHandler {
+	return &WaterTargetHandler{srv: srv}
+}
+
+func (h *WaterTargetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		httpjson.WriteJSON(w, http.StatusOK, models.WaterTarget{Target: h.srv.Water.Target(r.Context())})
+
+	case http.MethodPut:
+		var req models.WaterTarget
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		if req.Target < 500 || req.Target > 10000 {
+			var errs validationErrors
+			errs.add("target", "target must be between 500 and 10000 ml")
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		h.srv.Water.SetTarget(r.Context(), req.Target)
+		httpjson.WriteJSON(w, http.StatusOK, req)
+
+	default:
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -1,14 +1,21 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
-	"time"
 
 	"gym-api/internal/httpjson"
 	"gym-api/internal/models"
 	"gym-api/internal/server"
+	"gym-api/internal/store"
+)
+
+// Domyślny i maksymalny rozmiar strony listy treningów.
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
 )
 
 type WorkoutsHandler struct {
@@ -16,8 +23,9 @@ type WorkoutsHandler struct {
 }
 
 // NewWorkoutsHandler obsługuje operacje na kolekcji treningów:
-// - GET /workouts: zwraca listę wszystkich treningów
-// - POST /workouts: tworzy nowy trening na podstawie JSON-a
+//   - GET /workouts: zwraca stronę listy treningów (?limit=&offset= lub ?cursor=),
+//     opcjonalnie zawężoną do zakresu dat (?from=&to=); ?fields= wybiera zwracane pola
+//   - POST /workouts: tworzy nowy trening na podstawie JSON-a
 func NewWorkoutsHandler(srv *server.Server) *WorkoutsHandler {
 	return &WorkoutsHandler{srv: srv}
 }
@@ -27,52 +35,110 @@ func NewWorkoutsHandler(srv *server.Server) *WorkoutsHandler {
 func (h *WorkoutsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		// Zwracamy całą listę zapisanych treningów (w pamięci).
-		httpjson.WriteJSON(w, http.StatusOK, h.srv.Workouts.List())
-		return
-
-	case http.MethodPost:
-		// Parsowanie JSON-a żądania do struktury CreateWorkoutRequest.
-		var req models.CreateWorkoutRequest
-		if err := httpjson.ReadJSON(r, &req); err != nil {
-			httpjson.WriteError(w, http.StatusBadRequest, "Invalid JSON")
+		// Zwracamy jedną stronę treningów wraz z łączną liczbą. Strony wybieramy
+		// przez limit/offset albo nieprzezroczysty kursor (?cursor=) z poprzedniej strony.
+		var errs validationErrors
+		q := r.URL.Query()
+		limit := queryInt(&errs, q, "limit", defaultPageSize)
+		offset := queryInt(&errs, q, "offset", 0)
+		if limit < 1 || limit > maxPageSize {
+			errs.add("limit", "limit must be between 1 and 200")
+		}
+		if offset < 0 {
+			errs.add("offset", "offset must be >= 0")
+		}
+		query := store.WorkoutQuery{
+			Limit:  limit,
+			Offset: offset,
+			From:   queryDate(&errs, q, "from"),
+			To:     queryDate(&errs, q, "to"),
+			// Np. ?exercise=Bench+Press – wszystkie treningi z danym ćwiczeniem.
+			Exercise: strings.TrimSpace(q.Get("exercise")),
+			// Wyszukiwanie pełnotekstowe po tytule, nazwach ćwiczeń i notatkach (treningu, ćwiczeń i serii).
+			Search: strings.TrimSpace(q.Get("q")),
+		}
+		if query.From != "" && query.To != "" && query.From > query.To {
+			errs.add("to", "to must not be before from")
+		}
+		// Np. ?sort=date,-createdAt; "-" oznacza kolejność malejącą.
+		sortParam := strings.TrimSpace(q.Get("sort"))
+		query.Sort = parseSort(&errs, sortParam)
+		// Np. ?fields=id,title,date – widok kalendarza nie potrzebuje ćwiczeń.
+		fields := parseFields(&errs, q)
+		if c := q.Get("cursor"); c != "" {
+			cur, ok := decodeCursor(c, sortParam)
+			if !ok {
+				errs.add("cursor", "cursor is invalid")
+			}
+			if q.Has("offset") {
+				errs.add("offset", "offset cannot be combined with cursor")
+			}
+			query.After = &cur
+		}
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
 			return
 		}
 
-		req.Title = strings.TrimSpace(req.Title)
-		req.Date = strings.TrimSpace(req.Date)
-		req.Notes = strings.TrimSpace(req.Notes)
-
-		if req.Title == "" {
-			httpjson.WriteError(w, http.StatusBadRequest, "title is required")
+		items, total, more := h.srv.Workouts.List(r.Context(), query)
+		page := models.WorkoutPage{Items: items, Total: total, Limit: limit, Offset: offset}
+		if more && len(items) > 0 {
+			last := items[len(items)-1]
+			page.NextCursor = encodeCursor(store.CursorAt(last), sortParam)
+		}
+		items = workoutsInUnit(r.Context(), h.srv, items)
+		page.Items = items
+		// Frontend odpytuje listę cyklicznie – bez zmian wystarczy 304.
+		if notModified(w, r, pageETag(page)) {
 			return
 		}
-		if req.Date == "" {
-			httpjson.WriteError(w, http.StatusBadRequest, "date is required (YYYY-MM-DD)")
+		if fields != nil {
+			// Pole Items na zewnątrz przesłania Items z osadzonej strony.
+			sparse := struct {
+				models.WorkoutPage
+				Items []map[string]any `json:"items"`
+			}{WorkoutPage: page, Items: make([]map[string]any, 0, len(items))}
+			for _, wk := range items {
+				sparse.Items = append(sparse.Items, selectFields(wk, fields))
+			}
+			httpjson.WriteJSON(w, http.StatusOK, sparse)
 			return
 		}
-		if _, err := time.Parse("2006-01-02", req.Date); err != nil {
-			httpjson.WriteError(w, http.StatusBadRequest, "date must be YYYY-MM-DD")
+		httpjson.WriteJSON(w, http.StatusOK, page)
+		return
+
+	case http.MethodPost:
+		// Parsowanie JSON-a żądania do struktury CreateWorkoutRequest.
+		var req models.CreateWorkoutRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
 			return
 		}
-		if err := validateExercises(req.Exercises); err != "" {
-			httpjson.WriteError(w, http.StatusBadRequest, err)
+
+		wk := workoutFromRequest(models.Workout{}, req)
+
+		// Walidujemy wszystkie pola naraz, żeby formularz mógł oznaczyć każdy błąd.
+		var errs validationErrors
+		exercisesToKg(wk.Exercises, inputUnit(r.Context(), h.srv, &errs, req.WeightUnit))
+		if errs = append(errs, checkWorkout(r.Context(), h.srv, wk)...); len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
 			return
 		}
 
-		// Jeśli dane poprawne, tworzymy nowy obiekt treningu i zapisujemy w store.
-		wk := models.Workout{
-			Title:     req.Title,
-			Date:      req.Date,
-			Notes:     req.Notes,
-			Exercises: req.Exercises,
+		// Jeśli dane poprawne, zapisujemy nowy trening w store; pobite rekordy
+		// zwracamy od razu, żeby aplikacja mogła je pokazać.
+		created, records := h.srv.Workouts.CreateWithRecords(r.Context(), wk)
+		if records == nil {
+			records = []models.NewRecord{}
 		}
-		created := h.srv.Workouts.Create(wk)
-		httpjson.WriteJSON(w, http.StatusCreated, created)
+		unit := weightUnit(r.Context(), h.srv)
+		created = workoutInUnit(created, unit, true)
+		w.Header().Set("ETag", workoutETag(created))
+		httpjson.WriteJSON(w, http.StatusCreated, models.CreatedWorkout{Workout: created, NewRecords: newRecordsInUnit(records, unit)})
 		return
 
 	default:
-		httpjson.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -82,105 +148,209 @@ type WorkoutByIDHandler struct {
 
 // NewWorkoutByIDHandler obsługuje operacje na pojedynczym treningu po ID:
 // - GET /workouts/{id}
-// - PUT /workouts/{id}
-// - DELETE /workouts/{id}
+// - PUT /workouts/{id}: pełna zamiana treningu
+// - PATCH /workouts/{id}: JSON Merge Patch (RFC 7386)
+// - DELETE /workouts/{id}: przeniesienie do kosza
+//
+// PUT i PATCH z If-Match lub polem version kończą się 409, gdy ktoś zmienił trening w międzyczasie.
 func NewWorkoutByIDHandler(srv *server.Server) *WorkoutByIDHandler {
 	return &WorkoutByIDHandler{srv: srv}
 }
 
-// /workouts/{id} -> GET(read), PUT(update), DELETE(delete)
+// /workouts/{id} -> GET(read), PUT(replace), PATCH(update), DELETE(delete)
 func (h *WorkoutByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	id, ok := parseWorkoutID(r.URL.Path)
 	if !ok {
-		httpjson.WriteError(w, http.StatusNotFound, "Not found")
+		httpjson.WriteError(w, r, http.StatusNotFound, "Not found")
 		return
 	}
 
 	switch r.Method {
 	case http.MethodGet:
-		// Pobranie konkretnego treningu.
-		wk, found := h.srv.Workouts.Get(id)
+		// Pobranie konkretnego treningu (opcjonalnie tylko wybranych pól).
+		var errs validationErrors
+		fields := parseFields(&errs, r.URL.Query())
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
+			return
+		}
+		wk, found := h.srv.Workouts.Get(r.Context(), id)
 		if !found {
-			httpjson.WriteError(w, http.StatusNotFound, "Workout not found")
+			httpjson.WriteError(w, r, http.StatusNotFound, "Workout not found")
+			return
+		}
+		wk = workoutInUnit(wk, weightUnit(r.Context(), h.srv), true)
+		if notModified(w, r, workoutETag(wk)) {
+			return
+		}
+		if fields != nil {
+			httpjson.WriteJSON(w, http.StatusOK, selectFields(wk, fields))
 			return
 		}
 		httpjson.WriteJSON(w, http.StatusOK, wk)
 		return
 
 	case http.MethodPut:
-		// Parsujemy żądanie update i przygotowujemy bezpieczną modyfikację.
-		var req models.UpdateWorkoutRequest
-		if err := httpjson.ReadJSON(r, &req); err != nil {
-			httpjson.WriteError(w, http.StatusBadRequest, "Invalid JSON")
+		// PUT zastępuje cały trening: pominięte pola są czyszczone.
+		var req models.ReplaceWorkoutRequest
+		if err := httpjson.ReadJSON(w, r, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
 			return
 		}
 
-		// Fetch current workout without mutating store yet
-		cur, found := h.srv.Workouts.Get(id)
+		cur, found := h.srv.Workouts.Get(r.Context(), id)
 		if !found {
-			httpjson.WriteError(w, http.StatusNotFound, "Workout not found")
+			httpjson.WriteError(w, r, http.StatusNotFound, "Workout not found")
 			return
 		}
-
-		// Modyfikacje wykonujemy na kopii, aby nie zepsuć stanu przy błędach walidacji.
-		updated := cur
-		if req.Title != nil {
-			updated.Title = strings.TrimSpace(*req.Title)
-		}
-		if req.Date != nil {
-			updated.Date = strings.TrimSpace(*req.Date)
-		}
-		if req.Notes != nil {
-			updated.Notes = strings.TrimSpace(*req.Notes)
-		}
-		if req.Exercises != nil {
-			updated.Exercises = *req.Exercises
+		version, ok := expectedVersion(w, r, cur, req.Version)
+		if !ok {
+			return
 		}
+		h.save(w, r, id, version, req.WeightUnit, workoutFromRequest(cur, req.CreateWorkoutRequest))
+		return
 
-		// Walidacja danych zanim cokolwiek zapiszemy.
-		updated.Title = strings.TrimSpace(updated.Title)
-		updated.Date = strings.TrimSpace(updated.Date)
-		if updated.Title == "" {
-			httpjson.WriteError(w, http.StatusBadRequest, "title cannot be empty")
+	case http.MethodPatch:
+		// PATCH przyjmuje JSON Merge Patch (RFC 7386): zmieniamy tylko podane pola.
+		patch, ok := readMergePatch(w, r)
+		if !ok {
 			return
 		}
-		if updated.Date == "" {
-			httpjson.WriteError(w, http.StatusBadRequest, "date cannot be empty")
+
+		cur, found := h.srv.Workouts.Get(r.Context(), id)
+		if !found {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Workout not found")
 			return
 		}
-		if _, err := time.Parse("2006-01-02", updated.Date); err != nil {
-			httpjson.WriteError(w, http.StatusBadRequest, "date must be YYYY-MM-DD")
-			return
+
+		// Pole version nie jest częścią treningu, tylko warunkiem zapisu.
+		var bodyVersion *int
+		if v, present := patch["version"]; present {
+			delete(patch, "version")
+			n, isInt := v.(float64)
+			if v != nil && (!isInt || n != float64(int(n)) || n < 1) {
+				var errs validationErrors
+				errs.add("version", "version must be a positive integer")
+				httpjson.WriteValidationErrors(w, r, errs)
+				return
+			}
+			if v != nil {
+				bodyVersion = new(int)
+				*bodyVersion = int(n)
+			}
 		}
-		if errMsg := validateExercises(updated.Exercises); errMsg != "" {
-			httpjson.WriteError(w, http.StatusBadRequest, errMsg)
+		version, ok := expectedVersion(w, r, cur, bodyVersion)
+		if !ok {
 			return
 		}
 
-		// Zapisujemy poprawny stan atomowo w store.
-		final, err := h.srv.Workouts.Update(id, func(cur models.Workout) models.Workout {
-			return updated
-		})
-		if err != nil {
-			httpjson.WriteError(w, http.StatusNotFound, "Workout not found")
+		// Łatkę nakładamy na edytowalne pola treningu w postaci JSON i dekodujemy
+		// z powrotem, więc pola tylko do odczytu (id, createdAt) są odrzucane jak nieznane.
+		// Ciężary dokumentu są w jednostce łatki (domyślnie z profilu, jak w odpowiedzi GET).
+		var errs validationErrors
+		unit := patchUnit(r.Context(), h.srv, &errs, patch)
+		if len(errs) > 0 {
+			httpjson.WriteValidationErrors(w, r, errs)
 			return
 		}
-
-		httpjson.WriteJSON(w, http.StatusOK, final)
+		var req models.CreateWorkoutRequest
+		if err := applyMergePatch(editableWorkout(workoutInUnit(cur, unit, false)), patch, &req); err != nil {
+			httpjson.WriteReadError(w, r, err)
+			return
+		}
+		h.save(w, r, id, version, unit, workoutFromRequest(cur, req))
 		return
 
 	case http.MethodDelete:
-		// Usuwamy trening po ID.
-		if !h.srv.Workouts.Delete(id) {
-			httpjson.WriteError(w, http.StatusNotFound, "Workout not found")
+		// Przenosimy trening do kosza (POST /workouts/{id}/restore go przywraca).
+		if !h.srv.Workouts.Delete(r.Context(), id) {
+			httpjson.WriteError(w, r, http.StatusNotFound, "Workout not found")
 			return
 		}
 		w.WriteHeader(http.StatusNoContent)
 		return
 
 	default:
-		httpjson.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		httpjson.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// writeVersionConflict odpowiada 409, podając bieżącą wersję treningu,
+// żeby klient mógł pobrać świeże dane i ponowić edycję.
+func writeVersionConflict(w http.ResponseWriter, r *http.Request, cur models.Workout) {
+	w.Header().Set("ETag", workoutETag(cur))
+	httpjson.WriteErrorCode(w, r, http.StatusConflict, httpjson.CodeConflict,
+		"workout was modified by someone else (current version: %d)", cur.Version)
+}
+
+// workoutFromRequest nakłada pola z żądania na trening base, przycinając białe znaki.
+func workoutFromRequest(base models.Workout, req models.CreateWorkoutRequest) models.Workout {
+	base.Title = strings.TrimSpace(req.Title)
+	base.Date = strings.TrimSpace(req.Date)
+	base.Notes = strings.TrimSpace(req.Notes)
+	base.Exercises = req.Exercises
+	base.Planned = req.Planned
+	return base
+}
+
+// editableWorkout zwraca edytowalne pola treningu – dokument, na który PATCH nakłada łatkę.
+// Jednostki ciężarów w nim nie ma: łatka podaje ją osobno (patrz patchUnit).
+func editableWorkout(wk models.Workout) models.CreateWorkoutRequest {
+	return models.CreateWorkoutRequest{Title: wk.Title, Date: wk.Date, Notes: wk.Notes, Exercises: wk.Exercises, Planned: wk.Planned}
+}
+
+// expectedVersion ustala wersję, którą klient edytował: z If-Match (ETag z GET)
+// albo z pola version. Bez żadnej z nich zwraca 0, czyli zapis bez sprawdzania.
+// Przy nieaktualnym If-Match odpowiada 409 i zwraca ok = false.
+func expectedVersion(w http.ResponseWriter, r *http.Request, cur models.Workout, bodyVersion *int) (int, bool) {
+	version := 0
+	if bodyVersion != nil {
+		version = *bodyVersion
+	}
+	if im := r.Header.Get("If-Match"); im != "" {
+		// Wersję opisuje ETag w dowolnej jednostce.
+		lb := cur
+		lb.WeightUnit = models.UnitLb
+		if !etagMatches(im, workoutETag(cur)) && !etagMatches(im, workoutETag(lb)) {
+			writeVersionConflict(w, r, cur)
+			return 0, false
+		}
+		version = cur.Version
+	}
+	return version, true
+}
+
+// save waliduje i zapisuje nowy stan treningu (wspólna część PUT i PATCH); ciężary
+// w żądaniu są w jednostce unit (pusta = z profilu).
+func (h *WorkoutByIDHandler) save(w http.ResponseWriter, r *http.Request, id, version int, unit string, updated models.Workout) {
+	// Walidacja danych zanim cokolwiek zapiszemy.
+	var errs validationErrors
+	exercisesToKg(updated.Exercises, inputUnit(r.Context(), h.srv, &errs, unit))
+	if errs = append(errs, checkWorkout(r.Context(), h.srv, updated)...); len(errs) > 0 {
+		httpjson.WriteValidationErrors(w, r, errs)
+		return
+	}
+
+	// Zapisujemy poprawny stan atomowo w store.
+	final, err := h.srv.Workouts.Update(r.Context(), id, version, func(cur models.Workout) models.Workout {
+		return updated
+	})
+	if errors.Is(err, store.ErrVersionConflict) {
+		writeVersionConflict(w, r, final)
+		return
+	}
+	if err != nil {
+		httpjson.WriteError(w, r, http.StatusNotFound, "Workout not found")
+		return
 	}
+	writeWorkout(w, r, h.srv, http.StatusOK, final)
+}
+
+// writeWorkout odpowiada treningiem w jednostce z profilu, z nagłówkiem ETag.
+func writeWorkout(w http.ResponseWriter, r *http.Request, srv *server.Server, status int, wk models.Workout) {
+	wk = workoutInUnit(wk, weightUnit(r.Context(), srv), true)
+	w.Header().Set("ETag", workoutETag(wk))
+	httpjson.WriteJSON(w, status, wk)
 }
 
 func parseWorkoutID(path string) (int, bool) {
@@ -195,28 +365,3 @@ func parseWorkoutID(path string) (int, bool) {
 	}
 	return id, true
 }
-
-func validateExercises(exercises []models.Exercise) string {
-	// dozwalamy pustą listę, ale w praktyce trening zwykle ma ćwiczenia
-	// jak chcesz wymusić minimum 1 ćwiczenie, odkomentuj:
-	// if len(exercises) == 0 { return "exercises must have at least 1 exercise" }
-
-	for i, ex := range exercises {
-		name := strings.TrimSpace(ex.Name)
-		if name == "" {
-			return "exercise name is required (at index " + strconv.Itoa(i) + ")"
-		}
-		if len(ex.Sets) == 0 {
-			return "exercise sets must have at least 1 set for: " + name
-		}
-		for si, set := range ex.Sets {
-			if set.Reps <= 0 {
-				return "reps must be > 0 for exercise: " + name + ", set index " + strconv.Itoa(si)
-			}
-			if set.Weight != nil && *set.Weight < 0 {
-				return "weight must be >= 0 for exercise: " + name + ", set index " + strconv.Itoa(si)
-			}
-		}
-	}
-	return ""
-}
@@ -2,26 +2,144 @@ package httpjson
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strings"
 
+	"gym-api/internal/i18n"
 	"gym-api/internal/models"
 )
 
+// MaxBodyBytes ogranicza rozmiar body żądania czytanego przez ReadJSON.
+// Wartość ustawiana jest przy starcie na podstawie konfiguracji.
+var MaxBodyBytes int64 = 1 << 20
+
+// ErrBodyTooLarge oznacza, że body żądania przekroczyło MaxBodyBytes.
+var ErrBodyTooLarge = errors.New("request body too large")
+
 // WriteJSON zapisuje payload jako JSON z podanym statusem HTTP.
 func WriteJSON(w http.ResponseWriter, status int, payload any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(payload)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(payload)
+}
+
+// Maszynowe kody błędów zwracane w polu "code".
+const (
+	CodeInvalidJSON  = "invalid_json"
+	CodeBodyTooLarge = "body_too_large"
+	CodeValidation   = "validation_failed"
+	CodeConflict     = "version_conflict"
+	CodeMediaType    = "unsupported_media_type"
+)
+
+// ProblemTypePrefix poprzedza kod błędu w polu "type" (np. urn:gym-api:problem:invalid_json).
+const ProblemTypePrefix = "urn:gym-api:problem:"
+
+// WriteProblem zapisuje błąd jako application/problem+json (RFC 7807).
+// Puste Type i Title uzupełniamy na podstawie kodu błędu i statusu HTTP;
+// tytuł i komunikaty pól tłumaczymy na język z Accept-Language.
+// Detail powinien być już przetłumaczony przez wywołującego.
+func WriteProblem(w http.ResponseWriter, r *http.Request, p models.Problem) {
+	lang := i18n.FromRequest(r)
+	if p.Type == "" {
+		p.Type = "about:blank"
+		if p.Code != "" {
+			p.Type = ProblemTypePrefix + p.Code
+		}
+	}
+	if p.Title == "" {
+		p.Title = http.StatusText(p.Status)
+	}
+	p.Title = i18n.T(lang, p.Title)
+	for i := range p.Errors {
+		p.Errors[i].Message = i18n.T(lang, p.Errors[i].Message, p.Errors[i].Args...)
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Content-Language", string(lang))
+	w.Header().Add("Vary", "Accept-Language")
+	w.WriteHeader(p.Status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(p)
 }
 
-// WriteError opakowuje błąd w spójny format JSON {"error": ...}.
-func WriteError(w http.ResponseWriter, status int, msg string) {
-	WriteJSON(w, status, models.APIError{Error: msg})
+// WriteError odpowiada błędem problem+json z podanym statusem.
+// msg to angielski komunikat (klucz tłumaczenia) z opcjonalnymi argumentami jak w fmt.Sprintf.
+func WriteError(w http.ResponseWriter, r *http.Request, status int, msg string, args ...any) {
+	WriteProblem(w, r, models.Problem{Status: status, Detail: i18n.T(i18n.FromRequest(r), msg, args...)})
+}
+
+// WriteErrorCode działa jak WriteError, ale dołącza maszynowy kod błędu.
+func WriteErrorCode(w http.ResponseWriter, r *http.Request, status int, code, msg string, args ...any) {
+	WriteProblem(w, r, models.Problem{Status: status, Code: code, Detail: i18n.T(i18n.FromRequest(r), msg, args...)})
+}
+
+// WriteValidationErrors odpowiada 400 z listą wszystkich błędnych pól w "errors".
+func WriteValidationErrors(w http.ResponseWriter, r *http.Request, errs []models.FieldError) {
+	WriteProblem(w, r, models.Problem{
+		Status: http.StatusBadRequest,
+		Code:   CodeValidation,
+		Title:  "Validation failed",
+		Detail: i18n.T(i18n.FromRequest(r), "request contains %d invalid field(s)", len(errs)),
+		Errors: errs,
+	})
 }
 
 // ReadJSON parsuje body żądania do podanej struktury i blokuje nieznane pola.
-func ReadJSON(r *http.Request, dst any) error {
+// Body jest ograniczone do MaxBodyBytes i musi zawierać dokładnie jedną wartość JSON.
+func ReadJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxBodyBytes)
 	dec := json.NewDecoder(r.Body)
 	dec.DisallowUnknownFields()
-	return dec.Decode(dst)
+	if err := dec.Decode(dst); err != nil {
+		return DecodeError(err)
+	}
+	// Wszystko po pierwszej wartości (poza białymi znakami) traktujemy jako błąd.
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var mbe *http.MaxBytesError
+		if errors.As(err, &mbe) {
+			return ErrBodyTooLarge
+		}
+		return i18n.Errorf("request body must contain a single JSON value")
+	}
+	return nil
+}
+
+// WriteReadError odpowiada na błąd z ReadJSON: 413 dla zbyt dużego body, 400 dla pozostałych.
+func WriteReadError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, ErrBodyTooLarge) {
+		WriteErrorCode(w, r, http.StatusRequestEntityTooLarge, CodeBodyTooLarge, "request body must not exceed %d bytes", MaxBodyBytes)
+		return
+	}
+	WriteErrorCode(w, r, http.StatusBadRequest, CodeInvalidJSON, "Invalid JSON: %s", i18n.Localize(i18n.FromRequest(r), err))
+}
+
+// DecodeError zamienia błędy encoding/json na czytelne (i tłumaczalne) komunikaty dla klienta.
+func DecodeError(err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var mbe *http.MaxBytesError
+	switch {
+	case errors.As(err, &mbe):
+		return ErrBodyTooLarge
+	case errors.Is(err, io.EOF):
+		return i18n.Errorf("request body is empty")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return i18n.Errorf("request body is truncated")
+	case errors.As(err, &syntaxErr):
+		return i18n.Errorf("malformed JSON at offset %d", syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		if typeErr.Field != "" {
+			return i18n.Errorf("field %q must be of type %s", typeErr.Field, typeErr.Type)
+		}
+		return i18n.Errorf("body must be of type %s", typeErr.Type)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		return i18n.Errorf("unknown field %s", strings.TrimPrefix(err.Error(), "json: unknown field "))
+	}
+	return err
 }
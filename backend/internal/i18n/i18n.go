@@ -0,0 +1,93 @@
+package i18n
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Lang to kod obsługiwanego języka komunikatów.
+type Lang string
+
+const (
+	EN Lang = "en"
+	PL Lang = "pl"
+)
+
+// Default to język używany, gdy klient nie poprosił o żaden obsługiwany.
+const Default = EN
+
+// catalogs mapuje angielski tekst komunikatu (klucz, jak w gettext) na tłumaczenie.
+// Angielski nie potrzebuje katalogu – klucz jest już treścią.
+var catalogs = map[Lang]map[string]string{
+	PL: pl,
+}
+
+// T tłumaczy komunikat na podany język i wstawia argumenty (jak fmt.Sprintf).
+// Brak tłumaczenia oznacza powrót do angielskiego tekstu.
+func T(lang Lang, msg string, args ...any) string {
+	if tr, ok := catalogs[lang][msg]; ok {
+		msg = tr
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// FromRequest wybiera język na podstawie nagłówka Accept-Language.
+func FromRequest(r *http.Request) Lang {
+	return Negotiate(r.Header.Get("Accept-Language"))
+}
+
+// Negotiate wybiera najlepiej oceniony (wg q) obsługiwany język z nagłówka
+// Accept-Language, np. "pl-PL,pl;q=0.9,en;q=0.8" -> PL.
+func Negotiate(header string) Lang {
+	type candidate struct {
+		lang Lang
+		q    float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		lang := Lang(base)
+		if q > 0 && (lang == EN || catalogs[lang] != nil) {
+			candidates = append(candidates, candidate{lang, q})
+		}
+	}
+	if len(candidates) == 0 {
+		return Default
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].lang
+}
+
+// Error to błąd z komunikatem do przetłumaczenia; Error() zwraca wersję angielską.
+type Error struct {
+	Msg  string
+	Args []any
+}
+
+// Errorf tworzy błąd, który można później przetłumaczyć przez Localize.
+func Errorf(msg string, args ...any) *Error {
+	return &Error{Msg: msg, Args: args}
+}
+
+func (e *Error) Error() string { return T(EN, e.Msg, e.Args...) }
+
+// Localize zwraca treść błędu w podanym języku (dla zwykłych błędów – bez zmian).
+func Localize(lang Lang, err error) string {
+	if e, ok := err.(*Error); ok {
+		return T(lang, e.Msg, e.Args...)
+	}
+	return err.Error()
+}
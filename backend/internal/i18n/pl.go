@@ -0,0 +1,291 @@
+package i18n
+
+// pl to polskie tłumaczenia komunikatów API.
+var pl = map[string]string{
+	// Statusy HTTP (tytuły problemów).
+	"Bad Request":              "Błędne żądanie",
+	"Forbidden":                "Brak dostępu",
+	"Not Found":                "Nie znaleziono",
+	"Method Not Allowed":       "Niedozwolona metoda",
+	"Conflict":                 "Konflikt",
+	"Precondition Failed":      "Niespełniony warunek wstępny",
+	"Request Entity Too Large": "Zbyt duże żądanie",
+	"Unsupported Media Type":   "Nieobsługiwany typ danych",
+	"Unprocessable Entity":     "Nieprzetwarzalne dane",
+	"Internal Server Error":    "Wewnętrzny błąd serwera",
+
+	// Ogólne.
+	"Not found":             "Nie znaleziono",
+	"Method not allowed":    "Niedozwolona metoda",
+	"Validation failed":     "Błąd walidacji",
+	"must be an integer":    "wartość musi być liczbą całkowitą",
+	"must be true or false": "wartość musi być true albo false",
+	"must be a number":      "wartość musi być liczbą",
+
+	// Stronicowanie.
+	"limit must be between 1 and 200":                                   "limit musi mieścić się w zakresie 1–200",
+	"offset must be >= 0":                                               "offset musi być >= 0",
+	"cursor is invalid":                                                 "niepoprawny kursor",
+	"offset cannot be combined with cursor":                             "offset nie może być użyty razem z kursorem",
+	"to must not be before from":                                        "data to nie może być wcześniejsza niż from",
+	"fields must be a comma-separated list of workout fields":           "fields musi być listą pól treningu oddzielonych przecinkami",
+	"sort fields must be one of: id, date, title, createdAt, updatedAt": "sortować można tylko po polach: id, date, title, createdAt, updatedAt",
+
+	// Parsowanie JSON.
+	"Invalid JSON: %s":                              "Niepoprawny JSON: %s",
+	"request body must not exceed %d bytes":         "body żądania nie może przekraczać %d bajtów",
+	"request body is empty":                         "body żądania jest puste",
+	"request body is truncated":                     "body żądania jest niekompletne",
+	"malformed JSON at offset %d":                   "błędna składnia JSON na pozycji %d",
+	"field %q must be of type %s":                   "pole %q musi być typu %s",
+	"body must be of type %s":                       "body musi być typu %s",
+	"unknown field %s":                              "nieznane pole %s",
+	"request body must contain a single JSON value": "body żądania musi zawierać dokładnie jedną wartość JSON",
+	"request contains %d invalid field(s)":          "liczba błędnych pól w żądaniu: %d",
+	"merge patch must be a JSON object":             "merge patch musi być obiektem JSON",
+	"Content-Type must be %s":                       "Content-Type musi być %s",
+
+	// Treningi.
+	"Workout not found":                                                         "Nie znaleziono treningu",
+	"version must be a positive integer":                                        "wersja musi być dodatnią liczbą całkowitą",
+	"bulk request must contain between 1 and %d workouts":                       "żądanie bulk musi zawierać od 1 do %d treningów",
+	"ids must contain between 1 and %d workout IDs":                             "ids musi zawierać od 1 do %d identyfikatorów treningów",
+	"patch is required":                                                         "patch jest wymagany",
+	"Revision not found":                                                        "Nie znaleziono wersji",
+	"workout has no earlier version to undo":                                    "trening nie ma wcześniejszej wersji do przywrócenia",
+	"workout is not in the trash":                                               "treningu nie ma w koszu",
+	"workout was modified by someone else (current version: %d)":                "trening został w międzyczasie zmieniony (bieżąca wersja: %d)",
+	"title is required":                                                         "tytuł jest wymagany",
+	"date is required (YYYY-MM-DD)":                                             "data jest wymagana (RRRR-MM-DD)",
+	"date must be YYYY-MM-DD":                                                   "data musi mieć format RRRR-MM-DD",
+	"exercise name is required":                                                 "nazwa ćwiczenia jest wymagana",
+	"exercise must have at least 1 set":                                         "ćwiczenie musi mieć co najmniej 1 serię",
+	"reps must be > 0":                                                          "liczba powtórzeń musi być > 0",
+	"rpe must be between 0 and 10 in steps of 0.5":                              "RPE musi mieścić się w zakresie 0–10 co 0,5",
+	"percentOfTM must be > 0 and at most 150":                                   "percentOfTM musi być > 0 i nie większe niż 150",
+	"weight must be >= 0":                                                       "ciężar musi być >= 0",
+	"increment must be between 0 and 50 kg":                                     "przyrost musi mieścić się w zakresie 0–50 kg",
+	"failures must be between 1 and 10":                                         "failures musi mieścić się w zakresie 1–10",
+	"deload must be between 1 and 50 percent":                                   "deload musi mieścić się w zakresie 1–50%",
+	"scheme must be one of: linear, double":                                     "scheme musi mieć jedną z wartości: linear, double",
+	"rep range must satisfy 1 <= minReps < maxReps <= 50":                       "zakres powtórzeń musi spełniać 1 <= minReps < maxReps <= 50",
+	"target RPE must be between 5 and 10 in steps of 0.5":                       "docelowe RPE musi mieścić się w zakresie 5–10 co 0,5",
+	"window must be between 2 and 10 sessions":                                  "window musi mieścić się w zakresie 2–10 sesji",
+	"weeks must be between 2 and 26":                                            "weeks musi mieścić się w zakresie 2–26",
+	"weeks must be between 4 and 52":                                            "weeks musi mieścić się w zakresie 4–52",
+	"groupBy must be one of: muscle":                                            "groupBy musi mieć wartość: muscle",
+	"period must be one of: day, week, month":                                   "period musi mieć jedną z wartości: day, week, month",
+	"date range must not span more than 366 periods":                            "zakres dat nie może obejmować więcej niż 366 okresów",
+	"formula must be one of: epley, brzycki, lombardi":                          "formula musi mieć jedną z wartości: epley, brzycki, lombardi",
+	"exercise has not been logged yet":                                          "tego ćwiczenia nie ma jeszcze w żadnym treningu",
+	"sex must be one of: male, female":                                          "sex musi mieć jedną z wartości: male, female",
+	"bodyweight must be between 20 and 300 kg":                                  "masa ciała musi mieścić się w zakresie 20–300 kg",
+	"source must be one of: e1rm, actual":                                       "source musi mieć jedną z wartości: e1rm, actual",
+	"exercise is required":                                                      "parametr exercise jest wymagany",
+	"metric must be one of: e1rm, volume, topset":                               "metric musi mieć jedną z wartości: e1rm, volume, topset",
+	"bucket must be one of: day, week, month":                                   "bucket musi mieć jedną z wartości: day, week, month",
+	"month must be YYYY-MM":                                                     "miesiąc musi mieć format RRRR-MM",
+	"perWeek must be between 1 and 7":                                           "perWeek musi mieścić się w zakresie 1–7",
+	"year must be between 1900 and 2100":                                        "rok musi mieścić się w zakresie 1900–2100",
+	"Goal not found":                                                            "Nie znaleziono celu",
+	"type must be one of: lift, frequency":                                      "typ musi mieć jedną z wartości: lift, frequency",
+	"exerciseId is required for lift goals":                                     "exerciseId jest wymagane dla celu lift",
+	"targetWeight must be > 0 and at most 1000 kg":                              "targetWeight musi być > 0 i nie większe niż 1000 kg",
+	"title must not exceed 200 characters":                                      "tytuł nie może przekraczać 200 znaków",
+	"Bodyweight entry not found":                                                "Nie znaleziono pomiaru masy ciała",
+	"weight must be between 20 and 300 kg":                                      "masa ciała musi mieścić się w zakresie 20–300 kg",
+	"note must not exceed 500 characters":                                       "notatka nie może przekraczać 500 znaków",
+	"window must be between 1 and 90 days":                                      "window musi mieścić się w zakresie 1–90 dni",
+	"bodyweight is required when no bodyweight is logged":                       "podaj bodyweight albo zapisz pomiar masy ciała",
+	"Measurement not found":                                                     "Nie znaleziono pomiarów",
+	"Measurement site not found":                                                "Nie ma takiego miejsca pomiaru",
+	"values must contain at least one measurement":                              "values musi zawierać co najmniej jeden pomiar",
+	"unknown measurement site (see GET /measurements/sites)":                    "nieznane miejsce pomiaru (patrz GET /measurements/sites)",
+	"measurement must be between 5 and 300 cm":                                  "obwód musi mieścić się w zakresie 5–300 cm",
+	"Photo not found":                                                           "Nie znaleziono zdjęcia",
+	"photo file is required":                                                    "plik zdjęcia (pole photo) jest wymagany",
+	"photo must be a JPEG, PNG or WebP image":                                   "zdjęcie musi być obrazem JPEG, PNG albo WebP",
+	"photo must not exceed %d MB":                                               "zdjęcie nie może przekraczać %d MB",
+	"pose must be one of: front, side, back":                                    "pose musi mieć jedną z wartości: front, side, back",
+	"malformed multipart form":                                                  "niepoprawny formularz multipart",
+	"photo storage is unavailable":                                              "magazyn zdjęć jest niedostępny",
+	"height must be between 100 and 250 cm":                                     "wzrost musi mieścić się w zakresie 100–250 cm",
+	"bodyFat must be between 2 and 75%":                                         "procent tkanki tłuszczowej musi mieścić się w zakresie 2–75%",
+	"format must be one of: withings, renpho":                                   "format musi mieć jedną z wartości: withings, renpho",
+	"unrecognized smart-scale CSV header":                                       "nie rozpoznano nagłówka pliku CSV z wagi",
+	"malformed CSV file":                                                        "niepoprawny plik CSV",
+	"file must not exceed %d MB":                                                "plik nie może przekraczać %d MB",
+	"Nutrition entry not found":                                                 "Nie znaleziono wpisu dziennika żywienia",
+	"calories must be between 0 and 20000":                                      "kalorie muszą mieścić się w zakresie 0–20000",
+	"protein must be between 0 and 1000 g":                                      "białko musi mieścić się w zakresie 0–1000 g",
+	"carbs must be between 0 and 2000 g":                                        "węglowodany muszą mieścić się w zakresie 0–2000 g",
+	"fat must be between 0 and 1000 g":                                          "tłuszcz musi mieścić się w zakresie 0–1000 g",
+	"meal must not exceed 100 characters":                                       "nazwa posiłku nie może przekraczać 100 znaków",
+	"Water entry not found":                                                     "Nie znaleziono porcji wody",
+	"amount must be between 1 and 5000 ml":                                      "ilość musi mieścić się w zakresie 1–5000 ml",
+	"target must be between 500 and 10000 ml":                                   "cel musi mieścić się w zakresie 500–10000 ml",
+	"date range must not exceed 366 days":                                       "zakres dat nie może przekraczać 366 dni",
+	"Sleep entry not found":                                                     "Nie znaleziono wpisu snu",
+	"bedtime must be HH:MM":                                                     "godzina zaśnięcia musi mieć format HH:MM",
+	"wakeTime must be HH:MM":                                                    "godzina pobudki musi mieć format HH:MM",
+	"duration must not exceed the time between bedtime and wakeTime":            "czas snu nie może przekraczać czasu między zaśnięciem a pobudką",
+	"duration or bedtime and wakeTime are required":                             "wymagany jest czas snu albo godziny zaśnięcia i pobudki",
+	"duration must be between 0 and 1440 minutes":                               "czas snu musi mieścić się w zakresie 0–1440 minut",
+	"quality must be between 1 and 5":                                           "jakość musi mieścić się w zakresie 1–5",
+	"days must be between 1 and 90":                                             "liczba dni musi mieścić się w zakresie 1–90",
+	"Check-in not found":                                                        "Nie znaleziono oceny samopoczucia",
+	"energy must be between 1 and 5":                                            "energia musi mieścić się w zakresie 1–5",
+	"soreness must be between 1 and 5":                                          "zakwasy muszą mieścić się w zakresie 1–5",
+	"stress must be between 1 and 5":                                            "stres musi mieścić się w zakresie 1–5",
+	"sleepQuality must be between 1 and 5 (or logged in /sleep)":                "jakość snu musi mieścić się w zakresie 1–5 (albo być zapisana w /sleep)",
+	"Injury not found":                                                          "Nie znaleziono urazu",
+	"bodyPart is required":                                                      "część ciała jest wymagana",
+	"bodyPart must not exceed 100 characters":                                   "część ciała nie może przekraczać 100 znaków",
+	"severity must be one of: mild, moderate, severe":                           "stopień urazu musi mieć jedną z wartości: mild, moderate, severe",
+	"onsetDate must be YYYY-MM-DD":                                              "data początku musi mieć format YYYY-MM-DD",
+	"status must be one of: active, recovering, resolved":                       "status musi mieć jedną z wartości: active, recovering, resolved",
+	"Supplement intake not found":                                               "Nie znaleziono dawki suplementu",
+	"supplement name is required":                                               "nazwa suplementu jest wymagana",
+	"supplement name must not exceed 100 characters":                            "nazwa suplementu nie może przekraczać 100 znaków",
+	"dose must be between 0 and 100000":                                         "dawka musi mieścić się w zakresie 0–100000",
+	"unit must be one of: g, mg, µg, ml, IU, caps":                              "jednostka musi mieć jedną z wartości: g, mg, µg, ml, IU, caps",
+	"unit is required when dose is given":                                       "jednostka jest wymagana, gdy podano dawkę",
+	"cardio is only allowed for cardio exercises":                               "wynik kardio jest dozwolony tylko w ćwiczeniach kardio",
+	"type must be one of: strength, cardio, interval":                           "rodzaj musi mieć jedną z wartości: strength, cardio, interval",
+	"cardio exercises must not have sets":                                       "ćwiczenie kardio nie może mieć serii",
+	"cardio is required for cardio exercises":                                   "ćwiczenie kardio wymaga wyniku (cardio)",
+	"duration must be between 1 and 86400 seconds":                              "czas musi mieścić się w zakresie 1–86400 sekund",
+	"distance must be > 0 and at most 1000000 meters":                           "dystans musi być > 0 i najwyżej 1000000 metrów",
+	"avgPace must be > 0":                                                       "średnie tempo musi być > 0",
+	"avgHr must be between 30 and 250":                                          "średnie tętno musi mieścić się w zakresie 30–250",
+	"elevationGain must be between 0 and 20000 meters":                          "przewyższenie musi mieścić się w zakresie 0–20000 metrów",
+	"GPX file contains no track points":                                         "plik GPX nie zawiera punktów śladu",
+	"malformed GPX file":                                                        "niepoprawny plik GPX",
+	"track points must have timestamps":                                         "punkty śladu muszą mieć czas",
+	"FIT file checksum mismatch":                                                "niezgodna suma kontrolna pliku FIT",
+	"malformed FIT file":                                                        "niepoprawny plik FIT",
+	"FIT file contains no sessions":                                             "plik FIT nie zawiera sesji",
+	"%s integration is not configured":                                          "Integracja z serwisem %s nie jest skonfigurowana",
+	"%s is not connected":                                                       "Konto %s nie jest połączone",
+	"%s authorization was denied":                                               "Odmówiono autoryzacji w serwisie %s",
+	"%s access to activities was not granted":                                   "Nie przyznano dostępu do aktywności w serwisie %s",
+	"%s rejected the authorization, connect the account again":                  "Serwis %s odrzucił autoryzację, połącz konto ponownie",
+	"%s is unavailable":                                                         "Serwis %s jest niedostępny",
+	"invalid or expired OAuth state":                                            "nieprawidłowy lub przeterminowany parametr state OAuth",
+	"code is required":                                                          "parametr code jest wymagany",
+	"invalid webhook verify token":                                              "nieprawidłowy token weryfikacji webhooka",
+	"enable at least one of cardio and strength (or disconnect the account)":    "włącz import kardio lub treningów siłowych (albo rozłącz konto)",
+	"archive contains no Apple Health export.xml":                               "archiwum nie zawiera pliku export.xml z Apple Health",
+	"malformed Apple Health export":                                             "niepoprawny eksport Apple Health",
+	"seconds must be > 0":                                                       "liczba sekund musi być > 0",
+	"max heart rate is not set":                                                 "Nie ustawiono tętna maksymalnego",
+	"maxHr must be between 100 and 230":                                         "Tętno maksymalne musi mieć od 100 do 230 uderzeń/min",
+	"bpm must be between 30 and 250":                                            "Tętno musi mieć od 30 do 250 uderzeń/min",
+	"hrHistogram must be sorted by bpm without repeats":                         "Histogram tętna musi być posortowany rosnąco po tętnie, bez powtórzeń",
+	"hrHistogram must not exceed the cardio duration":                           "Histogram tętna nie może przekraczać czasu ćwiczenia",
+	"interval is only allowed for interval exercises":                           "blok interwałowy jest dozwolony tylko w ćwiczeniach interwałowych",
+	"interval exercises must not have sets":                                     "ćwiczenia interwałowe nie mogą mieć serii",
+	"interval is required for interval exercises":                               "blok interwałowy jest wymagany w ćwiczeniach interwałowych",
+	"format must be one of: custom, emom, tabata":                               "format musi mieć jedną z wartości: custom, emom, tabata",
+	"work must be between 1 and 3600 seconds":                                   "czas pracy musi mieć od 1 do 3600 sekund",
+	"rest must be between 0 and 3600 seconds":                                   "czas przerwy musi mieć od 0 do 3600 sekund",
+	"rounds must be between 1 and 100":                                          "liczba rund musi mieć od 1 do 100",
+	"emom rounds must last 60 seconds (work + rest)":                            "runda EMOM musi trwać 60 sekund (praca + przerwa)",
+	"type must be one of: warmup, working, dropset, failure, amrap, backoff":    "rodzaj serii musi mieć jedną z wartości: warmup, working, dropset, failure, amrap, backoff",
+	"reps must be >= 0":                                                         "liczba powtórzeń musi być >= 0",
+	"durationSeconds must be between 0 and 3600":                                "czas serii musi mieć od 0 do 3600 sekund",
+	"set must have reps > 0 or durationSeconds > 0":                             "seria musi mieć liczbę powtórzeń > 0 albo czas > 0",
+	"rir must be between 0 and 10":                                              "RIR musi mieć od 0 do 10",
+	"rir does not match rpe (rpe should be about 10 - rir)":                     "RIR nie zgadza się z RPE (RPE powinno wynosić około 10 − RIR)",
+	"tempo must be 4 phases like 3-1-1-0 (seconds 0-99 or X)":                   "tempo musi mieć 4 fazy, np. 3-1-1-0 (sekundy 0–99 albo X)",
+	"plannedRestSeconds must be between 0 and 3600":                             "zaplanowana przerwa musi mieć od 0 do 3600 sekund",
+	"restSeconds must be between 0 and 3600":                                    "przerwa musi mieć od 0 do 3600 sekund",
+	"exercise notes must not exceed 1000 characters":                            "notatki ćwiczenia mogą mieć najwyżej 1000 znaków",
+	"set notes must not exceed 200 characters":                                  "notatki serii mogą mieć najwyżej 200 znaków",
+	"groupId is required with groupType":                                        "groupId jest wymagane razem z groupType",
+	"groupId must not exceed 20 characters":                                     "groupId może mieć najwyżej 20 znaków",
+	"groupType is required with groupId":                                        "groupType jest wymagane razem z groupId",
+	"groupType must be one of: superset, circuit":                               "groupType musi mieć jedną z wartości: superset, circuit",
+	"exercises of a group must be consecutive":                                  "ćwiczenia grupy muszą następować po sobie",
+	"all exercises of a group must have the same groupType":                     "wszystkie ćwiczenia grupy muszą mieć ten sam groupType",
+	"a group must have at least 2 exercises":                                    "grupa musi mieć co najmniej 2 ćwiczenia",
+	"order must list each exercise position exactly once":                       "order musi zawierać każdą pozycję ćwiczenia dokładnie raz",
+	"addedWeight is only allowed for bodyweight sets":                           "addedWeight jest dozwolone tylko w seriach z masą ciała",
+	"assistance must not exceed bodyweight":                                     "odciążenie nie może przekraczać masy ciała",
+	"band must have a color or resistance":                                      "guma musi mieć kolor albo opór",
+	"band color must not exceed 30 characters":                                  "kolor gumy nie może przekraczać 30 znaków",
+	"band resistance must be between 0 and 150 kg":                              "opór gumy musi mieścić się w przedziale 0–150 kg",
+	"assisted band is only allowed for bodyweight sets":                         "guma odciążająca jest dozwolona tylko w seriach z masą ciała",
+	"percentOfTM cannot be combined with bodyweight":                            "percentOfTM nie może być użyte razem z bodyweight",
+	"weightUnit must be kg or lb":                                               "weightUnit musi mieć wartość kg albo lb",
+	"admin endpoints are disabled":                                              "endpointy administracyjne są wyłączone",
+	"admin token is missing or invalid":                                         "brak tokenu administratora albo jest nieprawidłowy",
+	"day must be one of: mon, tue, wed, thu, fri, sat, sun":                     "dzień musi mieć jedną z wartości: mon, tue, wed, thu, fri, sat, sun",
+	"days must list at least one training day":                                  "days musi zawierać co najmniej jeden dzień treningowy",
+	"days must have at least as many training days as the longest program week": "days musi zawierać co najmniej tyle dni treningowych, ile sesji ma najdłuższy tydzień programu",
+	"scheme must be one of: 531, gzclp":                                         "scheme musi mieć jedną z wartości: 531, gzclp",
+	"training max must be > 0":                                                  "maks treningowy musi być > 0",
+	"rounding must be >= 0":                                                     "zaokrąglenie musi być >= 0",
+	"program could not be generated":                                            "nie udało się wygenerować programu",
+	"time zone must be an IANA name (e.g. Europe/Warsaw)":                       "strefa czasowa musi być nazwą IANA (np. Europe/Warsaw)",
+	"Training max not found":                                                    "Nie ustawiono maksa treningowego",
+	"source must be one of: set, suggestion":                                    "source musi mieć jedną z wartości: set, suggestion",
+	"exactly one of delta and percent is required":                              "podaj dokładnie jedno z pól delta i percent",
+	"Template not found":                                                        "Nie znaleziono szablonu",
+	"template name is required":                                                 "nazwa szablonu jest wymagana",
+	"Program not found":                                                         "Nie znaleziono programu",
+	"program name is required":                                                  "nazwa programu jest wymagana",
+	"program must have between 1 and 52 weeks":                                  "program musi mieć od 1 do 52 tygodni",
+	"a week can have at most 7 sessions":                                        "tydzień może mieć najwyżej 7 sesji",
+	"template not found":                                                        "nie znaleziono szablonu",
+	"exercise not found in catalog":                                             "nie ma takiego ćwiczenia w katalogu",
+
+	// Katalog ćwiczeń.
+	"Exercise not found": "Nie znaleziono ćwiczenia",
+	"an exercise with this name or alias already exists":                                               "ćwiczenie o tej nazwie lub aliasie już istnieje",
+	"type must be one of: strength, cardio, mobility":                                                  "typ musi mieć jedną z wartości: strength, cardio, mobility",
+	"equipment must be one of: barbell, dumbbell, kettlebell, machine, cable, bodyweight, band, other": "sprzęt musi mieć jedną z wartości: barbell, dumbbell, kettlebell, machine, cable, bodyweight, band, other",
+	"global catalog exercises cannot be modified":                                                      "ćwiczeń z katalogu globalnego nie można zmieniać",
+	"unknown muscle group (see GET /muscle-groups)":                                                    "nieznana grupa mięśni (patrz GET /muscle-groups)",
+	"a muscle group cannot be both primary and secondary":                                              "grupa mięśni nie może być jednocześnie główna i pomocnicza",
+
+	"alias must not be empty":                     "alias nie może być pusty",
+	"alias must differ from the exercise name":    "alias musi różnić się od nazwy ćwiczenia",
+	"description must not exceed 2000 characters": "opis nie może przekraczać 2000 znaków",
+	"cues must contain at most 20 entries":        "lista wskazówek może mieć najwyżej 20 pozycji",
+	"cue must be between 1 and 200 characters":    "wskazówka musi mieć od 1 do 200 znaków",
+	"must be an absolute http(s) URL":             "wartość musi być bezwzględnym adresem http(s)",
+
+	// Grupy mięśni.
+	"Chest":      "Klatka piersiowa",
+	"Shoulders":  "Barki",
+	"Forearms":   "Przedramiona",
+	"Lats":       "Najszersze grzbietu",
+	"Upper back": "Górna część pleców",
+	"Traps":      "Czworoboczne",
+	"Neck":       "Szyja",
+	"Abs":        "Brzuch",
+	"Obliques":   "Mięśnie skośne brzucha",
+	"Lower back": "Dolna część pleców",
+	"Glutes":     "Pośladki",
+	"Quadriceps": "Czworogłowe",
+	"Hamstrings": "Dwugłowe uda",
+	"Adductors":  "Przywodziciele",
+	"Abductors":  "Odwodziciele",
+	"Calves":     "Łydki",
+
+	// Miejsca pomiaru obwodów.
+	"Waist":         "Talia",
+	"Hips":          "Biodra",
+	"Left arm":      "Lewe ramię",
+	"Right arm":     "Prawe ramię",
+	"Left forearm":  "Lewe przedramię",
+	"Right forearm": "Prawe przedramię",
+	"Left thigh":    "Lewe udo",
+	"Right thigh":   "Prawe udo",
+	"Left calf":     "Lewa łydka",
+	"Right calf":    "Prawa łydka",
+
+	"q is required":                  "parametr q jest wymagany",
+	"limit must be between 1 and 50": "limit musi mieścić się w zakresie 1–50",
+}
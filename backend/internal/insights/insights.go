@@ -0,0 +1,59 @@
+// Package insights analizuje historię treningów i wyciąga z niej wnioski, na razie
+// oznaki zmęczenia wymagające deloadu. Analizę uruchamia okresowo main (raport trafia
+// do InsightStore), a GET /insights może ją też policzyć na żądanie.
+package insights
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"gym-api/internal/models"
+	"gym-api/internal/progression"
+	"gym-api/internal/store"
+)
+
+// DefaultWindow to liczba ostatnich sesji ćwiczenia brana pod uwagę.
+const DefaultWindow = 3
+
+// deloadExercises to liczba zmęczonych ćwiczeń, od której proponujemy deload całego tygodnia.
+const deloadExercises = 2
+
+// Deload szuka zmęczenia w każdym ćwiczeniu z historii treningów (patrz progression.DetectFatigue).
+func Deload(ctx context.Context, workouts *store.WorkoutStore, window int) models.InsightReport {
+	report := models.InsightReport{GeneratedAt: time.Now().UTC(), Window: window, Insights: []models.Insight{}}
+	for _, u := range workouts.ExerciseUsage(ctx) {
+		var history []progression.Session
+		for _, s := range workouts.ExerciseHistory(ctx, u.ExerciseID, u.Name) {
+			history = append(history, progression.Session{Date: s.Date, Sets: s.Sets})
+		}
+		f, ok := progression.DetectFatigue(history, window)
+		if !ok || !f.Deload() {
+			continue
+		}
+		in := models.Insight{
+			Type:            models.InsightDeload,
+			ExerciseID:      u.ExerciseID,
+			Name:            u.Name,
+			Reasons:         []string{},
+			Sessions:        f.Sessions,
+			Weight:          f.Weight,
+			SuggestedWeight: f.SuggestedWeight,
+			MissedSessions:  f.MissedSessions,
+		}
+		if f.RisingRPE {
+			in.Reasons = append(in.Reasons, models.ReasonRisingRPE)
+			in.RPEFrom, in.RPETo = &f.RPEFrom, &f.RPETo
+		}
+		if f.MissedReps() {
+			in.Reasons = append(in.Reasons, models.ReasonMissedReps)
+		}
+		report.Insights = append(report.Insights, in)
+	}
+	slices.SortFunc(report.Insights, func(a, b models.Insight) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	report.DeloadRecommended = len(report.Insights) >= deloadExercises
+	return report
+}
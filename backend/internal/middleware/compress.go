@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	gzipPool = sync.Pool{New: func() any { w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression); return w }}
+	// "deflate" w HTTP to format zlib (RFC 9110 §8.4.1.2), nie surowy strumień flate.
+	zlibPool = sync.Pool{New: func() any { w, _ := zlib.NewWriterLevel(io.Discard, zlib.DefaultCompression); return w }}
+)
+
+// Compress kompresuje odpowiedzi tekstowe (JSON, HTML itp.) algorytmem gzip lub deflate,
+// zgodnie z nagłówkiem Accept-Encoding klienta. Odpowiedzi bez body
+// i już zakodowane przepuszczamy bez zmian.
+func Compress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding wybiera gzip lub deflate (w tej kolejności preferencji),
+// pomijając kodowania wyłączone przez klienta wartością q=0.
+func negotiateEncoding(header string) string {
+	accepted := map[string]bool{}
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		accepted[name] = q > 0
+	}
+	for _, enc := range []string{"gzip", "deflate"} {
+		if ok, listed := accepted[enc]; (listed && ok) || (!listed && accepted["*"]) {
+			return enc
+		}
+	}
+	return ""
+}
+
+// compressWriter decyduje o kompresji przy pierwszym WriteHeader/Write,
+// kiedy znany jest już status i Content-Type odpowiedzi.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	enc      io.WriteCloser
+	decided  bool
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if !cw.decided {
+		cw.decide(status)
+	}
+	cw.ResponseWriter.WriteHeader(status)
+}
+
+func (cw *compressWriter) Write(b []byte) (int, error) {
+	if !cw.decided {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.enc != nil {
+		return cw.enc.Write(b)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// Flush wypycha skompresowane dane do klienta (np. przy strumieniowaniu).
+func (cw *compressWriter) Flush() {
+	if f, ok := cw.enc.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap pozwala http.ResponseController dotrzeć do oryginalnego writera.
+func (cw *compressWriter) Unwrap() http.ResponseWriter { return cw.ResponseWriter }
+
+func (cw *compressWriter) decide(status int) {
+	cw.decided = true
+	h := cw.Header()
+	if status < 200 || status == http.StatusNoContent || status == http.StatusNotModified ||
+		h.Get("Content-Encoding") != "" || !compressible(h.Get("Content-Type")) {
+		return
+	}
+
+	h.Set("Content-Encoding", cw.encoding)
+	h.Del("Content-Length")
+	switch cw.encoding {
+	case "gzip":
+		gw := gzipPool.Get().(*gzip.Writer)
+		gw.Reset(cw.ResponseWriter)
+		cw.enc = gw
+	case "deflate":
+		zw := zlibPool.Get().(*zlib.Writer)
+		zw.Reset(cw.ResponseWriter)
+		cw.enc = zw
+	}
+}
+
+// close kończy strumień kompresji i oddaje writer do puli.
+func (cw *compressWriter) close() {
+	switch enc := cw.enc.(type) {
+	case *gzip.Writer:
+		_ = enc.Close()
+		gzipPool.Put(enc)
+	case *zlib.Writer:
+		_ = enc.Close()
+		zlibPool.Put(enc)
+	}
+}
+
+func compressible(contentType string) bool {
+	ct, _, _ := strings.Cut(contentType, ";")
+	ct = strings.TrimSpace(strings.ToLower(ct))
+	return strings.HasPrefix(ct, "text/") ||
+		ct == "application/json" ||
+		strings.HasSuffix(ct, "+json") ||
+		ct == "application/javascript" ||
+		ct == "application/xml" ||
+		ct == "image/svg+xml"
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+)
+
+// CORSOptions konfiguruje middleware CORS.
+type CORSOptions struct {
+	// AllowedOrigins to lista dozwolonych originów (np. "https://app.example.com");
+	// wpis "*" zezwala na dowolny origin.
+	AllowedOrigins []string
+	// AllowCredentials pozwala przeglądarce wysyłać ciasteczka i nagłówek Authorization.
+	// Specyfikacja zabrania łączenia tego z "*", co sprawdza walidacja konfiguracji.
+	AllowCredentials bool
+}
+
+const (
+	corsAllowMethods  = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	corsAllowHeaders  = "Content-Type, Authorization, If-None-Match, If-Match"
+	corsExposeHeaders = RequestIDHeader + ", ETag"
+	corsMaxAge        = "600"
+)
+
+// CORS dodaje nagłówki CORS dla dozwolonych originów i obsługuje preflight (OPTIONS).
+// Gdy odpowiedź zależy od nagłówka Origin, ustawia "Vary: Origin",
+// żeby cache (CDN, przeglądarka) nie podał odpowiedzi innemu originowi.
+func CORS(opts CORSOptions, next http.Handler) http.Handler {
+	allowAll := slices.Contains(opts.AllowedOrigins, "*")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		if !allowAll {
+			w.Header().Add("Vary", "Origin")
+		}
+		if preflight {
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		// Żądania spoza przeglądarki (bez Origin) przepuszczamy bez nagłówków CORS.
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed := allowAll || slices.Contains(opts.AllowedOrigins, origin)
+		if !allowed {
+			if preflight {
+				// Niedozwolony origin: przeglądarka i tak zablokuje żądanie, więc nie wołamy handlera.
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if allowAll && !opts.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		if opts.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			// Preflight nie wymaga body
+			w.Header().Set("Access-Control-Allow-Methods", corsAllowMethods)
+			w.Header().Set("Access-Control-Allow-Headers", corsAllowHeaders)
+			w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Access-Control-Expose-Headers", corsExposeHeaders)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// SecurityOptions określa nagłówki bezpieczeństwa dodawane do każdej odpowiedzi.
+// Pusta wartość tekstowa oznacza, że dany nagłówek nie jest wysyłany.
+type SecurityOptions struct {
+	FrameOptions          string // X-Frame-Options, np. "DENY"
+	ContentSecurityPolicy string // Content-Security-Policy
+	// HSTSMaxAge to czas (w sekundach) dla Strict-Transport-Security;
+	// nagłówek wysyłamy tylko przy włączonym TLS i dodatnim czasie.
+	HSTSMaxAge            int
+	HSTSIncludeSubdomains bool
+	TLS                   bool
+}
+
+// SecurityHeaders dodaje nagłówki utrudniające sniffing MIME, osadzanie w ramkach
+// i ładowanie obcych zasobów, a przy HTTPS także HSTS.
+func SecurityHeaders(opts SecurityOptions, next http.Handler) http.Handler {
+	hsts := ""
+	if opts.TLS && opts.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(opts.HSTSMaxAge)
+		if opts.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Set("X-Content-Type-Options", "nosniff")
+		if opts.FrameOptions != "" {
+			h.Set("X-Frame-Options", opts.FrameOptions)
+		}
+		if opts.ContentSecurityPolicy != "" {
+			h.Set("Content-Security-Policy", opts.ContentSecurityPolicy)
+		}
+		if hsts != "" {
+			h.Set("Strict-Transport-Security", hsts)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
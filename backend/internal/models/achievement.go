@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Achievement = odznaka zdobyta albo jeszcze zablokowana (GET /achievements)
+type Achievement struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Category    string     `json:"category"` // workouts, strength, volume, consistency
+	Earned      bool       `json:"earned"`
+	EarnedAt    *time.Time `json:"earnedAt,omitempty"`
+	Progress    float64    `json:"progress"` // bieżąca wartość, np. liczba treningów
+	Target      float64    `json:"target"`
+	Percent     int        `json:"percent"` // 0–100
+}
+
+// AchievementList = wszystkie odznaki z podsumowaniem
+type AchievementList struct {
+	Earned int           `json:"earned"`
+	Total  int           `json:"total"`
+	Items  []Achievement `json:"items"` // najpierw zdobyte (od najnowszej), potem zablokowane w kolejności reguł
+}
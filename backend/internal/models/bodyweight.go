@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// BodyweightEntry = jeden pomiar masy ciała
+type BodyweightEntry struct {
+	ID      int      `json:"id"`
+	Date    string   `json:"date"`              // YYYY-MM-DD
+	Weight  float64  `json:"weight"`            // kg; w odpowiedzi w jednostce WeightUnit
+	BodyFat *float64 `json:"bodyFat,omitempty"` // %, np. z wagi z pomiarem impedancji
+	Note    string   `json:"note,omitempty"`
+	// WeightUnit = jednostka Weight w odpowiedzi (patrz Profile); w magazynie pusta (kg).
+	WeightUnit string `json:"weightUnit,omitempty"`
+	// Source i MeasuredAt mają pomiary z importu z wagi; po MeasuredAt pomijamy duplikaty.
+	Source     string     `json:"source"` // manual, withings, renpho, applehealth, googlefit, fitbit
+	MeasuredAt *time.Time `json:"measuredAt,omitempty"`
+	Owner      string     `json:"-"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+// BodyweightRequest = dane pomiaru przy tworzeniu (POST) i zamianie (PUT)
+type BodyweightRequest struct {
+	Date    string   `json:"date"` // pusta = dzisiaj
+	Weight  float64  `json:"weight"`
+	BodyFat *float64 `json:"bodyFat"`
+	Note    string   `json:"note"`
+	// WeightUnit = jednostka Weight (kg albo lb); pusta = jednostka z profilu.
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
+
+// BodyweightImportError = wiersz pliku z wagi, którego nie udało się wczytać
+type BodyweightImportError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// BodyweightImportResult = wynik importu z wagi (POST /bodyweight/import)
+type BodyweightImportResult struct {
+	Format     string                  `json:"format"` // withings, renpho
+	Imported   int                     `json:"imported"`
+	Duplicates int                     `json:"duplicates"` // pomiary o czasie już zapisanym wcześniej
+	Errors     []BodyweightImportError `json:"errors"`
+}
+
+// BodyweightPoint = dzień na wykresie masy ciała
+type BodyweightPoint struct {
+	Date    string  `json:"date"`
+	Weight  float64 `json:"weight"`  // średnia z pomiarów tego dnia
+	Average float64 `json:"average"` // średnia krocząca z okna dni kończącego się tego dnia
+}
+
+// BodyweightTrend = masa ciała z wygładzeniem (GET /bodyweight/trend)
+type BodyweightTrend struct {
+	Window int               `json:"window"`           // długość okna średniej w dniach
+	Change *float64          `json:"change,omitempty"` // różnica średnich: ostatni − pierwszy punkt
+	Points []BodyweightPoint `json:"points"`           // od najstarszego, tylko dni z pomiarem
+}
+
+// BodyCompositionPoint = wskaźniki składu ciała w dniu pomiaru masy albo obwodów
+type BodyCompositionPoint struct {
+	Date           string   `json:"date"`
+	Weight         *float64 `json:"weight,omitempty"` // ostatni pomiar masy do tego dnia
+	BMI            *float64 `json:"bmi,omitempty"`
+	BodyFat        *float64 `json:"bodyFat,omitempty"`       // %
+	BodyFatSource  string   `json:"bodyFatSource,omitempty"` // navy (z obwodów) albo scale (z wagi)
+	LeanMass       *float64 `json:"leanMass,omitempty"`      // kg
+	FFMI           *float64 `json:"ffmi,omitempty"`
+	NormalizedFFMI *float64 `json:"normalizedFfmi,omitempty"`
+}
+
+// BodyCompositionSeries = skład ciała w czasie (GET /body-composition)
+type BodyCompositionSeries struct {
+	Sex    string                 `json:"sex"`
+	Height float64                `json:"height"` // cm
+	Points []BodyCompositionPoint `json:"points"` // od najstarszego
+}
@@ -0,0 +1,29 @@
+package models
+
+// CalendarWorkout = skrót treningu w widoku kalendarza
+type CalendarWorkout struct {
+	ID      int     `json:"id"`
+	Title   string  `json:"title"`
+	Planned bool    `json:"planned"`
+	Sets    int     `json:"sets"`
+	Tonnage float64 `json:"tonnage"` // kg × powtórzenia
+}
+
+// CalendarDay = jeden dzień miesiąca
+type CalendarDay struct {
+	Date     string            `json:"date"`
+	Trained  bool              `json:"trained"` // był co najmniej jeden wykonany trening
+	Tonnage  float64           `json:"tonnage"` // suma wykonanych treningów
+	Workouts []CalendarWorkout `json:"workouts"`
+	// Readiness = gotowość 0–100 z oceny samopoczucia tego dnia (POST /checkins)
+	Readiness *int `json:"readiness,omitempty"`
+}
+
+// CalendarMonth = widok miesiąca (GET /calendar/{month})
+type CalendarMonth struct {
+	Month        string        `json:"month"` // YYYY-MM
+	TrainingDays int           `json:"trainingDays"`
+	Workouts     int           `json:"workouts"` // wykonane, bez zaplanowanych
+	Tonnage      float64       `json:"tonnage"`
+	Days         []CalendarDay `json:"days"` // wszystkie dni miesiąca, od pierwszego
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Checkin = codzienna ocena samopoczucia (każdy wskaźnik w skali 1–5)
+type Checkin struct {
+	ID           int    `json:"id"`
+	Date         string `json:"date"` // YYYY-MM-DD
+	Energy       int    `json:"energy"`
+	Soreness     int    `json:"soreness"` // 5 = bardzo obolały
+	Stress       int    `json:"stress"`   // 5 = bardzo zestresowany
+	SleepQuality int    `json:"sleepQuality"`
+	// Readiness = gotowość do treningu 0–100 wyliczona z czterech ocen
+	// (wysoka energia i jakość snu podnoszą ją, zakwasy i stres obniżają).
+	Readiness int       `json:"readiness"`
+	Note      string    `json:"note,omitempty"`
+	Owner     string    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CheckinRequest = dane oceny przy tworzeniu (POST) i zamianie (PUT)
+type CheckinRequest struct {
+	Date     string `json:"date"` // pusta = dzisiaj
+	Energy   int    `json:"energy"`
+	Soreness int    `json:"soreness"`
+	Stress   int    `json:"stress"`
+	// SleepQuality pominięta = jakość ostatniej nocy z dziennika snu (GET /sleep).
+	SleepQuality int    `json:"sleepQuality"`
+	Note         string `json:"note"`
+}
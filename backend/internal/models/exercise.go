@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// CatalogExercise = ćwiczenie w katalogu (np. "Bench Press"), do którego
+// odwołują się ćwiczenia w treningach przez ExerciseID
+type CatalogExercise struct {
+	ID               int      `json:"id"`
+	Name             string   `json:"name"`             // nazwa kanoniczna, unikalna bez względu na wielkość liter
+	Aliases          []string `json:"aliases"`          // inne nazwy tego ćwiczenia, np. "OHP", "Wyciskanie żołnierskie"
+	PrimaryMuscles   []string `json:"primaryMuscles"`   // np. ["chest"]
+	SecondaryMuscles []string `json:"secondaryMuscles"` // np. ["triceps", "shoulders"]
+	Equipment        string   `json:"equipment"`        // np. "barbell"
+	Type             string   `json:"type"`             // strength, cardio, mobility
+	ExerciseInstructions
+	// Owner = właściciel ćwiczenia własnego; pusty = katalog globalny (tylko do odczytu przez API).
+	Owner     string    `json:"owner,omitempty"`
+	Archived  bool      `json:"archived"` // zarchiwizowane nie pojawiają się na liście, ale treningi dalej je wskazują
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CatalogExerciseRequest = dane ćwiczenia katalogowego przy tworzeniu (POST) i zamianie (PUT)
+type CatalogExerciseRequest struct {
+	Name             string   `json:"name"`
+	Aliases          []string `json:"aliases"`
+	PrimaryMuscles   []string `json:"primaryMuscles"`
+	SecondaryMuscles []string `json:"secondaryMuscles"`
+	Equipment        string   `json:"equipment"`
+	Type             string   `json:"type"`
+	ExerciseInstructions
+}
+
+// ExerciseInstructions = wskazówki techniczne wyświetlane obok ćwiczenia w treningu
+type ExerciseInstructions struct {
+	Description string   `json:"description"`
+	Cues        []string `json:"cues"`               // krótkie hasła, np. "łopatki ściągnięte"
+	ImageURL    string   `json:"imageUrl,omitempty"` // http(s)
+	VideoURL    string   `json:"videoUrl,omitempty"` // http(s), np. film na YouTube
+}
+
+// MuscleGroup = grupa mięśni z taksonomii używanej przez katalog ćwiczeń
+type MuscleGroup struct {
+	ID     string `json:"id"`     // np. "quadriceps"
+	Name   string `json:"name"`   // nazwa wyświetlana (tłumaczona wg Accept-Language)
+	Region string `json:"region"` // upper, core, lower
+}
+
+// ExerciseSuggestion = podpowiedź nazwy ćwiczenia przy wpisywaniu
+type ExerciseSuggestion struct {
+	Name       string `json:"name"`
+	ExerciseID int    `json:"exerciseId,omitempty"` // ID w katalogu, jeśli ćwiczenie tam jest
+	Source     string `json:"source"`               // catalog, history
+	Uses       int    `json:"uses"`                 // liczba treningów z tym ćwiczeniem
+}
+
+// ProgressionRule = sposób progresji ćwiczenia z katalogu, używany przez /workouts/next-suggestion
+type ProgressionRule struct {
+	ExerciseID int     `json:"exerciseId"`
+	Scheme     string  `json:"scheme"`              // linear: ciężar po każdej udanej sesji; double: zakres powtórzeń
+	Increment  float64 `json:"increment"`           // kg
+	MinReps    int     `json:"minReps,omitempty"`   // double: dolna granica zakresu, np. 8
+	MaxReps    int     `json:"maxReps,omitempty"`   // double: górna granica zakresu, np. 12
+	TargetRPE  float64 `json:"targetRpe,omitempty"` // docelowe RPE serii roboczych; 0 = domyślne (8)
+}
+
+// ProgressionRuleRequest = ustawienie reguły progresji (PUT /exercises/{id}/progression)
+type ProgressionRuleRequest struct {
+	Scheme    string  `json:"scheme"`
+	Increment float64 `json:"increment"`
+	MinReps   int     `json:"minReps"`
+	MaxReps   int     `json:"maxReps"`
+	TargetRPE float64 `json:"targetRpe"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Rodzaje celów.
+const (
+	GoalLift      = "lift"      // ciężar w ćwiczeniu, np. przysiad 140 kg
+	GoalFrequency = "frequency" // liczba treningów w tygodniu, np. 4×/tydzień
+)
+
+// Statusy celu.
+const (
+	GoalActive   = "active"
+	GoalAchieved = "achieved"
+	GoalMissed   = "missed" // minął termin, a cel nie został osiągnięty
+)
+
+// Goal = cel użytkownika z postępem liczonym z zapisanych treningów
+type Goal struct {
+	ID           int       `json:"id"`
+	Type         string    `json:"type"` // lift, frequency
+	Title        string    `json:"title"`
+	ExerciseID   int       `json:"exerciseId,omitempty"`   // cel lift
+	TargetWeight float64   `json:"targetWeight,omitempty"` // cel lift, kg; w odpowiedzi w jednostce WeightUnit
+	PerWeek      int       `json:"perWeek,omitempty"`      // cel frequency
+	Deadline     string    `json:"deadline,omitempty"`     // YYYY-MM-DD; brak = bez terminu
+	Owner        string    `json:"-"`                      // cele są prywatne
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	// Postęp (liczony przy odczycie, nie zapisywany).
+	Current float64 `json:"current"` // najcięższa seria albo treningi w bieżącym tygodniu
+	Target  float64 `json:"target"`
+	Percent int     `json:"percent"` // 0–100
+	Status  string  `json:"status"`  // active, achieved, missed
+	// WeightUnit = jednostka TargetWeight, Current i Target celu lift w odpowiedzi (patrz Profile).
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
+
+// GoalRequest = dane celu przy tworzeniu (POST) i zamianie (PUT)
+type GoalRequest struct {
+	Type         string  `json:"type"`
+	Title        string  `json:"title"` // puste = tytuł z ćwiczenia i ciężaru albo częstotliwości
+	ExerciseID   int     `json:"exerciseId"`
+	TargetWeight float64 `json:"targetWeight"`
+	PerWeek      int     `json:"perWeek"`
+	Deadline     string  `json:"deadline"`
+	// WeightUnit = jednostka targetWeight (kg albo lb); pusta = jednostka z profilu.
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
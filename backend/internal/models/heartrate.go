@@ -0,0 +1,54 @@
+package models
+
+// HRBucket = czas spędzony przy danym tętnie (histogram tętna ćwiczenia kardio)
+type HRBucket struct {
+	BPM     int `json:"bpm"`
+	Seconds int `json:"seconds"`
+}
+
+// HeartRateSettings = tętno maksymalne i wynikające z niego strefy (GET/PUT /heart-rate/max)
+type HeartRateSettings struct {
+	MaxHR int           `json:"maxHr"`           // uderzenia/min
+	Zones []HRZoneRange `json:"zones,omitempty"` // tylko w odpowiedzi
+}
+
+// HRZoneRange = granice strefy tętna (Min i Max włącznie)
+type HRZoneRange struct {
+	Zone int `json:"zone"` // 1–5
+	Min  int `json:"min"`  // uderzenia/min
+	Max  int `json:"max"`
+}
+
+// HRZoneTime = czas w strefie tętna
+type HRZoneTime struct {
+	Zone    int     `json:"zone"`
+	Seconds int     `json:"seconds"`
+	Percent float64 `json:"percent"` // % czasu z pomiarem tętna
+}
+
+// SessionHRZones = rozkład czasu w strefach jednego ćwiczenia kardio
+type SessionHRZones struct {
+	Exercise int    `json:"exercise"` // indeks ćwiczenia w treningu
+	Name     string `json:"name"`
+	// Estimated = brak histogramu tętna; cały czas ćwiczenia przypisano strefie średniego tętna.
+	Estimated bool         `json:"estimated"`
+	Below     int          `json:"below"` // sekundy poniżej strefy 1
+	Zones     []HRZoneTime `json:"zones"`
+}
+
+// WorkoutHRZones = strefy tętna ćwiczeń kardio treningu (GET /workouts/{id}/hr-zones)
+type WorkoutHRZones struct {
+	WorkoutID int              `json:"workoutId"`
+	Date      string           `json:"date"`
+	MaxHR     int              `json:"maxHr"`
+	Sessions  []SessionHRZones `json:"sessions"` // tylko ćwiczenia z tętnem
+}
+
+// HRZoneWeek = czas w strefach tętna w tygodniu (GET /stats/hr-zones)
+type HRZoneWeek struct {
+	WeekStart string       `json:"weekStart"` // poniedziałek, YYYY-MM-DD
+	Sessions  int          `json:"sessions"`  // ćwiczenia kardio z tętnem
+	Estimated int          `json:"estimated"` // z nich bez histogramu (strefa ze średniego tętna)
+	Below     int          `json:"below"`     // sekundy poniżej strefy 1
+	Zones     []HRZoneTime `json:"zones"`
+}
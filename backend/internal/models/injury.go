@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Stopnie urazu.
+const (
+	InjuryMild     = "mild"
+	InjuryModerate = "moderate"
+	InjurySevere   = "severe"
+)
+
+// Statusy urazu.
+const (
+	InjuryActive     = "active"
+	InjuryRecovering = "recovering" // wraca do treningu, ale z ograniczeniami
+	InjuryResolved   = "resolved"
+)
+
+// Injury = uraz albo dolegliwość bólowa
+type Injury struct {
+	ID        int    `json:"id"`
+	BodyPart  string `json:"bodyPart"`  // np. "lewy bark", "odcinek lędźwiowy"
+	Severity  string `json:"severity"`  // mild, moderate, severe
+	OnsetDate string `json:"onsetDate"` // YYYY-MM-DD
+	Status    string `json:"status"`    // active, recovering, resolved
+	// AffectedExercises = ID ćwiczeń z katalogu, których uraz nie pozwala wykonywać;
+	// dopóki uraz nie jest wyleczony (resolved), należy je zastępować innymi.
+	AffectedExercises []int     `json:"affectedExercises"`
+	Note              string    `json:"note,omitempty"`
+	Owner             string    `json:"-"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// InjuryRequest = dane urazu przy tworzeniu (POST) i zamianie (PUT)
+type InjuryRequest struct {
+	BodyPart          string `json:"bodyPart"`
+	Severity          string `json:"severity"`
+	OnsetDate         string `json:"onsetDate"` // pusta = dzisiaj
+	Status            string `json:"status"`    // pusty = active
+	AffectedExercises []int  `json:"affectedExercises"`
+	Note              string `json:"note"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+// Rodzaje i powody wniosków z analizy treningów.
+const (
+	InsightDeload = "deload"
+
+	ReasonRisingRPE  = "rising_rpe"  // RPE rośnie przy tym samym ciężarze
+	ReasonMissedReps = "missed_reps" // niedobrane powtórzenia w kilku sesjach
+)
+
+// Insight = wniosek dla jednego ćwiczenia (na razie tylko propozycja deloadu)
+type Insight struct {
+	Type            string   `json:"type"` // deload
+	ExerciseID      int      `json:"exerciseId,omitempty"`
+	Name            string   `json:"name"`
+	Reasons         []string `json:"reasons"`  // rising_rpe, missed_reps
+	Sessions        int      `json:"sessions"` // liczba przeanalizowanych sesji
+	Weight          float64  `json:"weight"`   // ciężar serii roboczych w ostatniej sesji
+	SuggestedWeight float64  `json:"suggestedWeight"`
+	RPEFrom         *float64 `json:"rpeFrom,omitempty"` // średnie RPE na początku wzrostu
+	RPETo           *float64 `json:"rpeTo,omitempty"`   // średnie RPE ostatniej sesji
+	MissedSessions  int      `json:"missedSessions"`
+}
+
+// InsightReport = wynik analizy zmęczenia (GET /insights)
+type InsightReport struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+	Window      int       `json:"window"` // ile ostatnich sesji ćwiczenia analizujemy
+	// DeloadRecommended = zmęczenie w kilku ćwiczeniach naraz, czyli czas na lżejszy tydzień.
+	DeloadRecommended bool      `json:"deloadRecommended"`
+	Insights          []Insight `json:"insights"`
+}
+
+// E1RMPoint = najlepszy szacowany 1RM z jednej sesji ćwiczenia
+type E1RMPoint struct {
+	Date   string  `json:"date"`
+	E1RM   float64 `json:"e1rm"`
+	Weight float64 `json:"weight"` // seria, z której pochodzi szacunek
+	Reps   int     `json:"reps"`
+}
+
+// PlateauReport = analiza stagnacji ćwiczenia (GET /exercises/{id}/plateau)
+type PlateauReport struct {
+	ExerciseID int    `json:"exerciseId"`
+	Name       string `json:"name"`
+	Weeks      int    `json:"weeks"` // długość okna analizy
+	// EnoughData = są sesje i w oknie, i przed nim; bez tego Plateau zawsze jest false.
+	EnoughData   bool        `json:"enoughData"`
+	Plateau      bool        `json:"plateau"` // w oknie nie poprawiono najlepszego 1RM
+	BestE1RM     float64     `json:"bestE1rm"`
+	BestDate     string      `json:"bestDate,omitempty"` // ostatnia poprawa najlepszego wyniku
+	WindowBest   float64     `json:"windowBest"`
+	PriorBest    float64     `json:"priorBest"`
+	TrendPerWeek float64     `json:"trendPerWeek"` // kg/tydzień w oknie (regresja liniowa)
+	Trend        []E1RMPoint `json:"trend"`        // okno i tak samo długi okres przed nim
+}
+
+// E1RMSeries = szacowany 1RM ćwiczenia sesja po sesji (GET /exercises/{id}/e1rm)
+type E1RMSeries struct {
+	ExerciseID int         `json:"exerciseId"`
+	Name       string      `json:"name"`
+	Formula    string      `json:"formula"` // epley, brzycki, lombardi
+	Best       *E1RMPoint  `json:"best,omitempty"`
+	Points     []E1RMPoint `json:"points"` // od najstarszej sesji
+	// WeightUnit = jednostka ciężarów w odpowiedzi (patrz Profile).
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Integration = połączenie konta z zewnętrznym serwisem (GET /integrations/strava)
+type Integration struct {
+	Provider    string     `json:"provider"` // np. "strava"
+	Connected   bool       `json:"connected"`
+	AthleteID   string     `json:"athleteId,omitempty"` // ID konta w serwisie
+	ConnectedAt *time.Time `json:"connectedAt,omitempty"`
+	LastSyncAt  *time.Time `json:"lastSyncAt,omitempty"`
+	LastError   string     `json:"lastError,omitempty"` // błąd ostatniej synchronizacji
+	Imported    int        `json:"imported"`            // aktywności powiązane z treningami
+	// Settings = co importować (tylko serwisy z treningami różnego rodzaju, np. Garmin)
+	Settings *IntegrationSettings `json:"settings,omitempty"`
+
+	Owner          string    `json:"-"`
+	AccessToken    string    `json:"-"`
+	RefreshToken   string    `json:"-"`
+	TokenExpiresAt time.Time `json:"-"`
+}
+
+// IntegrationSettings = ustawienia importu połączenia (PUT /integrations/garmin)
+type IntegrationSettings struct {
+	Cardio   bool `json:"cardio"`   // aktywności kardio (bieg, rower...)
+	Strength bool `json:"strength"` // treningi siłowe z seriami
+}
+
+// IntegrationConnect = adres strony zgody serwisu (POST /integrations/strava/connect)
+type IntegrationConnect struct {
+	AuthorizeURL string `json:"authorizeUrl"`
+}
+
+// SyncResult = wynik synchronizacji aktywności (POST /integrations/strava/sync)
+type SyncResult struct {
+	Fetched  int       `json:"fetched"`  // aktywności pobrane z serwisu
+	Imported []Workout `json:"imported"` // nowe treningi
+	// Duplicates = aktywności już zaimportowane albo pasujące do treningu wpisanego ręcznie.
+	Duplicates int `json:"duplicates"`
+	// Skipped = aktywności wyłączone w ustawieniach importu albo takie, z których nie da się
+	// zrobić poprawnego treningu (np. bez czasu); kolejna synchronizacja spróbuje ponownie.
+	Skipped int `json:"skipped"`
+	// Bodyweight = pomiary masy ciała (tylko serwisy z pomiarami z wagi: Google Fit, Fitbit)
+	Bodyweight *ImportCounts `json:"bodyweight,omitempty"`
+}
+
+// ImportCounts = liczba rekordów jednego rodzaju w imporcie archiwum
+type ImportCounts struct {
+	Imported   int `json:"imported"`
+	Duplicates int `json:"duplicates"` // już zaimportowane albo wpisane ręcznie
+	Skipped    int `json:"skipped"`    // niepoprawne (np. trening bez czasu, masa poza zakresem)
+}
+
+// AppleHealthImportResult = wynik importu eksportu Apple Health (POST /import/apple-health)
+type AppleHealthImportResult struct {
+	Workouts   ImportCounts `json:"workouts"`
+	Bodyweight ImportCounts `json:"bodyweight"`
+}
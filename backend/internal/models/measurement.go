@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// MeasurementSite = miejsce pomiaru obwodu (taksonomia w store, patrz GET /measurements/sites)
+type MeasurementSite struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Latest *float64 `json:"latest,omitempty"` // ostatni pomiar w cm
+	Date   string   `json:"date,omitempty"`   // data ostatniego pomiaru
+}
+
+// MeasurementEntry = pomiary obwodów z jednego dnia
+type MeasurementEntry struct {
+	ID        int                `json:"id"`
+	Date      string             `json:"date"`   // YYYY-MM-DD
+	Values    map[string]float64 `json:"values"` // miejsce pomiaru -> obwód w cm
+	Note      string             `json:"note,omitempty"`
+	Owner     string             `json:"-"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+// MeasurementRequest = dane pomiarów przy tworzeniu (POST) i zamianie (PUT)
+type MeasurementRequest struct {
+	Date   string             `json:"date"` // pusta = dzisiaj
+	Values map[string]float64 `json:"values"`
+	Note   string             `json:"note"`
+}
+
+// MeasurementPoint = jeden pomiar w historii miejsca
+type MeasurementPoint struct {
+	Date  string  `json:"date"`
+	Value float64 `json:"value"` // cm
+}
+
+// MeasurementHistory = historia jednego miejsca pomiaru (GET /measurements/sites/{site})
+type MeasurementHistory struct {
+	Site   string             `json:"site"`
+	Name   string             `json:"name"`
+	Unit   string             `json:"unit"`             // cm
+	Change *float64           `json:"change,omitempty"` // ostatni − pierwszy pomiar
+	Points []MeasurementPoint `json:"points"`           // od najstarszego
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// NutritionEntry = posiłek (albo suma dnia) w dzienniku żywienia
+type NutritionEntry struct {
+	ID        int       `json:"id"`
+	Date      string    `json:"date"`           // YYYY-MM-DD
+	Meal      string    `json:"meal,omitempty"` // np. "śniadanie"; dzień może mieć wiele wpisów
+	Calories  int       `json:"calories"`       // kcal
+	Protein   float64   `json:"protein"`        // g
+	Carbs     float64   `json:"carbs"`          // g
+	Fat       float64   `json:"fat"`            // g
+	Note      string    `json:"note,omitempty"`
+	Owner     string    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// NutritionRequest = dane wpisu przy tworzeniu (POST) i zamianie (PUT)
+type NutritionRequest struct {
+	Date     string  `json:"date"` // pusta = dzisiaj
+	Meal     string  `json:"meal"`
+	Calories int     `json:"calories"`
+	Protein  float64 `json:"protein"`
+	Carbs    float64 `json:"carbs"`
+	Fat      float64 `json:"fat"`
+	Note     string  `json:"note"`
+}
+
+// NutritionWeek = średnie dzienne spożycie w tygodniu (GET /nutrition/weekly)
+type NutritionWeek struct {
+	WeekStart string  `json:"weekStart"` // poniedziałek, YYYY-MM-DD
+	Days      int     `json:"days"`      // dni z co najmniej jednym wpisem
+	Calories  float64 `json:"calories"`  // średnia z dni z wpisami, kcal
+	Protein   float64 `json:"protein"`   // g
+	Carbs     float64 `json:"carbs"`     // g
+	Fat       float64 `json:"fat"`       // g
+}
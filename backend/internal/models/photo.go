@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Photo = zdjęcie sylwetki; sam plik leży w magazynie plików (blob), tu są metadane
+type Photo struct {
+	ID          int       `json:"id"`
+	Date        string    `json:"date"`           // YYYY-MM-DD, dzień zrobienia zdjęcia
+	Pose        string    `json:"pose,omitempty"` // front, side, back – do porównań tej samej pozy
+	Note        string    `json:"note,omitempty"`
+	ContentType string    `json:"contentType"`
+	Size        int       `json:"size"` // bajty
+	URL         string    `json:"url"`  // adres pliku: GET /photos/{id}/image
+	Key         string    `json:"-"`    // klucz w magazynie plików
+	Owner       string    `json:"-"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
@@ -0,0 +1,21 @@
+package models
+
+// Jednostki ciężaru. Wszystkie ciężary zapisujemy w kg; w lb tylko je przyjmujemy i zwracamy.
+const (
+	UnitKg = "kg"
+	UnitLb = "lb"
+)
+
+// KgPerLb to masa funta w kg (funt międzynarodowy, 1959).
+const KgPerLb = 0.45359237
+
+// Profile = ustawienia użytkownika (GET/PUT /profile)
+type Profile struct {
+	// WeightUnit = jednostka ciężarów w treningach, szablonach, historii ćwiczeń, maksach
+	// treningowych, rekordach, e1RM, propozycjach progresji, celach i dzienniku masy ciała:
+	// w niej API zwraca ciężary i przyjmuje je, gdy body nie podaje własnego weightUnit.
+	// W kg zostają statystyki zbiorcze (tonaż, obciążenie, normy siłowe, punkty trójboju
+	// i analiza stagnacji), bo ich wzory i progi są w kg, oraz przyrosty progresji
+	// (reguła ćwiczenia, ?increment=), zaokrąglane do talerzy 2,5 kg.
+	WeightUnit string `json:"weightUnit"` // kg (domyślnie) albo lb
+}
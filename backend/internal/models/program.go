@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Program = wielotygodniowy plan treningowy: kolejne tygodnie z sesjami opartymi o szablony
+type Program struct {
+	ID          int           `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Weeks       []ProgramWeek `json:"weeks"`
+	Owner       string        `json:"-"` // programy są prywatne, jak szablony
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+}
+
+// ProgramWeek = jeden tydzień programu; sesje wykonuje się w podanej kolejności
+type ProgramWeek struct {
+	Sessions []ProgramSession `json:"sessions"`
+}
+
+// ProgramSession = zaplanowany trening tygodnia wskazujący szablon
+type ProgramSession struct {
+	TemplateID int    `json:"templateId"`
+	Notes      string `json:"notes,omitempty"` // np. "ciężka sesja", "deload"
+}
+
+// ProgramRequest = dane programu przy tworzeniu (POST) i zamianie (PUT)
+type ProgramRequest struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Weeks       []ProgramWeek `json:"weeks"`
+}
+
+// GenerateProgramRequest = dane generatora programu (POST /programs/generate?scheme=)
+type GenerateProgramRequest struct {
+	Name          string             `json:"name"`          // pusty = nazwa schematu
+	TrainingMaxes map[string]float64 `json:"trainingMaxes"` // w jednostce WeightUnit; klucze: squat, bench, deadlift, press; brak = zapisany TM
+	Rounding      *float64           `json:"rounding"`      // krok zaokrąglania ciężarów w jednostce WeightUnit, domyślnie 2.5 kg albo 5 lb
+	// WeightUnit = jednostka maksów i kroku zaokrąglania (kg albo lb); pusta = jednostka z profilu.
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
+
+// GeneratedProgram = zapisany program wraz z szablonami utworzonymi dla jego sesji
+type GeneratedProgram struct {
+	Program   Program           `json:"program"`
+	Templates []WorkoutTemplate `json:"templates"`
+}
@@ -0,0 +1,43 @@
+package models
+
+// RecordEntry = jeden rekord osobisty i trening, w którym go ustanowiono
+type RecordEntry struct {
+	Value     float64 `json:"value"`            // ciężar, e1RM, tonaż albo liczba powtórzeń – zależnie od rekordu
+	Weight    float64 `json:"weight,omitempty"` // ciężar serii rekordowej
+	Reps      int     `json:"reps,omitempty"`   // powtórzenia serii rekordowej
+	Date      string  `json:"date"`
+	WorkoutID int     `json:"workoutId"`
+}
+
+// ExerciseRecords = rekordy osobiste jednego ćwiczenia (GET /prs)
+type ExerciseRecords struct {
+	ExerciseID     int          `json:"exerciseId,omitempty"` // 0 = ćwiczenie spoza katalogu
+	Name           string       `json:"name"`
+	HeaviestWeight *RecordEntry `json:"heaviestWeight,omitempty"`
+	BestE1RM       *RecordEntry `json:"bestE1rm,omitempty"`   // wzór Epleya, serie 1–10 powtórzeń
+	BestVolume     *RecordEntry `json:"bestVolume,omitempty"` // największy tonaż ćwiczenia w jednym treningu
+	// RepsAtWeight = najwięcej powtórzeń w serii na danym ciężarze, od najcięższego;
+	// wpis bez ciężaru dotyczy serii z masą ciała.
+	RepsAtWeight []RecordEntry `json:"repsAtWeight"`
+	// WeightUnit = jednostka ciężarów w odpowiedzi (patrz Profile).
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
+
+// Rodzaje rekordów osobistych.
+const (
+	RecordHeaviestWeight = "heaviest_weight"
+	RecordBestE1RM       = "best_e1rm"
+	RecordBestVolume     = "best_volume"
+	RecordRepsAtWeight   = "reps_at_weight"
+)
+
+// NewRecord = rekord pobity w nowo dodanym treningu
+type NewRecord struct {
+	ExerciseID int     `json:"exerciseId,omitempty"`
+	Name       string  `json:"name"`
+	Type       string  `json:"type"` // heaviest_weight, best_e1rm, best_volume, reps_at_weight
+	Value      float64 `json:"value"`
+	Previous   float64 `json:"previous"` // poprzedni rekord
+	Weight     float64 `json:"weight,omitempty"`
+	Reps       int     `json:"reps,omitempty"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// SleepEntry = jedna noc snu, przypisana do dnia pobudki
+type SleepEntry struct {
+	ID        int       `json:"id"`
+	Date      string    `json:"date"`               // YYYY-MM-DD, dzień pobudki
+	Bedtime   string    `json:"bedtime,omitempty"`  // HH:MM
+	WakeTime  string    `json:"wakeTime,omitempty"` // HH:MM
+	Duration  int       `json:"duration"`           // minuty snu
+	Quality   int       `json:"quality,omitempty"`  // 1–5, 0 = nie oceniono
+	Note      string    `json:"note,omitempty"`
+	Owner     string    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SleepRequest = dane nocy przy tworzeniu (POST) i zamianie (PUT); podaje się godziny
+// zaśnięcia i pobudki albo sam czas snu
+type SleepRequest struct {
+	Date     string `json:"date"` // pusta = dzisiaj
+	Bedtime  string `json:"bedtime"`
+	WakeTime string `json:"wakeTime"`
+	Duration int    `json:"duration"`
+	Quality  int    `json:"quality"`
+	Note     string `json:"note"`
+}
+
+// SleepSummary = sen z ostatnich dni (GET /sleep/summary), np. do oceny gotowości do treningu
+type SleepSummary struct {
+	Days        int         `json:"days"`                  // długość okna
+	Nights      int         `json:"nights"`                // noce z wpisem w oknie
+	AvgDuration *float64    `json:"avgDuration,omitempty"` // minuty
+	AvgQuality  *float64    `json:"avgQuality,omitempty"`  // z nocy z oceną
+	Debt        int         `json:"debt"`                  // minuty poniżej 8 h, sumowane po nocach z wpisem
+	LastNight   *SleepEntry `json:"lastNight,omitempty"`
+}
@@ -0,0 +1,196 @@
+package models
+
+// Strefy stosunku obciążenia ostrego do przewlekłego (ACWR).
+const (
+	LoadZoneLow     = "low"     // < 0,8: za mało bodźca, forma spada
+	LoadZoneOptimal = "optimal" // 0,8–1,3
+	LoadZoneHigh    = "high"    // 1,3–1,5: ryzyko rośnie
+	LoadZoneDanger  = "danger"  // > 1,5: gwałtowny skok obciążenia
+	LoadZoneNoData  = "no_data" // brak obciążenia przewlekłego
+)
+
+// WeeklyLoad = obciążenie 7-dniowego okresu z ACWR na jego koniec
+type WeeklyLoad struct {
+	Start   string   `json:"start"` // pierwszy dzień okresu
+	End     string   `json:"end"`   // ostatni dzień okresu
+	Load    float64  `json:"load"`
+	Chronic float64  `json:"chronic"`        // średnie obciążenie 4 tygodni kończących się tym
+	ACWR    *float64 `json:"acwr,omitempty"` // Load / Chronic; brak, gdy Chronic = 0
+	Zone    string   `json:"zone"`
+}
+
+// LoadReport = obciążenie treningowe i ACWR (GET /stats/load)
+type LoadReport struct {
+	Date    string       `json:"date"` // dzień, na który liczymy (koniec ostatniego tygodnia)
+	Acute   float64      `json:"acute"`
+	Chronic float64      `json:"chronic"`
+	ACWR    *float64     `json:"acwr,omitempty"`
+	Zone    string       `json:"zone"`
+	Warning bool         `json:"warning"` // ACWR w strefie high lub danger
+	Weeks   []WeeklyLoad `json:"weeks"`   // od najstarszego
+}
+
+// Docelowa tygodniowa liczba serii na grupę mięśni (typowe zalecenie na hipertrofię).
+const (
+	WeeklySetsMin = 10
+	WeeklySetsMax = 20
+)
+
+// Ocena tygodniowej liczby serii względem WeeklySetsMin–WeeklySetsMax.
+const (
+	VolumeBelow  = "below"
+	VolumeWithin = "within"
+	VolumeAbove  = "above"
+)
+
+// MuscleVolume = objętość jednej grupy mięśni w okresie; serie ćwiczeń, w których
+// grupa jest pomocnicza, liczą się za pół
+type MuscleVolume struct {
+	Muscle  string  `json:"muscle"` // ID z GET /muscle-groups
+	Sets    float64 `json:"sets"`
+	Tonnage float64 `json:"tonnage"`          // kg × powtórzenia
+	Status  string  `json:"status,omitempty"` // tylko dla period=week
+}
+
+// MuscleVolumePeriod = objętość wszystkich grup mięśni w jednym okresie
+type MuscleVolumePeriod struct {
+	Start   string         `json:"start"` // pierwszy dzień okresu
+	Muscles []MuscleVolume `json:"muscles"`
+	// Unassigned = serie ćwiczeń spoza katalogu lub bez przypisanych mięśni.
+	Unassigned int `json:"unassigned"`
+}
+
+// MuscleVolumeReport = objętość per grupa mięśni (GET /stats/volume?groupBy=muscle)
+type MuscleVolumeReport struct {
+	GroupBy string               `json:"groupBy"`
+	Period  string               `json:"period"`
+	From    string               `json:"from"`
+	To      string               `json:"to"`
+	Periods []MuscleVolumePeriod `json:"periods"`
+}
+
+// VolumeTotals = liczba treningów, serii, powtórzeń i tonaż w okresie
+type VolumeTotals struct {
+	Start    string  `json:"start,omitempty"` // pierwszy dzień okresu; pusty dla sumy całego zakresu
+	Workouts int     `json:"workouts"`
+	Sets     int     `json:"sets"`
+	Reps     int     `json:"reps"`
+	Tonnage  float64 `json:"tonnage"` // kg × powtórzenia
+}
+
+// VolumeReport = objętość treningowa w kolejnych okresach (GET /stats/volume)
+type VolumeReport struct {
+	Period  string         `json:"period"`
+	From    string         `json:"from"`
+	To      string         `json:"to"`
+	Total   VolumeTotals   `json:"total"`
+	Periods []VolumeTotals `json:"periods"`
+}
+
+// LiftStandard = porównanie jednego boju z normami siły
+type LiftStandard struct {
+	Lift       string             `json:"lift"` // squat, bench, deadlift, press
+	ExerciseID int                `json:"exerciseId,omitempty"`
+	Name       string             `json:"name"`
+	E1RM       *float64           `json:"e1rm,omitempty"` // najlepszy szacowany 1RM; brak = bój nie był trenowany
+	Ratio      float64            `json:"ratio"`          // e1RM / masa ciała
+	Level      string             `json:"level,omitempty"`
+	Score      float64            `json:"score"` // 0–100, 20 punktów na poziom
+	NextLevel  string             `json:"nextLevel,omitempty"`
+	NextWeight float64            `json:"nextWeight,omitempty"` // 1RM potrzebny na kolejny poziom
+	Thresholds map[string]float64 `json:"thresholds"`           // 1RM dla każdego poziomu
+}
+
+// StrengthStandardsReport = siła względem norm (GET /stats/strength-standards)
+type StrengthStandardsReport struct {
+	Sex        string         `json:"sex"`
+	Bodyweight float64        `json:"bodyweight"`
+	Lifts      []LiftStandard `json:"lifts"`
+}
+
+// PowerliftingLift = najlepszy wynik jednego boju trójboju
+type PowerliftingLift struct {
+	Lift       string  `json:"lift"` // squat, bench, deadlift
+	ExerciseID int     `json:"exerciseId,omitempty"`
+	Weight     float64 `json:"weight"` // 0 = bój nie był trenowany
+	Date       string  `json:"date,omitempty"`
+}
+
+// PowerliftingReport = punkty trójbojowe z najlepszych wyników (GET /stats/powerlifting)
+type PowerliftingReport struct {
+	Sex        string             `json:"sex"`
+	Bodyweight float64            `json:"bodyweight"`
+	Source     string             `json:"source"` // e1rm (szacowany 1RM) albo actual (najcięższy ciężar)
+	Lifts      []PowerliftingLift `json:"lifts"`
+	Total      float64            `json:"total"`
+	Wilks      float64            `json:"wilks"`
+	DOTS       float64            `json:"dots"`
+	IPFGL      float64            `json:"ipfGl"`
+	// Complete = wszystkie trzy boje mają wynik; bez tego punkty są zaniżone.
+	Complete bool `json:"complete"`
+}
+
+// ProgressPoint = wartość metryki w jednym okresie
+type ProgressPoint struct {
+	Start    string  `json:"start"` // pierwszy dzień okresu
+	Value    float64 `json:"value"`
+	Sessions int     `json:"sessions"` // liczba sesji ćwiczenia w okresie
+}
+
+// ProgressSeries = seria do wykresu postępów ćwiczenia (GET /stats/progress)
+type ProgressSeries struct {
+	ExerciseID int             `json:"exerciseId"`
+	Name       string          `json:"name"`
+	Metric     string          `json:"metric"` // e1rm, volume, topset
+	Bucket     string          `json:"bucket"` // day, week, month
+	Unit       string          `json:"unit"`   // kg
+	Points     []ProgressPoint `json:"points"` // od najstarszego okresu, tylko okresy z sesjami
+}
+
+// Streak = ciąg kolejnych tygodni z wymaganą liczbą treningów
+type Streak struct {
+	Weeks int    `json:"weeks"`
+	Start string `json:"start,omitempty"` // poniedziałek pierwszego tygodnia
+	End   string `json:"end,omitempty"`   // poniedziałek ostatniego tygodnia
+}
+
+// StreakReport = bieżąca i najdłuższa seria tygodni (GET /stats/streaks)
+type StreakReport struct {
+	PerWeek int    `json:"perWeek"` // wymagana liczba treningów w tygodniu
+	Current Streak `json:"current"`
+	Longest Streak `json:"longest"`
+	// Bieżący tydzień jeszcze trwa: seria nie jest przerwana, dopóki się nie skończy.
+	ThisWeekStart    string `json:"thisWeekStart"`
+	ThisWeekWorkouts int    `json:"thisWeekWorkouts"`
+	ThisWeekLeft     int    `json:"thisWeekLeft"` // ile treningów brakuje do zaliczenia tygodnia
+}
+
+// ExerciseRest = przerwy po seriach jednego ćwiczenia (GET /stats/rest)
+type ExerciseRest struct {
+	ExerciseID int     `json:"exerciseId,omitempty"`
+	Name       string  `json:"name"`
+	Sets       int     `json:"sets"`    // serie z zapisaną faktyczną przerwą
+	AvgRest    float64 `json:"avgRest"` // s
+	MinRest    int     `json:"minRest"`
+	MaxRest    int     `json:"maxRest"`
+	// Porównanie z planem tylko dla serii, które mają obie przerwy.
+	Planned     int     `json:"planned"`
+	AvgOverrun  float64 `json:"avgOverrun"`  // średnio o ile sekund dłużej niż plan (ujemne = krócej)
+	OverPlanned int     `json:"overPlanned"` // serie z przerwą dłuższą niż plan
+}
+
+// HeatmapDay = aktywność jednego dnia na mapie roku
+type HeatmapDay struct {
+	Date     string  `json:"date"`
+	Sessions int     `json:"sessions"`
+	Tonnage  float64 `json:"tonnage"`
+	Level    int     `json:"level"` // 0 = brak treningu, 1–4 = intensywność względem najcięższego dnia roku
+}
+
+// HeatmapReport = mapa aktywności całego roku (GET /stats/heatmap)
+type HeatmapReport struct {
+	Year         int          `json:"year"`
+	TrainingDays int          `json:"trainingDays"`
+	MaxTonnage   float64      `json:"maxTonnage"` // najcięższy dzień; od niego liczymy poziomy
+	Days         []HeatmapDay `json:"days"`       // wszystkie dni roku, od 1 stycznia
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// SupplementIntake = przyjęta dawka suplementu
+type SupplementIntake struct {
+	ID        int       `json:"id"`
+	Date      string    `json:"date"`           // YYYY-MM-DD
+	Name      string    `json:"name"`           // np. "Kreatyna"
+	Dose      float64   `json:"dose,omitempty"` // w jednostce Unit
+	Unit      string    `json:"unit,omitempty"` // g, mg, µg, ml, IU, caps
+	Note      string    `json:"note,omitempty"`
+	Owner     string    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SupplementIntakeRequest = dane dawki przy tworzeniu (POST) i zamianie (PUT)
+type SupplementIntakeRequest struct {
+	Date string  `json:"date"` // pusta = dzisiaj
+	Name string  `json:"name"`
+	Dose float64 `json:"dose"`
+	Unit string  `json:"unit"`
+	Note string  `json:"note"`
+}
+
+// SupplementAdherence = regularność przyjmowania suplementu (GET /supplements/adherence)
+type SupplementAdherence struct {
+	Name      string  `json:"name"`
+	DaysTaken int     `json:"daysTaken"` // dni z co najmniej jedną dawką
+	Days      int     `json:"days"`      // dni w zakresie
+	Adherence float64 `json:"adherence"` // % dni z dawką
+	Streak    int     `json:"streak"`    // dni z rzędu z dawką, kończące się ostatnim dniem zakresu
+	LastTaken string  `json:"lastTaken"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// WorkoutTemplate = szablon treningu (np. "Push A"), z którego można rozpocząć nowy trening
+type WorkoutTemplate struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	Notes     string     `json:"notes"`
+	Exercises []Exercise `json:"exercises"` // planowane serie; ciężar jest opcjonalny
+	Owner     string     `json:"-"`         // szablony są prywatne, widzi je tylko właściciel
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	// WeightUnit = jednostka ciężarów serii w odpowiedzi (patrz Profile); w magazynie pusta (kg).
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
+
+// TemplateRequest = dane szablonu przy tworzeniu (POST) i zamianie (PUT)
+type TemplateRequest struct {
+	Name      string     `json:"name"`
+	Notes     string     `json:"notes"`
+	Exercises []Exercise `json:"exercises"`
+	// WeightUnit = jednostka ciężarów w body (kg albo lb); pusta = jednostka z profilu.
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
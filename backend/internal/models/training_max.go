@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// TrainingMax = jeden wpis maksa treningowego (TM) ćwiczenia; bieżący TM to najnowszy wpis
+type TrainingMax struct {
+	ID         int       `json:"id"`
+	ExerciseID int       `json:"exerciseId"`
+	Name       string    `json:"name"`           // nazwa ćwiczenia z katalogu w chwili zapisu
+	Weight     float64   `json:"weight"`         // kg; w odpowiedzi w jednostce WeightUnit
+	Date       string    `json:"date"`           // "YYYY-MM-DD", od kiedy obowiązuje
+	Source     string    `json:"source"`         // set (ręcznie), adjust (korekta), suggestion (z AMRAP)
+	Note       string    `json:"note,omitempty"` // np. "po cyklu 3"
+	Owner      string    `json:"-"`
+	CreatedAt  time.Time `json:"createdAt"`
+	// WeightUnit = jednostka Weight w odpowiedzi (patrz Profile); w magazynie pusta (kg).
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
+
+// TrainingMaxRequest = ustawienie TM (PUT /exercises/{id}/training-max)
+type TrainingMaxRequest struct {
+	Weight float64 `json:"weight"`
+	Date   string  `json:"date"` // pusta = dzisiaj
+	Note   string  `json:"note"`
+	// Source = "suggestion" oznacza przyjęcie propozycji z AMRAP; domyślnie "set".
+	Source string `json:"source"`
+	// WeightUnit = jednostka ciężarów w body (kg albo lb); pusta = jednostka z profilu.
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
+
+// TrainingMaxAdjustRequest = korekta bieżącego TM o kilogramy albo procent
+// (POST /exercises/{id}/training-max/adjust); podaje się dokładnie jedno z pól
+type TrainingMaxAdjustRequest struct {
+	Delta   *float64 `json:"delta"`   // w jednostce WeightUnit, np. 2.5 albo -5
+	Percent *float64 `json:"percent"` // np. -10
+	Note    string   `json:"note"`
+	// WeightUnit = jednostka ciężarów w body (kg albo lb); pusta = jednostka z profilu.
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
+
+// TrainingMaxDetail = bieżący TM ćwiczenia, historia zmian i propozycja przeliczenia
+type TrainingMaxDetail struct {
+	ExerciseID int                    `json:"exerciseId"`
+	Current    *TrainingMax           `json:"current"` // null = TM nie ustawiono
+	History    []TrainingMax          `json:"history"` // od najnowszego
+	Suggestion *TrainingMaxSuggestion `json:"suggestion,omitempty"`
+	// WeightUnit = jednostka ciężarów w odpowiedzi (patrz Profile).
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
+
+// TrainingMaxSuggestion = TM wyliczony z najlepszej serii ostatnich sesji (np. AMRAP w 5/3/1):
+// 90% szacowanego 1RM (wzór Epleya), zaokrąglone w dół do 2,5 kg (w lb – do 5 lb); ciężary w jednostce TrainingMaxDetail
+type TrainingMaxSuggestion struct {
+	Weight    float64 `json:"weight"`
+	E1RM      float64 `json:"e1rm"`
+	Date      string  `json:"date"` // data serii, na której oparto propozycję
+	SetWeight float64 `json:"setWeight"`
+	SetReps   int     `json:"setReps"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// WaterEntry = wypita porcja wody
+type WaterEntry struct {
+	ID        int       `json:"id"`
+	Date      string    `json:"date"`   // YYYY-MM-DD
+	Amount    int       `json:"amount"` // ml
+	Owner     string    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// WaterRequest = dane porcji przy dodawaniu (POST /water)
+type WaterRequest struct {
+	Date   string `json:"date"` // pusta = dzisiaj
+	Amount int    `json:"amount"`
+}
+
+// WaterTarget = dzienny cel nawodnienia (GET/PUT /water/target)
+type WaterTarget struct {
+	Target int `json:"target"` // ml
+}
+
+// WaterDay = suma dnia na tle celu (GET /water/daily)
+type WaterDay struct {
+	Date     string `json:"date"`
+	Total    int    `json:"total"`    // ml
+	Target   int    `json:"target"`   // ml
+	Progress int    `json:"progress"` // % celu; może przekroczyć 100
+}
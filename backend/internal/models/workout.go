@@ -9,20 +9,148 @@ type Workout struct {
 	Date      string     `json:"date"`      // ISO: "2026-01-16" (proste i czytelne)
 	Notes     string     `json:"notes"`     // opcjonalne
 	Exercises []Exercise `json:"exercises"` // lista ćwiczeń
-	CreatedAt time.Time  `json:"createdAt"`
-	UpdatedAt time.Time  `json:"updatedAt"`
+	Planned   bool       `json:"planned"`   // zaplanowany (np. z programu), jeszcze nie wykonany
+	// WeightUnit = jednostka ciężarów w odpowiedzi (patrz Profile); w magazynie pusta, bo
+	// ciężary trzymamy w kg.
+	WeightUnit string     `json:"weightUnit,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	Version    int        `json:"version"`             // rośnie przy każdej zmianie; chroni przed nadpisaniem cudzych edycji
+	DeletedAt  *time.Time `json:"deletedAt,omitempty"` // ustawione tylko dla treningów w koszu
 }
 
+// Rodzaje ćwiczenia w treningu.
+const (
+	ExerciseStrength = "strength" // serie z powtórzeniami
+	ExerciseCardio   = "cardio"   // jeden wysiłek na czas/dystans
+	ExerciseInterval = "interval" // rundy pracy i przerwy (HIIT, EMOM, Tabata)
+)
+
+// Rodzaje grup ćwiczeń.
+const (
+	GroupSuperset = "superset" // ćwiczenia na przemian, seria po serii
+	GroupCircuit  = "circuit"  // obwód: po jednej serii każdego ćwiczenia w rundzie
+)
+
+// Formaty bloku interwałowego.
+const (
+	IntervalCustom = "custom" // dowolne czasy pracy i przerwy
+	IntervalEMOM   = "emom"   // co minutę: praca + przerwa = 60 s
+	IntervalTabata = "tabata" // domyślnie 8 rund po 20 s pracy i 10 s przerwy
+)
+
 // Exercise = jedno ćwiczenie w treningu
 type Exercise struct {
-	Name string `json:"name"` // np. "Bench Press"
-	Sets []Set  `json:"sets"` // serie
+	ExerciseID int    `json:"exerciseId,omitempty"` // ID w katalogu ćwiczeń; 0 = ćwiczenie spoza katalogu
+	Name       string `json:"name"`                 // np. "Bench Press"; przy ExerciseID uzupełniana z katalogu
+	// Order = pozycja ćwiczenia w treningu, od 1; nadaje ją API według kolejności na liście.
+	// Przy zapisie ćwiczenia z podanym order u wszystkich układamy według niego.
+	Order int `json:"order"`
+	// Type = strength, cardio albo interval; pusty = strength, chyba że ćwiczenie z katalogu jest kardio.
+	Type     string    `json:"type,omitempty"`
+	Sets     []Set     `json:"sets"`               // serie (tylko strength)
+	Cardio   *Cardio   `json:"cardio,omitempty"`   // wynik (tylko cardio)
+	Interval *Interval `json:"interval,omitempty"` // rundy (tylko interval)
+	Notes    string    `json:"notes,omitempty"`    // np. "pauza na klatce", "lewy bark pobolewa"
+	// GroupID łączy kolejne ćwiczenia w superserię albo obwód (dowolna etykieta, np. "A"),
+	// GroupType mówi, czym jest grupa; oba puste = ćwiczenie samodzielne.
+	GroupID   string `json:"groupId,omitempty"`
+	GroupType string `json:"groupType,omitempty"`
+}
+
+// Cardio = wynik ćwiczenia kardio (bieg, rower, wioślarz...)
+type Cardio struct {
+	Duration int      `json:"duration"`           // sekundy
+	Distance *float64 `json:"distance,omitempty"` // metry
+	// AvgPace = średnie tempo w s/km; bez podanej wartości liczone z czasu i dystansu.
+	AvgPace *float64 `json:"avgPace,omitempty"`
+	AvgHR   *int     `json:"avgHr,omitempty"` // średnie tętno, uderzenia/min
+	// ElevationGain = suma podejść w metrach (np. z importu GPX)
+	ElevationGain *float64 `json:"elevationGain,omitempty"`
+	// HRHistogram = ile sekund przy jakim tętnie (z importu GPX/FIT albo od klienta), rosnąco
+	// po bpm; z niego liczymy czas w strefach tętna.
+	HRHistogram []HRBucket `json:"hrHistogram,omitempty"`
+}
+
+// Interval = blok interwałowy: Rounds rund, każda to Work sekund pracy i Rest sekund przerwy
+type Interval struct {
+	Format string `json:"format,omitempty"` // custom (domyślnie), emom, tabata
+	Work   int    `json:"work"`             // s pracy w rundzie
+	Rest   int    `json:"rest"`             // s przerwy po rundzie; 0 = bez przerw
+	Rounds int    `json:"rounds"`
+	// TotalDuration = Rounds × (Work + Rest) w sekundach; liczone przez API.
+	TotalDuration int `json:"totalDuration"`
 }
 
+// Rodzaje serii.
+const (
+	SetWarmup  = "warmup"  // rozgrzewkowa; domyślnie nie liczy się do objętości i rekordów
+	SetWorking = "working" // robocza (domyślna)
+	SetDropset = "dropset"
+	SetFailure = "failure" // do upadku mięśniowego
+	SetAMRAP   = "amrap"   // tyle powtórzeń, ile się da
+	SetBackoff = "backoff" // lżejsza seria po serii głównej
+)
+
+// SetTypes to dozwolone rodzaje serii.
+var SetTypes = []string{SetWarmup, SetWorking, SetDropset, SetFailure, SetAMRAP, SetBackoff}
+
 // Set = pojedyncza seria
 type Set struct {
-	Reps   int      `json:"reps"`             // ilość powtórzeń
-	Weight *float64 `json:"weight,omitempty"` // kg, opcjonalnie
+	// Type = rodzaj serii (patrz SetTypes); pusty = working.
+	Type string `json:"type,omitempty"`
+	Reps int    `json:"reps"` // ilość powtórzeń; 0 przy serii na czas
+	// DurationSeconds = czas serii na czas (deska, spacer farmera); 0 = seria na powtórzenia.
+	DurationSeconds int      `json:"durationSeconds,omitempty"`
+	Weight          *float64 `json:"weight,omitempty"` // kg, opcjonalnie
+	// Bodyweight oznacza ćwiczenie z masą ciała (podciąganie, dipy): API wylicza Weight jako
+	// zalogowaną masę ciała z dnia treningu plus AddedWeight (obciążenie w pasie, kamizelka;
+	// ujemne = odciążenie na maszynie) i opór gumy, więc objętość i e1RM liczą się od
+	// faktycznego obciążenia. Bez pomiaru masy ciała seria zostaje bez ciężaru; zmiana
+	// dziennika masy ciała przelicza ciężar w zapisanych treningach.
+	Bodyweight  bool     `json:"bodyweight,omitempty"`
+	AddedWeight *float64 `json:"addedWeight,omitempty"` // kg, tylko przy bodyweight
+	Band        *Band    `json:"band,omitempty"`        // guma oporowa, opcjonalnie
+	RPE         *float64 `json:"rpe,omitempty"`         // odczuwalny wysiłek 0–10 co pół punktu, opcjonalnie
+	// RIR = powtórzenia w zapasie (0–10), opcjonalnie; podane razem z RPE musi mu odpowiadać
+	// (RPE ≈ 10 − RIR).
+	RIR *int `json:"rir,omitempty"`
+	// Tempo = czas faz powtórzenia w sekundach: opuszczanie-pauza-podnoszenie-pauza,
+	// np. "3-1-1-0"; X = faza wybuchowa ("2-0-X-0"). Opcjonalne.
+	Tempo string `json:"tempo,omitempty"`
+	// PlannedRestSeconds = zaplanowana przerwa po serii (z szablonu albo programu),
+	// RestSeconds = faktyczna przerwa (np. z minutnika w aplikacji); 0 = bez przerwy (superseria).
+	PlannedRestSeconds *int   `json:"plannedRestSeconds,omitempty"`
+	RestSeconds        *int   `json:"restSeconds,omitempty"`
+	Notes              string `json:"notes,omitempty"` // uwagi do serii, np. "ostatnie powtórzenie z asekuracją"
+	// PercentOfTM = ciężar jako procent maksa treningowego (np. 75 = "5 @ 75% TM");
+	// API wylicza z niego Weight według bieżącego TM ćwiczenia.
+	PercentOfTM *float64 `json:"percentOfTM,omitempty"`
+}
+
+// CreatedWorkout = odpowiedź na dodanie treningu: trening i pobite w nim rekordy
+type CreatedWorkout struct {
+	Workout
+	NewRecords []NewRecord `json:"newRecords"`
+}
+
+// WorkoutPage = strona listy treningów z metadanymi stronicowania
+type WorkoutPage struct {
+	Items  []Workout `json:"items"`
+	Total  int       `json:"total"`  // liczba wszystkich treningów
+	Limit  int       `json:"limit"`  // rozmiar strony
+	Offset int       `json:"offset"` // liczba pominiętych treningów
+	// NextCursor pozwala pobrać następną stronę (?cursor=); brak = koniec listy.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Band = guma oporowa w serii. W seriach z masą ciała jej opór wchodzi do Weight (Assisted
+// odejmuje go, jak guma pod kolanami przy podciąganiu); przy sztandze czy hantlach opór
+// gumy zmienia się w trakcie ruchu, więc tylko go zapisujemy, a Weight to sam ciężar.
+type Band struct {
+	Color      string  `json:"color,omitempty"`      // kolor gumy, np. "red" – producenci oznaczają nim opór
+	Resistance float64 `json:"resistance,omitempty"` // szacowany opór w kg; 0 = nieznany
+	Assisted   bool    `json:"assisted,omitempty"`   // guma odciąża zamiast dociążać
 }
 
 // Requesty (oddzielamy od modelu)
@@ -31,19 +159,182 @@ type CreateWorkoutRequest struct {
 	Date      string     `json:"date"` // "YYYY-MM-DD"
 	Notes     string     `json:"notes"`
 	Exercises []Exercise `json:"exercises"`
+	Planned   bool       `json:"planned"`
+	// WeightUnit = jednostka ciężarów w body (kg albo lb); pusta = jednostka z profilu.
+	WeightUnit string `json:"weightUnit,omitempty"`
 }
 
+// ReorderExercisesRequest = nowa kolejność ćwiczeń treningu (POST /workouts/{id}/exercises/reorder).
+type ReorderExercisesRequest struct {
+	// Order to bieżące pozycje ćwiczeń (pole order) w nowej kolejności, np. [2, 1, 3]
+	// zamienia miejscami dwa pierwsze; musi zawierać każdą pozycję dokładnie raz.
+	Order []int `json:"order"`
+	// Version = wersja, którą klient edytował; nieaktualna kończy się 409 Conflict.
+	Version *int `json:"version,omitempty"`
+}
+
+// ReplaceWorkoutRequest = pełna zamiana treningu (PUT); pominięte pola są czyszczone.
+type ReplaceWorkoutRequest struct {
+	CreateWorkoutRequest
+	// Version = wersja, którą klient edytował; nieaktualna kończy się 409 Conflict.
+	Version *int `json:"version,omitempty"`
+}
+
+// UpdateWorkoutRequest = częściowa aktualizacja (PATCH, JSON Merge Patch – RFC 7386):
+// pominięte pola zostają bez zmian, null czyści pole, a listę ćwiczeń podaje się w całości.
 type UpdateWorkoutRequest struct {
 	Title     *string     `json:"title,omitempty"`
 	Date      *string     `json:"date,omitempty"`
 	Notes     *string     `json:"notes,omitempty"`
 	Exercises *[]Exercise `json:"exercises,omitempty"`
+	Planned   *bool       `json:"planned,omitempty"`
+	// Version = wersja, którą klient edytował; nieaktualna kończy się 409 Conflict.
+	Version *int `json:"version,omitempty"`
+}
+
+// BatchUpdateRequest = ta sama zmiana (JSON Merge Patch) dla wielu treningów naraz
+type BatchUpdateRequest struct {
+	IDs   []int          `json:"ids"`
+	Patch map[string]any `json:"patch"` // pola jak w UpdateWorkoutRequest; null czyści pole
+}
+
+// Revision = jedna wersja treningu na liście historii zmian
+type Revision struct {
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Changed   []string  `json:"changed"` // ścieżki pól zmienionych względem poprzedniej wersji
 }
 
-type APIError struct {
-	Error string `json:"error"`
+// RevisionDetail = pełny stan treningu w danej wersji i zmiany względem poprzedniej
+type RevisionDetail struct {
+	Workout Workout       `json:"workout"`
+	Diff    []FieldChange `json:"diff"`
 }
 
-// Uwaga: struktury CreateWorkoutRequest i UpdateWorkoutRequest są odseparowane od modelu,
+// FieldChange = zmiana jednego pola, np. {"op": "replace", "path": "exercises[0].sets[1].reps", "old": 5, "new": 6}
+type FieldChange struct {
+	Op   string `json:"op"` // add, remove, replace
+	Path string `json:"path"`
+	Old  any    `json:"old,omitempty"`
+	New  any    `json:"new,omitempty"`
+}
+
+// AuditEntry = wpis dziennika audytu (kto, kiedy i co zmienił)
+type AuditEntry struct {
+	ID         int       `json:"id"`
+	Time       time.Time `json:"time"`
+	Actor      string    `json:"actor"`      // wykonawca; "anonymous" do czasu wprowadzenia kont
+	Action     string    `json:"action"`     // create, update, delete, restore, purge, undo
+	Resource   string    `json:"resource"`   // np. "workout"
+	ResourceID int       `json:"resourceId"` // ID zasobu
+	Summary    string    `json:"summary"`    // krótki opis zmiany
+}
+
+// ExerciseSetEntry = jedna seria ćwiczenia z historii (GET /exercises/{id}/history)
+type ExerciseSetEntry struct {
+	WorkoutID       int      `json:"workoutId"`
+	Date            string   `json:"date"`
+	Set             int      `json:"set"`            // numer serii w treningu, od 1
+	Type            string   `json:"type,omitempty"` // rodzaj serii (patrz SetTypes)
+	Reps            int      `json:"reps"`
+	DurationSeconds int      `json:"durationSeconds,omitempty"` // seria na czas
+	Weight          *float64 `json:"weight,omitempty"`
+	// Bodyweight, AddedWeight i Band jak w Set: Weight to wtedy masa ciała plus dociążenie.
+	Bodyweight  bool     `json:"bodyweight,omitempty"`
+	AddedWeight *float64 `json:"addedWeight,omitempty"`
+	Band        *Band    `json:"band,omitempty"`
+	RPE         *float64 `json:"rpe,omitempty"`
+	RIR         *int     `json:"rir,omitempty"`
+}
+
+// ExerciseHistoryPage = strona historii serii ćwiczenia, od najnowszego treningu
+type ExerciseHistoryPage struct {
+	ExerciseID int                `json:"exerciseId"`
+	Name       string             `json:"name"`
+	Items      []ExerciseSetEntry `json:"items"`
+	Total      int                `json:"total"` // liczba wszystkich serii w zakresie dat
+	Limit      int                `json:"limit"`
+	Offset     int                `json:"offset"`
+	// WeightUnit = jednostka ciężarów w odpowiedzi (patrz Profile).
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
+
+// LastExerciseSession = ostatnie wykonanie ćwiczenia (GET /exercises/{id}/last)
+type LastExerciseSession struct {
+	ExerciseID int    `json:"exerciseId"`
+	Name       string `json:"name"`
+	WorkoutID  int    `json:"workoutId"`
+	Date       string `json:"date"`
+	Sets       []Set  `json:"sets"`
+	Summary    string `json:"summary"` // np. "3x8 @ 80 kg, 1x6 @ 85 kg"
+	// WeightUnit = jednostka ciężarów w odpowiedzi (patrz Profile).
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
+
+// AuditPage = strona dziennika audytu
+type AuditPage struct {
+	Items  []AuditEntry `json:"items"`
+	Total  int          `json:"total"`
+	Limit  int          `json:"limit"`
+	Offset int          `json:"offset"`
+}
+
+// Problem = opis błędu w formacie RFC 7807 (application/problem+json)
+type Problem struct {
+	Type   string `json:"type"`             // URI typu problemu; "about:blank" gdy wystarcza sam status
+	Title  string `json:"title"`            // krótki opis typu problemu
+	Status int    `json:"status"`           // kod HTTP
+	Detail string `json:"detail,omitempty"` // szczegóły tego konkretnego wystąpienia
+	Code   string `json:"code,omitempty"`   // maszynowy kod błędu, np. "invalid_json"
+
+	Errors []FieldError `json:"errors,omitempty"` // błędy walidacji poszczególnych pól
+}
+
+// FieldError = błąd walidacji jednego pola, np. "exercises[0].sets[1].reps"
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Args    []any  `json:"-"` // argumenty komunikatu (jak fmt.Sprintf), wstawiane po tłumaczeniu
+}
+
+// Uwaga: struktury CreateWorkoutRequest, ReplaceWorkoutRequest i UpdateWorkoutRequest są odseparowane od modelu,
 // aby jasno zdefiniować, jakie pola klient może wysłać przy tworzeniu/aktualizacji.
 // Dzięki temu walidacja i ewolucja API są prostsze.
+
+// ScheduleProgramRequest = rozpisanie programu na konkretne dni (POST /programs/{id}/schedule)
+type ScheduleProgramRequest struct {
+	Start string   `json:"start"` // "YYYY-MM-DD", pierwszy dzień pierwszego tygodnia
+	Days  []string `json:"days"`  // dni treningowe, np. ["mon", "wed", "fri"]
+}
+
+// NextWorkoutSuggestion = propozycja ciężarów na kolejną sesję po treningu WorkoutID
+type NextWorkoutSuggestion struct {
+	WorkoutID int    `json:"workoutId"`
+	Date      string `json:"date"`
+	// Readiness = gotowość z dzisiejszej oceny samopoczucia (POST /checkins); poniżej 40
+	// podwyżki ciężaru zamieniamy na powtórzenie ostatniego.
+	Readiness *int                    `json:"readiness,omitempty"`
+	Exercises []ProgressionSuggestion `json:"exercises"`
+	// WeightUnit = jednostka ciężarów w odpowiedzi (patrz Profile).
+	WeightUnit string `json:"weightUnit,omitempty"`
+}
+
+// ProgressionSuggestion = propozycja dla jednego ćwiczenia
+type ProgressionSuggestion struct {
+	ExerciseID int     `json:"exerciseId,omitempty"`
+	Name       string  `json:"name"`
+	Scheme     string  `json:"scheme"` // linear, double (patrz ProgressionRule)
+	Action     string  `json:"action"` // increase, repeat, deload
+	Weight     float64 `json:"weight"` // na serię roboczą, w jednostce NextWorkoutSuggestion
+	Sets       int     `json:"sets"`
+	Reps       int     `json:"reps"`
+	LastWeight float64 `json:"lastWeight"`
+	Failures   int     `json:"failures"` // nieudane sesje z rzędu na lastWeight
+	// AverageRPE = średnie RPE serii roboczych ostatniej sesji (gdy je zapisano);
+	// powyżej TargetRPE propozycja jest obniżana (action = reduce).
+	AverageRPE *float64 `json:"averageRpe,omitempty"`
+	TargetRPE  float64  `json:"targetRpe"`
+	// Injuries = ID niewyleczonych urazów wykluczających ćwiczenie (GET /injuries);
+	// takie ćwiczenie trzeba zastąpić innym.
+	Injuries []int `json:"injuries,omitempty"`
+}
@@ -0,0 +1,199 @@
+// Package programs zawiera wbudowane generatory programów treningowych (5/3/1, GZCLP).
+// Z maksów treningowych (training max, TM) wylicza ciężary wszystkich serii,
+// żeby użytkownik nie musiał liczyć procentów w arkuszu.
+package programs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"gym-api/internal/models"
+)
+
+// Boje, dla których generatory potrzebują maksów treningowych, i ich nazwy w katalogu.
+var lifts = map[string]string{
+	"squat":    "Squat",
+	"bench":    "Bench Press",
+	"deadlift": "Deadlift",
+	"press":    "Overhead Press",
+}
+
+// Lifts zwraca klucze maksów treningowych wymaganych przez generatory.
+func Lifts() []string {
+	keys := make([]string, 0, len(lifts))
+	for k := range lifts {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// LiftName zwraca nazwę boju w katalogu dla klucza maksa treningowego (np. "press").
+func LiftName(key string) string {
+	return lifts[key]
+}
+
+// ErrUnknownScheme zwraca Generate dla nieznanego schematu.
+var ErrUnknownScheme = errors.New("unknown program scheme")
+
+// ErrMissingTrainingMax zwraca Generate, gdy brakuje maksa któregoś boju (albo jest <= 0).
+var ErrMissingTrainingMax = errors.New("missing training max")
+
+// Schemes to dostępne generatory.
+var Schemes = []string{"531", "gzclp"}
+
+// Plan to wygenerowany program przed zapisaniem: tygodnie z sesjami, z których
+// powstaną szablony treningów.
+type Plan struct {
+	Name        string
+	Description string
+	Weeks       [][]Session
+}
+
+// Session to jeden trening planu.
+type Session struct {
+	Name      string
+	Notes     string
+	Exercises []models.Exercise
+}
+
+// Generate buduje plan według schematu scheme. tm to maksy treningowe w kg
+// (klucze z Lifts), a ciężary zaokrągla do wielokrotności rounding.
+func Generate(scheme string, tm map[string]float64, rounding float64) (Plan, error) {
+	for _, k := range Lifts() {
+		if tm[k] <= 0 {
+			return Plan{}, fmt.Errorf("%w for %s", ErrMissingTrainingMax, k)
+		}
+	}
+	r := rounder(rounding)
+	switch scheme {
+	case "531":
+		return wendler531(tm, r), nil
+	case "gzclp":
+		return gzclp(tm, r), nil
+	}
+	return Plan{}, ErrUnknownScheme
+}
+
+// rounder zaokrągla ciężar w dół do wielokrotności step (talerze dokłada się parami).
+func rounder(step float64) func(float64) float64 {
+	return func(kg float64) float64 {
+		if step <= 0 {
+			return kg
+		}
+		return math.Floor(kg/step+1e-9) * step
+	}
+}
+
+// sets tworzy n serii po reps powtórzeń z tym samym ciężarem.
+func sets(n, reps int, weight float64) []models.Set {
+	out := make([]models.Set, n)
+	for i := range out {
+		w := weight
+		out[i] = models.Set{Reps: reps, Weight: &w}
+	}
+	return out
+}
+
+// wendler531 to klasyczny 4-tygodniowy cykl 5/3/1: cztery dni (jeden bój główny na dzień),
+// trzy serie procentowe z TM i tydzień deloadu. Serie zachowują percentOfTM, więc
+// rozpisanie programu po zmianie TM przelicza ciężary.
+func wendler531(tm map[string]float64, round func(float64) float64) Plan {
+	type scheme struct {
+		pct  [3]float64
+		reps [3]int
+		name string
+	}
+	weeks := []scheme{
+		{[3]float64{0.65, 0.75, 0.85}, [3]int{5, 5, 5}, "5s"},
+		{[3]float64{0.70, 0.80, 0.90}, [3]int{3, 3, 3}, "3s"},
+		{[3]float64{0.75, 0.85, 0.95}, [3]int{5, 3, 1}, "5/3/1"},
+		{[3]float64{0.40, 0.50, 0.60}, [3]int{5, 5, 5}, "deload"},
+	}
+	days := []string{"press", "deadlift", "bench", "squat"}
+
+	plan := Plan{
+		Name:        "5/3/1",
+		Description: "4-tygodniowy cykl 5/3/1 Wendlera; ciężary liczone z maksów treningowych.",
+	}
+	for n, wk := range weeks {
+		var week []Session
+		for _, lift := range days {
+			ex := models.Exercise{Name: lifts[lift]}
+			for i := range 3 {
+				set := sets(1, wk.reps[i], round(tm[lift]*wk.pct[i]))
+				pct := wk.pct[i] * 100
+				set[0].PercentOfTM = &pct
+				ex.Sets = append(ex.Sets, set...)
+			}
+			notes := fmt.Sprintf("%.0f/%.0f/%.0f%% TM", wk.pct[0]*100, wk.pct[1]*100, wk.pct[2]*100)
+			if wk.name != "deload" {
+				notes += ", ostatnia seria na maksimum powtórzeń (AMRAP)"
+			}
+			week = append(week, Session{
+				Name:      fmt.Sprintf("5/3/1 T%d %s – %s", n+1, wk.name, lifts[lift]),
+				Notes:     notes,
+				Exercises: []models.Exercise{ex},
+			})
+		}
+		plan.Weeks = append(plan.Weeks, week)
+	}
+	return plan
+}
+
+// Parametry GZCLP: liczba tygodni (3 treningi w tygodniu, rotacja A1, B1, A2, B2)
+// i przyrosty ciężaru po każdym wystąpieniu dnia.
+const (
+	gzclpWeeks     = 4
+	gzclpPerWeek   = 3
+	gzclpUpperStep = 2.5
+	gzclpLowerStep = 5
+)
+
+// gzclp to program GZCLP: T1 (5x3+ na 85% TM), T2 (3x10 na 65% TM) i T3 bez ciężaru
+// z góry (3x15+). Każde kolejne wystąpienie dnia dokłada ciężar według progresji liniowej.
+func gzclp(tm map[string]float64, round func(float64) float64) Plan {
+	type day struct {
+		name   string
+		t1, t2 string
+		t3     string
+	}
+	rotation := []day{
+		{"A1", "squat", "bench", "Lat Pulldown"},
+		{"B1", "press", "deadlift", "Dumbbell Row"},
+		{"A2", "bench", "squat", "Lat Pulldown"},
+		{"B2", "deadlift", "press", "Dumbbell Row"},
+	}
+	step := func(lift string) float64 {
+		if lift == "squat" || lift == "deadlift" {
+			return gzclpLowerStep
+		}
+		return gzclpUpperStep
+	}
+
+	plan := Plan{
+		Name:        "GZCLP",
+		Description: "GZCLP: rotacja A1/B1/A2/B2, 3 treningi w tygodniu, progresja liniowa T1 i T2.",
+	}
+	var week []Session
+	for i := range gzclpWeeks * gzclpPerWeek {
+		d := rotation[i%len(rotation)]
+		k := float64(i / len(rotation)) // które to wystąpienie tego dnia
+		week = append(week, Session{
+			Name:  fmt.Sprintf("GZCLP %s #%d", d.name, i/len(rotation)+1),
+			Notes: "T1 5x3 (ostatnia seria AMRAP), T2 3x10, T3 3x15 (ostatnia seria AMRAP)",
+			Exercises: []models.Exercise{
+				{Name: lifts[d.t1], Sets: sets(5, 3, round(tm[d.t1]*0.85+k*step(d.t1)))},
+				{Name: lifts[d.t2], Sets: sets(3, 10, round(tm[d.t2]*0.65+k*step(d.t2)))},
+				{Name: d.t3, Sets: []models.Set{{Reps: 15}, {Reps: 15}, {Reps: 15}}},
+			},
+		})
+		if len(week) == gzclpPerWeek {
+			plan.Weeks = append(plan.Weeks, week)
+			week = nil
+		}
+	}
+	return plan
+}
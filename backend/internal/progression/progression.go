@@ -0,0 +1,469 @@
+// Package progression wylicza ciężary na kolejny trening z historii ćwiczenia:
+// progresja liniowa (ciężar po udanej sesji, deload po serii porażek) albo podwójna
+// (najpierw powtórzenia w zakresie, potem ciężar).
+package progression
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// dateLayout to format dat sesji (YYYY-MM-DD).
+const dateLayout = "2006-01-02"
+
+// Akcje proponowane na kolejną sesję.
+const (
+	ActionIncrease = "increase" // sesja udana – więcej ciężaru
+	ActionRepeat   = "repeat"   // nieudana – ten sam ciężar jeszcze raz
+	ActionDeload   = "deload"   // zbyt wiele porażek z rzędu – lżej i od nowa
+	ActionReduce   = "reduce"   // sesja cięższa niż planowano (RPE) – trochę lżej
+)
+
+// Session to jedno wykonanie ćwiczenia (serie z jednego treningu).
+type Session struct {
+	Date string
+	Sets []models.Set
+}
+
+// LinearRule opisuje progresję liniową.
+type LinearRule struct {
+	Increment     float64 // kg dodawane po udanej sesji
+	MaxFailures   int     // tyle nieudanych sesji z rzędu na jednym ciężarze wywołuje deload
+	DeloadPercent float64 // o jaki ułamek zmniejszamy ciężar przy deloadzie (0.1 = 10%)
+	Rounding      float64 // krok zaokrąglania ciężaru przy deloadzie
+}
+
+// DefaultLinear to typowe ustawienia dla programów typu 5x5: +2,5 kg, deload o 10%
+// po trzech nieudanych sesjach.
+var DefaultLinear = LinearRule{Increment: 2.5, MaxFailures: 3, DeloadPercent: 0.1, Rounding: 2.5}
+
+// Suggestion to propozycja na kolejną sesję ćwiczenia.
+type Suggestion struct {
+	Action     string
+	Weight     float64 // ciężar serii roboczych
+	Sets       int     // liczba serii roboczych
+	Reps       int     // docelowa liczba powtórzeń w serii
+	LastWeight float64 // ciężar serii roboczych w ostatniej sesji
+	Failures   int     // nieudane sesje z rzędu na LastWeight
+}
+
+// workSets wybiera serie robocze, czyli serie z najwyższym ciężarem w sesji
+// (lżejsze traktujemy jak rozgrzewkowe). Serie na czas i oznaczone jako rozgrzewkowe pomijamy.
+// ok = false, gdy żadna seria nie ma ciężaru.
+func workSets(s Session) (weight float64, sets []models.Set, ok bool) {
+	for _, set := range s.Sets {
+		if set.Weight == nil || set.Reps == 0 || set.Type == models.SetWarmup {
+			continue
+		}
+		switch {
+		case !ok || *set.Weight > weight:
+			weight, sets, ok = *set.Weight, []models.Set{set}, true
+		case *set.Weight == weight:
+			sets = append(sets, set)
+		}
+	}
+	return weight, sets, ok
+}
+
+// succeeded mówi, czy wszystkie serie robocze osiągnęły docelową liczbę powtórzeń,
+// którą wyznacza pierwsza z nich.
+func succeeded(sets []models.Set) bool {
+	for _, s := range sets {
+		if s.Reps < sets[0].Reps {
+			return false
+		}
+	}
+	return true
+}
+
+// Linear wylicza propozycję z historii ćwiczenia (od najnowszej sesji).
+// ok = false, gdy ostatnia sesja nie ma serii z ciężarem.
+func Linear(history []Session, rule LinearRule) (Suggestion, bool) {
+	if len(history) == 0 {
+		return Suggestion{}, false
+	}
+	weight, sets, ok := workSets(history[0])
+	if !ok {
+		return Suggestion{}, false
+	}
+	sg := Suggestion{Action: ActionIncrease, Weight: weight + rule.Increment, Sets: len(sets), Reps: sets[0].Reps, LastWeight: weight}
+	for _, s := range history {
+		w, ws, ok := workSets(s)
+		if !ok || w != weight || succeeded(ws) {
+			break
+		}
+		sg.Failures++
+	}
+	switch {
+	case sg.Failures == 0:
+	case sg.Failures >= rule.MaxFailures:
+		sg.Action, sg.Weight = ActionDeload, roundDown(weight*(1-rule.DeloadPercent), rule.Rounding)
+	default:
+		sg.Action, sg.Weight = ActionRepeat, weight
+	}
+	return sg, true
+}
+
+// DoubleRule opisuje podwójną progresję: najpierw powtórzenia w zakresie, potem ciężar.
+type DoubleRule struct {
+	MinReps   int
+	MaxReps   int
+	Increment float64
+}
+
+// Double wylicza propozycję dla podwójnej progresji (np. 3x8–12): gdy wszystkie serie
+// robocze osiągnęły MaxReps, dokładamy ciężar i wracamy do MinReps; inaczej zostajemy
+// przy ciężarze i celujemy w jedno powtórzenie więcej niż najsłabsza seria.
+func Double(history []Session, rule DoubleRule) (Suggestion, bool) {
+	if len(history) == 0 {
+		return Suggestion{}, false
+	}
+	weight, sets, ok := workSets(history[0])
+	if !ok {
+		return Suggestion{}, false
+	}
+	weakest := sets[0].Reps
+	for _, s := range sets {
+		weakest = min(weakest, s.Reps)
+	}
+	if weakest >= rule.MaxReps {
+		return Suggestion{Action: ActionIncrease, Weight: weight + rule.Increment, Sets: len(sets), Reps: rule.MinReps, LastWeight: weight}, true
+	}
+	reps := min(max(weakest+1, rule.MinReps), rule.MaxReps)
+	return Suggestion{Action: ActionRepeat, Weight: weight, Sets: len(sets), Reps: reps, LastWeight: weight}, true
+}
+
+// DefaultTargetRPE to docelowe RPE serii roboczych (2 powtórzenia w zapasie).
+const DefaultTargetRPE = 8
+
+// rpeLoadStep to zmiana ciężaru odpowiadająca jednemu punktowi RPE (ok. 4%,
+// według typowych tabel RPE dla 1–5 powtórzeń).
+const rpeLoadStep = 0.04
+
+// SetRPE zwraca RPE serii, a gdy go nie podano – wyliczone z powtórzeń w zapasie
+// (10 − RIR). ok = false, gdy seria nie ma żadnego z nich.
+func SetRPE(set models.Set) (float64, bool) {
+	switch {
+	case set.RPE != nil:
+		return *set.RPE, true
+	case set.RIR != nil:
+		return max(10-float64(*set.RIR), 0), true
+	}
+	return 0, false
+}
+
+// AverageRPE zwraca średnie RPE serii roboczych sesji (patrz SetRPE); ok = false, gdy
+// żadna ich nie ma.
+func AverageRPE(s Session) (float64, bool) {
+	_, sets, ok := workSets(s)
+	if !ok {
+		return 0, false
+	}
+	sum, n := 0.0, 0
+	for _, set := range sets {
+		if rpe, ok := SetRPE(set); ok {
+			sum += rpe
+			n++
+		}
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return sum / float64(n), true
+}
+
+// Autoregulate koryguje propozycję według RPE ostatniej sesji: gdy średnie RPE serii
+// roboczych przekracza target, ciężar spada o rpeLoadStep za każdy punkt ponad cel
+// (licząc od ostatniego ciężaru, zaokrąglając w dół do rounding). Deloadu nie zmienia,
+// a propozycji nigdy nie podnosi.
+func Autoregulate(sg Suggestion, last Session, target, rounding float64) Suggestion {
+	avg, ok := AverageRPE(last)
+	if !ok || avg <= target || sg.Action == ActionDeload {
+		return sg
+	}
+	if w := roundDown(sg.LastWeight*(1-rpeLoadStep*(avg-target)), rounding); w < sg.Weight {
+		sg.Action, sg.Weight = ActionReduce, w
+	}
+	return sg
+}
+
+// LowReadiness to próg gotowości (0–100), poniżej którego nie proponujemy więcej ciężaru.
+const LowReadiness = 40
+
+// Readiness liczy gotowość do treningu 0–100 z ocen 1–5: energia i jakość snu ją
+// podnoszą, zakwasy i stres obniżają. Wszystkie cztery oceny ważą tyle samo.
+func Readiness(energy, soreness, stress, sleepQuality int) int {
+	points := (energy - 1) + (5 - soreness) + (5 - stress) + (sleepQuality - 1)
+	return int(math.Round(float64(points) / 16 * 100))
+}
+
+// AdjustForReadiness zamienia podwyżkę ciężaru na powtórzenie ostatniego, gdy gotowość
+// z dzisiejszej oceny samopoczucia jest poniżej LowReadiness. Słaby dzień to nie powód
+// do deloadu, ale też nie pora na nowy ciężar. Liczby powtórzeń nie zmienia.
+func AdjustForReadiness(sg Suggestion, readiness int) Suggestion {
+	if readiness >= LowReadiness || sg.Action != ActionIncrease {
+		return sg
+	}
+	sg.Action, sg.Weight = ActionRepeat, sg.LastWeight
+	return sg
+}
+
+// EpleyOneRM szacuje maksimum na jedno powtórzenie ze serii: weight × (1 + reps/30).
+func EpleyOneRM(weight float64, reps int) float64 {
+	if reps <= 1 {
+		return weight
+	}
+	return weight * (1 + float64(reps)/30)
+}
+
+// Parametry propozycji TM: bierzemy serie do 10 powtórzeń (wyżej szacunek 1RM jest
+// niewiarygodny) z kilku ostatnich sesji, a TM to 90% szacowanego 1RM (jak w 5/3/1).
+const (
+	tmMaxReps  = 10
+	tmSessions = 3
+	tmPercent  = 0.9
+)
+
+// Wzory szacowania 1RM.
+const (
+	FormulaEpley    = "epley"    // weight × (1 + reps/30)
+	FormulaBrzycki  = "brzycki"  // weight × 36 / (37 − reps)
+	FormulaLombardi = "lombardi" // weight × reps^0,1
+)
+
+// Formulas to obsługiwane wzory 1RM.
+var Formulas = []string{FormulaEpley, FormulaBrzycki, FormulaLombardi}
+
+// OneRM szacuje 1RM wybranym wzorem (nieznany wzór = Epley). Pojedyncze powtórzenie
+// to po prostu ciężar serii.
+func OneRM(formula string, weight float64, reps int) float64 {
+	if reps <= 1 {
+		return weight
+	}
+	switch formula {
+	case FormulaBrzycki:
+		return weight * 36 / (37 - float64(reps))
+	case FormulaLombardi:
+		return weight * math.Pow(float64(reps), 0.1)
+	}
+	return EpleyOneRM(weight, reps)
+}
+
+// BestE1RM zwraca najwyższy szacowany 1RM (wzorem Epleya) z serii sesji (serie 1–10
+// powtórzeń, wyżej szacunek jest niewiarygodny) i serię, z której pochodzi.
+func BestE1RM(s Session) (e1rm float64, best models.Set, ok bool) {
+	return BestE1RMWith(s, FormulaEpley)
+}
+
+// BestE1RMWith działa jak BestE1RM z wybranym wzorem (patrz OneRM).
+func BestE1RMWith(s Session, formula string) (e1rm float64, best models.Set, ok bool) {
+	for _, set := range s.Sets {
+		if set.Weight == nil || set.Reps < 1 || set.Reps > tmMaxReps {
+			continue
+		}
+		if est := OneRM(formula, *set.Weight, set.Reps); !ok || est > e1rm {
+			e1rm, best, ok = est, set, true
+		}
+	}
+	return e1rm, best, ok
+}
+
+// TrainingMaxFromHistory proponuje TM z najlepszej serii ostatnich sesji (od najnowszej),
+// np. z serii AMRAP kończącej tydzień 5/3/1. Zwraca też serię, na której go oparto.
+func TrainingMaxFromHistory(history []Session, rounding float64) (tm, e1rm float64, best Session, ok bool) {
+	for _, s := range history[:min(tmSessions, len(history))] {
+		if est, set, found := BestE1RM(s); found && est > e1rm {
+			e1rm, best, ok = est, Session{Date: s.Date, Sets: []models.Set{set}}, true
+		}
+	}
+	if !ok {
+		return 0, 0, Session{}, false
+	}
+	return roundDown(e1rm*tmPercent, rounding), e1rm, best, true
+}
+
+// E1RMPoint to najlepszy szacowany 1RM jednej sesji.
+type E1RMPoint struct {
+	Date   string
+	E1RM   float64
+	Weight float64 // ciężar serii, z której pochodzi szacunek
+	Reps   int
+}
+
+// Plateau opisuje postęp szacowanego 1RM w oknie ostatnich tygodni.
+type Plateau struct {
+	EnoughData   bool        // są sesje zarówno w oknie, jak i przed nim
+	Plateau      bool        // w oknie nie padł nowy najlepszy wynik
+	BestE1RM     float64     // najlepszy wynik w całej historii
+	BestDate     string      // data najlepszego wyniku (ostatniej poprawy)
+	WindowBest   float64     // najlepszy wynik w oknie
+	PriorBest    float64     // najlepszy wynik przed oknem
+	TrendPerWeek float64     // nachylenie prostej regresji w oknie, kg/tydzień
+	Trend        []E1RMPoint // sesje z okna i z tak samo długiego okresu przed nim, od najstarszej
+}
+
+// DetectPlateau sprawdza, czy szacowany 1RM poprawił się w ostatnich weeks tygodniach
+// (licząc od ostatniej sesji) względem wcześniejszej historii. Historia od najnowszej sesji.
+func DetectPlateau(history []Session, weeks int) Plateau {
+	var points []E1RMPoint
+	for _, s := range history {
+		if e1rm, set, ok := BestE1RM(s); ok {
+			points = append(points, E1RMPoint{Date: s.Date, E1RM: e1rm, Weight: *set.Weight, Reps: set.Reps})
+		}
+	}
+	var p Plateau
+	if len(points) == 0 {
+		p.Trend = []E1RMPoint{}
+		return p
+	}
+	last, err := time.Parse(dateLayout, points[0].Date)
+	if err != nil {
+		p.Trend = []E1RMPoint{}
+		return p
+	}
+	windowStart := last.AddDate(0, 0, -7*weeks).Format(dateLayout)
+	trendStart := last.AddDate(0, 0, -14*weeks).Format(dateLayout)
+
+	inWindow, prior := 0, 0
+	for _, pt := range points {
+		if pt.E1RM > p.BestE1RM || pt.E1RM == p.BestE1RM && pt.Date < p.BestDate {
+			p.BestE1RM, p.BestDate = pt.E1RM, pt.Date
+		}
+		if pt.Date > windowStart {
+			inWindow++
+			p.WindowBest = max(p.WindowBest, pt.E1RM)
+		} else {
+			prior++
+			p.PriorBest = max(p.PriorBest, pt.E1RM)
+		}
+		if pt.Date > trendStart {
+			p.Trend = append(p.Trend, pt)
+		}
+	}
+	slices.Reverse(p.Trend)
+	p.EnoughData = inWindow > 0 && prior > 0
+	p.Plateau = p.EnoughData && p.WindowBest <= p.PriorBest
+	p.TrendPerWeek = slope(p.Trend, windowStart)
+	return p
+}
+
+// slope liczy nachylenie prostej regresji (kg/tydzień) dla punktów po dacie from.
+func slope(points []E1RMPoint, from string) float64 {
+	var xs, ys []float64
+	for _, pt := range points {
+		if pt.Date <= from {
+			continue
+		}
+		d, err := time.Parse(dateLayout, pt.Date)
+		if err != nil {
+			continue
+		}
+		xs = append(xs, float64(d.Unix())/(7*24*3600))
+		ys = append(ys, pt.E1RM)
+	}
+	if len(xs) < 2 {
+		return 0
+	}
+	var mx, my float64
+	for i := range xs {
+		mx += xs[i]
+		my += ys[i]
+	}
+	mx /= float64(len(xs))
+	my /= float64(len(ys))
+	var num, den float64
+	for i := range xs {
+		num += (xs[i] - mx) * (ys[i] - my)
+		den += (xs[i] - mx) * (xs[i] - mx)
+	}
+	if den == 0 {
+		return 0
+	}
+	return num / den
+}
+
+// Progi wykrywania zmęczenia: RPE rosnące o co najmniej punkt na stałym ciężarze
+// albo niedobrane powtórzenia w co najmniej dwóch sesjach okna.
+const (
+	fatigueRPERise   = 1.0
+	fatigueMinMisses = 2
+)
+
+// Fatigue opisuje oznaki zmęczenia w ostatnich sesjach ćwiczenia.
+type Fatigue struct {
+	Sessions        int     // liczba przeanalizowanych sesji (najwyżej window)
+	Weight          float64 // ciężar serii roboczych w ostatniej sesji
+	RisingRPE       bool    // RPE rośnie przy tym samym ciężarze
+	RPEFrom         float64 // średnie RPE najstarszej sesji na tym ciężarze (gdy RisingRPE)
+	RPETo           float64 // średnie RPE ostatniej sesji (gdy RisingRPE)
+	MissedSessions  int     // sesje z niedobranymi powtórzeniami w serii roboczej
+	SuggestedWeight float64 // ciężar po deloadzie (DefaultLinear)
+}
+
+// MissedReps mówi, czy powtórzenia nie weszły w co najmniej dwóch sesjach.
+func (f Fatigue) MissedReps() bool {
+	return f.MissedSessions >= fatigueMinMisses
+}
+
+// Deload mówi, czy zmęczenie uzasadnia deload.
+func (f Fatigue) Deload() bool {
+	return f.RisingRPE || f.MissedReps()
+}
+
+// DetectFatigue szuka narastającego zmęczenia w ostatnich window sesjach ćwiczenia
+// (od najnowszej): RPE serii roboczych rośnie z sesji na sesję przy niezmienionym ciężarze
+// albo powtórzenia nie wchodzą w kilku sesjach. ok = false, gdy sesji z ciężarem jest
+// mniej niż dwie.
+func DetectFatigue(history []Session, window int) (Fatigue, bool) {
+	var recent []Session
+	for _, s := range history {
+		if len(recent) == window {
+			break
+		}
+		if _, _, ok := workSets(s); ok {
+			recent = append(recent, s)
+		}
+	}
+	if len(recent) < 2 {
+		return Fatigue{}, false
+	}
+	weight, _, _ := workSets(recent[0])
+	f := Fatigue{Sessions: len(recent), Weight: weight}
+	for _, s := range recent {
+		if _, sets, _ := workSets(s); !succeeded(sets) {
+			f.MissedSessions++
+		}
+	}
+
+	// RPE od najnowszej sesji ma spadać (czyli z czasem rosnąć), a ciężar się nie zmieniać.
+	prev, n := 0.0, 0
+	for _, s := range recent {
+		w, _, _ := workSets(s)
+		rpe, ok := AverageRPE(s)
+		if w != weight || !ok || n > 0 && rpe > prev {
+			break
+		}
+		if n == 0 {
+			f.RPETo = rpe
+		}
+		prev, n = rpe, n+1
+	}
+	if n >= 2 && f.RPETo-prev >= fatigueRPERise {
+		f.RisingRPE, f.RPEFrom = true, prev
+	} else {
+		f.RPETo = 0
+	}
+	f.SuggestedWeight = roundDown(weight*(1-DefaultLinear.DeloadPercent), DefaultLinear.Rounding)
+	return f, true
+}
+
+// roundDown zaokrągla ciężar w dół do wielokrotności step (step <= 0 = bez zaokrąglania).
+func roundDown(kg, step float64) float64 {
+	if step <= 0 {
+		return kg
+	}
+	return math.Floor(kg/step+1e-9) * step
+}
@@ -0,0 +1,192 @@
+// Package scale czyta eksporty CSV z wag łazienkowych (Withings, Renpho) i zamienia
+// je na pomiary masy ciała i tkanki tłuszczowej. Format rozpoznajemy po nagłówku.
+package scale
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// Obsługiwane formaty eksportu.
+const (
+	FormatWithings = "withings"
+	FormatRenpho   = "renpho"
+)
+
+// Formats to formaty, które można wskazać jawnie.
+var Formats = []string{FormatWithings, FormatRenpho}
+
+// ErrUnknownFormat oznacza nagłówek, którego nie rozpoznajemy.
+var ErrUnknownFormat = errors.New("unrecognized smart-scale CSV header")
+
+// Reading to jeden pomiar z wagi.
+type Reading struct {
+	Line    int       // numer wiersza w pliku (od 1, z nagłówkiem)
+	At      time.Time // czas pomiaru (czas lokalny wagi zapisany jako UTC)
+	Weight  float64   // kg
+	BodyFat *float64  // %
+}
+
+// LineError to błąd jednego wiersza; pozostałe wiersze czytamy dalej.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Formaty czasu spotykane w eksportach (Withings: ISO z sekundami, Renpho: różnie
+// zależnie od wersji aplikacji i ustawień regionalnych).
+var timeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"01/02/2006 15:04:05",
+	"01/02/2006 15:04",
+	"01/02/2006, 15:04:05",
+	"01/02/2006, 15:04",
+	"2006.01.02 15:04:05",
+	"02.01.2006 15:04",
+	"2006-01-02",
+}
+
+// columns to pozycje potrzebnych kolumn w nagłówku; -1 = brak kolumny.
+type columns struct {
+	date, time, weight, fatPct, fatKg int
+	pounds                            bool
+}
+
+// Parse czyta plik CSV w podanym formacie (pusty = rozpoznaj z nagłówka) i zwraca
+// wykryty format, poprawne pomiary oraz błędy pojedynczych wierszy.
+func Parse(r io.Reader, format string) (string, []Reading, []LineError, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	header, err := cr.Read()
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("scale: %w", err)
+	}
+	detected, cols, ok := detect(header)
+	if !ok || format != "" && format != detected {
+		return "", nil, nil, ErrUnknownFormat
+	}
+
+	var readings []Reading
+	var lineErrs []LineError
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			lineErrs = append(lineErrs, LineError{Line: line, Err: err})
+			continue
+		}
+		if len(rec) == 0 || len(rec) == 1 && strings.TrimSpace(rec[0]) == "" {
+			continue
+		}
+		rd, err := cols.reading(rec)
+		if err != nil {
+			lineErrs = append(lineErrs, LineError{Line: line, Err: err})
+			continue
+		}
+		rd.Line = line
+		readings = append(readings, rd)
+	}
+	return detected, readings, lineErrs, nil
+}
+
+// detect rozpoznaje format po nazwach kolumn.
+func detect(header []string) (string, columns, bool) {
+	c := columns{date: -1, time: -1, weight: -1, fatPct: -1, fatKg: -1}
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		switch {
+		case h == "date" || h == "time of measurement" || h == "measurement time":
+			c.date = i
+		case h == "time":
+			c.time = i
+		case strings.HasPrefix(h, "weight"):
+			c.weight = i
+			c.pounds = strings.Contains(h, "lb")
+		case strings.HasPrefix(h, "body fat") && strings.Contains(h, "%"):
+			c.fatPct = i
+		case strings.HasPrefix(h, "fat mass"):
+			c.fatKg = i
+		}
+	}
+	if c.date < 0 || c.weight < 0 {
+		return "", c, false
+	}
+	// Withings podaje masę tłuszczu w kg (albo wcale), Renpho – procent tkanki tłuszczowej.
+	if c.fatPct >= 0 {
+		return FormatRenpho, c, true
+	}
+	return FormatWithings, c, true
+}
+
+func (c columns) reading(rec []string) (Reading, error) {
+	field := func(i int) string {
+		if i < 0 || i >= len(rec) {
+			return ""
+		}
+		return strings.TrimSpace(rec[i])
+	}
+	stamp := field(c.date)
+	if t := field(c.time); t != "" {
+		stamp += " " + t
+	}
+	at, err := parseTime(stamp)
+	if err != nil {
+		return Reading{}, err
+	}
+	weight, err := parseNumber(field(c.weight))
+	if err != nil || weight <= 0 {
+		return Reading{}, fmt.Errorf("invalid weight %q", field(c.weight))
+	}
+	if c.pounds {
+		weight *= models.KgPerLb
+	}
+	rd := Reading{At: at, Weight: weight}
+	if v := field(c.fatPct); v != "" && v != "--" {
+		if pct, err := parseNumber(v); err == nil && pct > 0 {
+			rd.BodyFat = &pct
+		}
+	}
+	if v := field(c.fatKg); v != "" {
+		if kg, err := parseNumber(v); err == nil && kg > 0 {
+			if c.pounds {
+				kg *= models.KgPerLb
+			}
+			pct := kg / weight * 100
+			rd.BodyFat = &pct
+		}
+	}
+	return rd, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// parseNumber akceptuje też przecinek dziesiętny i jednostkę po liczbie ("82,4 kg").
+func parseNumber(s string) (float64, error) {
+	s = strings.TrimSpace(strings.TrimRight(strings.ToLower(s), "kglbs% "))
+	return strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+}
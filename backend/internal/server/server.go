@@ -1,14 +1,84 @@
 package server
 
-import "gym-api/internal/store"
+import (
+	"gym-api/internal/blob"
+	"gym-api/internal/fitbit"
+	"gym-api/internal/garmin"
+	"gym-api/internal/googlefit"
+	"gym-api/internal/store"
+	"gym-api/internal/strava"
+)
 
-// Server agreguje zależności aplikacji (tu: magazyn treningów)
+// Server agreguje zależności aplikacji (magazyny danych)
 // i jest przekazywany do handlerów HTTP.
 type Server struct {
-	Workouts *store.WorkoutStore
+	Workouts  *store.WorkoutStore
+	Audit     *store.AuditStore
+	Exercises *store.ExerciseStore // katalog ćwiczeń
+	Templates *store.TemplateStore
+	Programs  *store.ProgramStore
+	// Progression trzyma reguły progresji ćwiczeń (domyślnie liniowa).
+	Progression *store.ProgressionStore
+	// TrainingMaxes trzyma historię maksów treningowych (TM) dla programów procentowych.
+	TrainingMaxes *store.TrainingMaxStore
+	// Insights trzyma ostatni raport analizy treningów (liczony w tle, patrz main).
+	Insights *store.InsightStore
+	// Achievements pamięta zdobyte odznaki (reguły w pakiecie achievements).
+	Achievements *store.AchievementStore
+	Goals        *store.GoalStore
+	Bodyweight   *store.BodyweightStore
+	Measurements *store.MeasurementStore // obwody ciała
+	Photos       *store.PhotoStore
+	Nutrition    *store.NutritionStore // kalorie i makroskładniki
+	Water        *store.WaterStore
+	Sleep        *store.SleepStore
+	HeartRate    *store.HeartRateStore // tętno maksymalne (strefy tętna)
+	Profiles     *store.ProfileStore   // ustawienia użytkownika (jednostka ciężaru)
+	Checkins     *store.CheckinStore   // codzienne oceny samopoczucia (gotowość do treningu)
+	Injuries     *store.InjuryStore
+	Supplements  *store.SupplementStore
+	// Integrations trzyma połączenia z zewnętrznymi serwisami (tokeny, zaimportowane aktywności).
+	Integrations *store.IntegrationStore
+	// Strava to klient API Stravy; nil, gdy integracji nie skonfigurowano.
+	Strava *strava.Client
+	// Garmin to klient Garmin Connect; nil, gdy integracji nie skonfigurowano.
+	Garmin *garmin.Client
+	// GoogleFit to klient Google Fitness API; nil, gdy integracji nie skonfigurowano.
+	GoogleFit *googlefit.Client
+	// Fitbit to klient Fitbit Web API; nil, gdy integracji nie skonfigurowano.
+	Fitbit *fitbit.Client
+	// Blobs trzyma pliki zdjęć; domyślnie w pamięci, main podmienia według konfiguracji.
+	Blobs blob.Store
+	// AdminToken otwiera endpointy administracyjne (dziennik audytu); pusty = zamknięte.
+	AdminToken string
 }
 
-// New tworzy nowy obiekt serwera z wstrzykniętym magazynem treningów.
-func New(workouts *store.WorkoutStore) *Server {
-	return &Server{Workouts: workouts}
+// New tworzy serwer z pamięciowymi magazynami (jedyny backend, patrz config.Storage).
+func New() *Server {
+	audit := store.NewAuditStore()
+	return &Server{
+		Workouts:      store.NewWorkoutStore(audit),
+		Audit:         audit,
+		Exercises:     store.NewExerciseStore(),
+		Templates:     store.NewTemplateStore(),
+		Programs:      store.NewProgramStore(),
+		Progression:   store.NewProgressionStore(),
+		TrainingMaxes: store.NewTrainingMaxStore(),
+		Insights:      store.NewInsightStore(),
+		Achievements:  store.NewAchievementStore(),
+		Goals:         store.NewGoalStore(),
+		Bodyweight:    store.NewBodyweightStore(),
+		Measurements:  store.NewMeasurementStore(),
+		Photos:        store.NewPhotoStore(),
+		Nutrition:     store.NewNutritionStore(),
+		Water:         store.NewWaterStore(),
+		Sleep:         store.NewSleepStore(),
+		Checkins:      store.NewCheckinStore(),
+		Injuries:      store.NewInjuryStore(),
+		Supplements:   store.NewSupplementStore(),
+		HeartRate:     store.NewHeartRateStore(),
+		Profiles:      store.NewProfileStore(),
+		Integrations:  store.NewIntegrationStore(),
+		Blobs:         blob.NewMemory(),
+	}
 }
@@ -0,0 +1,73 @@
+package standards
+
+import "math"
+
+// Współczynniki Wilksa (wersja z 1995 r.) dla wielomianu 5. stopnia masy ciała.
+var wilksCoef = map[string][6]float64{
+	Male:   {-216.0475144, 16.2606339, -0.002388645, -0.00113732, 7.01863e-06, -1.291e-08},
+	Female: {594.31747775582, -27.23842536447, 0.82112226871, -0.00930733913, 4.731582e-05, -9.054e-08},
+}
+
+// Zakresy masy ciała, w których wzory są określone (poza nimi przycinamy).
+var wilksRange = map[string][2]float64{
+	Male:   {40, 201.9},
+	Female: {26.51, 154.53},
+}
+
+// Współczynniki DOTS dla wielomianu 4. stopnia masy ciała.
+var dotsCoef = map[string][5]float64{
+	Male:   {-307.75076, 24.0900756, -0.1918759221, 0.0007391293, -0.000001093},
+	Female: {-57.96288, 13.6175032, -0.1126655495, 0.0005158568, -0.0000010706},
+}
+
+var dotsRange = map[string][2]float64{
+	Male:   {40, 210},
+	Female: {40, 150},
+}
+
+// Współczynniki IPF GL (trójbój klasyczny, bez sprzętu): A, B, C.
+var glCoef = map[string][3]float64{
+	Male:   {1199.72839, 1025.18162, 0.00921},
+	Female: {610.32796, 1045.59282, 0.03048},
+}
+
+// poly liczy wartość wielomianu o współczynnikach od wyrazu wolnego.
+func poly(coef []float64, x float64) float64 {
+	sum, pow := 0.0, 1.0
+	for _, c := range coef {
+		sum += c * pow
+		pow *= x
+	}
+	return sum
+}
+
+func clamp(x float64, r [2]float64) float64 {
+	return min(max(x, r[0]), r[1])
+}
+
+// Wilks zwraca punkty Wilksa za wynik total (kg) przy masie ciała bodyweight.
+func Wilks(sex string, total, bodyweight float64) float64 {
+	c, ok := wilksCoef[sex]
+	if !ok {
+		return 0
+	}
+	return total * 500 / poly(c[:], clamp(bodyweight, wilksRange[sex]))
+}
+
+// DOTS zwraca punkty DOTS za wynik total (kg) przy masie ciała bodyweight.
+func DOTS(sex string, total, bodyweight float64) float64 {
+	c, ok := dotsCoef[sex]
+	if !ok {
+		return 0
+	}
+	return total * 500 / poly(c[:], clamp(bodyweight, dotsRange[sex]))
+}
+
+// IPFGL zwraca punkty IPF GL (trójbój klasyczny) za wynik total przy masie ciała bodyweight.
+func IPFGL(sex string, total, bodyweight float64) float64 {
+	c, ok := glCoef[sex]
+	if !ok {
+		return 0
+	}
+	return total * 100 / (c[0] - c[1]*math.Exp(-c[2]*bodyweight))
+}
@@ -0,0 +1,74 @@
+// Package standards porównuje siłę z normami dla bojów podstawowych. Progi to wielokrotności
+// masy ciała szacowanego 1RM (uproszczone, uśrednione normy popularnych tabel, np. Strength
+// Level i ExRx) dla pięciu poziomów: od osoby nietrenującej do elity.
+package standards
+
+import "math"
+
+// Poziomy zaawansowania, od najniższego.
+const (
+	Untrained    = "untrained"
+	Novice       = "novice"
+	Intermediate = "intermediate"
+	Advanced     = "advanced"
+	Elite        = "elite"
+)
+
+// Levels to poziomy w kolejności rosnącej.
+var Levels = []string{Untrained, Novice, Intermediate, Advanced, Elite}
+
+// Płcie w normach.
+const (
+	Male   = "male"
+	Female = "female"
+)
+
+// ratios to progi poziomów (1RM / masa ciała) per płeć i bój (klucze jak w programs.Lifts).
+var ratios = map[string]map[string][5]float64{
+	Male: {
+		"squat":    {0.75, 1.25, 1.5, 2.25, 2.75},
+		"bench":    {0.5, 0.75, 1.25, 1.75, 2.0},
+		"deadlift": {1.0, 1.5, 2.0, 2.5, 3.0},
+		"press":    {0.4, 0.55, 0.8, 1.05, 1.35},
+	},
+	Female: {
+		"squat":    {0.5, 0.75, 1.25, 1.5, 2.0},
+		"bench":    {0.25, 0.5, 0.75, 1.0, 1.5},
+		"deadlift": {0.5, 1.0, 1.25, 1.75, 2.5},
+		"press":    {0.2, 0.35, 0.5, 0.75, 1.0},
+	},
+}
+
+// Result to klasyfikacja jednego boju.
+type Result struct {
+	Level      string             // najwyższy osiągnięty poziom; "" = poniżej progu untrained
+	Score      float64            // 0–100: 20 punktów na poziom, liniowo między progami
+	Next       string             // kolejny poziom; "" po osiągnięciu elite
+	NextWeight float64            // 1RM potrzebny na kolejny poziom (kg)
+	Thresholds map[string]float64 // 1RM dla każdego poziomu (kg)
+}
+
+// Classify ocenia szacowany 1RM boju lift przy masie ciała bodyweight.
+// ok = false dla nieznanej płci lub boju.
+func Classify(sex, lift string, e1rm, bodyweight float64) (Result, bool) {
+	th, ok := ratios[sex][lift]
+	if !ok || bodyweight <= 0 {
+		return Result{}, false
+	}
+	r := Result{Thresholds: make(map[string]float64, len(Levels))}
+	for i, level := range Levels {
+		r.Thresholds[level] = math.Round(th[i]*bodyweight*10) / 10
+	}
+	ratio := e1rm / bodyweight
+	prev := 0.0
+	for i, t := range th {
+		if ratio < t {
+			r.Score = 20*float64(i) + 20*(ratio-prev)/(t-prev)
+			r.Next, r.NextWeight = Levels[i], r.Thresholds[Levels[i]]
+			return r, true
+		}
+		r.Level, prev = Levels[i], t
+	}
+	r.Score = 100
+	return r, true
+}
@@ -0,0 +1,53 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// AchievementStore pamięta, kiedy wykonawca zdobył każdą odznakę. Zdobytej odznaki
+// się nie odbiera, nawet gdy trening, który ją dał, zostanie później usunięty.
+type AchievementStore struct {
+	mu     sync.RWMutex
+	earned map[string]map[string]time.Time // wykonawca -> ID odznaki -> czas zdobycia
+}
+
+// NewAchievementStore tworzy pusty magazyn odznak.
+func NewAchievementStore() *AchievementStore {
+	return &AchievementStore{earned: map[string]map[string]time.Time{}}
+}
+
+// Earned zwraca odznaki wykonawcy z czasem zdobycia.
+func (s *AchievementStore) Earned(ctx context.Context) map[string]time.Time {
+	defer startSpan(ctx, "AchievementStore.Earned")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[string]time.Time, len(s.earned[ActorFrom(ctx)]))
+	for id, at := range s.earned[ActorFrom(ctx)] {
+		out[id] = at
+	}
+	return out
+}
+
+// Award zapisuje zdobycie odznaki i zwraca jego czas; ponowne przyznanie zwraca
+// pierwotny czas.
+func (s *AchievementStore) Award(ctx context.Context, id string) time.Time {
+	defer startSpan(ctx, "AchievementStore.Award")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	actor := ActorFrom(ctx)
+	if at, ok := s.earned[actor][id]; ok {
+		return at
+	}
+	if s.earned[actor] == nil {
+		s.earned[actor] = map[string]time.Time{}
+	}
+	at := time.Now().UTC()
+	s.earned[actor][id] = at
+	return at
+}
@@ -0,0 +1,152 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// Akcje zapisywane w dzienniku audytu.
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditPurge   = "purge"
+	AuditUndo    = "undo"
+)
+
+// maxAuditEntries to liczba najnowszych wpisów, które trzyma dziennik; starsze usuwamy
+// partiami po auditTrimBatch, żeby nie kopiować dziennika przy każdym wpisie.
+const (
+	maxAuditEntries = 10000
+	auditTrimBatch  = 1000
+)
+
+// AuditStore to dziennik audytu w pamięci: wpisy można tylko dopisywać i czytać.
+// Trzyma co najwyżej maxAuditEntries najnowszych wpisów.
+type AuditStore struct {
+	mu      sync.RWMutex
+	entries []models.AuditEntry
+	nextID  int
+}
+
+// NewAuditStore tworzy pusty dziennik audytu.
+func NewAuditStore() *AuditStore {
+	return &AuditStore{}
+}
+
+type actorKey struct{}
+
+// WithActor zapisuje w kontekście, kto wykonuje operację (trafia do dziennika audytu).
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom zwraca wykonawcę operacji z kontekstu. API nie ma jeszcze
+// uwierzytelniania, więc domyślnie jest to "anonymous".
+func ActorFrom(ctx context.Context) string {
+	if a, ok := ctx.Value(actorKey{}).(string); ok && a != "" {
+		return a
+	}
+	return "anonymous"
+}
+
+// Record dopisuje wpis do dziennika; ID i czas nadaje magazyn.
+func (s *AuditStore) Record(ctx context.Context, action, resource string, resourceID int, summary string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.entries) >= maxAuditEntries+auditTrimBatch {
+		s.entries = slices.Clone(s.entries[len(s.entries)-maxAuditEntries:])
+	}
+	s.nextID++
+	s.entries = append(s.entries, models.AuditEntry{
+		ID:         s.nextID,
+		Time:       time.Now(),
+		Actor:      ActorFrom(ctx),
+		Action:     action,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Summary:    summary,
+	})
+}
+
+// AuditQuery opisuje stronę dziennika audytu; puste filtry = wszystkie wpisy.
+type AuditQuery struct {
+	Limit      int
+	Offset     int
+	Resource   string
+	ResourceID int
+}
+
+// List zwraca stronę wpisów (od najnowszych) i łączną liczbę pasujących wpisów.
+func (s *AuditStore) List(ctx context.Context, q AuditQuery) ([]models.AuditEntry, int) {
+	defer startSpan(ctx, "AuditStore.List")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var all []models.AuditEntry
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		e := s.entries[i]
+		if q.Resource != "" && e.Resource != q.Resource {
+			continue
+		}
+		if q.ResourceID != 0 && e.ResourceID != q.ResourceID {
+			continue
+		}
+		all = append(all, e)
+	}
+	total := len(all)
+	start := min(q.Offset, total)
+	end := total
+	if q.Limit > 0 {
+		end = min(start+q.Limit, total)
+	}
+	return all[start:end], total
+}
+
+// workoutSummary opisuje trening w jednym zdaniu, np. `"Push day" on 2026-01-16`.
+func workoutSummary(w models.Workout) string {
+	return fmt.Sprintf("%q on %s", w.Title, w.Date)
+}
+
+// changeSummary wymienia pola, które zmieniły się między wersjami treningu.
+func changeSummary(prev, next models.Workout) string {
+	changed := changedFields(prev, next)
+	if len(changed) == 0 {
+		return "no changes"
+	}
+	return "changed " + strings.Join(changed, ", ")
+}
+
+// changedFields zwraca nazwy (JSON) edytowalnych pól, które różnią się między treningami.
+func changedFields(a, b models.Workout) []string {
+	var out []string
+	if a.Title != b.Title {
+		out = append(out, "title")
+	}
+	if a.Date != b.Date {
+		out = append(out, "date")
+	}
+	if a.Notes != b.Notes {
+		out = append(out, "notes")
+	}
+	if !reflect.DeepEqual(a.Exercises, b.Exercises) {
+		out = append(out, "exercises")
+	}
+	if a.Planned != b.Planned {
+		out = append(out, "planned")
+	}
+	return out
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// BodyweightStore trzyma pomiary masy ciała. Każdy widzi tylko własne pomiary (ActorFrom).
+type BodyweightStore struct {
+	items *collection[models.BodyweightEntry]
+	// importMu szereguje importy, żeby dwa równoległe wgrania tego samego pliku
+	// nie ominęły sprawdzania duplikatów.
+	importMu sync.Mutex
+}
+
+// NewBodyweightStore tworzy pusty magazyn pomiarów.
+func NewBodyweightStore() *BodyweightStore {
+	return &BodyweightStore{items: newCollection(func(e *models.BodyweightEntry, id int, now time.Time, created bool) {
+		e.ID = id
+		if created {
+			e.CreatedAt = now
+		}
+		e.UpdatedAt = now
+	})}
+}
+
+// Create zapisuje pomiar wykonawcy.
+func (s *BodyweightStore) Create(ctx context.Context, e models.BodyweightEntry) models.BodyweightEntry {
+	defer startSpan(ctx, "BodyweightStore.Create")()
+
+	e.Owner = ActorFrom(ctx)
+	return s.items.create(e)
+}
+
+// Import zapisuje pomiary z wagi, pomijając te, których MeasuredAt wykonawca już ma
+// (także powtórzone w samym pliku). Zwraca liczbę zapisanych i pominiętych pomiarów.
+func (s *BodyweightStore) Import(ctx context.Context, entries []models.BodyweightEntry) (imported, duplicates int) {
+	defer startSpan(ctx, "BodyweightStore.Import")()
+
+	s.importMu.Lock()
+	defer s.importMu.Unlock()
+
+	actor := ActorFrom(ctx)
+	seen := make(map[time.Time]bool)
+	for _, e := range s.items.list(func(e models.BodyweightEntry) bool { return e.Owner == actor && e.MeasuredAt != nil }) {
+		seen[*e.MeasuredAt] = true
+	}
+	for _, e := range entries {
+		if e.MeasuredAt == nil || seen[*e.MeasuredAt] {
+			duplicates++
+			continue
+		}
+		seen[*e.MeasuredAt] = true
+		e.Owner = actor
+		s.items.create(e)
+		imported++
+	}
+	return imported, duplicates
+}
+
+// List zwraca pomiary wykonawcy z zakresu [from, to] (puste = bez ograniczeń),
+// od najnowszego (po dacie, potem ID).
+func (s *BodyweightStore) List(ctx context.Context, from, to string) []models.BodyweightEntry {
+	defer startSpan(ctx, "BodyweightStore.List")()
+
+	actor := ActorFrom(ctx)
+	out := s.items.list(func(e models.BodyweightEntry) bool {
+		return e.Owner == actor && (from == "" || e.Date >= from) && (to == "" || e.Date <= to)
+	})
+	slices.SortFunc(out, func(a, b models.BodyweightEntry) int {
+		return cmp.Or(cmp.Compare(b.Date, a.Date), cmp.Compare(b.ID, a.ID))
+	})
+	return out
+}
+
+// Latest zwraca najnowszy pomiar wykonawcy.
+func (s *BodyweightStore) Latest(ctx context.Context) (models.BodyweightEntry, bool) {
+	defer startSpan(ctx, "BodyweightStore.Latest")()
+
+	all := s.List(ctx, "", "")
+	if len(all) == 0 {
+		return models.BodyweightEntry{}, false
+	}
+	return all[0], true
+}
+
+// At zwraca masę ciała wykonawcy w dniu date: ostatni pomiar z tego dnia lub wcześniejszy,
+// a gdy takiego nie ma – najwcześniejszy późniejszy (masę często loguje się dopiero po treningu).
+func (s *BodyweightStore) At(ctx context.Context, date string) (models.BodyweightEntry, bool) {
+	defer startSpan(ctx, "BodyweightStore.At")()
+
+	all := s.List(ctx, "", "")
+	for _, e := range all {
+		if e.Date <= date {
+			return e, true
+		}
+	}
+	if len(all) == 0 {
+		return models.BodyweightEntry{}, false
+	}
+	return all[len(all)-1], true
+}
+
+// Get zwraca pomiar wykonawcy o podanym ID.
+func (s *BodyweightStore) Get(ctx context.Context, id int) (models.BodyweightEntry, bool) {
+	defer startSpan(ctx, "BodyweightStore.Get")()
+
+	e, ok := s.items.get(id)
+	if !ok || e.Owner != ActorFrom(ctx) {
+		return models.BodyweightEntry{}, false
+	}
+	return e, true
+}
+
+// Update zastępuje pomiar wykonawcy (ID, CreatedAt, właściciel i pochodzenie z importu
+// zostają, żeby ponowny import nie dodał poprawionego pomiaru drugi raz).
+func (s *BodyweightStore) Update(ctx context.Context, id int, e models.BodyweightEntry) (models.BodyweightEntry, error) {
+	defer startSpan(ctx, "BodyweightStore.Update")()
+
+	actor := ActorFrom(ctx)
+	return s.items.update(id, func(cur models.BodyweightEntry) (models.BodyweightEntry, error) {
+		if cur.Owner != actor {
+			return cur, ErrNotFound
+		}
+		e.Owner, e.CreatedAt = cur.Owner, cur.CreatedAt
+		if cur.MeasuredAt != nil {
+			e.Source, e.MeasuredAt = cur.Source, cur.MeasuredAt
+		}
+		return e, nil
+	})
+}
+
+// Delete usuwa pomiar wykonawcy.
+func (s *BodyweightStore) Delete(ctx context.Context, id int) bool {
+	defer startSpan(ctx, "BodyweightStore.Delete")()
+
+	if _, ok := s.Get(ctx, id); !ok {
+		return false
+	}
+	return s.items.delete(id)
+}
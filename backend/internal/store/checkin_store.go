@@ -0,0 +1,96 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// CheckinStore trzyma codzienne oceny samopoczucia. Każdy widzi tylko własne oceny
+// (ActorFrom).
+type CheckinStore struct {
+	items *collection[models.Checkin]
+}
+
+// NewCheckinStore tworzy pusty magazyn ocen.
+func NewCheckinStore() *CheckinStore {
+	return &CheckinStore{items: newCollection(func(e *models.Checkin, id int, now time.Time, created bool) {
+		e.ID = id
+		if created {
+			e.CreatedAt = now
+		}
+		e.UpdatedAt = now
+	})}
+}
+
+// Create zapisuje ocenę wykonawcy.
+func (s *CheckinStore) Create(ctx context.Context, e models.Checkin) models.Checkin {
+	defer startSpan(ctx, "CheckinStore.Create")()
+
+	e.Owner = ActorFrom(ctx)
+	return s.items.create(e)
+}
+
+// List zwraca oceny wykonawcy z zakresu [from, to] (puste = bez ograniczeń),
+// od najnowszej (po dacie, potem ID).
+func (s *CheckinStore) List(ctx context.Context, from, to string) []models.Checkin {
+	defer startSpan(ctx, "CheckinStore.List")()
+
+	actor := ActorFrom(ctx)
+	out := s.items.list(func(e models.Checkin) bool {
+		return e.Owner == actor && (from == "" || e.Date >= from) && (to == "" || e.Date <= to)
+	})
+	slices.SortFunc(out, func(a, b models.Checkin) int {
+		return cmp.Or(cmp.Compare(b.Date, a.Date), cmp.Compare(b.ID, a.ID))
+	})
+	return out
+}
+
+// Get zwraca ocenę wykonawcy o podanym ID.
+func (s *CheckinStore) Get(ctx context.Context, id int) (models.Checkin, bool) {
+	defer startSpan(ctx, "CheckinStore.Get")()
+
+	e, ok := s.items.get(id)
+	if !ok || e.Owner != ActorFrom(ctx) {
+		return models.Checkin{}, false
+	}
+	return e, true
+}
+
+// Update zastępuje ocenę wykonawcy (ID, CreatedAt i właściciel zostają).
+func (s *CheckinStore) Update(ctx context.Context, id int, e models.Checkin) (models.Checkin, error) {
+	defer startSpan(ctx, "CheckinStore.Update")()
+
+	actor := ActorFrom(ctx)
+	return s.items.update(id, func(cur models.Checkin) (models.Checkin, error) {
+		if cur.Owner != actor {
+			return cur, ErrNotFound
+		}
+		e.Owner, e.CreatedAt = cur.Owner, cur.CreatedAt
+		return e, nil
+	})
+}
+
+// Delete usuwa ocenę wykonawcy.
+func (s *CheckinStore) Delete(ctx context.Context, id int) bool {
+	defer startSpan(ctx, "CheckinStore.Delete")()
+
+	if _, ok := s.Get(ctx, id); !ok {
+		return false
+	}
+	return s.items.delete(id)
+}
+
+// ForDate zwraca najnowszą ocenę wykonawcy z podanego dnia.
+func (s *CheckinStore) ForDate(ctx context.Context, date string) (models.Checkin, bool) {
+	defer startSpan(ctx, "CheckinStore.ForDate")()
+
+	all := s.List(ctx, date, date)
+	if len(all) == 0 {
+		return models.Checkin{}, false
+	}
+	return all[0], true
+}
@@ -0,0 +1,91 @@
+package store
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// collection to bezpieczna współbieżnie kolekcja encji w pamięci z kolejnymi ID od 1.
+// Wspólny fundament prostych magazynów (katalog ćwiczeń, szablony, ...); magazyny
+// opakowują ją metodami z kontekstem, spanami i zapytaniami specyficznymi dla encji.
+type collection[T any] struct {
+	mu     sync.RWMutex
+	nextID int
+	items  map[int]T
+	// stamp ustawia ID i znaczniki czasu: przy tworzeniu (created = true) i przy każdej zmianie.
+	stamp func(t *T, id int, now time.Time, created bool)
+}
+
+func newCollection[T any](stamp func(t *T, id int, now time.Time, created bool)) *collection[T] {
+	return &collection[T]{nextID: 1, items: make(map[int]T), stamp: stamp}
+}
+
+// create nadaje encji ID i znaczniki czasu, po czym ją zapisuje.
+func (c *collection[T]) create(t T) T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stamp(&t, c.nextID, time.Now(), true)
+	c.items[c.nextID] = t
+	c.nextID++
+	return t
+}
+
+func (c *collection[T]) get(id int) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	t, ok := c.items[id]
+	return t, ok
+}
+
+// list zwraca encje spełniające keep (nil = wszystkie) w kolejności ID.
+func (c *collection[T]) list(keep func(T) bool) []T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ids := make([]int, 0, len(c.items))
+	for id, t := range c.items {
+		if keep == nil || keep(t) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	out := make([]T, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, c.items[id])
+	}
+	return out
+}
+
+// update zmienia encję funkcją upd; błąd z upd przerywa zapis i jest zwracany.
+// Dla nieistniejącego ID zwraca ErrNotFound.
+func (c *collection[T]) update(id int, upd func(cur T) (T, error)) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cur, ok := c.items[id]
+	if !ok {
+		var zero T
+		return zero, ErrNotFound
+	}
+	next, err := upd(cur)
+	if err != nil {
+		return cur, err
+	}
+	c.stamp(&next, id, time.Now(), false)
+	c.items[id] = next
+	return next, nil
+}
+
+func (c *collection[T]) delete(id int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+	delete(c.items, id)
+	return true
+}
@@ -0,0 +1,276 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// Błędy katalogu ćwiczeń.
+var (
+	ErrDuplicateName = errors.New("exercise name or alias already exists")
+	ErrReadOnly      = errors.New("global catalog exercises are read-only")
+)
+
+// ExerciseStore to katalog ćwiczeń w pamięci. Składa się z katalogu globalnego
+// (Owner == "") i nałożonych na niego ćwiczeń własnych użytkowników: każdy widzi
+// katalog globalny i swoje ćwiczenia, a nazwy muszą być unikalne w tym widoku.
+type ExerciseStore struct {
+	items *collection[models.CatalogExercise]
+	// namesMu szereguje zapisy zmieniające nazwy, żeby sprawdzenie unikalności i zapis
+	// były jedną operacją – dwa równoległe żądania z tą samą nazwą nie przejdą obu.
+	namesMu sync.Mutex
+}
+
+// NewExerciseStore tworzy pusty katalog ćwiczeń.
+func NewExerciseStore() *ExerciseStore {
+	return &ExerciseStore{items: newCollection(func(e *models.CatalogExercise, id int, now time.Time, created bool) {
+		e.ID = id
+		if created {
+			e.CreatedAt = now
+		}
+		e.UpdatedAt = now
+	})}
+}
+
+// ExerciseQuery filtruje katalog; puste pola = bez ograniczeń.
+type ExerciseQuery struct {
+	Search          string // fragment nazwy lub aliasu (bez rozróżniania wielkości liter)
+	MuscleGroup     string // ID grupy mięśni (główna lub pomocnicza)
+	PrimaryOnly     bool   // MuscleGroup tylko wśród mięśni głównych
+	Equipment       string
+	Type            string
+	IncludeArchived bool
+}
+
+// visibleTo informuje, czy ćwiczenie należy do widoku katalogu danego użytkownika.
+func visibleTo(e models.CatalogExercise, actor string) bool {
+	return e.Owner == "" || e.Owner == actor
+}
+
+// containsName sprawdza, czy nazwa lub któryś alias zawiera fragment sub.
+func containsName(e models.CatalogExercise, sub string) bool {
+	sub = strings.ToLower(sub)
+	for _, n := range append([]string{e.Name}, e.Aliases...) {
+		if strings.Contains(strings.ToLower(n), sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q ExerciseQuery) matches(e models.CatalogExercise) bool {
+	if e.Archived && !q.IncludeArchived {
+		return false
+	}
+	if q.Search != "" && !containsName(e, q.Search) {
+		return false
+	}
+	if q.MuscleGroup != "" && !slices.Contains(e.PrimaryMuscles, q.MuscleGroup) &&
+		(q.PrimaryOnly || !slices.Contains(e.SecondaryMuscles, q.MuscleGroup)) {
+		return false
+	}
+	if q.Equipment != "" && e.Equipment != q.Equipment {
+		return false
+	}
+	if q.Type != "" && e.Type != q.Type {
+		return false
+	}
+	return true
+}
+
+// Create dodaje ćwiczenie do katalogu: globalne, gdy e.Owner jest pusty, inaczej własne.
+// Nazwa musi być unikalna w widoku właściciela (ErrDuplicateName); nowe ćwiczenie
+// globalne nie może kolidować z niczyim ćwiczeniem własnym.
+func (s *ExerciseStore) Create(ctx context.Context, e models.CatalogExercise) (models.CatalogExercise, error) {
+	defer startSpan(ctx, "ExerciseStore.Create")()
+
+	s.namesMu.Lock()
+	defer s.namesMu.Unlock()
+
+	if s.nameTaken(e, e.Owner, 0, e.Owner == "") {
+		return models.CatalogExercise{}, ErrDuplicateName
+	}
+	return s.items.create(e), nil
+}
+
+// Seed dodaje ćwiczenia do katalogu globalnego i zwraca liczbę dodanych. Ćwiczenia,
+// których nazwa jest już zajęta, pomija, a z pozostałych usuwa zajęte aliasy,
+// więc ponowny import tych samych danych niczego nie duplikuje.
+func (s *ExerciseStore) Seed(ctx context.Context, exercises []models.CatalogExercise) int {
+	defer startSpan(ctx, "ExerciseStore.Seed")()
+
+	s.namesMu.Lock()
+	defer s.namesMu.Unlock()
+
+	added := 0
+	for _, e := range exercises {
+		if _, ok := s.findByName(e.Name, "", 0, true); ok {
+			continue
+		}
+		e.Aliases = slices.DeleteFunc(slices.Clone(e.Aliases), func(a string) bool {
+			_, taken := s.findByName(a, "", 0, true)
+			return taken
+		})
+		e.Owner, e.Archived = "", false
+		s.items.create(e)
+		added++
+	}
+	return added
+}
+
+// List zwraca ćwiczenia z widoku wykonawcy (ActorFrom) pasujące do filtrów, alfabetycznie.
+func (s *ExerciseStore) List(ctx context.Context, q ExerciseQuery) []models.CatalogExercise {
+	defer startSpan(ctx, "ExerciseStore.List")()
+
+	actor := ActorFrom(ctx)
+	out := s.items.list(func(e models.CatalogExercise) bool { return visibleTo(e, actor) && q.matches(e) })
+	slices.SortStableFunc(out, func(a, b models.CatalogExercise) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return out
+}
+
+// Get pobiera ćwiczenie po ID z widoku wykonawcy (także zarchiwizowane).
+func (s *ExerciseStore) Get(ctx context.Context, id int) (models.CatalogExercise, bool) {
+	defer startSpan(ctx, "ExerciseStore.Get")()
+
+	e, ok := s.items.get(id)
+	if !ok || !visibleTo(e, ActorFrom(ctx)) {
+		return models.CatalogExercise{}, false
+	}
+	return e, true
+}
+
+// FindByName szuka niezarchiwizowanego ćwiczenia z widoku wykonawcy po nazwie
+// lub aliasie (bez rozróżniania wielkości liter), np. "OHP" -> "Overhead Press".
+func (s *ExerciseStore) FindByName(ctx context.Context, name string) (models.CatalogExercise, bool) {
+	defer startSpan(ctx, "ExerciseStore.FindByName")()
+
+	e, ok := s.findByName(name, ActorFrom(ctx), 0, false)
+	if !ok || e.Archived {
+		return models.CatalogExercise{}, false
+	}
+	return e, true
+}
+
+// hasName sprawdza, czy ćwiczenie nazywa się name lub ma taki alias.
+func hasName(e models.CatalogExercise, name string) bool {
+	if strings.EqualFold(e.Name, name) {
+		return true
+	}
+	for _, a := range e.Aliases {
+		if strings.EqualFold(a, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// nameTaken sprawdza, czy nazwa lub któryś alias e koliduje z innym ćwiczeniem.
+func (s *ExerciseStore) nameTaken(e models.CatalogExercise, owner string, skipID int, allOwners bool) bool {
+	for _, n := range append([]string{e.Name}, e.Aliases...) {
+		if _, ok := s.findByName(n, owner, skipID, allOwners); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// findByName szuka nazwy (lub aliasu) w widoku owner (z pominięciem skipID); allOwners
+// rozszerza wyszukiwanie na ćwiczenia własne wszystkich użytkowników.
+// Ćwiczenia własne mają pierwszeństwo przed globalnymi.
+func (s *ExerciseStore) findByName(name, owner string, skipID int, allOwners bool) (models.CatalogExercise, bool) {
+	name = strings.TrimSpace(name)
+	found := s.items.list(func(e models.CatalogExercise) bool {
+		return e.ID != skipID && (allOwners || visibleTo(e, owner)) && hasName(e, name)
+	})
+	slices.SortStableFunc(found, func(a, b models.CatalogExercise) int {
+		return strings.Compare(b.Owner, a.Owner)
+	})
+	if len(found) == 0 {
+		return models.CatalogExercise{}, false
+	}
+	return found[0], true
+}
+
+// Update zastępuje dane własnego ćwiczenia wykonawcy (ID, CreatedAt i właściciel zostają).
+// Zwraca ErrNotFound, ErrReadOnly dla katalogu globalnego albo ErrDuplicateName,
+// gdy nowa nazwa koliduje z innym ćwiczeniem.
+func (s *ExerciseStore) Update(ctx context.Context, id int, e models.CatalogExercise) (models.CatalogExercise, error) {
+	defer startSpan(ctx, "ExerciseStore.Update")()
+
+	s.namesMu.Lock()
+	defer s.namesMu.Unlock()
+
+	actor := ActorFrom(ctx)
+	if s.nameTaken(e, actor, id, false) {
+		return models.CatalogExercise{}, ErrDuplicateName
+	}
+	return s.items.update(id, func(cur models.CatalogExercise) (models.CatalogExercise, error) {
+		if err := checkOwner(cur, actor); err != nil {
+			return cur, err
+		}
+		e.Owner, e.Archived, e.CreatedAt = cur.Owner, cur.Archived, cur.CreatedAt
+		return e, nil
+	})
+}
+
+// SetArchived archiwizuje lub przywraca własne ćwiczenie wykonawcy.
+func (s *ExerciseStore) SetArchived(ctx context.Context, id int, archived bool) (models.CatalogExercise, error) {
+	defer startSpan(ctx, "ExerciseStore.SetArchived")()
+
+	actor := ActorFrom(ctx)
+	return s.items.update(id, func(cur models.CatalogExercise) (models.CatalogExercise, error) {
+		if err := checkOwner(cur, actor); err != nil {
+			return cur, err
+		}
+		cur.Archived = archived
+		return cur, nil
+	})
+}
+
+// SetInstructions zastępuje opis, wskazówki i multimedia własnego ćwiczenia wykonawcy.
+func (s *ExerciseStore) SetInstructions(ctx context.Context, id int, in models.ExerciseInstructions) (models.CatalogExercise, error) {
+	defer startSpan(ctx, "ExerciseStore.SetInstructions")()
+
+	actor := ActorFrom(ctx)
+	return s.items.update(id, func(cur models.CatalogExercise) (models.CatalogExercise, error) {
+		if err := checkOwner(cur, actor); err != nil {
+			return cur, err
+		}
+		cur.ExerciseInstructions = in
+		return cur, nil
+	})
+}
+
+// Delete usuwa własne ćwiczenie wykonawcy z katalogu. Treningi zachowują jego nazwę.
+func (s *ExerciseStore) Delete(ctx context.Context, id int) error {
+	defer startSpan(ctx, "ExerciseStore.Delete")()
+
+	e, ok := s.items.get(id)
+	if !ok {
+		return ErrNotFound
+	}
+	if err := checkOwner(e, ActorFrom(ctx)); err != nil {
+		return err
+	}
+	s.items.delete(id)
+	return nil
+}
+
+// checkOwner pozwala zmieniać tylko własne ćwiczenia; cudze są niewidoczne (ErrNotFound).
+func checkOwner(e models.CatalogExercise, actor string) error {
+	switch {
+	case e.Owner == "":
+		return ErrReadOnly
+	case e.Owner != actor:
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// GoalStore trzyma cele użytkowników w pamięci. Każdy widzi tylko własne cele (ActorFrom).
+type GoalStore struct {
+	items *collection[models.Goal]
+}
+
+// NewGoalStore tworzy pusty magazyn celów.
+func NewGoalStore() *GoalStore {
+	return &GoalStore{items: newCollection(func(g *models.Goal, id int, now time.Time, created bool) {
+		g.ID = id
+		if created {
+			g.CreatedAt = now
+		}
+		g.UpdatedAt = now
+	})}
+}
+
+// Create zapisuje cel wykonawcy.
+func (s *GoalStore) Create(ctx context.Context, g models.Goal) models.Goal {
+	defer startSpan(ctx, "GoalStore.Create")()
+
+	g.Owner = ActorFrom(ctx)
+	return s.items.create(g)
+}
+
+// List zwraca cele wykonawcy w kolejności dodania.
+func (s *GoalStore) List(ctx context.Context) []models.Goal {
+	defer startSpan(ctx, "GoalStore.List")()
+
+	actor := ActorFrom(ctx)
+	return s.items.list(func(g models.Goal) bool { return g.Owner == actor })
+}
+
+// Get zwraca cel wykonawcy o podanym ID.
+func (s *GoalStore) Get(ctx context.Context, id int) (models.Goal, bool) {
+	defer startSpan(ctx, "GoalStore.Get")()
+
+	g, ok := s.items.get(id)
+	if !ok || g.Owner != ActorFrom(ctx) {
+		return models.Goal{}, false
+	}
+	return g, true
+}
+
+// Update zastępuje cel wykonawcy (ID, CreatedAt i właściciel zostają).
+func (s *GoalStore) Update(ctx context.Context, id int, g models.Goal) (models.Goal, error) {
+	defer startSpan(ctx, "GoalStore.Update")()
+
+	actor := ActorFrom(ctx)
+	return s.items.update(id, func(cur models.Goal) (models.Goal, error) {
+		if cur.Owner != actor {
+			return cur, ErrNotFound
+		}
+		g.Owner, g.CreatedAt = cur.Owner, cur.CreatedAt
+		return g, nil
+	})
+}
+
+// Delete usuwa cel wykonawcy.
+func (s *GoalStore) Delete(ctx context.Context, id int) bool {
+	defer startSpan(ctx, "GoalStore.Delete")()
+
+	if _, ok := s.Get(ctx, id); !ok {
+		return false
+	}
+	return s.items.delete(id)
+}
@@ -0,0 +1,37 @@
+package store
+
+import (
+	"context"
+	"sync"
+)
+
+// HeartRateStore trzyma tętno maksymalne użytkowników, z którego liczymy strefy tętna.
+// Każdy widzi tylko własne ustawienie (ActorFrom).
+type HeartRateStore struct {
+	mu    sync.RWMutex
+	maxHR map[string]int // wykonawca -> uderzenia/min
+}
+
+// NewHeartRateStore tworzy pusty magazyn ustawień tętna.
+func NewHeartRateStore() *HeartRateStore {
+	return &HeartRateStore{maxHR: make(map[string]int)}
+}
+
+// MaxHR zwraca tętno maksymalne wykonawcy; ok = false, gdy go nie ustawił.
+func (s *HeartRateStore) MaxHR(ctx context.Context) (int, bool) {
+	defer startSpan(ctx, "HeartRateStore.MaxHR")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.maxHR[ActorFrom(ctx)]
+	return v, ok
+}
+
+// SetMaxHR ustawia tętno maksymalne wykonawcy.
+func (s *HeartRateStore) SetMaxHR(ctx context.Context, bpm int) {
+	defer startSpan(ctx, "HeartRateStore.SetMaxHR")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxHR[ActorFrom(ctx)] = bpm
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// InjuryStore trzyma dziennik urazów. Każdy widzi tylko własne urazy (ActorFrom).
+type InjuryStore struct {
+	items *collection[models.Injury]
+}
+
+// NewInjuryStore tworzy pusty dziennik urazów.
+func NewInjuryStore() *InjuryStore {
+	return &InjuryStore{items: newCollection(func(e *models.Injury, id int, now time.Time, created bool) {
+		e.ID = id
+		if created {
+			e.CreatedAt = now
+		}
+		e.UpdatedAt = now
+	})}
+}
+
+// Create zapisuje uraz wykonawcy.
+func (s *InjuryStore) Create(ctx context.Context, e models.Injury) models.Injury {
+	defer startSpan(ctx, "InjuryStore.Create")()
+
+	e.Owner = ActorFrom(ctx)
+	return s.items.create(e)
+}
+
+// List zwraca urazy wykonawcy, opcjonalnie tylko o podanym statusie, od najnowszego
+// (po dacie początku, potem ID).
+func (s *InjuryStore) List(ctx context.Context, status string) []models.Injury {
+	defer startSpan(ctx, "InjuryStore.List")()
+
+	actor := ActorFrom(ctx)
+	out := s.items.list(func(e models.Injury) bool {
+		return e.Owner == actor && (status == "" || e.Status == status)
+	})
+	slices.SortFunc(out, func(a, b models.Injury) int {
+		return cmp.Or(cmp.Compare(b.OnsetDate, a.OnsetDate), cmp.Compare(b.ID, a.ID))
+	})
+	return out
+}
+
+// AffectedExercises zwraca ID ćwiczeń, których wykonawca nie powinien robić przez
+// niewyleczone urazy (status inny niż resolved), z urazami, które je wykluczają.
+func (s *InjuryStore) AffectedExercises(ctx context.Context) map[int][]models.Injury {
+	defer startSpan(ctx, "InjuryStore.AffectedExercises")()
+
+	out := map[int][]models.Injury{}
+	for _, e := range s.List(ctx, "") {
+		if e.Status == models.InjuryResolved {
+			continue
+		}
+		for _, id := range e.AffectedExercises {
+			out[id] = append(out[id], e)
+		}
+	}
+	return out
+}
+
+// Get zwraca uraz wykonawcy o podanym ID.
+func (s *InjuryStore) Get(ctx context.Context, id int) (models.Injury, bool) {
+	defer startSpan(ctx, "InjuryStore.Get")()
+
+	e, ok := s.items.get(id)
+	if !ok || e.Owner != ActorFrom(ctx) {
+		return models.Injury{}, false
+	}
+	return e, true
+}
+
+// Update zastępuje uraz wykonawcy (ID, CreatedAt i właściciel zostają).
+func (s *InjuryStore) Update(ctx context.Context, id int, e models.Injury) (models.Injury, error) {
+	defer startSpan(ctx, "InjuryStore.Update")()
+
+	actor := ActorFrom(ctx)
+	return s.items.update(id, func(cur models.Injury) (models.Injury, error) {
+		if cur.Owner != actor {
+			return cur, ErrNotFound
+		}
+		e.Owner, e.CreatedAt = cur.Owner, cur.CreatedAt
+		return e, nil
+	})
+}
+
+// Delete usuwa uraz wykonawcy.
+func (s *InjuryStore) Delete(ctx context.Context, id int) bool {
+	defer startSpan(ctx, "InjuryStore.Delete")()
+
+	if _, ok := s.Get(ctx, id); !ok {
+		return false
+	}
+	return s.items.delete(id)
+}
@@ -0,0 +1,42 @@
+package store
+
+import (
+	"context"
+	"sync"
+
+	"gym-api/internal/models"
+)
+
+// InsightStore trzyma ostatni raport z analizy treningów, liczony okresowo w tle.
+type InsightStore struct {
+	mu     sync.RWMutex
+	report *models.InsightReport
+}
+
+// NewInsightStore tworzy magazyn bez raportu.
+func NewInsightStore() *InsightStore {
+	return &InsightStore{}
+}
+
+// Latest zwraca ostatni raport; ok = false, gdy analiza jeszcze się nie wykonała.
+func (s *InsightStore) Latest(ctx context.Context) (models.InsightReport, bool) {
+	defer startSpan(ctx, "InsightStore.Latest")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.report == nil {
+		return models.InsightReport{}, false
+	}
+	return *s.report, true
+}
+
+// Set zastępuje raport nowym.
+func (s *InsightStore) Set(ctx context.Context, r models.InsightReport) {
+	defer startSpan(ctx, "InsightStore.Set")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.report = &r
+}
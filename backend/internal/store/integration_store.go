@@ -0,0 +1,266 @@
+package store
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// oauthStateTTL ogranicza czas między rozpoczęciem autoryzacji OAuth a powrotem
+// z serwisu; starsze state są odrzucane.
+const oauthStateTTL = 10 * time.Minute
+
+// IntegrationStore trzyma połączenia kont z zewnętrznymi serwisami (tokeny OAuth)
+// i pamięta, które aktywności już zaimportowano. Każdy widzi tylko własne połączenia
+// (ActorFrom); wywołania z tła (harmonogram, webhooki) ustawiają wykonawcę z Owner.
+type IntegrationStore struct {
+	mu    sync.RWMutex
+	conns map[integrationKey]models.Integration
+	// imported mapuje ID aktywności w serwisie na ID treningu. Przetrwa rozłączenie,
+	// żeby ponowne połączenie nie dublowało treningów.
+	imported map[integrationKey]map[string]int
+	states   map[string]oauthState
+}
+
+type integrationKey struct {
+	owner, provider string
+}
+
+type oauthState struct {
+	integrationKey
+	verifier string // PKCE code_verifier (serwisy OAuth 2.0 z PKCE)
+	expires  time.Time
+}
+
+// NewIntegrationStore tworzy pusty magazyn połączeń.
+func NewIntegrationStore() *IntegrationStore {
+	return &IntegrationStore{
+		conns:    make(map[integrationKey]models.Integration),
+		imported: make(map[integrationKey]map[string]int),
+		states:   make(map[string]oauthState),
+	}
+}
+
+// AddState zapamiętuje state rozpoczętej przez wykonawcę autoryzacji OAuth wraz
+// z weryfikatorem PKCE (pusty, gdy serwis go nie używa).
+func (s *IntegrationStore) AddState(ctx context.Context, provider, state, verifier string) {
+	defer startSpan(ctx, "IntegrationStore.AddState")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, st := range s.states {
+		if now.After(st.expires) {
+			delete(s.states, k)
+		}
+	}
+	s.states[state] = oauthState{integrationKey{ActorFrom(ctx), provider}, verifier, now.Add(oauthStateTTL)}
+}
+
+// TakeState zużywa state z powrotu OAuth i zwraca wykonawcę, który rozpoczął
+// autoryzację, oraz weryfikator PKCE; ok = false dla nieznanego lub przeterminowanego state.
+func (s *IntegrationStore) TakeState(ctx context.Context, provider, state string) (owner, verifier string, ok bool) {
+	defer startSpan(ctx, "IntegrationStore.TakeState")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.states[state]
+	delete(s.states, state)
+	if !ok || st.provider != provider || time.Now().After(st.expires) {
+		return "", "", false
+	}
+	return st.owner, st.verifier, true
+}
+
+// Connect zapisuje (albo zastępuje) połączenie wykonawcy z serwisem in.Provider.
+func (s *IntegrationStore) Connect(ctx context.Context, in models.Integration) models.Integration {
+	defer startSpan(ctx, "IntegrationStore.Connect")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := integrationKey{ActorFrom(ctx), in.Provider}
+	now := time.Now()
+	in.Owner = key.owner
+	in.Connected = true
+	in.ConnectedAt = &now
+	in.LastError = ""
+	// Przy ponownym połączeniu tego samego konta synchronizacja rusza od poprzedniego miejsca.
+	// Ustawienia importu zostają przy ponownym połączeniu dowolnego konta.
+	if prev, ok := s.conns[key]; ok {
+		if prev.AthleteID == in.AthleteID {
+			in.LastSyncAt = prev.LastSyncAt
+		}
+		if in.Settings == nil {
+			in.Settings = prev.Settings
+		}
+	}
+	s.conns[key] = in
+	return s.status(key)
+}
+
+// Get zwraca połączenie wykonawcy z serwisem; ok = false, gdy go nie połączono.
+// Z niepołączonym serwisem zwraca sam status (Connected = false).
+func (s *IntegrationStore) Get(ctx context.Context, provider string) (models.Integration, bool) {
+	defer startSpan(ctx, "IntegrationStore.Get")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key := integrationKey{ActorFrom(ctx), provider}
+	_, ok := s.conns[key]
+	return s.status(key), ok
+}
+
+// status wymaga trzymanej blokady.
+func (s *IntegrationStore) status(key integrationKey) models.Integration {
+	c, ok := s.conns[key]
+	if !ok {
+		c = models.Integration{Provider: key.provider, Owner: key.owner}
+	}
+	c.Imported = len(s.imported[key])
+	return c
+}
+
+// Disconnect usuwa połączenie wykonawcy (tokeny), zostawiając pamięć zaimportowanych aktywności.
+func (s *IntegrationStore) Disconnect(ctx context.Context, provider string) bool {
+	defer startSpan(ctx, "IntegrationStore.Disconnect")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := integrationKey{ActorFrom(ctx), provider}
+	if _, ok := s.conns[key]; !ok {
+		return false
+	}
+	delete(s.conns, key)
+	return true
+}
+
+// Owners zwraca wykonawców z połączonym serwisem (do synchronizacji w tle).
+func (s *IntegrationStore) Owners(ctx context.Context, provider string) []string {
+	defer startSpan(ctx, "IntegrationStore.Owners")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []string
+	for k := range s.conns {
+		if k.provider == provider {
+			out = append(out, k.owner)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// OwnerOf zwraca wykonawcę, który połączył konto athleteID (do obsługi webhooków).
+func (s *IntegrationStore) OwnerOf(ctx context.Context, provider, athleteID string) (string, bool) {
+	defer startSpan(ctx, "IntegrationStore.OwnerOf")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for k, c := range s.conns {
+		if k.provider == provider && c.AthleteID == athleteID {
+			return k.owner, true
+		}
+	}
+	return "", false
+}
+
+// SetToken zapisuje odświeżone tokeny połączenia wykonawcy.
+func (s *IntegrationStore) SetToken(ctx context.Context, provider, access, refresh string, expires time.Time) {
+	defer startSpan(ctx, "IntegrationStore.SetToken")()
+
+	s.update(ctx, provider, func(c *models.Integration) {
+		c.AccessToken, c.RefreshToken, c.TokenExpiresAt = access, refresh, expires
+	})
+}
+
+// SetSettings zmienia ustawienia importu połączenia wykonawcy; ok = false, gdy
+// serwisu nie połączono.
+func (s *IntegrationStore) SetSettings(ctx context.Context, provider string, settings models.IntegrationSettings) (models.Integration, bool) {
+	defer startSpan(ctx, "IntegrationStore.SetSettings")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := integrationKey{ActorFrom(ctx), provider}
+	c, ok := s.conns[key]
+	if !ok {
+		return models.Integration{}, false
+	}
+	c.Settings = &settings
+	s.conns[key] = c
+	return s.status(key), true
+}
+
+// SyncDone zapisuje wynik synchronizacji: przy sukcesie czas, przy błędzie jego opis.
+func (s *IntegrationStore) SyncDone(ctx context.Context, provider string, at time.Time, err error) {
+	defer startSpan(ctx, "IntegrationStore.SyncDone")()
+
+	s.update(ctx, provider, func(c *models.Integration) {
+		if err != nil {
+			c.LastError = err.Error()
+			return
+		}
+		c.LastSyncAt, c.LastError = &at, ""
+	})
+}
+
+func (s *IntegrationStore) update(ctx context.Context, provider string, fn func(*models.Integration)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := integrationKey{ActorFrom(ctx), provider}
+	if c, ok := s.conns[key]; ok {
+		fn(&c)
+		s.conns[key] = c
+	}
+}
+
+// ImportedWorkout zwraca ID treningu powiązanego z aktywnością serwisu.
+func (s *IntegrationStore) ImportedWorkout(ctx context.Context, provider, externalID string) (int, bool) {
+	defer startSpan(ctx, "IntegrationStore.ImportedWorkout")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.imported[integrationKey{ActorFrom(ctx), provider}][externalID]
+	return id, ok
+}
+
+// LinkedWorkouts zwraca ID treningów powiązanych z aktywnościami serwisu.
+func (s *IntegrationStore) LinkedWorkouts(ctx context.Context, provider string) map[int]bool {
+	defer startSpan(ctx, "IntegrationStore.LinkedWorkouts")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[int]bool)
+	for _, id := range s.imported[integrationKey{ActorFrom(ctx), provider}] {
+		out[id] = true
+	}
+	return out
+}
+
+// Link wiąże aktywność serwisu z treningiem (zaimportowanym albo wpisanym ręcznie),
+// żeby kolejne synchronizacje jej nie dublowały.
+func (s *IntegrationStore) Link(ctx context.Context, provider, externalID string, workoutID int) {
+	defer startSpan(ctx, "IntegrationStore.Link")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := integrationKey{ActorFrom(ctx), provider}
+	if s.imported[key] == nil {
+		s.imported[key] = make(map[string]int)
+	}
+	s.imported[key][externalID] = workoutID
+}
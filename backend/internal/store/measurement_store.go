@@ -0,0 +1,115 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// measurementSites to miejsca pomiaru obwodów; kończyny mierzymy osobno po obu stronach.
+var measurementSites = []models.MeasurementSite{
+	{ID: "neck", Name: "Neck"},
+	{ID: "shoulders", Name: "Shoulders"},
+	{ID: "chest", Name: "Chest"},
+	{ID: "waist", Name: "Waist"},
+	{ID: "hips", Name: "Hips"},
+	{ID: "arm-left", Name: "Left arm"},
+	{ID: "arm-right", Name: "Right arm"},
+	{ID: "forearm-left", Name: "Left forearm"},
+	{ID: "forearm-right", Name: "Right forearm"},
+	{ID: "thigh-left", Name: "Left thigh"},
+	{ID: "thigh-right", Name: "Right thigh"},
+	{ID: "calf-left", Name: "Left calf"},
+	{ID: "calf-right", Name: "Right calf"},
+}
+
+// MeasurementSites zwraca kopię listy miejsc pomiaru.
+func MeasurementSites() []models.MeasurementSite {
+	return slices.Clone(measurementSites)
+}
+
+// MeasurementSite zwraca miejsce pomiaru o podanym ID.
+func MeasurementSite(id string) (models.MeasurementSite, bool) {
+	i := slices.IndexFunc(measurementSites, func(m models.MeasurementSite) bool { return m.ID == id })
+	if i < 0 {
+		return models.MeasurementSite{}, false
+	}
+	return measurementSites[i], true
+}
+
+// MeasurementStore trzyma pomiary obwodów. Każdy widzi tylko własne pomiary (ActorFrom).
+type MeasurementStore struct {
+	items *collection[models.MeasurementEntry]
+}
+
+// NewMeasurementStore tworzy pusty magazyn pomiarów.
+func NewMeasurementStore() *MeasurementStore {
+	return &MeasurementStore{items: newCollection(func(e *models.MeasurementEntry, id int, now time.Time, created bool) {
+		e.ID = id
+		if created {
+			e.CreatedAt = now
+		}
+		e.UpdatedAt = now
+	})}
+}
+
+// Create zapisuje pomiary wykonawcy.
+func (s *MeasurementStore) Create(ctx context.Context, e models.MeasurementEntry) models.MeasurementEntry {
+	defer startSpan(ctx, "MeasurementStore.Create")()
+
+	e.Owner = ActorFrom(ctx)
+	return s.items.create(e)
+}
+
+// List zwraca pomiary wykonawcy z zakresu [from, to] (puste = bez ograniczeń),
+// od najnowszego (po dacie, potem ID).
+func (s *MeasurementStore) List(ctx context.Context, from, to string) []models.MeasurementEntry {
+	defer startSpan(ctx, "MeasurementStore.List")()
+
+	actor := ActorFrom(ctx)
+	out := s.items.list(func(e models.MeasurementEntry) bool {
+		return e.Owner == actor && (from == "" || e.Date >= from) && (to == "" || e.Date <= to)
+	})
+	slices.SortFunc(out, func(a, b models.MeasurementEntry) int {
+		return cmp.Or(cmp.Compare(b.Date, a.Date), cmp.Compare(b.ID, a.ID))
+	})
+	return out
+}
+
+// Get zwraca pomiary wykonawcy o podanym ID.
+func (s *MeasurementStore) Get(ctx context.Context, id int) (models.MeasurementEntry, bool) {
+	defer startSpan(ctx, "MeasurementStore.Get")()
+
+	e, ok := s.items.get(id)
+	if !ok || e.Owner != ActorFrom(ctx) {
+		return models.MeasurementEntry{}, false
+	}
+	return e, true
+}
+
+// Update zastępuje pomiary wykonawcy (ID, CreatedAt i właściciel zostają).
+func (s *MeasurementStore) Update(ctx context.Context, id int, e models.MeasurementEntry) (models.MeasurementEntry, error) {
+	defer startSpan(ctx, "MeasurementStore.Update")()
+
+	actor := ActorFrom(ctx)
+	return s.items.update(id, func(cur models.MeasurementEntry) (models.MeasurementEntry, error) {
+		if cur.Owner != actor {
+			return cur, ErrNotFound
+		}
+		e.Owner, e.CreatedAt = cur.Owner, cur.CreatedAt
+		return e, nil
+	})
+}
+
+// Delete usuwa pomiary wykonawcy.
+func (s *MeasurementStore) Delete(ctx context.Context, id int) bool {
+	defer startSpan(ctx, "MeasurementStore.Delete")()
+
+	if _, ok := s.Get(ctx, id); !ok {
+		return false
+	}
+	return s.items.delete(id)
+}
@@ -0,0 +1,41 @@
+package store
+
+import "gym-api/internal/models"
+
+// muscleGroups to stała taksonomia grup mięśni, do której odwołują się ćwiczenia
+// katalogowe (primaryMuscles/secondaryMuscles). Posłuży też do liczenia objętości per mięsień.
+var muscleGroups = []models.MuscleGroup{
+	{ID: "chest", Name: "Chest", Region: "upper"},
+	{ID: "shoulders", Name: "Shoulders", Region: "upper"},
+	{ID: "biceps", Name: "Biceps", Region: "upper"},
+	{ID: "triceps", Name: "Triceps", Region: "upper"},
+	{ID: "forearms", Name: "Forearms", Region: "upper"},
+	{ID: "lats", Name: "Lats", Region: "upper"},
+	{ID: "upper-back", Name: "Upper back", Region: "upper"},
+	{ID: "traps", Name: "Traps", Region: "upper"},
+	{ID: "neck", Name: "Neck", Region: "upper"},
+	{ID: "abs", Name: "Abs", Region: "core"},
+	{ID: "obliques", Name: "Obliques", Region: "core"},
+	{ID: "lower-back", Name: "Lower back", Region: "core"},
+	{ID: "glutes", Name: "Glutes", Region: "lower"},
+	{ID: "quadriceps", Name: "Quadriceps", Region: "lower"},
+	{ID: "hamstrings", Name: "Hamstrings", Region: "lower"},
+	{ID: "adductors", Name: "Adductors", Region: "lower"},
+	{ID: "abductors", Name: "Abductors", Region: "lower"},
+	{ID: "calves", Name: "Calves", Region: "lower"},
+}
+
+// MuscleGroups zwraca kopię taksonomii grup mięśni.
+func MuscleGroups() []models.MuscleGroup {
+	return append([]models.MuscleGroup(nil), muscleGroups...)
+}
+
+// IsMuscleGroup sprawdza, czy id należy do taksonomii.
+func IsMuscleGroup(id string) bool {
+	for _, m := range muscleGroups {
+		if m.ID == id {
+			return true
+		}
+	}
+	return false
+}
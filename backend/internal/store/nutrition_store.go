@@ -0,0 +1,84 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// NutritionStore trzyma dziennik żywienia. Każdy widzi tylko własne wpisy (ActorFrom).
+type NutritionStore struct {
+	items *collection[models.NutritionEntry]
+}
+
+// NewNutritionStore tworzy pusty dziennik żywienia.
+func NewNutritionStore() *NutritionStore {
+	return &NutritionStore{items: newCollection(func(e *models.NutritionEntry, id int, now time.Time, created bool) {
+		e.ID = id
+		if created {
+			e.CreatedAt = now
+		}
+		e.UpdatedAt = now
+	})}
+}
+
+// Create zapisuje wpis wykonawcy.
+func (s *NutritionStore) Create(ctx context.Context, e models.NutritionEntry) models.NutritionEntry {
+	defer startSpan(ctx, "NutritionStore.Create")()
+
+	e.Owner = ActorFrom(ctx)
+	return s.items.create(e)
+}
+
+// List zwraca wpisy wykonawcy z zakresu [from, to] (puste = bez ograniczeń),
+// od najnowszego (po dacie, potem ID).
+func (s *NutritionStore) List(ctx context.Context, from, to string) []models.NutritionEntry {
+	defer startSpan(ctx, "NutritionStore.List")()
+
+	actor := ActorFrom(ctx)
+	out := s.items.list(func(e models.NutritionEntry) bool {
+		return e.Owner == actor && (from == "" || e.Date >= from) && (to == "" || e.Date <= to)
+	})
+	slices.SortFunc(out, func(a, b models.NutritionEntry) int {
+		return cmp.Or(cmp.Compare(b.Date, a.Date), cmp.Compare(b.ID, a.ID))
+	})
+	return out
+}
+
+// Get zwraca wpis wykonawcy o podanym ID.
+func (s *NutritionStore) Get(ctx context.Context, id int) (models.NutritionEntry, bool) {
+	defer startSpan(ctx, "NutritionStore.Get")()
+
+	e, ok := s.items.get(id)
+	if !ok || e.Owner != ActorFrom(ctx) {
+		return models.NutritionEntry{}, false
+	}
+	return e, true
+}
+
+// Update zastępuje wpis wykonawcy (ID, CreatedAt i właściciel zostają).
+func (s *NutritionStore) Update(ctx context.Context, id int, e models.NutritionEntry) (models.NutritionEntry, error) {
+	defer startSpan(ctx, "NutritionStore.Update")()
+
+	actor := ActorFrom(ctx)
+	return s.items.update(id, func(cur models.NutritionEntry) (models.NutritionEntry, error) {
+		if cur.Owner != actor {
+			return cur, ErrNotFound
+		}
+		e.Owner, e.CreatedAt = cur.Owner, cur.CreatedAt
+		return e, nil
+	})
+}
+
+// Delete usuwa wpis wykonawcy.
+func (s *NutritionStore) Delete(ctx context.Context, id int) bool {
+	defer startSpan(ctx, "NutritionStore.Delete")()
+
+	if _, ok := s.Get(ctx, id); !ok {
+		return false
+	}
+	return s.items.delete(id)
+}
@@ -0,0 +1,71 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// PhotoStore trzyma metadane zdjęć sylwetki; pliki są w magazynie plików (blob).
+// Każdy widzi tylko własne zdjęcia (ActorFrom).
+type PhotoStore struct {
+	items *collection[models.Photo]
+}
+
+// NewPhotoStore tworzy pusty magazyn zdjęć.
+func NewPhotoStore() *PhotoStore {
+	return &PhotoStore{items: newCollection(func(p *models.Photo, id int, now time.Time, created bool) {
+		p.ID = id
+		if created {
+			p.CreatedAt = now
+		}
+	})}
+}
+
+// Create zapisuje zdjęcie wykonawcy.
+func (s *PhotoStore) Create(ctx context.Context, p models.Photo) models.Photo {
+	defer startSpan(ctx, "PhotoStore.Create")()
+
+	p.Owner = ActorFrom(ctx)
+	return s.items.create(p)
+}
+
+// List zwraca zdjęcia wykonawcy z zakresu [from, to] (puste = bez ograniczeń),
+// opcjonalnie tylko w danej pozie, od najnowszego.
+func (s *PhotoStore) List(ctx context.Context, from, to, pose string) []models.Photo {
+	defer startSpan(ctx, "PhotoStore.List")()
+
+	actor := ActorFrom(ctx)
+	out := s.items.list(func(p models.Photo) bool {
+		return p.Owner == actor && (from == "" || p.Date >= from) && (to == "" || p.Date <= to) && (pose == "" || p.Pose == pose)
+	})
+	slices.SortFunc(out, func(a, b models.Photo) int {
+		return cmp.Or(cmp.Compare(b.Date, a.Date), cmp.Compare(b.ID, a.ID))
+	})
+	return out
+}
+
+// Get zwraca zdjęcie wykonawcy o podanym ID.
+func (s *PhotoStore) Get(ctx context.Context, id int) (models.Photo, bool) {
+	defer startSpan(ctx, "PhotoStore.Get")()
+
+	p, ok := s.items.get(id)
+	if !ok || p.Owner != ActorFrom(ctx) {
+		return models.Photo{}, false
+	}
+	return p, true
+}
+
+// Delete usuwa metadane zdjęcia wykonawcy i zwraca je, żeby wywołujący mógł usunąć plik.
+func (s *PhotoStore) Delete(ctx context.Context, id int) (models.Photo, bool) {
+	defer startSpan(ctx, "PhotoStore.Delete")()
+
+	p, ok := s.Get(ctx, id)
+	if !ok || !s.items.delete(id) {
+		return models.Photo{}, false
+	}
+	return p, true
+}
@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"sync"
+
+	"gym-api/internal/models"
+)
+
+// ProfileStore trzyma ustawienia użytkowników. Każdy widzi tylko własny profil (ActorFrom).
+type ProfileStore struct {
+	mu       sync.RWMutex
+	profiles map[string]models.Profile // wykonawca -> profil
+}
+
+// NewProfileStore tworzy pusty magazyn profili.
+func NewProfileStore() *ProfileStore {
+	return &ProfileStore{profiles: make(map[string]models.Profile)}
+}
+
+// Get zwraca profil wykonawcy; bez zapisanego profilu – domyślny (kg).
+func (s *ProfileStore) Get(ctx context.Context) models.Profile {
+	defer startSpan(ctx, "ProfileStore.Get")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.profiles[ActorFrom(ctx)]
+	if !ok {
+		p.WeightUnit = models.UnitKg
+	}
+	return p
+}
+
+// Set zapisuje profil wykonawcy.
+func (s *ProfileStore) Set(ctx context.Context, p models.Profile) {
+	defer startSpan(ctx, "ProfileStore.Set")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profiles[ActorFrom(ctx)] = p
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// ProgramStore trzyma programy treningowe w pamięci. Tak jak szablony, każdy
+// użytkownik widzi tylko własne programy (ActorFrom).
+type ProgramStore struct {
+	items *collection[models.Program]
+}
+
+// NewProgramStore tworzy pusty magazyn programów.
+func NewProgramStore() *ProgramStore {
+	return &ProgramStore{items: newCollection(func(p *models.Program, id int, now time.Time, created bool) {
+		p.ID = id
+		if created {
+			p.CreatedAt = now
+		}
+		p.UpdatedAt = now
+	})}
+}
+
+// Create zapisuje program jako własny program wykonawcy.
+func (s *ProgramStore) Create(ctx context.Context, p models.Program) models.Program {
+	defer startSpan(ctx, "ProgramStore.Create")()
+
+	p.Owner = ActorFrom(ctx)
+	return s.items.create(p)
+}
+
+// List zwraca programy wykonawcy w kolejności dodania.
+func (s *ProgramStore) List(ctx context.Context) []models.Program {
+	defer startSpan(ctx, "ProgramStore.List")()
+
+	actor := ActorFrom(ctx)
+	return s.items.list(func(p models.Program) bool { return p.Owner == actor })
+}
+
+// Get zwraca program wykonawcy o podanym ID.
+func (s *ProgramStore) Get(ctx context.Context, id int) (models.Program, bool) {
+	defer startSpan(ctx, "ProgramStore.Get")()
+
+	p, ok := s.items.get(id)
+	if !ok || p.Owner != ActorFrom(ctx) {
+		return models.Program{}, false
+	}
+	return p, true
+}
+
+// Update zastępuje program wykonawcy (ID, CreatedAt i właściciel zostają).
+func (s *ProgramStore) Update(ctx context.Context, id int, p models.Program) (models.Program, error) {
+	defer startSpan(ctx, "ProgramStore.Update")()
+
+	actor := ActorFrom(ctx)
+	return s.items.update(id, func(cur models.Program) (models.Program, error) {
+		if cur.Owner != actor {
+			return cur, ErrNotFound
+		}
+		p.Owner, p.CreatedAt = cur.Owner, cur.CreatedAt
+		return p, nil
+	})
+}
+
+// Delete usuwa program wykonawcy. Wskazywane przez niego szablony zostają.
+func (s *ProgramStore) Delete(ctx context.Context, id int) bool {
+	defer startSpan(ctx, "ProgramStore.Delete")()
+
+	if _, ok := s.Get(ctx, id); !ok {
+		return false
+	}
+	return s.items.delete(id)
+}
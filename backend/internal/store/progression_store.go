@@ -0,0 +1,57 @@
+package store
+
+import (
+	"context"
+	"sync"
+
+	"gym-api/internal/models"
+)
+
+// ProgressionStore trzyma reguły progresji ustawione przez użytkowników dla ćwiczeń
+// z katalogu. Reguła jest prywatna, także dla ćwiczeń z katalogu globalnego.
+type ProgressionStore struct {
+	mu    sync.RWMutex
+	rules map[progressionKey]models.ProgressionRule
+}
+
+type progressionKey struct {
+	owner      string
+	exerciseID int
+}
+
+// NewProgressionStore tworzy pusty magazyn reguł progresji.
+func NewProgressionStore() *ProgressionStore {
+	return &ProgressionStore{rules: make(map[progressionKey]models.ProgressionRule)}
+}
+
+// Get zwraca regułę wykonawcy dla ćwiczenia; ok = false, gdy nie ustawiono własnej.
+func (s *ProgressionStore) Get(ctx context.Context, exerciseID int) (models.ProgressionRule, bool) {
+	defer startSpan(ctx, "ProgressionStore.Get")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r, ok := s.rules[progressionKey{ActorFrom(ctx), exerciseID}]
+	return r, ok
+}
+
+// Set zapisuje regułę wykonawcy dla ćwiczenia rule.ExerciseID.
+func (s *ProgressionStore) Set(ctx context.Context, rule models.ProgressionRule) models.ProgressionRule {
+	defer startSpan(ctx, "ProgressionStore.Set")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rules[progressionKey{ActorFrom(ctx), rule.ExerciseID}] = rule
+	return rule
+}
+
+// Delete usuwa regułę wykonawcy, przywracając domyślną progresję liniową.
+func (s *ProgressionStore) Delete(ctx context.Context, exerciseID int) {
+	defer startSpan(ctx, "ProgressionStore.Delete")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.rules, progressionKey{ActorFrom(ctx), exerciseID})
+}
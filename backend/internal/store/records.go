@@ -0,0 +1,238 @@
+package store
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+
+	"gym-api/internal/models"
+	"gym-api/internal/progression"
+)
+
+// recordIndex utrzymuje rekordy osobiste per ćwiczenie. Każdy trening wnosi do ćwiczenia
+// najlepsze wyniki z jednej sesji; przy zmianie treningu przeliczamy tylko ćwiczenia,
+// których dotyczył (z ich sesji, bez przeglądania całej historii). Treningi zaplanowane
+// i serie rozgrzewkowe pomijamy. Nie jest bezpieczny współbieżnie – chroni go mutex magazynu.
+type recordIndex struct {
+	sessions  map[recordKey]map[int]sessionBest
+	records   map[recordKey]models.ExerciseRecords
+	byWorkout map[int][]recordKey // ćwiczenia treningu, potrzebne do usunięcia go z indeksu
+}
+
+// recordKey identyfikuje ćwiczenie jak sameExercise: po ID z katalogu albo po nazwie.
+type recordKey struct {
+	exerciseID int
+	name       string // małymi literami; tylko dla ćwiczeń spoza katalogu
+}
+
+func recordKeyOf(ex models.Exercise) recordKey {
+	if ex.ExerciseID != 0 {
+		return recordKey{exerciseID: ex.ExerciseID}
+	}
+	return recordKey{name: strings.ToLower(strings.TrimSpace(ex.Name))}
+}
+
+// sessionBest to najlepsze wyniki ćwiczenia w jednym treningu.
+type sessionBest struct {
+	workoutID int
+	date      string
+	name      string
+	heaviest  *models.RecordEntry
+	e1rm      *models.RecordEntry
+	volume    float64
+	repsAt    map[float64]int
+}
+
+func newRecordIndex() *recordIndex {
+	return &recordIndex{
+		sessions:  make(map[recordKey]map[int]sessionBest),
+		records:   make(map[recordKey]models.ExerciseRecords),
+		byWorkout: make(map[int][]recordKey),
+	}
+}
+
+// put uwzględnia trening w rekordach, zastępując jego poprzednią wersję.
+// Zwraca rekordy pobite przez ten trening (patrz newRecords).
+func (ix *recordIndex) put(w models.Workout) []models.NewRecord {
+	touched := ix.detach(w.ID)
+	if !w.Planned {
+		bests := map[recordKey]*sessionBest{}
+		var keys []recordKey
+		for _, ex := range w.Exercises {
+			k := recordKeyOf(ex)
+			if k.exerciseID == 0 && k.name == "" {
+				continue
+			}
+			b := bests[k]
+			if b == nil {
+				b = &sessionBest{workoutID: w.ID, date: w.Date, name: strings.TrimSpace(ex.Name), repsAt: map[float64]int{}}
+				bests[k] = b
+				keys = append(keys, k)
+			}
+			b.add(ex.Sets)
+		}
+		for _, k := range keys {
+			if ix.sessions[k] == nil {
+				ix.sessions[k] = make(map[int]sessionBest)
+			}
+			ix.sessions[k][w.ID] = *bests[k]
+		}
+		ix.byWorkout[w.ID] = keys
+		touched = append(touched, keys...)
+	}
+	before := make(map[recordKey]models.ExerciseRecords, len(touched))
+	for _, k := range touched {
+		before[k] = ix.records[k]
+	}
+	ix.refresh(touched)
+
+	var out []models.NewRecord
+	for _, k := range ix.byWorkout[w.ID] {
+		out = append(out, newRecords(before[k], ix.records[k], w.ID)...)
+	}
+	return out
+}
+
+// newRecords porównuje rekordy ćwiczenia przed i po zapisaniu treningu id. Liczą się
+// tylko rekordy, które już wcześniej istniały – pierwsza sesja ćwiczenia niczego nie bije.
+func newRecords(before, after models.ExerciseRecords, id int) []models.NewRecord {
+	var out []models.NewRecord
+	beat := func(typ string, prev, cur *models.RecordEntry) {
+		if prev != nil && cur != nil && cur.WorkoutID == id && cur.Value > prev.Value {
+			out = append(out, models.NewRecord{
+				ExerciseID: after.ExerciseID,
+				Name:       after.Name,
+				Type:       typ,
+				Value:      cur.Value,
+				Previous:   prev.Value,
+				Weight:     cur.Weight,
+				Reps:       cur.Reps,
+			})
+		}
+	}
+	beat(models.RecordHeaviestWeight, before.HeaviestWeight, after.HeaviestWeight)
+	beat(models.RecordBestE1RM, before.BestE1RM, after.BestE1RM)
+	beat(models.RecordBestVolume, before.BestVolume, after.BestVolume)
+	for _, cur := range after.RepsAtWeight {
+		for _, prev := range before.RepsAtWeight {
+			if prev.Weight == cur.Weight {
+				beat(models.RecordRepsAtWeight, &prev, &cur)
+			}
+		}
+	}
+	return out
+}
+
+// remove usuwa trening z rekordów.
+func (ix *recordIndex) remove(id int) {
+	ix.refresh(ix.detach(id))
+}
+
+// detach usuwa sesje treningu i zwraca ćwiczenia, których rekordy trzeba przeliczyć.
+func (ix *recordIndex) detach(id int) []recordKey {
+	keys := ix.byWorkout[id]
+	for _, k := range keys {
+		delete(ix.sessions[k], id)
+	}
+	delete(ix.byWorkout, id)
+	return keys
+}
+
+func (b *sessionBest) add(sets []models.Set) {
+	sets = slices.DeleteFunc(slices.Clone(sets), func(s models.Set) bool { return s.Type == models.SetWarmup })
+	for _, set := range sets {
+		if set.Reps <= 0 {
+			continue
+		}
+		// Serie bez ciężaru (z masą ciała) liczą się tylko do powtórzeń na ciężarze 0.
+		w := 0.0
+		if set.Weight != nil {
+			w = *set.Weight
+		}
+		if set.Reps > b.repsAt[w] {
+			b.repsAt[w] = set.Reps
+		}
+		if set.Weight == nil {
+			continue
+		}
+		b.volume += w * float64(set.Reps)
+		if b.heaviest == nil || w > b.heaviest.Weight || w == b.heaviest.Weight && set.Reps > b.heaviest.Reps {
+			b.heaviest = &models.RecordEntry{Value: w, Weight: w, Reps: set.Reps}
+		}
+	}
+	if e1rm, set, ok := progression.BestE1RM(progression.Session{Sets: sets}); ok && (b.e1rm == nil || e1rm > b.e1rm.Value) {
+		b.e1rm = &models.RecordEntry{Value: math.Round(e1rm*10) / 10, Weight: *set.Weight, Reps: set.Reps}
+	}
+}
+
+// refresh przelicza rekordy wskazanych ćwiczeń z ich sesji. Przy remisie rekord należy
+// do sesji, w której padł wcześniej.
+func (ix *recordIndex) refresh(keys []recordKey) {
+	for _, k := range keys {
+		sessions := ix.sessions[k]
+		if len(sessions) == 0 || !hasResults(sessions) {
+			delete(ix.sessions, k)
+			delete(ix.records, k)
+			continue
+		}
+		ordered := make([]sessionBest, 0, len(sessions))
+		for _, s := range sessions {
+			ordered = append(ordered, s)
+		}
+		slices.SortFunc(ordered, func(a, b sessionBest) int {
+			return cmp.Or(cmp.Compare(a.date, b.date), cmp.Compare(a.workoutID, b.workoutID))
+		})
+
+		rec := models.ExerciseRecords{ExerciseID: k.exerciseID, Name: ordered[len(ordered)-1].name}
+		repsAt := map[float64]models.RecordEntry{}
+		for _, s := range ordered {
+			at := func(e models.RecordEntry) *models.RecordEntry {
+				e.Date, e.WorkoutID = s.date, s.workoutID
+				return &e
+			}
+			if s.heaviest != nil && (rec.HeaviestWeight == nil || s.heaviest.Value > rec.HeaviestWeight.Value) {
+				rec.HeaviestWeight = at(*s.heaviest)
+			}
+			if s.e1rm != nil && (rec.BestE1RM == nil || s.e1rm.Value > rec.BestE1RM.Value) {
+				rec.BestE1RM = at(*s.e1rm)
+			}
+			if s.volume > 0 && (rec.BestVolume == nil || s.volume > rec.BestVolume.Value) {
+				rec.BestVolume = at(models.RecordEntry{Value: s.volume})
+			}
+			for w, reps := range s.repsAt {
+				if cur, ok := repsAt[w]; !ok || reps > cur.Reps {
+					repsAt[w] = *at(models.RecordEntry{Value: float64(reps), Weight: w, Reps: reps})
+				}
+			}
+		}
+		rec.RepsAtWeight = make([]models.RecordEntry, 0, len(repsAt))
+		for _, e := range repsAt {
+			rec.RepsAtWeight = append(rec.RepsAtWeight, e)
+		}
+		slices.SortFunc(rec.RepsAtWeight, func(a, b models.RecordEntry) int { return cmp.Compare(b.Weight, a.Weight) })
+		ix.records[k] = rec
+	}
+}
+
+// hasResults mówi, czy któraś sesja ma choć jedną serię z powtórzeniami.
+func hasResults(sessions map[int]sessionBest) bool {
+	for _, s := range sessions {
+		if len(s.repsAt) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// list zwraca rekordy wszystkich ćwiczeń, posortowane po nazwie.
+func (ix *recordIndex) list() []models.ExerciseRecords {
+	out := make([]models.ExerciseRecords, 0, len(ix.records))
+	for _, r := range ix.records {
+		out = append(out, r)
+	}
+	slices.SortFunc(out, func(a, b models.ExerciseRecords) int {
+		return cmp.Or(cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), cmp.Compare(a.ExerciseID, b.ExerciseID))
+	})
+	return out
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"strings"
+	"unicode"
+
+	"gym-api/internal/models"
+)
+
+// searchIndex to prosty odwrócony indeks pełnotekstowy treningów w pamięci:
+// token -> zbiór ID treningów, w których występuje (tytuł, notatki, nazwy ćwiczeń).
+// Nie jest bezpieczny współbieżnie – chroni go mutex magazynu.
+type searchIndex struct {
+	postings map[string]map[int]struct{}
+	docs     map[int][]string // tokeny dokumentu, potrzebne do usunięcia go z indeksu
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings: make(map[string]map[int]struct{}),
+		docs:     make(map[int][]string),
+	}
+}
+
+// put indeksuje trening, zastępując jego poprzednią wersję.
+func (ix *searchIndex) put(w models.Workout) {
+	ix.remove(w.ID)
+
+	text := []string{w.Title, w.Notes}
+	for _, ex := range w.Exercises {
+		text = append(text, ex.Name, ex.Notes)
+		for _, set := range ex.Sets {
+			text = append(text, set.Notes)
+		}
+	}
+	tokens := uniqueTokens(strings.Join(text, " "))
+	for _, tok := range tokens {
+		ids := ix.postings[tok]
+		if ids == nil {
+			ids = make(map[int]struct{})
+			ix.postings[tok] = ids
+		}
+		ids[w.ID] = struct{}{}
+	}
+	ix.docs[w.ID] = tokens
+}
+
+// remove usuwa trening z indeksu.
+func (ix *searchIndex) remove(id int) {
+	for _, tok := range ix.docs[id] {
+		delete(ix.postings[tok], id)
+		if len(ix.postings[tok]) == 0 {
+			delete(ix.postings, tok)
+		}
+	}
+	delete(ix.docs, id)
+}
+
+// search zwraca ID treningów zawierających wszystkie słowa zapytania.
+// Słowo pasuje do tokenu, który się od niego zaczyna ("ben" -> "bench"),
+// co wystarcza do wyszukiwania w trakcie pisania.
+func (ix *searchIndex) search(query string) map[int]struct{} {
+	var result map[int]struct{}
+	for _, term := range uniqueTokens(query) {
+		matched := make(map[int]struct{})
+		for tok, ids := range ix.postings {
+			if strings.HasPrefix(tok, term) {
+				for id := range ids {
+					matched[id] = struct{}{}
+				}
+			}
+		}
+		if result == nil {
+			result = matched
+		} else {
+			for id := range result {
+				if _, ok := matched[id]; !ok {
+					delete(result, id)
+				}
+			}
+		}
+		if len(result) == 0 {
+			break
+		}
+	}
+	if result == nil {
+		result = make(map[int]struct{})
+	}
+	return result
+}
+
+// uniqueTokens dzieli tekst na małe litery/cyfry, bez powtórzeń.
+func uniqueTokens(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	seen := make(map[string]struct{}, len(fields))
+	out := fields[:0]
+	for _, f := range fields {
+		if _, ok := seen[f]; !ok {
+			seen[f] = struct{}{}
+			out = append(out, f)
+		}
+	}
+	return out
+}
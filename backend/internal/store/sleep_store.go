@@ -0,0 +1,84 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// SleepStore trzyma dziennik snu. Każdy widzi tylko własne wpisy (ActorFrom).
+type SleepStore struct {
+	items *collection[models.SleepEntry]
+}
+
+// NewSleepStore tworzy pusty dziennik snu.
+func NewSleepStore() *SleepStore {
+	return &SleepStore{items: newCollection(func(e *models.SleepEntry, id int, now time.Time, created bool) {
+		e.ID = id
+		if created {
+			e.CreatedAt = now
+		}
+		e.UpdatedAt = now
+	})}
+}
+
+// Create zapisuje wpis wykonawcy.
+func (s *SleepStore) Create(ctx context.Context, e models.SleepEntry) models.SleepEntry {
+	defer startSpan(ctx, "SleepStore.Create")()
+
+	e.Owner = ActorFrom(ctx)
+	return s.items.create(e)
+}
+
+// List zwraca wpisy wykonawcy z zakresu [from, to] (puste = bez ograniczeń),
+// od najnowszego (po dacie, potem ID).
+func (s *SleepStore) List(ctx context.Context, from, to string) []models.SleepEntry {
+	defer startSpan(ctx, "SleepStore.List")()
+
+	actor := ActorFrom(ctx)
+	out := s.items.list(func(e models.SleepEntry) bool {
+		return e.Owner == actor && (from == "" || e.Date >= from) && (to == "" || e.Date <= to)
+	})
+	slices.SortFunc(out, func(a, b models.SleepEntry) int {
+		return cmp.Or(cmp.Compare(b.Date, a.Date), cmp.Compare(b.ID, a.ID))
+	})
+	return out
+}
+
+// Get zwraca wpis wykonawcy o podanym ID.
+func (s *SleepStore) Get(ctx context.Context, id int) (models.SleepEntry, bool) {
+	defer startSpan(ctx, "SleepStore.Get")()
+
+	e, ok := s.items.get(id)
+	if !ok || e.Owner != ActorFrom(ctx) {
+		return models.SleepEntry{}, false
+	}
+	return e, true
+}
+
+// Update zastępuje wpis wykonawcy (ID, CreatedAt i właściciel zostają).
+func (s *SleepStore) Update(ctx context.Context, id int, e models.SleepEntry) (models.SleepEntry, error) {
+	defer startSpan(ctx, "SleepStore.Update")()
+
+	actor := ActorFrom(ctx)
+	return s.items.update(id, func(cur models.SleepEntry) (models.SleepEntry, error) {
+		if cur.Owner != actor {
+			return cur, ErrNotFound
+		}
+		e.Owner, e.CreatedAt = cur.Owner, cur.CreatedAt
+		return e, nil
+	})
+}
+
+// Delete usuwa wpis wykonawcy.
+func (s *SleepStore) Delete(ctx context.Context, id int) bool {
+	defer startSpan(ctx, "SleepStore.Delete")()
+
+	if _, ok := s.Get(ctx, id); !ok {
+		return false
+	}
+	return s.items.delete(id)
+}
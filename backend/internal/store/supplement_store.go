@@ -0,0 +1,85 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// SupplementStore trzyma dziennik suplementacji. Każdy widzi tylko własne dawki
+// (ActorFrom).
+type SupplementStore struct {
+	items *collection[models.SupplementIntake]
+}
+
+// NewSupplementStore tworzy pusty dziennik suplementacji.
+func NewSupplementStore() *SupplementStore {
+	return &SupplementStore{items: newCollection(func(e *models.SupplementIntake, id int, now time.Time, created bool) {
+		e.ID = id
+		if created {
+			e.CreatedAt = now
+		}
+		e.UpdatedAt = now
+	})}
+}
+
+// Create zapisuje dawkę wykonawcy.
+func (s *SupplementStore) Create(ctx context.Context, e models.SupplementIntake) models.SupplementIntake {
+	defer startSpan(ctx, "SupplementStore.Create")()
+
+	e.Owner = ActorFrom(ctx)
+	return s.items.create(e)
+}
+
+// List zwraca dawki wykonawcy z zakresu [from, to] (puste = bez ograniczeń),
+// od najnowszej (po dacie, potem ID).
+func (s *SupplementStore) List(ctx context.Context, from, to string) []models.SupplementIntake {
+	defer startSpan(ctx, "SupplementStore.List")()
+
+	actor := ActorFrom(ctx)
+	out := s.items.list(func(e models.SupplementIntake) bool {
+		return e.Owner == actor && (from == "" || e.Date >= from) && (to == "" || e.Date <= to)
+	})
+	slices.SortFunc(out, func(a, b models.SupplementIntake) int {
+		return cmp.Or(cmp.Compare(b.Date, a.Date), cmp.Compare(b.ID, a.ID))
+	})
+	return out
+}
+
+// Get zwraca dawkę wykonawcy o podanym ID.
+func (s *SupplementStore) Get(ctx context.Context, id int) (models.SupplementIntake, bool) {
+	defer startSpan(ctx, "SupplementStore.Get")()
+
+	e, ok := s.items.get(id)
+	if !ok || e.Owner != ActorFrom(ctx) {
+		return models.SupplementIntake{}, false
+	}
+	return e, true
+}
+
+// Update zastępuje dawkę wykonawcy (ID, CreatedAt i właściciel zostają).
+func (s *SupplementStore) Update(ctx context.Context, id int, e models.SupplementIntake) (models.SupplementIntake, error) {
+	defer startSpan(ctx, "SupplementStore.Update")()
+
+	actor := ActorFrom(ctx)
+	return s.items.update(id, func(cur models.SupplementIntake) (models.SupplementIntake, error) {
+		if cur.Owner != actor {
+			return cur, ErrNotFound
+		}
+		e.Owner, e.CreatedAt = cur.Owner, cur.CreatedAt
+		return e, nil
+	})
+}
+
+// Delete usuwa dawkę wykonawcy.
+func (s *SupplementStore) Delete(ctx context.Context, id int) bool {
+	defer startSpan(ctx, "SupplementStore.Delete")()
+
+	if _, ok := s.Get(ctx, id); !ok {
+		return false
+	}
+	return s.items.delete(id)
+}
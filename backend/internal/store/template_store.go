@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// TemplateStore trzyma szablony treningów w pamięci. Każdy użytkownik widzi
+// tylko własne szablony (ActorFrom); cudze zachowują się jak nieistniejące.
+type TemplateStore struct {
+	items *collection[models.WorkoutTemplate]
+}
+
+// NewTemplateStore tworzy pusty magazyn szablonów.
+func NewTemplateStore() *TemplateStore {
+	return &TemplateStore{items: newCollection(func(t *models.WorkoutTemplate, id int, now time.Time, created bool) {
+		t.ID = id
+		if created {
+			t.CreatedAt = now
+		}
+		t.UpdatedAt = now
+	})}
+}
+
+// Create zapisuje szablon jako własny szablon wykonawcy.
+func (s *TemplateStore) Create(ctx context.Context, t models.WorkoutTemplate) models.WorkoutTemplate {
+	defer startSpan(ctx, "TemplateStore.Create")()
+
+	t.Owner = ActorFrom(ctx)
+	return s.items.create(t)
+}
+
+// List zwraca szablony wykonawcy w kolejności dodania.
+func (s *TemplateStore) List(ctx context.Context) []models.WorkoutTemplate {
+	defer startSpan(ctx, "TemplateStore.List")()
+
+	actor := ActorFrom(ctx)
+	return s.items.list(func(t models.WorkoutTemplate) bool { return t.Owner == actor })
+}
+
+// Get zwraca szablon wykonawcy o podanym ID.
+func (s *TemplateStore) Get(ctx context.Context, id int) (models.WorkoutTemplate, bool) {
+	defer startSpan(ctx, "TemplateStore.Get")()
+
+	t, ok := s.items.get(id)
+	if !ok || t.Owner != ActorFrom(ctx) {
+		return models.WorkoutTemplate{}, false
+	}
+	return t, true
+}
+
+// Update zastępuje szablon wykonawcy (ID, CreatedAt i właściciel zostają).
+func (s *TemplateStore) Update(ctx context.Context, id int, t models.WorkoutTemplate) (models.WorkoutTemplate, error) {
+	defer startSpan(ctx, "TemplateStore.Update")()
+
+	actor := ActorFrom(ctx)
+	return s.items.update(id, func(cur models.WorkoutTemplate) (models.WorkoutTemplate, error) {
+		if cur.Owner != actor {
+			return cur, ErrNotFound
+		}
+		t.Owner, t.CreatedAt = cur.Owner, cur.CreatedAt
+		return t, nil
+	})
+}
+
+// Delete usuwa szablon wykonawcy. Treningi rozpoczęte z szablonu zostają.
+func (s *TemplateStore) Delete(ctx context.Context, id int) bool {
+	defer startSpan(ctx, "TemplateStore.Delete")()
+
+	if _, ok := s.Get(ctx, id); !ok {
+		return false
+	}
+	return s.items.delete(id)
+}
@@ -0,0 +1,16 @@
+package store
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("gym-api/internal/store")
+
+// startSpan otwiera span dla operacji magazynu. Zwróconą funkcję należy
+// wywołać przez defer, np. defer startSpan(ctx, "WorkoutStore.List")().
+func startSpan(ctx context.Context, name string) func() {
+	_, span := tracer.Start(ctx, name)
+	return func() { span.End() }
+}
@@ -0,0 +1,81 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// TrainingMaxStore trzyma historię maksów treningowych (TM) użytkowników.
+// Wpisów się nie zmienia: każda zmiana TM to nowy wpis, a bieżący TM to najnowszy.
+type TrainingMaxStore struct {
+	items *collection[models.TrainingMax]
+}
+
+// NewTrainingMaxStore tworzy pusty magazyn maksów treningowych.
+func NewTrainingMaxStore() *TrainingMaxStore {
+	return &TrainingMaxStore{items: newCollection(func(t *models.TrainingMax, id int, now time.Time, created bool) {
+		t.ID = id
+		if created {
+			t.CreatedAt = now
+		}
+	})}
+}
+
+// Add zapisuje nowy TM wykonawcy.
+func (s *TrainingMaxStore) Add(ctx context.Context, t models.TrainingMax) models.TrainingMax {
+	defer startSpan(ctx, "TrainingMaxStore.Add")()
+
+	t.Owner = ActorFrom(ctx)
+	return s.items.create(t)
+}
+
+// History zwraca wpisy TM wykonawcy dla ćwiczenia, od najnowszego (po dacie, potem ID).
+func (s *TrainingMaxStore) History(ctx context.Context, exerciseID int) []models.TrainingMax {
+	defer startSpan(ctx, "TrainingMaxStore.History")()
+
+	actor := ActorFrom(ctx)
+	out := s.items.list(func(t models.TrainingMax) bool { return t.Owner == actor && t.ExerciseID == exerciseID })
+	slices.SortFunc(out, newestTrainingMax)
+	return out
+}
+
+// Latest zwraca bieżący TM wykonawcy dla ćwiczenia.
+func (s *TrainingMaxStore) Latest(ctx context.Context, exerciseID int) (models.TrainingMax, bool) {
+	defer startSpan(ctx, "TrainingMaxStore.Latest")()
+
+	h := s.History(ctx, exerciseID)
+	if len(h) == 0 {
+		return models.TrainingMax{}, false
+	}
+	return h[0], true
+}
+
+// Current zwraca bieżące TM wykonawcy, po jednym na ćwiczenie, w kolejności nazw.
+func (s *TrainingMaxStore) Current(ctx context.Context) []models.TrainingMax {
+	defer startSpan(ctx, "TrainingMaxStore.Current")()
+
+	actor := ActorFrom(ctx)
+	latest := map[int]models.TrainingMax{}
+	for _, t := range s.items.list(func(t models.TrainingMax) bool { return t.Owner == actor }) {
+		if cur, ok := latest[t.ExerciseID]; !ok || newestTrainingMax(t, cur) < 0 {
+			latest[t.ExerciseID] = t
+		}
+	}
+	out := make([]models.TrainingMax, 0, len(latest))
+	for _, t := range latest {
+		out = append(out, t)
+	}
+	slices.SortFunc(out, func(a, b models.TrainingMax) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ExerciseID, b.ExerciseID))
+	})
+	return out
+}
+
+// newestTrainingMax porządkuje wpisy od najnowszego.
+func newestTrainingMax(a, b models.TrainingMax) int {
+	return cmp.Or(cmp.Compare(b.Date, a.Date), cmp.Compare(b.ID, a.ID))
+}
@@ -0,0 +1,92 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// DefaultWaterTarget to dzienny cel nawodnienia w ml, dopóki użytkownik nie ustawi własnego.
+const DefaultWaterTarget = 2500
+
+// WaterStore trzyma dziennik nawodnienia i dzienne cele. Każdy widzi tylko własne
+// wpisy (ActorFrom).
+type WaterStore struct {
+	items *collection[models.WaterEntry]
+
+	mu      sync.RWMutex
+	targets map[string]int // wykonawca -> cel w ml
+}
+
+// NewWaterStore tworzy pusty dziennik nawodnienia.
+func NewWaterStore() *WaterStore {
+	return &WaterStore{
+		items: newCollection(func(e *models.WaterEntry, id int, now time.Time, created bool) {
+			e.ID = id
+			if created {
+				e.CreatedAt = now
+			}
+			e.UpdatedAt = now
+		}),
+		targets: make(map[string]int),
+	}
+}
+
+// Create zapisuje porcję wykonawcy.
+func (s *WaterStore) Create(ctx context.Context, e models.WaterEntry) models.WaterEntry {
+	defer startSpan(ctx, "WaterStore.Create")()
+
+	e.Owner = ActorFrom(ctx)
+	return s.items.create(e)
+}
+
+// List zwraca porcje wykonawcy z zakresu [from, to] (puste = bez ograniczeń),
+// od najnowszej (po dacie, potem ID).
+func (s *WaterStore) List(ctx context.Context, from, to string) []models.WaterEntry {
+	defer startSpan(ctx, "WaterStore.List")()
+
+	actor := ActorFrom(ctx)
+	out := s.items.list(func(e models.WaterEntry) bool {
+		return e.Owner == actor && (from == "" || e.Date >= from) && (to == "" || e.Date <= to)
+	})
+	slices.SortFunc(out, func(a, b models.WaterEntry) int {
+		return cmp.Or(cmp.Compare(b.Date, a.Date), cmp.Compare(b.ID, a.ID))
+	})
+	return out
+}
+
+// Delete usuwa porcję wykonawcy.
+func (s *WaterStore) Delete(ctx context.Context, id int) bool {
+	defer startSpan(ctx, "WaterStore.Delete")()
+
+	e, ok := s.items.get(id)
+	if !ok || e.Owner != ActorFrom(ctx) {
+		return false
+	}
+	return s.items.delete(id)
+}
+
+// Target zwraca dzienny cel wykonawcy (DefaultWaterTarget, jeśli nie ustawił własnego).
+func (s *WaterStore) Target(ctx context.Context) int {
+	defer startSpan(ctx, "WaterStore.Target")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if t, ok := s.targets[ActorFrom(ctx)]; ok {
+		return t
+	}
+	return DefaultWaterTarget
+}
+
+// SetTarget ustawia dzienny cel wykonawcy.
+func (s *WaterStore) SetTarget(ctx context.Context, ml int) {
+	defer startSpan(ctx, "WaterStore.SetTarget")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.targets[ActorFrom(ctx)] = ml
+}
@@ -0,0 +1,119 @@
+package store
+
+import (
+	"cmp"
+	"strings"
+	"time"
+
+	"gym-api/internal/models"
+)
+
+// WorkoutQuery opisuje, którą stronę listy treningów pobrać.
+type WorkoutQuery struct {
+	Limit  int            // maksymalna liczba elementów; 0 = bez limitu
+	Offset int            // liczba pominiętych elementów
+	After  *WorkoutCursor // jeśli ustawiony, zwracamy elementy za tym miejscem listy
+	Sort   []SortKey      // kolejność; pusta = DefaultSort
+
+	// Filtry (puste = bez ograniczeń); daty w formacie YYYY-MM-DD, zakres obustronnie domknięty.
+	From     string
+	To       string
+	Exercise string // nazwa ćwiczenia (bez rozróżniania wielkości liter)
+	Search   string // słowa szukane w tytule, nazwach ćwiczeń i notatkach (także ćwiczeń i serii); wszystkie muszą wystąpić
+}
+
+// SortKey to jedno kryterium sortowania, np. {Field: "date", Desc: true}.
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// SortFields to pola, po których można sortować listę treningów.
+var SortFields = []string{"id", "date", "title", "createdAt", "updatedAt"}
+
+// DefaultSort: od najnowszych treningów.
+var DefaultSort = []SortKey{{Field: "date", Desc: true}, {Field: "id", Desc: true}}
+
+// sortKeys zwraca kryteria sortowania uzupełnione o ID, żeby kolejność była jednoznaczna.
+func (q WorkoutQuery) sortKeys() []SortKey {
+	keys := q.Sort
+	if len(keys) == 0 {
+		keys = DefaultSort
+	}
+	for _, k := range keys {
+		if k.Field == "id" {
+			return keys
+		}
+	}
+	return append(keys[:len(keys):len(keys)], SortKey{Field: "id", Desc: keys[0].Desc})
+}
+
+// compareWorkouts porównuje treningi według kolejnych kryteriów (-1, 0, 1).
+func compareWorkouts(a, b models.Workout, keys []SortKey) int {
+	for _, k := range keys {
+		var c int
+		switch k.Field {
+		case "id":
+			c = cmp.Compare(a.ID, b.ID)
+		case "date":
+			c = cmp.Compare(a.Date, b.Date)
+		case "title":
+			c = cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		case "createdAt":
+			c = a.CreatedAt.Compare(b.CreatedAt)
+		case "updatedAt":
+			c = a.UpdatedAt.Compare(b.UpdatedAt)
+		}
+		if k.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// matches sprawdza, czy trening spełnia filtry zapytania.
+func (q WorkoutQuery) matches(w models.Workout) bool {
+	// Daty ISO porównujemy leksykograficznie – kolejność zgadza się z chronologią.
+	if q.From != "" && w.Date < q.From {
+		return false
+	}
+	if q.To != "" && w.Date > q.To {
+		return false
+	}
+	if q.Exercise != "" && !hasExercise(w, q.Exercise) {
+		return false
+	}
+	return true
+}
+
+// hasExercise sprawdza, czy trening zawiera ćwiczenie o podanej nazwie.
+func hasExercise(w models.Workout, name string) bool {
+	for _, ex := range w.Exercises {
+		if strings.EqualFold(strings.TrimSpace(ex.Name), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkoutCursor wskazuje pozycję na liście: wartości pól sortowania
+// ostatniego zwróconego treningu.
+type WorkoutCursor struct {
+	ID        int
+	Date      string
+	Title     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CursorAt tworzy kursor wskazujący miejsce tuż za podanym treningiem.
+func CursorAt(w models.Workout) WorkoutCursor {
+	return WorkoutCursor{ID: w.ID, Date: w.Date, Title: w.Title, CreatedAt: w.CreatedAt, UpdatedAt: w.UpdatedAt}
+}
+
+func (c WorkoutCursor) workout() models.Workout {
+	return models.Workout{ID: c.ID, Date: c.Date, Title: c.Title, CreatedAt: c.CreatedAt, UpdatedAt: c.UpdatedAt}
+}
@@ -0,0 +1,206 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"gym-api/internal/models"
+	"gym-api/internal/progression"
+)
+
+// defaultIntensity to mnożnik obciążenia serii bez zapisanego RPE (odpowiada RPE 8).
+const defaultIntensity = 0.8
+
+// DayLoad to obciążenie treningowe jednego dnia.
+type DayLoad struct {
+	Date string
+	Load float64 // suma ciężar × powtórzenia × intensywność (RPE/10) po seriach
+}
+
+// DailyLoad sumuje obciążenie wykonanych treningów w zakresie dat [from, to] (puste =
+// bez ograniczeń), dzień po dniu od najstarszego. Intensywność serii to RPE/10 (z RIR,
+// gdy brak RPE), a bez nich defaultIntensity; serie bez ciężaru pomijamy.
+func (s *WorkoutStore) DailyLoad(ctx context.Context, from, to string) []DayLoad {
+	defer startSpan(ctx, "WorkoutStore.DailyLoad")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	byDate := map[string]float64{}
+	for _, w := range s.workouts {
+		if w.Planned || from != "" && w.Date < from || to != "" && w.Date > to {
+			continue
+		}
+		byDate[w.Date] += workoutLoad(w)
+	}
+	out := make([]DayLoad, 0, len(byDate))
+	for d, l := range byDate {
+		out = append(out, DayLoad{Date: d, Load: l})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Date < out[j].Date })
+	return out
+}
+
+// workoutLoad liczy obciążenie treningu z serii roboczych; rozgrzewka nie męczy na tyle,
+// żeby liczyć ją do ACWR.
+func workoutLoad(w models.Workout) float64 {
+	load := 0.0
+	for _, ex := range w.Exercises {
+		for _, set := range ex.Sets {
+			if set.Weight == nil || set.Type == models.SetWarmup {
+				continue
+			}
+			intensity := defaultIntensity
+			if rpe, ok := progression.SetRPE(set); ok {
+				intensity = rpe / 10
+			}
+			load += *set.Weight * float64(set.Reps) * intensity
+		}
+	}
+	return load
+}
+
+// dateLayout to format dat treningów (YYYY-MM-DD).
+const dateLayout = "2006-01-02"
+
+// Okresy agregacji statystyk.
+const (
+	PeriodDay   = "day"
+	PeriodWeek  = "week"  // tydzień od poniedziałku
+	PeriodMonth = "month" // miesiąc kalendarzowy
+)
+
+// PeriodStart zwraca pierwszy dzień okresu, do którego należy data (YYYY-MM-DD);
+// nieznany okres lub błędna data dają datę bez zmian.
+func PeriodStart(date, period string) string {
+	d, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return date
+	}
+	switch period {
+	case PeriodWeek:
+		return d.AddDate(0, 0, -(int(d.Weekday())+6)%7).Format(dateLayout)
+	case PeriodMonth:
+		return d.AddDate(0, 0, 1-d.Day()).Format(dateLayout)
+	}
+	return date
+}
+
+// PeriodVolume to objętość wszystkich wykonanych treningów w jednym okresie.
+type PeriodVolume struct {
+	Period   string // pierwszy dzień okresu (patrz PeriodStart)
+	Workouts int
+	Sets     int
+	Reps     int
+	Tonnage  float64 // kg × powtórzenia
+}
+
+// VolumeByPeriod sumuje treningi, serie, powtórzenia i tonaż wykonanych treningów
+// z zakresu [from, to] per okres, od najstarszego; okresy bez treningów pomija.
+// Serie rozgrzewkowe liczą się tylko z warmups. Agregacja należy do magazynu, żeby
+// backend SQL mógł ją zrobić jednym GROUP BY.
+func (s *WorkoutStore) VolumeByPeriod(ctx context.Context, from, to, period string, warmups bool) []PeriodVolume {
+	defer startSpan(ctx, "WorkoutStore.VolumeByPeriod")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	byPeriod := map[string]*PeriodVolume{}
+	for _, w := range s.workouts {
+		if w.Planned || from != "" && w.Date < from || to != "" && w.Date > to {
+			continue
+		}
+		p := PeriodStart(w.Date, period)
+		v := byPeriod[p]
+		if v == nil {
+			v = &PeriodVolume{Period: p}
+			byPeriod[p] = v
+		}
+		v.Workouts++
+		for _, ex := range w.Exercises {
+			for _, set := range ex.Sets {
+				if set.Type == models.SetWarmup && !warmups {
+					continue
+				}
+				v.Sets++
+				v.Reps += set.Reps
+				if set.Weight != nil {
+					v.Tonnage += *set.Weight * float64(set.Reps)
+				}
+			}
+		}
+	}
+	out := make([]PeriodVolume, 0, len(byPeriod))
+	for _, v := range byPeriod {
+		out = append(out, *v)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Period < out[j].Period })
+	return out
+}
+
+// ExerciseVolume to objętość jednego ćwiczenia w jednym okresie.
+type ExerciseVolume struct {
+	Period     string // pierwszy dzień okresu (patrz PeriodStart)
+	ExerciseID int    // 0 = ćwiczenie spoza katalogu
+	Name       string
+	Sets       int
+	Reps       int
+	Tonnage    float64 // kg × powtórzenia
+}
+
+// ExerciseVolumeByPeriod sumuje serie, powtórzenia i tonaż wykonanych treningów
+// z zakresu [from, to] per okres i ćwiczenie (ćwiczenia spoza katalogu po nazwie),
+// w kolejności okresów, a w okresie – nazw. Serie rozgrzewkowe liczą się tylko z warmups.
+func (s *WorkoutStore) ExerciseVolumeByPeriod(ctx context.Context, from, to, period string, warmups bool) []ExerciseVolume {
+	defer startSpan(ctx, "WorkoutStore.ExerciseVolumeByPeriod")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type key struct {
+		period string
+		id     int
+		name   string
+	}
+	byKey := map[key]*ExerciseVolume{}
+	for _, w := range s.workouts {
+		if w.Planned || from != "" && w.Date < from || to != "" && w.Date > to {
+			continue
+		}
+		p := PeriodStart(w.Date, period)
+		for _, ex := range w.Exercises {
+			k := key{period: p, id: ex.ExerciseID}
+			if ex.ExerciseID == 0 {
+				k.name = strings.ToLower(strings.TrimSpace(ex.Name))
+			}
+			v := byKey[k]
+			if v == nil {
+				v = &ExerciseVolume{Period: p, ExerciseID: ex.ExerciseID, Name: strings.TrimSpace(ex.Name)}
+				byKey[k] = v
+			}
+			for _, set := range ex.Sets {
+				if set.Type == models.SetWarmup && !warmups {
+					continue
+				}
+				v.Sets++
+				v.Reps += set.Reps
+				if set.Weight != nil {
+					v.Tonnage += *set.Weight * float64(set.Reps)
+				}
+			}
+		}
+	}
+	out := make([]ExerciseVolume, 0, len(byKey))
+	for _, v := range byKey {
+		out = append(out, *v)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Period != out[j].Period {
+			return out[i].Period < out[j].Period
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
@@ -1,30 +1,68 @@
 package store
 
 import (
+	"cmp"
+	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"gym-api/internal/models"
 )
 
+// Błędy zwracane przez magazyny.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrVersionConflict = errors.New("workout version conflict")
+	ErrNothingToUndo   = errors.New("workout has no earlier version")
+)
+
 // WorkoutStore to prosty, bezpieczny współbieżnie magazyn treningów w pamięci.
 type WorkoutStore struct {
 	mu       sync.RWMutex
 	nextID   int
 	workouts map[int]models.Workout
+	trash    map[int]models.Workout // usunięte treningi, do przywrócenia lub trwałego skasowania
+	search   *searchIndex
+	records  *recordIndex // rekordy osobiste, aktualizowane przy każdej zmianie
+	audit    *AuditStore  // dziennik zmian; nil = bez audytu
+	// history trzyma poprzednie wersje każdego treningu (najstarsze pierwsze),
+	// maksymalnie maxRevisions na trening.
+	history map[int][]models.Workout
 }
 
+// maxRevisions ogranicza liczbę przechowywanych poprzednich wersji jednego treningu.
+const maxRevisions = 50
+
 // NewWorkoutStore inicjalizuje pusty magazyn z pierwszym ID = 1.
-func NewWorkoutStore() *WorkoutStore {
+// Każda zmiana treningu trafia do dziennika audit (może być nil).
+func NewWorkoutStore(audit *AuditStore) *WorkoutStore {
 	return &WorkoutStore{
+		audit:    audit,
 		nextID:   1,
 		workouts: make(map[int]models.Workout),
+		trash:    make(map[int]models.Workout),
+		history:  make(map[int][]models.Workout),
+		search:   newSearchIndex(),
+		records:  newRecordIndex(),
 	}
 }
 
 // Create dodaje nowy trening, nadaje ID i znaczniki czasu.
-func (s *WorkoutStore) Create(w models.Workout) models.Workout {
+func (s *WorkoutStore) Create(ctx context.Context, w models.Workout) models.Workout {
+	created, _ := s.CreateWithRecords(ctx, w)
+	return created
+}
+
+// CreateWithRecords działa jak Create i dodatkowo zwraca rekordy osobiste pobite
+// przez nowy trening (pod tą samą blokadą, więc porównanie jest spójne).
+func (s *WorkoutStore) CreateWithRecords(ctx context.Context, w models.Workout) (models.Workout, []models.NewRecord) {
+	defer startSpan(ctx, "WorkoutStore.Create")()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -32,27 +70,94 @@ func (s *WorkoutStore) Create(w models.Workout) models.Workout {
 	w.ID = s.nextID
 	w.CreatedAt = now
 	w.UpdatedAt = now
+	w.Version = 1
+	numberExercises(w.Exercises)
 
 	s.workouts[w.ID] = w
+	s.search.put(w)
+	records := s.records.put(w)
 	s.nextID++
+	s.audit.Record(ctx, AuditCreate, "workout", w.ID, workoutSummary(w))
+
+	return w, records
+}
+
+// CreateMany dodaje wiele treningów naraz, pod jedną blokadą: inne żądania
+// widzą albo wszystkie nowe treningi, albo żaden. Kolejność ID odpowiada kolejności ws.
+func (s *WorkoutStore) CreateMany(ctx context.Context, ws []models.Workout) []models.Workout {
+	defer startSpan(ctx, "WorkoutStore.CreateMany")()
 
-	return w
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	out := make([]models.Workout, 0, len(ws))
+	for _, w := range ws {
+		w.ID = s.nextID
+		w.CreatedAt = now
+		w.UpdatedAt = now
+		w.Version = 1
+		numberExercises(w.Exercises)
+
+		s.workouts[w.ID] = w
+		s.search.put(w)
+		s.records.put(w)
+		s.nextID++
+		s.audit.Record(ctx, AuditCreate, "workout", w.ID, workoutSummary(w))
+		out = append(out, w)
+	}
+	return out
 }
 
-// List zwraca kopię listy treningów w formie slice.
-func (s *WorkoutStore) List() []models.Workout {
+// List zwraca kopię strony treningów w kolejności q.Sort (domyślnie od najnowszych),
+// łączną liczbę pasujących do filtrów treningów (niezależnie od stronicowania) oraz informację,
+// czy za zwróconą stroną są kolejne elementy.
+func (s *WorkoutStore) List(ctx context.Context, q WorkoutQuery) ([]models.Workout, int, bool) {
+	defer startSpan(ctx, "WorkoutStore.List")()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	out := make([]models.Workout, 0, len(s.workouts))
+	var hits map[int]struct{}
+	if q.Search != "" {
+		hits = s.search.search(q.Search)
+	}
+
+	all := make([]models.Workout, 0, len(s.workouts))
 	for _, v := range s.workouts {
-		out = append(out, v)
+		if hits != nil {
+			if _, ok := hits[v.ID]; !ok {
+				continue
+			}
+		}
+		if q.matches(v) {
+			all = append(all, v)
+		}
 	}
-	return out
+	// Stała kolejność (z ID jako rozstrzygnięciem remisów) jest konieczna,
+	// żeby strony się nie nakładały; mapa sama w sobie kolejności nie ma.
+	keys := q.sortKeys()
+	sort.Slice(all, func(i, j int) bool { return compareWorkouts(all[i], all[j], keys) < 0 })
+
+	total := len(all)
+	start := 0
+	if q.After != nil {
+		// Kursor jest stabilny: nowe treningi nie przesuwają już pobranych stron.
+		after := q.After.workout()
+		start = sort.Search(total, func(i int) bool { return compareWorkouts(all[i], after, keys) > 0 })
+	}
+	start = min(start+q.Offset, total)
+	end := total
+	if q.Limit > 0 {
+		end = min(start+q.Limit, total)
+	}
+	return all[start:end], total, end < total
 }
 
 // Get pobiera trening po ID. Drugi zwracany parametr informuje, czy znaleziono.
-func (s *WorkoutStore) Get(id int) (models.Workout, bool) {
+func (s *WorkoutStore) Get(ctx context.Context, id int) (models.Workout, bool) {
+	defer startSpan(ctx, "WorkoutStore.Get")()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -60,31 +165,399 @@ func (s *WorkoutStore) Get(id int) (models.Workout, bool) {
 	return w, ok
 }
 
-// Update modyfikuje istniejący trening używając podanej funkcji i aktualizuje znacznik czasu.
-func (s *WorkoutStore) Update(id int, upd func(current models.Workout) models.Workout) (models.Workout, error) {
+// Update modyfikuje istniejący trening używając podanej funkcji, aktualizuje znacznik czasu
+// i podbija wersję. Jeśli version > 0, a bieżąca wersja jest inna, zwraca ErrVersionConflict
+// (sprawdzenie i zapis są atomowe); version == 0 oznacza zapis bez sprawdzania.
+func (s *WorkoutStore) Update(ctx context.Context, id, version int, upd func(current models.Workout) models.Workout) (models.Workout, error) {
+	defer startSpan(ctx, "WorkoutStore.Update")()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	cur, ok := s.workouts[id]
 	if !ok {
-		return models.Workout{}, errors.New("not found")
+		return models.Workout{}, ErrNotFound
+	}
+	if version > 0 && cur.Version != version {
+		return cur, ErrVersionConflict
 	}
 
+	prev := cur
 	cur = upd(cur)
+	numberExercises(cur.Exercises)
 	cur.UpdatedAt = time.Now()
+	cur.Version = prev.Version + 1
+	s.remember(prev)
 	s.workouts[id] = cur
+	s.search.put(cur)
+	s.records.put(cur)
+	s.audit.Record(ctx, AuditUpdate, "workout", id, changeSummary(prev, cur))
 
 	return cur, nil
 }
 
-// Delete usuwa trening po ID i zwraca informację o powodzeniu.
-func (s *WorkoutStore) Delete(id int) bool {
+// RefreshWeights podmienia ćwiczenia treningu na te same z przeliczonymi ciężarami
+// pochodnymi (np. serie z masą ciała po zmianie dziennika masy ciała). To nie jest edycja
+// użytkownika, więc wersja, UpdatedAt (a z nim ETag), historia wersji i dziennik audytu
+// zostają bez zmian. Jeśli od odczytu w wersji version trening się zmienił, nic nie
+// zapisujemy i zwracamy false – zapis treningu przeliczył już ciężary sam.
+func (s *WorkoutStore) RefreshWeights(ctx context.Context, id, version int, exs []models.Exercise) (models.Workout, bool) {
+	defer startSpan(ctx, "WorkoutStore.RefreshWeights")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur, ok := s.workouts[id]
+	if !ok || cur.Version != version {
+		return cur, false
+	}
+	cur.Exercises = exs
+	s.workouts[id] = cur
+	s.search.put(cur)
+	s.records.put(cur)
+	return cur, true
+}
+
+// UpdateMany modyfikuje wiele treningów atomowo: jeśli któregoś nie ma (ErrNotFound)
+// albo upd zwróci błąd, nie zapisujemy żadnej zmiany i zwracamy ten błąd.
+// Zwraca treningi w kolejności ids.
+func (s *WorkoutStore) UpdateMany(ctx context.Context, ids []int, upd func(current models.Workout) (models.Workout, error)) ([]models.Workout, error) {
+	defer startSpan(ctx, "WorkoutStore.UpdateMany")()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, ok := s.workouts[id]; !ok {
+	// Najpierw liczymy wszystkie nowe stany, zapisujemy dopiero gdy każdy się udał.
+	now := time.Now()
+	out := make([]models.Workout, 0, len(ids))
+	for _, id := range ids {
+		cur, ok := s.workouts[id]
+		if !ok {
+			return nil, fmt.Errorf("workout %d: %w", id, ErrNotFound)
+		}
+		next := cur.Version + 1
+		cur, err := upd(cur)
+		if err != nil {
+			return nil, err
+		}
+		cur.ID = id
+		cur.UpdatedAt = now
+		cur.Version = next
+		numberExercises(cur.Exercises)
+		out = append(out, cur)
+	}
+	for _, w := range out {
+		s.audit.Record(ctx, AuditUpdate, "workout", w.ID, changeSummary(s.workouts[w.ID], w))
+		s.remember(s.workouts[w.ID])
+		s.workouts[w.ID] = w
+		s.search.put(w)
+		s.records.put(w)
+	}
+	return out, nil
+}
+
+// Delete przenosi trening do kosza i zwraca informację o powodzeniu.
+// Trening znika z listy i wyszukiwania, ale można go przywrócić przez Restore.
+func (s *WorkoutStore) Delete(ctx context.Context, id int) bool {
+	defer startSpan(ctx, "WorkoutStore.Delete")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, ok := s.workouts[id]
+	if !ok {
 		return false
 	}
+	now := time.Now()
+	w.DeletedAt = &now
+	s.trash[id] = w
 	delete(s.workouts, id)
+	s.search.remove(id)
+	s.records.remove(id)
+	s.audit.Record(ctx, AuditDelete, "workout", id, workoutSummary(w))
 	return true
 }
+
+// Trash zwraca treningi z kosza, od ostatnio usuniętych.
+func (s *WorkoutStore) Trash(ctx context.Context) []models.Workout {
+	defer startSpan(ctx, "WorkoutStore.Trash")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]models.Workout, 0, len(s.trash))
+	for _, w := range s.trash {
+		out = append(out, w)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].DeletedAt.Equal(*out[j].DeletedAt) {
+			return out[i].DeletedAt.After(*out[j].DeletedAt)
+		}
+		return out[i].ID > out[j].ID
+	})
+	return out
+}
+
+// Restore przywraca trening z kosza. Drugi zwracany parametr informuje, czy był w koszu.
+func (s *WorkoutStore) Restore(ctx context.Context, id int) (models.Workout, bool) {
+	defer startSpan(ctx, "WorkoutStore.Restore")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, ok := s.trash[id]
+	if !ok {
+		return models.Workout{}, false
+	}
+	s.remember(w)
+	w.DeletedAt = nil
+	w.UpdatedAt = time.Now()
+	w.Version++
+	delete(s.trash, id)
+	s.workouts[id] = w
+	s.search.put(w)
+	s.records.put(w)
+	s.audit.Record(ctx, AuditRestore, "workout", id, workoutSummary(w))
+	return w, true
+}
+
+// Purge trwale kasuje treningi, które leżą w koszu dłużej niż retention,
+// i zwraca ich liczbę.
+func (s *WorkoutStore) Purge(ctx context.Context, retention time.Duration) int {
+	defer startSpan(ctx, "WorkoutStore.Purge")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := time.Now().Add(-retention)
+	n := 0
+	for id, w := range s.trash {
+		if w.DeletedAt.Before(cutoff) {
+			delete(s.trash, id)
+			delete(s.history, id)
+			s.audit.Record(ctx, AuditPurge, "workout", id, workoutSummary(w))
+			n++
+		}
+	}
+	return n
+}
+
+// remember zapisuje poprzednią wersję treningu w historii. Wołać pod blokadą zapisu.
+func (s *WorkoutStore) remember(prev models.Workout) {
+	prev.DeletedAt = nil
+	h := append(s.history[prev.ID], prev)
+	if len(h) > maxRevisions {
+		h = h[len(h)-maxRevisions:]
+	}
+	s.history[prev.ID] = h
+}
+
+// Revisions zwraca wszystkie zachowane wersje treningu, od najstarszej do bieżącej
+// (numer wersji = pole Version). Działa też dla treningów w koszu.
+func (s *WorkoutStore) Revisions(ctx context.Context, id int) ([]models.Workout, bool) {
+	defer startSpan(ctx, "WorkoutStore.Revisions")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cur, ok := s.workouts[id]
+	if !ok {
+		if cur, ok = s.trash[id]; !ok {
+			return nil, false
+		}
+	}
+	out := make([]models.Workout, 0, len(s.history[id])+1)
+	out = append(out, s.history[id]...)
+	return append(out, cur), true
+}
+
+// Undo cofa ostatnią zmianę treningu: przywraca edytowalne pola z poprzedniej wersji
+// z historii i zdejmuje ją z historii, więc kolejne wywołania cofają się dalej.
+// Wynik zapisujemy jako nową wersję (Version rośnie), żeby ETagi i If-Match działały dalej.
+// Jeśli version > 0, a bieżąca wersja jest inna, zwraca ErrVersionConflict.
+func (s *WorkoutStore) Undo(ctx context.Context, id, version int) (models.Workout, error) {
+	defer startSpan(ctx, "WorkoutStore.Undo")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur, ok := s.workouts[id]
+	if !ok {
+		return models.Workout{}, ErrNotFound
+	}
+	if version > 0 && cur.Version != version {
+		return cur, ErrVersionConflict
+	}
+	h := s.history[id]
+	if len(h) == 0 {
+		return cur, ErrNothingToUndo
+	}
+	prev := h[len(h)-1]
+	s.history[id] = h[:len(h)-1]
+
+	next := cur
+	next.Title, next.Date, next.Notes, next.Exercises, next.Planned = prev.Title, prev.Date, prev.Notes, prev.Exercises, prev.Planned
+	next.UpdatedAt = time.Now()
+	next.Version = cur.Version + 1
+	s.workouts[id] = next
+	s.search.put(next)
+	s.records.put(next)
+	s.audit.Record(ctx, AuditUndo, "workout", id, fmt.Sprintf("reverted to version %d, %s", prev.Version, changeSummary(cur, next)))
+	return next, nil
+}
+
+// ExerciseUsage mówi, ile razy ćwiczenie o danej nazwie pojawiło się w treningach.
+type ExerciseUsage struct {
+	Name       string // pisownia z najnowszego treningu
+	ExerciseID int    // ID w katalogu, jeśli któryś wpis był z nim powiązany
+	Count      int    // liczba treningów z tym ćwiczeniem
+	LastDate   string // data ostatniego treningu z tym ćwiczeniem
+}
+
+// ExerciseUsage zbiera nazwy ćwiczeń z historii treningów (bez rozróżniania wielkości liter).
+func (s *WorkoutStore) ExerciseUsage(ctx context.Context) []ExerciseUsage {
+	defer startSpan(ctx, "WorkoutStore.ExerciseUsage")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	byName := map[string]*ExerciseUsage{}
+	for _, w := range s.workouts {
+		seen := map[string]bool{}
+		for _, ex := range w.Exercises {
+			key := strings.ToLower(strings.TrimSpace(ex.Name))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			u := byName[key]
+			if u == nil {
+				u = &ExerciseUsage{}
+				byName[key] = u
+			}
+			u.Count++
+			if w.Date >= u.LastDate {
+				u.Name, u.LastDate = strings.TrimSpace(ex.Name), w.Date
+			}
+			if ex.ExerciseID != 0 {
+				u.ExerciseID = ex.ExerciseID
+			}
+		}
+	}
+	out := make([]ExerciseUsage, 0, len(byName))
+	for _, u := range byName {
+		out = append(out, *u)
+	}
+	return out
+}
+
+// LastExercise zwraca ćwiczenie z najnowszego wykonanego treningu (po dacie, potem ID),
+// w którym wystąpiło (patrz sameExercise). Treningi zaplanowane pomija.
+// Drugi wynik to data tego treningu.
+func (s *WorkoutStore) LastExercise(ctx context.Context, exerciseID int, name string) (models.Exercise, string, bool) {
+	defer startSpan(ctx, "WorkoutStore.LastExercise")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var (
+		best   models.Exercise
+		bestWk models.Workout
+		found  bool
+	)
+	for _, w := range s.workouts {
+		if w.Planned || found && (w.Date < bestWk.Date || w.Date == bestWk.Date && w.ID < bestWk.ID) {
+			continue
+		}
+		for _, ex := range w.Exercises {
+			if sameExercise(ex, exerciseID, name) {
+				best, bestWk, found = ex, w, true
+				break
+			}
+		}
+	}
+	return best, bestWk.Date, found
+}
+
+// LastCompleted zwraca najnowszy wykonany (niezaplanowany) trening.
+func (s *WorkoutStore) LastCompleted(ctx context.Context) (models.Workout, bool) {
+	defer startSpan(ctx, "WorkoutStore.LastCompleted")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var (
+		best  models.Workout
+		found bool
+	)
+	for _, w := range s.workouts {
+		if !w.Planned && (!found || w.Date > best.Date || w.Date == best.Date && w.ID > best.ID) {
+			best, found = w, true
+		}
+	}
+	return best, found
+}
+
+// ExerciseSession to wystąpienie ćwiczenia w jednym treningu.
+type ExerciseSession struct {
+	WorkoutID int
+	Date      string
+	Sets      []models.Set // serie ze wszystkich wpisów tego ćwiczenia w treningu
+}
+
+// ExerciseHistory zwraca wystąpienia ćwiczenia w wykonanych treningach, od najnowszego
+// (po dacie, potem ID). Ćwiczenie dopasowujemy jak w sameExercise.
+func (s *WorkoutStore) ExerciseHistory(ctx context.Context, exerciseID int, name string) []ExerciseSession {
+	defer startSpan(ctx, "WorkoutStore.ExerciseHistory")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []ExerciseSession
+	for _, w := range s.workouts {
+		if w.Planned {
+			continue
+		}
+		var sets []models.Set
+		found := false
+		for _, ex := range w.Exercises {
+			if sameExercise(ex, exerciseID, name) {
+				sets = append(sets, ex.Sets...)
+				found = true
+			}
+		}
+		if found {
+			out = append(out, ExerciseSession{WorkoutID: w.ID, Date: w.Date, Sets: sets})
+		}
+	}
+	slices.SortFunc(out, func(a, b ExerciseSession) int {
+		return cmp.Or(cmp.Compare(b.Date, a.Date), cmp.Compare(b.WorkoutID, a.WorkoutID))
+	})
+	return out
+}
+
+// numberExercises nadaje ćwiczeniom pozycje 1..n według kolejności na liście.
+func numberExercises(exs []models.Exercise) {
+	for i := range exs {
+		exs[i].Order = i + 1
+	}
+}
+
+// sameExercise dopasowuje ćwiczenie treningu po exerciseID, a gdy ten jest 0 –
+// po nazwie (bez rozróżniania wielkości liter).
+func sameExercise(ex models.Exercise, exerciseID int, name string) bool {
+	if exerciseID != 0 {
+		return ex.ExerciseID == exerciseID
+	}
+	return strings.EqualFold(strings.TrimSpace(ex.Name), strings.TrimSpace(name))
+}
+
+// Records zwraca rekordy osobiste wszystkich ćwiczeń z wykonanych treningów, po nazwie.
+func (s *WorkoutStore) Records(ctx context.Context) []models.ExerciseRecords {
+	defer startSpan(ctx, "WorkoutStore.Records")()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.records.list()
+}
@@ -0,0 +1,208 @@
+// Package strava to klient API Stravy (https://developers.strava.com) w zakresie
+// potrzebnym do synchronizacji treningów: autoryzacja OAuth (wymiana kodu i odświeżanie
+// tokenu), lista i szczegóły aktywności oraz zdarzenia webhooka.
+package strava
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL to adres API Stravy.
+const DefaultBaseURL = "https://www.strava.com"
+
+// Scope to uprawnienia, o które prosimy: odczyt wszystkich aktywności (także prywatnych).
+const Scope = "activity:read_all"
+
+// ErrUnauthorized oznacza odrzucony token albo kod autoryzacji (np. użytkownik
+// odwołał dostęp w ustawieniach Stravy).
+var ErrUnauthorized = errors.New("strava: unauthorized")
+
+// Client wywołuje API Stravy w imieniu aplikacji o podanym ClientID.
+type Client struct {
+	ClientID     string
+	ClientSecret string
+	// RedirectURL to adres powrotu z autoryzacji (GET /api/v1/integrations/strava/callback).
+	RedirectURL string
+	// VerifyToken potwierdza subskrypcję webhooka (hub.verify_token przy jej zakładaniu).
+	VerifyToken string
+	BaseURL     string       // pusty = DefaultBaseURL
+	HTTP        *http.Client // nil = klient z 30-sekundowym timeoutem
+}
+
+// Token to tokeny dostępu konta połączonego przez OAuth.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+	AthleteID    int64 // zwracany tylko przy wymianie kodu
+}
+
+// Expired informuje, czy token trzeba odświeżyć (z minutowym zapasem).
+func (t Token) Expired(now time.Time) bool {
+	return !now.Add(time.Minute).Before(t.ExpiresAt)
+}
+
+// Activity to podsumowanie aktywności z GET /athlete/activities i GET /activities/{id}.
+type Activity struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	SportType string `json:"sport_type"` // np. Run, TrailRun, Ride, Rowing
+	// StartDateLocal to czas rozpoczęcia w strefie sportowca, zapisany jak UTC.
+	StartDateLocal     time.Time `json:"start_date_local"`
+	MovingTime         int       `json:"moving_time"`  // s
+	ElapsedTime        int       `json:"elapsed_time"` // s
+	Distance           float64   `json:"distance"`     // m
+	TotalElevationGain float64   `json:"total_elevation_gain"`
+	HasHeartrate       bool      `json:"has_heartrate"`
+	AverageHeartrate   float64   `json:"average_heartrate"`
+	Manual             bool      `json:"manual"` // wpisana ręcznie w Stravie, bez zapisu z urządzenia
+}
+
+// Event to zdarzenie webhooka (POST na adres subskrypcji).
+type Event struct {
+	ObjectType string            `json:"object_type"` // activity albo athlete
+	ObjectID   int64             `json:"object_id"`
+	AspectType string            `json:"aspect_type"` // create, update, delete
+	OwnerID    int64             `json:"owner_id"`
+	EventTime  int64             `json:"event_time"`
+	Updates    map[string]string `json:"updates"` // np. {"authorized": "false"} po odwołaniu dostępu
+}
+
+// Deauthorized informuje, czy zdarzenie oznacza odwołanie dostępu przez sportowca.
+func (e Event) Deauthorized() bool {
+	return e.ObjectType == "athlete" && e.Updates["authorized"] == "false"
+}
+
+// AuthorizeURL zwraca adres strony zgody Stravy; po akceptacji Strava przekierowuje
+// na RedirectURL z ?code= i przekazanym state.
+func (c *Client) AuthorizeURL(state string) string {
+	q := url.Values{
+		"client_id":       {c.ClientID},
+		"redirect_uri":    {c.RedirectURL},
+		"response_type":   {"code"},
+		"approval_prompt": {"auto"},
+		"scope":           {Scope},
+		"state":           {state},
+	}
+	return c.baseURL() + "/oauth/authorize?" + q.Encode()
+}
+
+// Exchange wymienia kod z przekierowania OAuth na tokeny.
+func (c *Client) Exchange(ctx context.Context, code string) (Token, error) {
+	return c.token(ctx, url.Values{"grant_type": {"authorization_code"}, "code": {code}})
+}
+
+// Refresh odświeża wygasły token dostępu.
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (Token, error) {
+	return c.token(ctx, url.Values{"grant_type": {"refresh_token"}, "refresh_token": {refreshToken}})
+}
+
+func (c *Client) token(ctx context.Context, form url.Values) (Token, error) {
+	form.Set("client_id", c.ClientID)
+	form.Set("client_secret", c.ClientSecret)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL()+"/oauth/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return Token{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresAt    int64  `json:"expires_at"`
+		Athlete      struct {
+			ID int64 `json:"id"`
+		} `json:"athlete"`
+	}
+	if err := c.do(req, &body); err != nil {
+		return Token{}, err
+	}
+	return Token{
+		AccessToken:  body.AccessToken,
+		RefreshToken: body.RefreshToken,
+		ExpiresAt:    time.Unix(body.ExpiresAt, 0),
+		AthleteID:    body.Athlete.ID,
+	}, nil
+}
+
+// perPage to rozmiar strony listy aktywności (maksimum API to 200).
+const perPage = 100
+
+// Activities zwraca aktywności rozpoczęte po after, od najstarszej, pobierając
+// kolejne strony aż do końca listy.
+func (c *Client) Activities(ctx context.Context, accessToken string, after time.Time) ([]Activity, error) {
+	var out []Activity
+	for page := 1; ; page++ {
+		q := url.Values{
+			"after":    {strconv.FormatInt(after.Unix(), 10)},
+			"page":     {strconv.Itoa(page)},
+			"per_page": {strconv.Itoa(perPage)},
+		}
+		var batch []Activity
+		if err := c.get(ctx, accessToken, "/api/v3/athlete/activities?"+q.Encode(), &batch); err != nil {
+			return nil, err
+		}
+		out = append(out, batch...)
+		if len(batch) < perPage {
+			return out, nil
+		}
+	}
+}
+
+// Activity zwraca jedną aktywność (np. wskazaną w zdarzeniu webhooka).
+func (c *Client) Activity(ctx context.Context, accessToken string, id int64) (Activity, error) {
+	var a Activity
+	err := c.get(ctx, accessToken, "/api/v3/activities/"+strconv.FormatInt(id, 10), &a)
+	return a, err
+}
+
+func (c *Client) get(ctx context.Context, accessToken, path string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL()+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	return c.do(req, dst)
+}
+
+func (c *Client) do(req *http.Request, dst any) error {
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("strava: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	// Nieważny kod albo token odświeżania to 400 z endpointu tokenów.
+	case resp.StatusCode == http.StatusUnauthorized,
+		resp.StatusCode == http.StatusBadRequest && strings.HasSuffix(req.URL.Path, "/oauth/token"):
+		return ErrUnauthorized
+	case resp.StatusCode >= 300:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("strava: %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("strava: decode %s: %w", req.URL.Path, err)
+	}
+	return nil
+}
+
+func (c *Client) baseURL() string {
+	if c.BaseURL != "" {
+		return strings.TrimRight(c.BaseURL, "/")
+	}
+	return DefaultBaseURL
+}
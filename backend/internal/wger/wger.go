@@ -0,0 +1,190 @@
+// Package wger importuje ćwiczenia z otwartej bazy wger (https://wger.de) do katalogu.
+// Czyta format odpowiedzi GET /api/v2/exerciseinfo/ ({"results": [...]}); z pakietem
+// dostarczamy wybrany zestaw popularnych ćwiczeń, żeby nowa instalacja nie miała pustego katalogu.
+package wger
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gym-api/internal/models"
+)
+
+//go:embed exercises.json
+var bundled []byte
+
+// Identyfikatory języków w wger.
+const (
+	langEnglish = 2
+	langPolish  = 13
+)
+
+// categoryCardio to kategoria "Cardio"; pozostałe kategorie traktujemy jako ćwiczenia siłowe.
+const categoryCardio = 15
+
+// muscles mapuje ID mięśni wger na grupy z taksonomii katalogu (patrz GET /muscle-groups).
+var muscles = map[int]string{
+	1:  "biceps",     // Biceps brachii
+	2:  "shoulders",  // Anterior deltoid
+	3:  "chest",      // Serratus anterior
+	4:  "chest",      // Pectoralis major
+	5:  "triceps",    // Triceps brachii
+	6:  "abs",        // Rectus abdominis
+	7:  "calves",     // Gastrocnemius
+	8:  "glutes",     // Gluteus maximus
+	9:  "traps",      // Trapezius
+	10: "quadriceps", // Quadriceps femoris
+	11: "hamstrings", // Biceps femoris
+	12: "lats",       // Latissimus dorsi
+	13: "biceps",     // Brachialis
+	14: "obliques",   // Obliquus externus abdominis
+	15: "calves",     // Soleus
+}
+
+// equipment mapuje ID sprzętu wger na sprzęt katalogu. Ławki i maty (8, 9, 4) to
+// tylko akcesoria, więc o sprzęcie decyduje pozostała pozycja listy.
+var equipment = map[int]string{
+	1:  "barbell",    // Barbell
+	2:  "barbell",    // SZ-Bar
+	3:  "dumbbell",   // Dumbbell
+	5:  "other",      // Swiss Ball
+	6:  "bodyweight", // Pull-up bar
+	7:  "bodyweight", // none (bodyweight exercise)
+	10: "kettlebell", // Kettlebell
+	11: "band",       // Resistance band
+}
+
+type dump struct {
+	Results []exercise `json:"results"`
+}
+
+type ref struct {
+	ID int `json:"id"`
+}
+
+type exercise struct {
+	ID               int           `json:"id"`
+	Category         ref           `json:"category"`
+	Muscles          []ref         `json:"muscles"`
+	MusclesSecondary []ref         `json:"muscles_secondary"`
+	Equipment        []ref         `json:"equipment"`
+	Translations     []translation `json:"translations"`
+}
+
+type translation struct {
+	Language    int    `json:"language"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Aliases     []struct {
+		Alias string `json:"alias"`
+	} `json:"aliases"`
+}
+
+// Bundled zwraca ćwiczenia z zestawu dołączonego do programu.
+func Bundled() ([]models.CatalogExercise, error) {
+	return Parse(bundled)
+}
+
+// Load czyta zrzut wger z pliku (np. zapisaną odpowiedź /api/v2/exerciseinfo/?limit=1000).
+func Load(path string) ([]models.CatalogExercise, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse zamienia zrzut wger na ćwiczenia katalogu globalnego. Ćwiczenia bez angielskiej
+// nazwy pomijamy; polska nazwa i aliasy trafiają do aliasów, żeby treningi wpisywane
+// po polsku łączyły się z tym samym ćwiczeniem.
+func Parse(data []byte) ([]models.CatalogExercise, error) {
+	var d dump
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("wger: %w", err)
+	}
+	if d.Results == nil {
+		return nil, errors.New(`wger: missing "results"`)
+	}
+	out := make([]models.CatalogExercise, 0, len(d.Results))
+	for _, ex := range d.Results {
+		if e, ok := convert(ex); ok {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func convert(ex exercise) (models.CatalogExercise, bool) {
+	e := models.CatalogExercise{Aliases: []string{}, Type: "strength"}
+	var names []string
+	for _, t := range ex.Translations {
+		switch t.Language {
+		case langEnglish:
+			e.Name = strings.TrimSpace(t.Name)
+			e.Description = plainText(t.Description)
+		case langPolish:
+			names = append(names, t.Name)
+		default:
+			continue
+		}
+		for _, a := range t.Aliases {
+			names = append(names, a.Alias)
+		}
+	}
+	if e.Name == "" {
+		return e, false
+	}
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		if n != "" && !strings.EqualFold(n, e.Name) && !slices.ContainsFunc(e.Aliases, func(a string) bool { return strings.EqualFold(a, n) }) {
+			e.Aliases = append(e.Aliases, n)
+		}
+	}
+	e.PrimaryMuscles = muscleGroups(ex.Muscles, nil)
+	e.SecondaryMuscles = muscleGroups(ex.MusclesSecondary, e.PrimaryMuscles)
+	e.Equipment = equipmentOf(ex.Equipment)
+	if ex.Category.ID == categoryCardio {
+		e.Type = "cardio"
+	}
+	e.Cues = []string{}
+	return e, true
+}
+
+// muscleGroups tłumaczy mięśnie wger na grupy katalogu bez powtórzeń, pomijając grupy ze skip.
+func muscleGroups(refs []ref, skip []string) []string {
+	out := []string{}
+	for _, r := range refs {
+		if g, ok := muscles[r.ID]; ok && !slices.Contains(out, g) && !slices.Contains(skip, g) {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// equipmentOf wybiera sprzęt katalogu. Brak sprzętu w wger oznacza zwykle maszynę
+// lub wyciąg, a same akcesoria (mata, ławka) – ćwiczenie z masą ciała.
+func equipmentOf(refs []ref) string {
+	if len(refs) == 0 {
+		return "machine"
+	}
+	for _, r := range refs {
+		if eq, ok := equipment[r.ID]; ok {
+			return eq
+		}
+	}
+	return "bodyweight"
+}
+
+var tags = regexp.MustCompile(`<[^>]*>`)
+
+// plainText usuwa znaczniki HTML z opisu wger.
+func plainText(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(tags.ReplaceAllString(s, " "))), " ")
+}
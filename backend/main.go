@@ -1,50 +1,369 @@
 package main
 
 import (
-	"log"
+	"cmp"
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"net/http/pprof"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"gym-api/internal/api"
+	"gym-api/internal/blob"
+	"gym-api/internal/config"
+	"gym-api/internal/fitbit"
+	"gym-api/internal/garmin"
+	"gym-api/internal/googlefit"
 	"gym-api/internal/handlers"
+	"gym-api/internal/httpjson"
+	"gym-api/internal/insights"
+	"gym-api/internal/middleware"
+	"gym-api/internal/models"
 	"gym-api/internal/server"
 	"gym-api/internal/store"
+	"gym-api/internal/strava"
+	"gym-api/internal/tracing"
+	"gym-api/internal/wger"
+
+	"golang.org/x/crypto/acme/autocert"
 )
 
+// shutdownTimeout ogranicza czas oczekiwania na zakończenie trwających żądań przy zamykaniu.
+const shutdownTimeout = 15 * time.Second
+
 // main uruchamia serwer HTTP i rejestruje endpointy aplikacji.
 // W pamięci trzymamy proste "store" na treningi (bez bazy danych).
 func main() {
-	// Inicjalizacja pamięciowego magazynu i serwisu,
+	// Konfiguracja ze zmiennych środowiskowych i flag.
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "błędna konfiguracja:", err)
+		os.Exit(2)
+	}
+
+	// Logi w formacie JSON na stdout – łatwe do zbierania w kontenerze.
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: cfg.LogLevel}))
+	slog.SetDefault(logger)
+
+	// Tracing OpenTelemetry (aktywny tylko, gdy ustawiono endpoint OTLP).
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		logger.Error("nie udało się skonfigurować tracingu", "err", err)
+		os.Exit(1)
+	}
+
+	// Kontekst anulowany przy SIGINT/SIGTERM (Ctrl+C, docker stop).
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Endpointy pprof są domyślnie wyłączone; włączamy je podając adres panelu admina.
+	var pprofServer *http.Server
+	if cfg.PprofAddr != "" {
+		pprofServer = startPprof(logger, cfg.PprofAddr)
+	}
+
+	// Inicjalizacja pamięciowych magazynów (jedyny backend, patrz cfg.Storage) w serwisie,
 	// który przekazujemy do handlerów HTTP.
-	workoutStore := store.NewWorkoutStore()
-	srv := server.New(workoutStore)
+	srv := server.New()
+	if srv.Blobs, err = openBlobs(cfg.Blob); err != nil {
+		logger.Error("nie udało się otworzyć magazynu plików", "err", err)
+		os.Exit(1)
+	}
+	seedCatalog(ctx, logger, srv.Exercises, cfg.ExerciseSeed)
+	srv.AdminToken = cfg.AdminToken
+	if cfg.TrashRetention > 0 {
+		go purgeTrash(ctx, logger, srv.Workouts, cfg.TrashRetention)
+	}
+	go refreshInsights(ctx, logger, srv)
+	if cfg.Strava.Enabled() {
+		srv.Strava = &strava.Client{
+			ClientID:     cfg.Strava.ClientID,
+			ClientSecret: cfg.Strava.ClientSecret,
+			RedirectURL:  cfg.Strava.RedirectURL,
+			VerifyToken:  cfg.Strava.VerifyToken,
+		}
+		if cfg.Strava.SyncInterval > 0 {
+			go syncIntegration(ctx, logger, srv, "strava", cfg.Strava.SyncInterval)
+		}
+	}
+	if cfg.Garmin.Enabled() {
+		srv.Garmin = &garmin.Client{
+			ClientID:     cfg.Garmin.ClientID,
+			ClientSecret: cfg.Garmin.ClientSecret,
+			RedirectURL:  cfg.Garmin.RedirectURL,
+		}
+		if cfg.Garmin.SyncInterval > 0 {
+			go syncIntegration(ctx, logger, srv, "garmin", cfg.Garmin.SyncInterval)
+		}
+	}
+	if cfg.GoogleFit.Enabled() {
+		srv.GoogleFit = &googlefit.Client{
+			ClientID:     cfg.GoogleFit.ClientID,
+			ClientSecret: cfg.GoogleFit.ClientSecret,
+			RedirectURL:  cfg.GoogleFit.RedirectURL,
+		}
+		if cfg.GoogleFit.SyncInterval > 0 {
+			go syncIntegration(ctx, logger, srv, "googlefit", cfg.GoogleFit.SyncInterval)
+		}
+	}
+	if cfg.Fitbit.Enabled() {
+		srv.Fitbit = &fitbit.Client{
+			ClientID:     cfg.Fitbit.ClientID,
+			ClientSecret: cfg.Fitbit.ClientSecret,
+			RedirectURL:  cfg.Fitbit.RedirectURL,
+		}
+		if cfg.Fitbit.SyncInterval > 0 {
+			go syncIntegration(ctx, logger, srv, "fitbit", cfg.Fitbit.SyncInterval)
+		}
+	}
+
+	httpjson.MaxBodyBytes = cfg.MaxBodyBytes
 
 	// Router oparty o http.ServeMux i ścieżki z prefixem.
 	mux := http.NewServeMux()
 
-	// Prosty endpoint zdrowotny.
+	// Prosty endpoint zdrowotny (poza wersjonowaniem, używany przez infrastrukturę).
 	mux.Handle("/health", handlers.NewHealthHandler())
-	// Kolekcja treningów: GET (lista), POST (dodanie).
-	mux.Handle("/workouts", handlers.NewWorkoutsHandler(srv))
-	// Pojedynczy trening po ID: GET, PUT, DELETE.
-	mux.Handle("/workouts/", handlers.NewWorkoutByIDHandler(srv))
-
-	log.Println("Gym API startuje na http://localhost:8080")
-	// Start serwera z prostym CORS middleware; w przypadku błędu zatrzymujemy program.
-	log.Fatal(http.ListenAndServe(":8080", withCORS(mux)))
-}
-
-// withCORS dodaje nagłówki CORS i obsługuje preflight (OPTIONS) dla żądań z przeglądarki.
-func withCORS(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-
-		if r.Method == http.MethodOptions {
-			// Preflight nie wymaga body
-			w.WriteHeader(http.StatusNoContent)
+
+	// Wersjonowane API: /api/v1/...
+	v1 := api.V1(srv)
+	api.Mount(mux, v1)
+	// Dokumentacja: Swagger UI pod /docs/ i spec OpenAPI pod /docs/openapi.json.
+	mux.Handle("/docs/", http.StripPrefix("/docs", api.Docs(v1)))
+	mux.Handle("/docs", http.RedirectHandler("/docs/", http.StatusMovedPermanently))
+	// Dotychczasowe ścieżki bez prefiksu działają dalej jako alias bieżącej wersji.
+	legacy := api.Legacy(v1.Handler)
+	mux.Handle("/workouts", legacy)
+	mux.Handle("/workouts/", legacy)
+
+	httpServer := &http.Server{
+		Addr: cfg.Addr(),
+		// Logowanie żądań, nagłówki bezpieczeństwa, CORS z listą dozwolonych originów
+		// i kompresja odpowiedzi.
+		Handler: middleware.Logging(logger, middleware.SecurityHeaders(middleware.SecurityOptions{
+			FrameOptions:          cfg.Security.FrameOptions,
+			ContentSecurityPolicy: cfg.Security.ContentSecurityPolicy,
+			HSTSMaxAge:            cfg.Security.HSTSMaxAge,
+			HSTSIncludeSubdomains: cfg.Security.HSTSIncludeSubdomains,
+			TLS:                   cfg.TLS.Enabled(),
+		}, middleware.CORS(middleware.CORSOptions{
+			AllowedOrigins:   cfg.CORSOrigins,
+			AllowCredentials: cfg.CORSCredentials,
+		}, middleware.Compress(mux)))),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// HTTPS z automatycznymi certyfikatami: autocert dostarcza certyfikaty
+	// w TLSConfig, a osobny serwer HTTP obsługuje wyzwania ACME i przekierowuje na HTTPS.
+	var acmeServer *http.Server
+	if cfg.TLS.Autocert() {
+		httpServer.TLSConfig, acmeServer = setupAutocert(logger, cfg.TLS)
+	}
+
+	// Serwer działa w tle, a główna gorutyna czeka na sygnał lub błąd startu.
+	serveErr := make(chan error, 1)
+	go func() {
+		logger.Info("Gym API startuje", "addr", cfg.Addr(), "storage", cfg.Storage, "tls", cfg.TLS.Enabled())
+		if cfg.TLS.Enabled() {
+			// Przy autocert pliki są puste, a certyfikat pochodzi z TLSConfig.GetCertificate.
+			serveErr <- httpServer.ListenAndServeTLS(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+			return
+		}
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("serwer zakończył działanie", "err", err)
+			_ = shutdownTracing(context.Background())
+			os.Exit(1)
+		}
+	case <-ctx.Done():
+	}
+	stop()
+
+	// Graceful shutdown: przestajemy przyjmować połączenia i czekamy
+	// na dokończenie trwających żądań (maksymalnie shutdownTimeout).
+	logger.Info("zamykanie serwera")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	exitCode := 0
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		logger.Error("nie udało się łagodnie zamknąć serwera", "err", err)
+		exitCode = 1
+	}
+	if pprofServer != nil {
+		_ = pprofServer.Shutdown(shutdownCtx)
+	}
+	if acmeServer != nil {
+		_ = acmeServer.Shutdown(shutdownCtx)
+	}
+	// Magazyn jest w pamięci, więc nie ma czego zapisywać na dysk;
+	// opróżniamy jedynie bufor spanów tracingu.
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("nie udało się opróżnić tracingu", "err", err)
+	}
+	logger.Info("serwer zatrzymany")
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+}
+
+// setupAutocert konfiguruje pobieranie certyfikatów Let's Encrypt dla podanych domen
+// i uruchamia w tle serwer HTTP dla wyzwań ACME (http-01) z przekierowaniem na HTTPS.
+func setupAutocert(logger *slog.Logger, cfg config.TLSConfig) (*tls.Config, *http.Server) {
+	m := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(cfg.AutocertDomains...),
+		Cache:      autocert.DirCache(cfg.AutocertCacheDir),
+		Email:      cfg.AutocertEmail,
+	}
+
+	srv := &http.Server{Addr: cfg.ACMEHTTPAddr, Handler: m.HTTPHandler(nil), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		logger.Info("serwer ACME startuje", "addr", cfg.ACMEHTTPAddr, "domains", cfg.AutocertDomains)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("serwer ACME zakończył działanie", "err", err)
+		}
+	}()
+	return m.TLSConfig(), srv
+}
+
+// startPprof uruchamia w tle osobny serwer z endpointami net/http/pprof.
+// Trzymamy go na innym porcie niż API, żeby nie wystawiać profilera publicznie.
+func startPprof(logger *slog.Logger, addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		logger.Info("pprof dostępny", "addr", "http://"+addr+"/debug/pprof/")
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("serwer pprof zakończył działanie", "err", err)
+		}
+	}()
+	return srv
+}
+
+// seedCatalog zasila katalog globalny ćwiczeniami z wger: z pliku source albo
+// z zestawu wbudowanego. Magazyn jest w pamięci, więc robimy to przy każdym starcie.
+// Błąd importu nie zatrzymuje serwera – katalog zostaje wtedy pusty.
+func seedCatalog(ctx context.Context, logger *slog.Logger, s *store.ExerciseStore, source string) {
+	var (
+		exercises []models.CatalogExercise
+		err       error
+	)
+	switch source {
+	case "none":
+		return
+	case "":
+		exercises, err = wger.Bundled()
+	default:
+		exercises, err = wger.Load(source)
+	}
+	if err != nil {
+		logger.Error("nie udało się wczytać katalogu ćwiczeń", "source", source, "err", err)
+		return
+	}
+	logger.Info("zaimportowano katalog ćwiczeń", "added", s.Seed(ctx, exercises), "source", cmp.Or(source, "bundled"))
+}
+
+// trashPurgeInterval określa, jak często sprzątamy kosz z przeterminowanych treningów.
+const trashPurgeInterval = time.Hour
+
+// purgeTrash co trashPurgeInterval trwale kasuje treningi leżące w koszu dłużej
+// niż retention, aż do anulowania ctx.
+func purgeTrash(ctx context.Context, logger *slog.Logger, s *store.WorkoutStore, retention time.Duration) {
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
 			return
+		case <-ticker.C:
+			if n := s.Purge(ctx, retention); n > 0 {
+				logger.Info("wyczyszczono kosz", "purged", n, "retention", retention.String())
+			}
 		}
+	}
+}
 
-		next.ServeHTTP(w, r)
-	})
+// insightsInterval określa, jak często przeliczamy raport zmęczenia (GET /insights).
+const insightsInterval = 15 * time.Minute
+
+// openBlobs tworzy magazyn plików (zdjęć) wybrany w konfiguracji.
+func openBlobs(cfg config.BlobConfig) (blob.Store, error) {
+	switch cfg.Backend {
+	case "local":
+		return blob.NewLocal(cfg.Dir)
+	case "s3":
+		return blob.NewS3(blob.S3Options{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+	}
+	return blob.NewMemory(), nil
+}
+
+// refreshInsights liczy raport zmęczenia od razu, a potem co insightsInterval,
+// aż do anulowania ctx.
+func refreshInsights(ctx context.Context, logger *slog.Logger, srv *server.Server) {
+	ticker := time.NewTicker(insightsInterval)
+	defer ticker.Stop()
+	for {
+		report := insights.Deload(ctx, srv.Workouts, insights.DefaultWindow)
+		srv.Insights.Set(ctx, report)
+		if report.DeloadRecommended {
+			logger.Info("zalecany deload", "exercises", len(report.Insights))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncIntegration co interval synchronizuje wszystkie konta połączone z serwisem provider,
+// aż do anulowania ctx. Błąd jednego konta nie przerywa pozostałych; trafia do jego
+// stanu połączenia.
+func syncIntegration(ctx context.Context, logger *slog.Logger, srv *server.Server, provider string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, owner := range srv.Integrations.Owners(ctx, provider) {
+			res, err := handlers.SyncIntegration(store.WithActor(ctx, owner), srv, provider)
+			if err != nil {
+				logger.Warn("synchronizacja nie powiodła się", "provider", provider, "owner", owner, "err", err)
+				continue
+			}
+			if len(res.Imported) > 0 {
+				logger.Info("zaimportowano aktywności", "provider", provider, "owner", owner, "imported", len(res.Imported))
+			}
+		}
+	}
 }
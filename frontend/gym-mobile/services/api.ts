@@ -7,6 +7,8 @@
 // Dla emulatora Android użyj: http://10.0.2.2:8080
 // Dla symulatora iOS / web: http://localhost:8080
 const API_URL = 'http://localhost:8080';
+// Endpointy treningów są wersjonowane (/api/v1), /health pozostaje bez prefiksu
+const API_V1 = `${API_URL}/api/v1`;
 
 // ============================================
 // TYPY DANYCH (zgodne z backendem Go)
@@ -16,14 +18,25 @@ const API_URL = 'http://localhost:8080';
 export interface Set {
   reps: number;      // Liczba powtórzeń
   weight?: number;   // Ciężar w kg (opcjonalnie)
+  rpe?: number;      // Odczuwalny wysiłek 0–10 co 0,5 (opcjonalnie)
+  percentOfTM?: number; // % maksa treningowego (np. 75); API wylicza z niego weight
 }
 
 /** Pojedyncze ćwiczenie w treningu */
 export interface Exercise {
+  exerciseId?: number; // ID w katalogu ćwiczeń (uzupełniane przez API, gdy nazwa jest w katalogu)
   name: string;      // Nazwa ćwiczenia (np. "Wyciskanie sztangi")
   sets: Set[];       // Lista serii
 }
 
+/** Wskazówki techniczne ćwiczenia z katalogu */
+export interface ExerciseInstructions {
+  description: string;
+  cues: string[];      // Krótkie hasła (np. "łopatki ściągnięte")
+  imageUrl?: string;
+  videoUrl?: string;
+}
+
 /** Pełny obiekt treningu zwracany z API */
 export interface Workout {
   id: number;
@@ -31,8 +44,34 @@ export interface Workout {
   date: string;          // Format: YYYY-MM-DD
   notes: string;
   exercises: Exercise[];
+  planned: boolean;      // zaplanowany z programu, jeszcze nie wykonany
   createdAt: string;
   updatedAt: string;
+  version: number;
+}
+
+/** Rekord osobisty pobity w nowym treningu */
+export interface NewRecord {
+  exerciseId?: number;
+  name: string;
+  type: 'heaviest_weight' | 'best_e1rm' | 'best_volume' | 'reps_at_weight';
+  value: number;
+  previous: number;
+  weight?: number;
+  reps?: number;
+}
+
+/** Odpowiedź na utworzenie treningu */
+export interface CreatedWorkout extends Workout {
+  newRecords: NewRecord[]; // do pokazania gratulacji od razu po zapisie
+}
+
+/** Strona listy treningów z metadanymi stronicowania */
+export interface WorkoutPage {
+  items: Workout[];
+  total: number;     // Liczba wszystkich treningów
+  limit: number;
+  offset: number;
 }
 
 /** Request do tworzenia nowego treningu */
@@ -41,14 +80,17 @@ export interface CreateWorkoutRequest {
   date: string;
   notes?: string;
   exercises: Exercise[];
+  planned?: boolean;
 }
 
-/** Request do aktualizacji treningu (wszystkie pola opcjonalne) */
+/** Request do aktualizacji treningu (PUT zastępuje cały trening – pominięte pola są czyszczone) */
 export interface UpdateWorkoutRequest {
-  title?: string;
-  date?: string;
+  title: string;
+  date: string;
   notes?: string;
-  exercises?: Exercise[];
+  exercises: Exercise[];
+  planned?: boolean;     // pominięte przy PUT = trening wykonany
+  version?: number;      // jeśli podana i nieaktualna, API zwraca 409
 }
 
 // ============================================
@@ -56,23 +98,31 @@ export interface UpdateWorkoutRequest {
 // ============================================
 
 /**
- * Pobiera listę wszystkich treningów
- * GET /workouts
+ * Pobiera jedną stronę treningów (od najnowszych)
+ * GET /api/v1/workouts?limit=&offset=
  */
-export async function getWorkouts(): Promise<Workout[]> {
-  const response = await fetch(`${API_URL}/workouts`);
+export async function getWorkoutsPage(limit = 50, offset = 0): Promise<WorkoutPage> {
+  const response = await fetch(`${API_V1}/workouts?limit=${limit}&offset=${offset}`);
   if (!response.ok) {
     throw new Error('Nie udało się pobrać treningów');
   }
   return response.json();
 }
 
+/**
+ * Pobiera listę treningów (pierwsza strona o maksymalnym rozmiarze)
+ */
+export async function getWorkouts(): Promise<Workout[]> {
+  const page = await getWorkoutsPage(200);
+  return page.items;
+}
+
 /**
  * Pobiera pojedynczy trening po ID
- * GET /workouts/:id
+ * GET /api/v1/workouts/:id
  */
 export async function getWorkout(id: number): Promise<Workout> {
-  const response = await fetch(`${API_URL}/workouts/${id}`);
+  const response = await fetch(`${API_V1}/workouts/${id}`);
   if (!response.ok) {
     throw new Error('Nie znaleziono treningu');
   }
@@ -81,10 +131,10 @@ export async function getWorkout(id: number): Promise<Workout> {
 
 /**
  * Tworzy nowy trening
- * POST /workouts
+ * POST /api/v1/workouts
  */
-export async function createWorkout(workout: CreateWorkoutRequest): Promise<Workout> {
-  const response = await fetch(`${API_URL}/workouts`, {
+export async function createWorkout(workout: CreateWorkoutRequest): Promise<CreatedWorkout> {
+  const response = await fetch(`${API_V1}/workouts`, {
     method: 'POST',
     headers: { 'Content-Type': 'application/json' },
     body: JSON.stringify(workout),
@@ -97,10 +147,10 @@ export async function createWorkout(workout: CreateWorkoutRequest): Promise<Work
 
 /**
  * Aktualizuje istniejący trening
- * PUT /workouts/:id
+ * PUT /api/v1/workouts/:id
  */
 export async function updateWorkout(id: number, workout: UpdateWorkoutRequest): Promise<Workout> {
-  const response = await fetch(`${API_URL}/workouts/${id}`, {
+  const response = await fetch(`${API_V1}/workouts/${id}`, {
     method: 'PUT',
     headers: { 'Content-Type': 'application/json' },
     body: JSON.stringify(workout),
@@ -113,11 +163,11 @@ export async function updateWorkout(id: number, workout: UpdateWorkoutRequest):
 
 /**
  * Usuwa trening po ID
- * DELETE /workouts/:id
+ * DELETE /api/v1/workouts/:id
  * Backend zwraca 204 No Content przy sukcesie
  */
 export async function deleteWorkout(id: number): Promise<void> {
-  const response = await fetch(`${API_URL}/workouts/${id}`, {
+  const response = await fetch(`${API_V1}/workouts/${id}`, {
     method: 'DELETE',
   });
   // 204 No Content oznacza sukces
@@ -126,6 +176,18 @@ export async function deleteWorkout(id: number): Promise<void> {
   }
 }
 
+/**
+ * Pobiera instrukcje ćwiczenia z katalogu (do pokazania obok ćwiczenia w treningu)
+ * GET /api/v1/exercises/:id/instructions
+ */
+export async function getExerciseInstructions(exerciseId: number): Promise<ExerciseInstructions> {
+  const response = await fetch(`${API_V1}/exercises/${exerciseId}/instructions`);
+  if (!response.ok) {
+    throw new Error('Nie udało się pobrać instrukcji ćwiczenia');
+  }
+  return response.json();
+}
+
 /**
  * Sprawdza czy API działa
  * GET /health